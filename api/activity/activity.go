@@ -0,0 +1,146 @@
+// Package activity records who changed what through the zopdev API. Every mutating route records its principal, the
+// action, the entity it acts on, the parameters of the request with their secrets redacted and its outcome in an
+// append-only log, apart from the results of the cloud audits.
+package activity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxBodySize is the size of the largest request body recorded, the parameters of a larger body are not recorded.
+const maxBodySize = 64 << 10
+
+// Redacted replaces the values of the secret parameters.
+const Redacted = "[REDACTED]"
+
+// Request is what is recorded of a request, its parameters are redacted.
+type Request struct {
+	Method string
+	Path   string
+	// Params holds the query parameters and the fields of the JSON body of the request.
+	Params map[string]any
+}
+
+type requestKey struct{}
+
+// Middleware reads the parameters of the mutating requests before their handlers consume the body, the handlers of
+// gofr have no access to the raw request.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		req := Request{Method: r.Method, Path: r.URL.Path, Params: make(map[string]any)}
+
+		for k, v := range r.URL.Query() {
+			if len(v) == 1 {
+				req.Params[k] = v[0]
+			} else {
+				req.Params[k] = v
+			}
+		}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+
+			// The body is given back to the handler whole, including what was not read.
+			r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+
+			if err == nil && len(body) <= maxBodySize {
+				addBody(req.Params, body)
+			}
+		}
+
+		req.Params = Redact(req.Params)
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestKey{}, req)))
+	})
+}
+
+// RequestFromContext returns what was read of the request, it is not set for the requests not changing anything.
+func RequestFromContext(ctx context.Context) (Request, bool) {
+	req, ok := ctx.Value(requestKey{}).(Request)
+
+	return req, ok
+}
+
+// addBody adds the fields of a JSON object to the parameters, any other body is recorded as a whole.
+func addBody(params map[string]any, body []byte) {
+	var v any
+
+	if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &v) != nil {
+		return
+	}
+
+	fields, ok := v.(map[string]any)
+	if !ok {
+		params["body"] = v
+
+		return
+	}
+
+	for k, f := range fields {
+		params[k] = f
+	}
+}
+
+// Redact returns a copy of the parameters replacing the values of the secret fields, at any depth of the JSON objects.
+// The credentials of the cloud accounts are redacted as a whole.
+func Redact(params map[string]any) map[string]any {
+	redacted := make(map[string]any, len(params))
+
+	for k, v := range params {
+		if isSecret(k) {
+			redacted[k] = Redacted
+		} else {
+			redacted[k] = redactValue(v)
+		}
+	}
+
+	return redacted
+}
+
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return Redact(val)
+	case []any:
+		redacted := make([]any, len(val))
+
+		for i := range val {
+			redacted[i] = redactValue(val[i])
+		}
+
+		return redacted
+	default:
+		return v
+	}
+}
+
+// isSecret returns whether a field holds a secret, whatever its case and separators, e.g. secretAccessKey or
+// private_key.
+func isSecret(field string) bool {
+	name := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(field))
+
+	for _, secret := range []string{"credential", "password", "secret", "token", "privatekey", "accesskey", "apikey",
+		"externalid"} {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,120 @@
+package activity
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		expected *Request
+	}{
+		{name: "read request", method: http.MethodGet, target: "/cloud-accounts?limit=5"},
+		{
+			name:   "JSON body",
+			method: http.MethodPost,
+			target: "/cloud-account/3/resources/state?dryRun=true",
+			body:   `{"resources":[{"id":1}],"state":"SUSPEND"}`,
+			expected: &Request{Method: http.MethodPost, Path: "/cloud-account/3/resources/state", Params: map[string]any{
+				"dryRun": "true", "resources": []any{map[string]any{"id": float64(1)}}, "state": "SUSPEND",
+			}},
+		},
+		{
+			name:   "secrets redacted",
+			method: http.MethodPost,
+			target: "/cloud-accounts",
+			body:   `{"name":"prod","provider":"aws","credentials":{"aws_access_key_id":"AKIA","aws_secret_access_key":"secret"}}`,
+			expected: &Request{Method: http.MethodPost, Path: "/cloud-accounts", Params: map[string]any{
+				"name": "prod", "provider": "aws", "credentials": Redacted,
+			}},
+		},
+		{
+			name:   "body that is not an object",
+			method: http.MethodPatch,
+			target: "/applications/1/environments",
+			body:   `[{"id":2,"level":1}]`,
+			expected: &Request{Method: http.MethodPatch, Path: "/applications/1/environments", Params: map[string]any{
+				"body": []any{map[string]any{"id": float64(2), "level": float64(1)}},
+			}},
+		},
+		{
+			name:     "no body",
+			method:   http.MethodDelete,
+			target:   "/api-keys/4",
+			expected: &Request{Method: http.MethodDelete, Path: "/api-keys/4", Params: map[string]any{}},
+		},
+		{
+			name:     "body too large",
+			method:   http.MethodPost,
+			target:   "/applications",
+			body:     `{"name":"` + strings.Repeat("a", maxBodySize) + `"}`,
+			expected: &Request{Method: http.MethodPost, Path: "/applications", Params: map[string]any{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				req  Request
+				ok   bool
+				body []byte
+			)
+
+			handler := Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				req, ok = RequestFromContext(r.Context())
+				body, _ = io.ReadAll(r.Body)
+			}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body)))
+
+			// The handler reads the body whole, whether it is recorded or not.
+			assert.Equal(t, tc.body, string(body))
+
+			if tc.expected == nil {
+				assert.False(t, ok)
+
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, *tc.expected, req)
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	params := map[string]any{
+		"name":         "oci",
+		"apiKey":       "zop_abc",
+		"client-token": "token",
+		"providerDetails": map[string]any{
+			"roleArn":     "arn:aws:iam::123456789012:role/zopdev",
+			"external_id": "shared",
+		},
+		"keys": []any{map[string]any{"privateKey": "-----BEGIN", "fingerprint": "aa:bb"}},
+	}
+
+	assert.Equal(t, map[string]any{
+		"name":         "oci",
+		"apiKey":       Redacted,
+		"client-token": Redacted,
+		"providerDetails": map[string]any{
+			"roleArn":     "arn:aws:iam::123456789012:role/zopdev",
+			"external_id": Redacted,
+		},
+		"keys": []any{map[string]any{"privateKey": Redacted, "fingerprint": "aa:bb"}},
+	}, Redact(params))
+
+	// The parameters themselves are left untouched.
+	assert.Equal(t, "zop_abc", params["apiKey"])
+}
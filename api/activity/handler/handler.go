@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"github.com/zopdev/zopdev/api/activity/service"
+	"github.com/zopdev/zopdev/api/activity/store"
+)
+
+type Handler struct {
+	service service.ActivityService
+}
+
+// New creates a new Handler with the provided ActivityService.
+func New(svc service.ActivityService) *Handler {
+	return &Handler{service: svc}
+}
+
+// ListActivity returns the entries of the activity log of the organization, the latest first, e.g.
+// ?actor=jane@example.com&action=cloud_account.delete&targetType=cloud_account&targetId=3&outcome=failure
+// &since=2025-07-01T00:00:00Z&until=2025-07-02T00:00:00Z&limit=50. The next page is requested with before set to the
+// ID of the last entry returned.
+func (h *Handler) ListActivity(ctx *gofr.Context) (any, error) {
+	filter, err := getFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.List(ctx, filter)
+}
+
+// ExportActivity returns the entries of the activity log matching the filters of ListActivity as a file, in the
+// format set by ?format=csv or ?format=json, csv by default.
+func (h *Handler) ExportActivity(ctx *gofr.Context) (any, error) {
+	filter, err := getFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	format := strings.ToLower(ctx.Param("format"))
+	if format == "" {
+		format = service.FormatCSV
+	}
+
+	content, err := h.service.Export(ctx, filter, format)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := "text/csv"
+	if format == service.FormatJSON {
+		contentType = "application/json"
+	}
+
+	return response.File{Content: content, ContentType: contentType}, nil
+}
+
+func getFilter(ctx *gofr.Context) (*store.Filter, error) {
+	filter := &store.Filter{
+		Actor:      ctx.Param("actor"),
+		Action:     ctx.Param("action"),
+		TargetType: ctx.Param("targetType"),
+		TargetID:   ctx.Param("targetId"),
+		Outcome:    ctx.Param("outcome"),
+	}
+
+	if filter.Outcome != "" && filter.Outcome != store.OutcomeSuccess && filter.Outcome != store.OutcomeFailure {
+		return nil, http.ErrorInvalidParam{Params: []string{"outcome"}}
+	}
+
+	var err error
+
+	if filter.Since, err = timeParam(ctx, "since"); err != nil {
+		return nil, err
+	}
+
+	if filter.Until, err = timeParam(ctx, "until"); err != nil {
+		return nil, err
+	}
+
+	if filter.Before, err = numberParam(ctx, "before"); err != nil {
+		return nil, err
+	}
+
+	limit, err := numberParam(ctx, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = int(limit)
+
+	return filter, nil
+}
+
+// timeParam parses a query parameter in RFC 3339, it is zero when it is not set.
+func timeParam(ctx *gofr.Context, name string) (time.Time, error) {
+	v := ctx.Param(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, http.ErrorInvalidParam{Params: []string{name}}
+	}
+
+	return t, nil
+}
+
+// numberParam parses a query parameter holding a positive number, it is zero when it is not set.
+func numberParam(ctx *gofr.Context, name string) (int64, error) {
+	v := ctx.Param(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, http.ErrorInvalidParam{Params: []string{name}}
+	}
+
+	return n, nil
+}
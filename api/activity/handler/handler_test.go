@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	netHTTP "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"github.com/zopdev/zopdev/api/activity"
+	"github.com/zopdev/zopdev/api/activity/service"
+	"github.com/zopdev/zopdev/api/activity/store"
+)
+
+// requestContext returns the context of a request read by the activity middleware.
+func requestContext(method, target, body string, vars map[string]string) *gofr.Context {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	ctx := context.Background()
+
+	activity.Middleware(netHTTP.HandlerFunc(func(_ netHTTP.ResponseWriter, r *netHTTP.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return &gofr.Context{Context: ctx, Request: http.NewRequest(mux.SetURLVars(req, vars))}
+}
+
+func TestHandler_ListActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockActivityService(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		name        string
+		query       string
+		expected    *store.Filter
+		expectedErr error
+	}{
+		{name: "no filter", expected: &store.Filter{}},
+		{
+			name: "every filter",
+			query: "?actor=admin@example.com&action=cloud_account.delete&targetType=cloud_account&targetId=3" +
+				"&outcome=failure&since=2025-07-01T00:00:00Z&until=2025-07-02T00:00:00Z&before=50&limit=10",
+			expected: &store.Filter{Actor: "admin@example.com", Action: "cloud_account.delete", TargetType: "cloud_account",
+				TargetID: "3", Outcome: store.OutcomeFailure, Since: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC), Before: 50, Limit: 10},
+		},
+		{name: "invalid outcome", query: "?outcome=denied", expectedErr: http.ErrorInvalidParam{Params: []string{"outcome"}}},
+		{name: "invalid since", query: "?since=yesterday", expectedErr: http.ErrorInvalidParam{Params: []string{"since"}}},
+		{name: "invalid until", query: "?until=2025-07-02", expectedErr: http.ErrorInvalidParam{Params: []string{"until"}}},
+		{name: "invalid before", query: "?before=last", expectedErr: http.ErrorInvalidParam{Params: []string{"before"}}},
+		{name: "invalid limit", query: "?limit=-1", expectedErr: http.ErrorInvalidParam{Params: []string{"limit"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := requestContext(netHTTP.MethodGet, "/activity"+tc.query, "", nil)
+
+			if tc.expected != nil {
+				mockService.EXPECT().List(ctx, tc.expected).Return([]store.Entry{{ID: 1}}, nil)
+			}
+
+			resp, err := h.ListActivity(ctx)
+
+			require.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr == nil {
+				assert.Equal(t, []store.Entry{{ID: 1}}, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_ExportActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockActivityService(ctrl)
+	h := New(mockService)
+
+	ctx := requestContext(netHTTP.MethodGet, "/activity/export?actor=admin@example.com", "", nil)
+	mockService.EXPECT().Export(ctx, &store.Filter{Actor: "admin@example.com"}, service.FormatCSV).Return([]byte("id\n"), nil)
+
+	resp, err := h.ExportActivity(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, response.File{Content: []byte("id\n"), ContentType: "text/csv"}, resp)
+
+	ctx = requestContext(netHTTP.MethodGet, "/activity/export?format=JSON", "", nil)
+	mockService.EXPECT().Export(ctx, &store.Filter{}, service.FormatJSON).Return([]byte("[]"), nil)
+
+	resp, err = h.ExportActivity(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, response.File{Content: []byte("[]"), ContentType: "application/json"}, resp)
+
+	ctx = requestContext(netHTTP.MethodGet, "/activity/export?format=xml", "", nil)
+	mockService.EXPECT().Export(ctx, &store.Filter{}, "xml").Return(nil, http.ErrorInvalidParam{Params: []string{"format"}})
+
+	_, err = h.ExportActivity(ctx)
+
+	require.Equal(t, http.ErrorInvalidParam{Params: []string{"format"}}, err)
+
+	_, err = h.ExportActivity(requestContext(netHTTP.MethodGet, "/activity/export?limit=many", "", nil))
+
+	require.Equal(t, http.ErrorInvalidParam{Params: []string{"limit"}}, err)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/activity"
+	"github.com/zopdev/zopdev/api/activity/service"
+	"github.com/zopdev/zopdev/api/activity/store"
+)
+
+// Recorder records the outcome of the mutating requests in the activity log.
+type Recorder struct {
+	service service.ActivityService
+}
+
+// NewRecorder creates a Recorder.
+func NewRecorder(svc service.ActivityService) *Recorder {
+	return &Recorder{service: svc}
+}
+
+// Record returns a handler running next and recording its outcome as the action, e.g. cloud_account.update. The type
+// of the entity changed is the start of the action, up to the dot. Its ID is read from the path parameter named by
+// target, or from the ID of the response when target is empty, e.g. for the creations. next is expected to
+// authenticate the request, i.e. to be wrapped by the Authorizer, the requests of no principal are not recorded.
+func (r *Recorder) Record(action, target string, next gofr.Handler) gofr.Handler {
+	targetType, _, _ := strings.Cut(action, ".")
+
+	return func(ctx *gofr.Context) (any, error) {
+		res, err := next(ctx)
+
+		entry := &store.Entry{Action: action, TargetType: targetType, Outcome: store.OutcomeSuccess}
+
+		if req, ok := activity.RequestFromContext(ctx); ok {
+			entry.Method, entry.Path, entry.Parameters = req.Method, req.Path, req.Params
+		}
+
+		switch {
+		case target != "":
+			entry.TargetID = ctx.PathParam(target)
+		case err == nil:
+			entry.TargetID = responseID(res)
+		}
+
+		if err != nil {
+			entry.Outcome, entry.Error = store.OutcomeFailure, err.Error()
+		}
+
+		// Failing to record the request does not fail it, it is already done.
+		if er := r.service.Record(ctx, entry); er != nil {
+			ctx.Errorf("failed to record %s in the activity log: %v", action, er)
+		}
+
+		return res, err
+	}
+}
+
+// responseID returns the ID of the entity returned by a handler, it is empty when the response has no ID.
+func responseID(res any) string {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		ID json.RawMessage `json:"id"`
+	}
+
+	if json.Unmarshal(b, &body) != nil {
+		return ""
+	}
+
+	return strings.Trim(string(body.ID), `"`)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	netHTTP "net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/activity"
+	"github.com/zopdev/zopdev/api/activity/service"
+	"github.com/zopdev/zopdev/api/activity/store"
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockActivityService(ctrl)
+	rec := NewRecorder(mockService)
+
+	authenticated := func(res any, err error) gofr.Handler {
+		return func(ctx *gofr.Context) (any, error) {
+			ctx.Context = auth.NewContext(ctx.Context, &auth.Principal{Subject: "admin@example.com", Method: auth.MethodOIDC})
+
+			return res, err
+		}
+	}
+
+	t.Run("target from the path", func(t *testing.T) {
+		ctx := requestContext(netHTTP.MethodPatch, "/cloud-accounts/3", `{"name":"prod","credentials":{"key":"k"}}`,
+			map[string]string{"id": "3"})
+
+		mockService.EXPECT().Record(ctx, &store.Entry{Action: "cloud_account.update", TargetType: "cloud_account",
+			TargetID: "3", Method: netHTTP.MethodPatch, Path: "/cloud-accounts/3",
+			Parameters: map[string]any{"name": "prod", "credentials": activity.Redacted}, Outcome: store.OutcomeSuccess}).
+			Return(nil)
+
+		resp, err := rec.Record("cloud_account.update", "id", authenticated("updated", nil))(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, "updated", resp)
+	})
+
+	t.Run("target from the response", func(t *testing.T) {
+		ctx := requestContext(netHTTP.MethodPost, "/api-keys", `{"name":"ci"}`, nil)
+
+		mockService.EXPECT().Record(ctx, &store.Entry{Action: "api_key.create", TargetType: "api_key", TargetID: "4",
+			Method: netHTTP.MethodPost, Path: "/api-keys", Parameters: map[string]any{"name": "ci"},
+			Outcome: store.OutcomeSuccess}).Return(nil)
+
+		_, err := rec.Record("api_key.create", "", authenticated(map[string]any{"id": 4, "key": "zop_key"}, nil))(ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		ctx := requestContext(netHTTP.MethodPost, "/applications", `{"name":"web"}`, nil)
+		forbidden := auth.ForbiddenError{Role: auth.RoleAdmin, Scope: auth.Global()}
+
+		mockService.EXPECT().Record(ctx, &store.Entry{Action: "application.create", TargetType: "application",
+			Method: netHTTP.MethodPost, Path: "/applications", Parameters: map[string]any{"name": "web"},
+			Outcome: store.OutcomeFailure, Error: forbidden.Error()}).Return(nil)
+
+		_, err := rec.Record("application.create", "", authenticated(nil, forbidden))(ctx)
+
+		require.Equal(t, forbidden, err)
+	})
+
+	t.Run("record error", func(t *testing.T) {
+		mockContainer, _ := container.NewMockContainer(t)
+		ctx := requestContext(netHTTP.MethodDelete, "/api-keys/4", "", map[string]string{"id": "4"})
+		ctx.Container = mockContainer
+
+		mockService.EXPECT().Record(ctx, gomock.Any()).Return(assert.AnError)
+
+		resp, err := rec.Record("api_key.revoke", "id", authenticated("revoked", nil))(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, "revoked", resp)
+	})
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/activity/store"
+)
+
+type ActivityService interface {
+	Record(ctx *gofr.Context, entry *store.Entry) error
+	List(ctx *gofr.Context, filter *store.Filter) ([]store.Entry, error)
+	Export(ctx *gofr.Context, filter *store.Filter, format string) ([]byte, error)
+}
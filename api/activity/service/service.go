@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/activity/store"
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+const (
+	// defaultLimit is the number of entries returned when no limit is requested.
+	defaultLimit = 100
+	// maxLimit caps the number of entries returned in a single request.
+	maxLimit = 1000
+	// maxExportLimit caps the number of entries exported at once, the older entries are exported with the filter
+	// before set to the ID of the last entry exported.
+	maxExportLimit = 10000
+)
+
+// The formats the activity log is exported in.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+type Service struct {
+	store store.ActivityStore
+}
+
+// New creates a new ActivityService.
+func New(str store.ActivityStore) ActivityService {
+	return &Service{store: str}
+}
+
+// Record appends an entry to the activity log on behalf of the principal of the request. The requests without a
+// principal are not recorded, they did not pass the authentication and changed nothing.
+func (s *Service) Record(ctx *gofr.Context, entry *store.Entry) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	entry.Actor = principal.Subject
+	entry.ActorMethod = principal.Method
+
+	_, err := s.store.InsertEntry(ctx, entry)
+
+	return err
+}
+
+// List returns the entries of the activity log matching the filter, the latest entries come first.
+func (s *Service) List(ctx *gofr.Context, filter *store.Filter) ([]store.Entry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+
+	filter.Limit = min(filter.Limit, maxLimit)
+
+	return s.store.GetEntries(ctx, filter)
+}
+
+// Export returns the entries of the activity log matching the filter as a CSV or a JSON document, the latest entries
+// come first.
+func (s *Service) Export(ctx *gofr.Context, filter *store.Filter, format string) ([]byte, error) {
+	if format != FormatCSV && format != FormatJSON {
+		return nil, http.ErrorInvalidParam{Params: []string{"format"}}
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = maxExportLimit
+	}
+
+	filter.Limit = min(filter.Limit, maxExportLimit)
+
+	entries, err := s.store.GetEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatJSON {
+		return json.Marshal(entries)
+	}
+
+	return toCSV(entries)
+}
+
+// toCSV writes an entry per row, the parameters are written as a JSON object.
+func toCSV(entries []store.Entry) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	err := w.Write([]string{"id", "created_at", "organization_id", "actor", "actor_method", "action", "target_type",
+		"target_id", "method", "path", "parameters", "outcome", "error"})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		e := &entries[i]
+
+		var params []byte
+
+		if len(e.Parameters) > 0 {
+			params, err = json.Marshal(e.Parameters)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = w.Write([]string{strconv.FormatInt(e.ID, 10), e.CreatedAt, strconv.FormatInt(e.OrganizationID, 10), e.Actor,
+			e.ActorMethod, e.Action, e.TargetType, e.TargetID, e.Method, e.Path, string(params), e.Outcome, e.Error})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
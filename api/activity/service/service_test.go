@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/activity/store"
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+func TestService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockActivityStore(ctrl)
+	s := New(mockStore)
+
+	ctx := &gofr.Context{Context: auth.NewContext(context.Background(),
+		&auth.Principal{Subject: "api-key:1", Method: auth.MethodAPIKey, Organization: 2})}
+	entry := &store.Entry{Action: "cloud_account.sync_resources", TargetType: "cloud_account", TargetID: "3",
+		Outcome: store.OutcomeSuccess}
+
+	mockStore.EXPECT().InsertEntry(ctx, &store.Entry{Actor: "api-key:1", ActorMethod: auth.MethodAPIKey,
+		Action: "cloud_account.sync_resources", TargetType: "cloud_account", TargetID: "3", Outcome: store.OutcomeSuccess}).
+		Return(entry, nil)
+
+	require.NoError(t, s.Record(ctx, entry))
+
+	mockStore.EXPECT().InsertEntry(ctx, gomock.Any()).Return(nil, assert.AnError)
+
+	require.Equal(t, assert.AnError, s.Record(ctx, &store.Entry{}))
+
+	// The requests without a principal are not recorded.
+	require.NoError(t, s.Record(&gofr.Context{Context: context.Background()}, &store.Entry{}))
+}
+
+func TestService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockActivityStore(ctrl)
+	s := New(mockStore)
+	ctx := &gofr.Context{Context: context.Background()}
+
+	mockStore.EXPECT().GetEntries(ctx, &store.Filter{Actor: "admin@example.com", Limit: defaultLimit}).
+		Return([]store.Entry{{ID: 1}}, nil)
+
+	entries, err := s.List(ctx, &store.Filter{Actor: "admin@example.com"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []store.Entry{{ID: 1}}, entries)
+
+	mockStore.EXPECT().GetEntries(ctx, &store.Filter{Limit: maxLimit}).Return(nil, assert.AnError)
+
+	_, err = s.List(ctx, &store.Filter{Limit: 5000})
+
+	require.Equal(t, assert.AnError, err)
+}
+
+func TestService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockActivityStore(ctrl)
+	s := New(mockStore)
+	ctx := &gofr.Context{Context: context.Background()}
+
+	entries := []store.Entry{
+		{ID: 8, OrganizationID: 1, Actor: "api-key:1", ActorMethod: "api_key", Action: "resource_group.delete",
+			TargetType: "resource_group", TargetID: "5", Method: "DELETE", Path: "/cloud-account/3/resource-groups/5",
+			Outcome: store.OutcomeFailure, Error: "resource group not found", CreatedAt: "2025-07-05T11:00:00Z"},
+		{ID: 7, OrganizationID: 1, Actor: "admin@example.com", ActorMethod: "oidc", Action: "cloud_account.update",
+			TargetType: "cloud_account", TargetID: "3", Method: "PATCH", Path: "/cloud-accounts/3",
+			Parameters: map[string]any{"name": "prod"}, Outcome: store.OutcomeSuccess, CreatedAt: "2025-07-05T10:00:00Z"},
+	}
+
+	mockStore.EXPECT().GetEntries(ctx, &store.Filter{Limit: maxExportLimit}).Return(entries, nil).Times(2)
+
+	csv, err := s.Export(ctx, &store.Filter{}, FormatCSV)
+
+	require.NoError(t, err)
+	assert.Equal(t, "id,created_at,organization_id,actor,actor_method,action,target_type,target_id,method,path,parameters,"+
+		"outcome,error\n"+
+		"8,2025-07-05T11:00:00Z,1,api-key:1,api_key,resource_group.delete,resource_group,5,DELETE,"+
+		"/cloud-account/3/resource-groups/5,,failure,resource group not found\n"+
+		`7,2025-07-05T10:00:00Z,1,admin@example.com,oidc,cloud_account.update,cloud_account,3,PATCH,/cloud-accounts/3,`+
+		`"{""name"":""prod""}",success,`+"\n", string(csv))
+
+	json, err := s.Export(ctx, &store.Filter{}, FormatJSON)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(json), `"parameters":{"name":"prod"}`)
+
+	_, err = s.Export(ctx, &store.Filter{}, "xml")
+
+	require.Equal(t, http.ErrorInvalidParam{Params: []string{"format"}}, err)
+
+	mockStore.EXPECT().GetEntries(ctx, &store.Filter{Limit: 10}).Return(nil, assert.AnError)
+
+	_, err = s.Export(ctx, &store.Filter{Limit: 10}, FormatCSV)
+
+	require.Equal(t, assert.AnError, err)
+}
@@ -0,0 +1,8 @@
+package store
+
+import "gofr.dev/pkg/gofr"
+
+type ActivityStore interface {
+	InsertEntry(ctx *gofr.Context, entry *Entry) (*Entry, error)
+	GetEntries(ctx *gofr.Context, filter *Filter) ([]Entry, error)
+}
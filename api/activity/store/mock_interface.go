@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mock_interface.go -package=store
+//
+
+// Package store is a generated GoMock package.
+package store
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+)
+
+// MockActivityStore is a mock of ActivityStore interface.
+type MockActivityStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityStoreMockRecorder is the mock recorder for MockActivityStore.
+type MockActivityStoreMockRecorder struct {
+	mock *MockActivityStore
+}
+
+// NewMockActivityStore creates a new mock instance.
+func NewMockActivityStore(ctrl *gomock.Controller) *MockActivityStore {
+	mock := &MockActivityStore{ctrl: ctrl}
+	mock.recorder = &MockActivityStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityStore) EXPECT() *MockActivityStoreMockRecorder {
+	return m.recorder
+}
+
+// GetEntries mocks base method.
+func (m *MockActivityStore) GetEntries(ctx *gofr.Context, filter *Filter) ([]Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", ctx, filter)
+	ret0, _ := ret[0].([]Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockActivityStoreMockRecorder) GetEntries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockActivityStore)(nil).GetEntries), ctx, filter)
+}
+
+// InsertEntry mocks base method.
+func (m *MockActivityStore) InsertEntry(ctx *gofr.Context, entry *Entry) (*Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEntry", ctx, entry)
+	ret0, _ := ret[0].(*Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertEntry indicates an expected call of InsertEntry.
+func (mr *MockActivityStoreMockRecorder) InsertEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEntry", reflect.TypeOf((*MockActivityStore)(nil).InsertEntry), ctx, entry)
+}
@@ -0,0 +1,45 @@
+// Package store persists the activity log, it is only appended to.
+package store
+
+import "time"
+
+// The outcomes of the recorded requests.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Entry is a change made through the API, or attempted.
+type Entry struct {
+	ID             int64 `json:"id"`
+	OrganizationID int64 `json:"organizationId"`
+	// Actor is the subject of the principal of the request, e.g. the email of a user or the subject of an API key.
+	Actor       string `json:"actor"`
+	ActorMethod string `json:"actorMethod"`
+	// Action names the change, e.g. cloud_account.create or resource.change_state.
+	Action     string `json:"action"`
+	TargetType string `json:"targetType"`
+	// TargetID is the ID of the entity changed, it is empty when it is not known, e.g. a creation that failed.
+	TargetID string `json:"targetId,omitempty"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	// Parameters holds the query parameters and the body of the request, their secrets redacted.
+	Parameters map[string]any `json:"parameters,omitempty"`
+	Outcome    string         `json:"outcome"`
+	Error      string         `json:"error,omitempty"`
+	CreatedAt  string         `json:"createdAt"`
+}
+
+// Filter holds the criteria the activity log is queried by, the empty criteria are not applied.
+type Filter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	Outcome    string
+	Since      time.Time
+	Until      time.Time
+	// Before pages through the log, only the entries older than the entry with this ID are returned.
+	Before int64
+	Limit  int
+}
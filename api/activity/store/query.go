@@ -0,0 +1,11 @@
+package store
+
+// The entries of an organization are filtered by organization_id = COALESCE(?, organization_id), given
+// auth.OrganizationFilter.
+const (
+	INSERTENTRYQUERY = "INSERT INTO activity_log (organization_id, actor, actor_method, action, target_type, target_id," +
+		" method, path, parameters, outcome, error, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	// GETENTRIESQUERY is completed by the criteria of the filter, the order and the limit.
+	GETENTRIESQUERY = "SELECT id, organization_id, actor, actor_method, action, target_type, target_id, method, path," +
+		" parameters, outcome, error, created_at FROM activity_log WHERE organization_id = COALESCE(?, organization_id)"
+)
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+type Store struct{}
+
+// New creates a new ActivityStore.
+func New() ActivityStore {
+	return &Store{}
+}
+
+// InsertEntry appends an entry to the activity log of the organization of the request.
+func (*Store) InsertEntry(ctx *gofr.Context, entry *Entry) (*Entry, error) {
+	entry.OrganizationID = auth.OrganizationID(ctx)
+	entry.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	var params sql.NullString
+
+	if len(entry.Parameters) > 0 {
+		b, err := json.Marshal(entry.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		params = sql.NullString{String: string(b), Valid: true}
+	}
+
+	res, err := ctx.SQL.ExecContext(ctx, INSERTENTRYQUERY, entry.OrganizationID, entry.Actor, entry.ActorMethod,
+		entry.Action, entry.TargetType, entry.TargetID, entry.Method, entry.Path, params, entry.Outcome,
+		sql.NullString{String: entry.Error, Valid: entry.Error != ""}, entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetEntries returns the entries of the activity log of the organization of the request matching the filter, the
+// latest entries come first.
+func (*Store) GetEntries(ctx *gofr.Context, filter *Filter) ([]Entry, error) {
+	var (
+		query = GETENTRIESQUERY
+		args  = []any{auth.OrganizationFilter(ctx)}
+	)
+
+	for _, c := range []struct{ column, value string }{
+		{"actor", filter.Actor},
+		{"action", filter.Action},
+		{"target_type", filter.TargetType},
+		{"target_id", filter.TargetID},
+		{"outcome", filter.Outcome},
+	} {
+		if c.value != "" {
+			query += " AND " + c.column + " = ?"
+
+			args = append(args, c.value)
+		}
+	}
+
+	// created_at is stored in UTC in RFC 3339, hence the times are formatted the same way to compare them.
+	if !filter.Since.IsZero() {
+		query += " AND created_at >= ?"
+
+		args = append(args, filter.Since.UTC().Format(time.RFC3339))
+	}
+
+	if !filter.Until.IsZero() {
+		query += " AND created_at < ?"
+
+		args = append(args, filter.Until.UTC().Format(time.RFC3339))
+	}
+
+	if filter.Before > 0 {
+		query += " AND id < ?"
+
+		args = append(args, filter.Before)
+	}
+
+	rows, err := ctx.SQL.QueryContext(ctx, query+" ORDER BY id DESC LIMIT ?;", append(args, filter.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]Entry, 0)
+
+	for rows.Next() {
+		entry, er := scanEntry(rows)
+		if er != nil {
+			return nil, er
+		}
+
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func scanEntry(rows *sql.Rows) (*Entry, error) {
+	var (
+		entry          Entry
+		params, errMsg sql.NullString
+	)
+
+	err := rows.Scan(&entry.ID, &entry.OrganizationID, &entry.Actor, &entry.ActorMethod, &entry.Action, &entry.TargetType,
+		&entry.TargetID, &entry.Method, &entry.Path, &params, &entry.Outcome, &errMsg, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Valid {
+		if err = json.Unmarshal([]byte(params.String), &entry.Parameters); err != nil {
+			return nil, err
+		}
+	}
+
+	entry.Error = errMsg.String
+
+	return &entry, nil
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+var entryColumns = []string{"id", "organization_id", "actor", "actor_method", "action", "target_type", "target_id", "method",
+	"path", "parameters", "outcome", "error", "created_at"}
+
+// organizationContext returns the context of a request in the organization.
+func organizationContext(t *testing.T, organization int64) (*gofr.Context, *container.Mocks) {
+	t.Helper()
+
+	mockContainer, mock := container.NewMockContainer(t)
+	p := &auth.Principal{Subject: "admin@example.com", Organization: organization}
+
+	return &gofr.Context{Context: auth.NewContext(context.Background(), p), Container: mockContainer}, mock
+}
+
+func TestStore_InsertEntry(t *testing.T) {
+	ctx, mock := organizationContext(t, 2)
+
+	mock.SQL.ExpectExec(INSERTENTRYQUERY).WithArgs(int64(2), "admin@example.com", "oidc", "cloud_account.update",
+		"cloud_account", "3", "PATCH", "/cloud-accounts/3", sql.NullString{String: `{"name":"prod"}`, Valid: true},
+		OutcomeSuccess, sql.NullString{}, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	entry, err := New().InsertEntry(ctx, &Entry{Actor: "admin@example.com", ActorMethod: "oidc",
+		Action: "cloud_account.update", TargetType: "cloud_account", TargetID: "3", Method: "PATCH",
+		Path: "/cloud-accounts/3", Parameters: map[string]any{"name": "prod"}, Outcome: OutcomeSuccess})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), entry.ID)
+	assert.Equal(t, int64(2), entry.OrganizationID)
+	assert.NotEmpty(t, entry.CreatedAt)
+
+	mock.SQL.ExpectExec(INSERTENTRYQUERY).WithArgs(int64(2), "admin@example.com", "oidc", "api_key.revoke", "api_key",
+		"4", "DELETE", "/api-keys/4", sql.NullString{}, OutcomeFailure, sql.NullString{String: "not found", Valid: true},
+		sqlmock.AnyArg()).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err = New().InsertEntry(ctx, &Entry{Actor: "admin@example.com", ActorMethod: "oidc", Action: "api_key.revoke",
+		TargetType: "api_key", TargetID: "4", Method: "DELETE", Path: "/api-keys/4", Outcome: OutcomeFailure,
+		Error: "not found"})
+
+	require.Equal(t, sql.ErrConnDone, err)
+}
+
+func TestStore_GetEntries(t *testing.T) {
+	ctx, mock := organizationContext(t, 2)
+
+	mock.SQL.ExpectQuery(GETENTRIESQUERY+" ORDER BY id DESC LIMIT ?;").WithArgs(int64(2), 100).
+		WillReturnRows(sqlmock.NewRows(entryColumns).
+			AddRow(8, 2, "api-key:1", "api_key", "resource_group.delete", "resource_group", "5", "DELETE",
+				"/cloud-account/3/resource-groups/5", nil, OutcomeFailure, "resource group not found", "2025-07-05T11:00:00Z").
+			AddRow(7, 2, "admin@example.com", "oidc", "cloud_account.update", "cloud_account", "3", "PATCH",
+				"/cloud-accounts/3", `{"name":"prod"}`, OutcomeSuccess, nil, "2025-07-05T10:00:00Z"))
+
+	entries, err := New().GetEntries(ctx, &Filter{Limit: 100})
+
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{ID: 8, OrganizationID: 2, Actor: "api-key:1", ActorMethod: "api_key", Action: "resource_group.delete",
+			TargetType: "resource_group", TargetID: "5", Method: "DELETE", Path: "/cloud-account/3/resource-groups/5",
+			Outcome: OutcomeFailure, Error: "resource group not found", CreatedAt: "2025-07-05T11:00:00Z"},
+		{ID: 7, OrganizationID: 2, Actor: "admin@example.com", ActorMethod: "oidc", Action: "cloud_account.update",
+			TargetType: "cloud_account", TargetID: "3", Method: "PATCH", Path: "/cloud-accounts/3",
+			Parameters: map[string]any{"name": "prod"}, Outcome: OutcomeSuccess, CreatedAt: "2025-07-05T10:00:00Z"},
+	}, entries)
+}
+
+func TestStore_GetEntries_Filter(t *testing.T) {
+	ctx, mock := organizationContext(t, 2)
+	since := time.Date(2025, 7, 1, 2, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	mock.SQL.ExpectQuery(GETENTRIESQUERY+" AND actor = ? AND target_type = ? AND target_id = ? AND outcome = ?"+
+		" AND created_at >= ? AND created_at < ? AND id < ? ORDER BY id DESC LIMIT ?;").
+		WithArgs(int64(2), "admin@example.com", "cloud_account", "3", OutcomeFailure, "2025-07-01T00:00:00Z",
+			"2025-07-02T00:00:00Z", int64(50), 10).
+		WillReturnRows(sqlmock.NewRows(entryColumns))
+
+	entries, err := New().GetEntries(ctx, &Filter{Actor: "admin@example.com", TargetType: "cloud_account", TargetID: "3",
+		Outcome: OutcomeFailure, Since: since, Until: since.Add(24 * time.Hour), Before: 50, Limit: 10})
+
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	mock.SQL.ExpectQuery(GETENTRIESQUERY+" AND action = ? ORDER BY id DESC LIMIT ?;").
+		WithArgs(int64(2), "api_key.create", 10).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err = New().GetEntries(ctx, &Filter{Action: "api_key.create", Limit: 10})
+
+	require.Equal(t, sql.ErrConnDone, err)
+}
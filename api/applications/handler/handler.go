@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/zopdev/zopdev/api/applications/service"
 	"github.com/zopdev/zopdev/api/applications/store"
+	"github.com/zopdev/zopdev/api/auth"
 
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/http"
@@ -47,7 +49,10 @@ func (h *Handler) ListApplications(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	return applications, nil
+	// Only the applications the principal of the request may view are listed.
+	return slices.DeleteFunc(applications, func(app store.Application) bool {
+		return !auth.Can(ctx, auth.RoleViewer, auth.Application(app.ID))
+	}), nil
 }
 
 func (h *Handler) GetApplication(ctx *gofr.Context) (interface{}, error) {
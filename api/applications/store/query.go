@@ -1,9 +1,14 @@
 package store
 
+// The applications are filtered by organization_id = COALESCE(?, organization_id), given auth.OrganizationFilter.
 const (
-	INSERTQUERY            = "INSERT INTO application ( name) VALUES ( ?);"
-	GETALLQUERY            = "SELECT id, name, created_at, updated_at FROM application WHERE deleted_at IS NULL;"
-	GETBYNAMEQUERY         = "SELECT id, name, created_at, updated_at FROM application WHERE name = ? and deleted_at IS NULL;"
+	INSERTQUERY = "INSERT INTO application (organization_id, name) VALUES (?, ?);"
+	GETALLQUERY = "SELECT id, name, created_at, updated_at FROM application WHERE organization_id = COALESCE(?, organization_id)" +
+		" and deleted_at IS NULL;"
+	// The names are unique in an organization.
+	GETBYNAMEQUERY = "SELECT id, name, created_at, updated_at FROM application WHERE name = ?" +
+		" and organization_id = COALESCE(?, organization_id) and deleted_at IS NULL;"
 	INSERTENVIRONMENTQUERY = "INSERT INTO environment (name,level,application_id) VALUES ( ?,?, ?);"
-	GETBYIDQUERY           = "SELECT id, name, created_at, updated_at FROM application WHERE id = ? and deleted_at IS NULL;"
+	GETBYIDQUERY           = "SELECT id, name, created_at, updated_at FROM application WHERE id = ?" +
+		" and organization_id = COALESCE(?, organization_id) and deleted_at IS NULL;"
 )
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
 )
 
 type Store struct{}
@@ -12,7 +14,7 @@ func New() ApplicationStore {
 	return &Store{}
 }
 func (*Store) InsertApplication(ctx *gofr.Context, application *Application) (*Application, error) {
-	res, err := ctx.SQL.ExecContext(ctx, INSERTQUERY, application.Name)
+	res, err := ctx.SQL.ExecContext(ctx, INSERTQUERY, auth.OrganizationID(ctx), application.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -24,7 +26,7 @@ func (*Store) InsertApplication(ctx *gofr.Context, application *Application) (*A
 }
 
 func (*Store) GetALLApplications(ctx *gofr.Context) ([]Application, error) {
-	rows, err := ctx.SQL.QueryContext(ctx, GETALLQUERY)
+	rows, err := ctx.SQL.QueryContext(ctx, GETALLQUERY, auth.OrganizationFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +52,7 @@ func (*Store) GetALLApplications(ctx *gofr.Context) ([]Application, error) {
 }
 
 func (*Store) GetApplicationByName(ctx *gofr.Context, name string) (*Application, error) {
-	row := ctx.SQL.QueryRowContext(ctx, GETBYNAMEQUERY, name)
+	row := ctx.SQL.QueryRowContext(ctx, GETBYNAMEQUERY, name, auth.OrganizationFilter(ctx))
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
@@ -67,7 +69,7 @@ func (*Store) GetApplicationByName(ctx *gofr.Context, name string) (*Application
 }
 
 func (*Store) GetApplicationByID(ctx *gofr.Context, id int) (*Application, error) {
-	row := ctx.SQL.QueryRowContext(ctx, GETBYIDQUERY, id)
+	row := ctx.SQL.QueryRowContext(ctx, GETBYIDQUERY, id, auth.OrganizationFilter(ctx))
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/auth"
 )
 
 func TestInsertApplication(t *testing.T) {
@@ -34,7 +36,7 @@ func TestInsertApplication(t *testing.T) {
 			expectedError: false,
 			mockBehavior: func() {
 				mock.SQL.ExpectExec(INSERTQUERY).
-					WithArgs(application.Name).
+					WithArgs(auth.DefaultOrganization, application.Name).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -44,7 +46,7 @@ func TestInsertApplication(t *testing.T) {
 			expectedError: true,
 			mockBehavior: func() {
 				mock.SQL.ExpectExec(INSERTQUERY).
-					WithArgs(application.Name).
+					WithArgs(auth.DefaultOrganization, application.Name).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -88,7 +90,7 @@ func TestGetALLApplications(t *testing.T) {
 			mockBehavior: func() {
 				mockRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
 					AddRow(1, "Test Application", time.Now(), time.Now())
-				mock.SQL.ExpectQuery(GETALLQUERY).
+				mock.SQL.ExpectQuery(GETALLQUERY).WithArgs(nil).
 					WillReturnRows(mockRows)
 			},
 			expectedError: false,
@@ -97,7 +99,7 @@ func TestGetALLApplications(t *testing.T) {
 		{
 			name: "failure on query execution",
 			mockBehavior: func() {
-				mock.SQL.ExpectQuery(GETALLQUERY).
+				mock.SQL.ExpectQuery(GETALLQUERY).WithArgs(nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectedError: true,
@@ -146,7 +148,7 @@ func TestGetApplicationByName(t *testing.T) {
 				mockRow := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
 					AddRow(1, "Test Application", time.Now(), time.Now())
 				mock.SQL.ExpectQuery(GETBYNAMEQUERY).
-					WithArgs("Test Application").
+					WithArgs("Test Application", nil).
 					WillReturnRows(mockRow)
 			},
 			expectedError: false,
@@ -158,7 +160,7 @@ func TestGetApplicationByName(t *testing.T) {
 			appName: "Non-existent Application",
 			mockBehavior: func() {
 				mock.SQL.ExpectQuery(GETBYNAMEQUERY).
-					WithArgs("Non-existent Application").
+					WithArgs("Non-existent Application", nil).
 					WillReturnRows(sqlmock.NewRows(nil))
 			},
 			expectedError: true,
@@ -170,7 +172,7 @@ func TestGetApplicationByName(t *testing.T) {
 			appName: "Test Application",
 			mockBehavior: func() {
 				mock.SQL.ExpectQuery(GETBYNAMEQUERY).
-					WithArgs("Test Application").
+					WithArgs("Test Application", nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectedError: true,
@@ -226,7 +228,7 @@ func TestGetApplicationByID(t *testing.T) {
 				mockRow := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
 					AddRow(1, "Test Application", time.Now(), time.Now())
 				mock.SQL.ExpectQuery(GETBYIDQUERY).
-					WithArgs(1).
+					WithArgs(1, nil).
 					WillReturnRows(mockRow)
 			},
 			expectedError: false,
@@ -238,7 +240,7 @@ func TestGetApplicationByID(t *testing.T) {
 			appID: 1,
 			mockBehavior: func() {
 				mock.SQL.ExpectQuery(GETBYIDQUERY).
-					WithArgs(1).
+					WithArgs(1, nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectedError: true,
@@ -250,7 +252,7 @@ func TestGetApplicationByID(t *testing.T) {
 			appID: 1,
 			mockBehavior: func() {
 				mock.SQL.ExpectQuery(GETBYIDQUERY).
-					WithArgs(1).
+					WithArgs(1, nil).
 					WillReturnRows(sqlmock.NewRows(nil))
 			},
 			expectedError: true,
@@ -282,3 +284,18 @@ func TestGetApplicationByID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetALLApplications_Organization(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	p := &auth.Principal{Subject: "dev@example.com", Organization: 2}
+	ctx := &gofr.Context{Context: auth.NewContext(context.Background(), p), Container: mockContainer}
+
+	mock.SQL.ExpectQuery(GETALLQUERY).WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow(3, "payments", time.Now(), time.Now()))
+
+	applications, err := New().GetALLApplications(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, applications, 1)
+}
@@ -0,0 +1,13 @@
+package client
+
+import "gofr.dev/pkg/gofr"
+
+// CredentialProvider fetches the credentials of the cloud accounts in-process.
+type CredentialProvider interface {
+	FetchCredentials(ctx *gofr.Context, cloudAccountID int64) (interface{}, error)
+}
+
+// ServiceAuth authenticates the calls made to the internal routes of the cloud-account service.
+type ServiceAuth interface {
+	Headers(service string) map[string]string
+}
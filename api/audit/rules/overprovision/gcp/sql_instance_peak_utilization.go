@@ -1,8 +1,6 @@
 package gcp
 
 import (
-	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -13,7 +11,6 @@ import (
 
 	"gofr.dev/pkg/gofr"
 
-	"golang.org/x/oauth2/google"
 	"golang.org/x/sync/errgroup"
 
 	"google.golang.org/api/iterator"
@@ -22,6 +19,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zopdev/zopdev/api/audit/store"
+	gcpProvider "github.com/zopdev/zopdev/api/resources/providers/gcp"
 )
 
 var (
@@ -34,6 +32,8 @@ var (
 )
 
 const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 	// Status levels used to classify CPU utilization.
 	danger    = "danger"    // CPU usage is too high or too low; system may be under-provisioned or overloaded.
 	warning   = "warning"   // CPU usage is  within tolerable limits.
@@ -47,12 +47,24 @@ const (
 )
 
 // CheckCloudSQLProvisionedUsage checks the provisioned usage of Cloud SQL instances
-// in a given Google Cloud project. It retrieves the list of Cloud SQL instances
-// and their utilization metrics using the Google Cloud SQL Admin API and the
+// in the Google Cloud projects of a cloud account. It retrieves the list of Cloud SQL instances
+// of every project and their utilization metrics using the Google Cloud SQL Admin API and the
 // Cloud Monitoring API.
 func CheckCloudSQLProvisionedUsage(ctx *gofr.Context, creds any) ([]store.Items, error) {
-	cred, err := getGoogleCredentials(ctx, creds)
+	if creds == nil {
+		return nil, errInvalidGCPCreds
+	}
+
+	gcpClient := gcpProvider.New()
+
+	cred, err := gcpClient.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, errInvalidJSONCredentials
+	}
+
+	projects, err := gcpClient.GetProjects(ctx, creds)
 	if err != nil {
+		ctx.Errorf("failed to list projects: %v", err)
 		return nil, err
 	}
 
@@ -62,12 +74,6 @@ func CheckCloudSQLProvisionedUsage(ctx *gofr.Context, creds any) ([]store.Items,
 		return nil, errCreateSQLAdminService
 	}
 
-	instancesList, err := sqlService.Instances.List(cred.ProjectID).Do()
-	if err != nil {
-		ctx.Errorf("failed to list instances: %v", err)
-		return nil, errListCloudSQLInstances
-	}
-
 	monitoringClient, err := monitoring.NewMetricClient(ctx, option.WithCredentials(cred))
 	if err != nil {
 		ctx.Errorf("failed to create monitoring client: %v", err)
@@ -76,7 +82,25 @@ func CheckCloudSQLProvisionedUsage(ctx *gofr.Context, creds any) ([]store.Items,
 
 	defer monitoringClient.Close()
 
-	return getResult(ctx, cred.ProjectID, instancesList, monitoringClient)
+	results := make([]store.Items, 0)
+
+	for _, projectID := range projects {
+		instancesList, er := sqlService.Instances.List(projectID).Do()
+		if er != nil {
+			ctx.Errorf("failed to list instances of project %s: %v", projectID, er)
+			return results, errListCloudSQLInstances
+		}
+
+		items, er := getResult(ctx, projectID, instancesList, monitoringClient)
+
+		results = append(results, items...)
+
+		if er != nil {
+			return results, er
+		}
+	}
+
+	return results, nil
 }
 
 func getResult(ctx *gofr.Context, projectID string,
@@ -128,6 +152,7 @@ func getResult(ctx *gofr.Context, projectID string,
 
 			meta := map[string]any{
 				"peak_utilization": peakUsage,
+				"project":          projectID,
 			}
 
 			mu.Lock()
@@ -148,28 +173,3 @@ func getResult(ctx *gofr.Context, projectID string,
 
 	return results, nil
 }
-
-func getGoogleCredentials(ctx context.Context, creds any) (*google.Credentials, error) {
-	if creds == nil {
-		return nil, errInvalidGCPCreds
-	}
-
-	b, err := json.Marshal(creds)
-	if err != nil {
-		return nil, errInvalidGCPCreds
-	}
-
-	var gcpCred Credentials
-
-	err = json.Unmarshal(b, &gcpCred)
-	if err != nil {
-		return nil, errInvalidGCPCreds
-	}
-
-	cred, err := google.CredentialsFromJSON(ctx, b, "https://www.googleapis.com/auth/cloud-platform")
-	if err != nil {
-		return nil, errInvalidJSONCredentials
-	}
-
-	return cred, nil
-}
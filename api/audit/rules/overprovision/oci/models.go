@@ -1,10 +1,8 @@
 package oci
 
-type Credentials struct {
-	TenancyOCID string `json:"tenancy_ocid"`
-	UserOCID    string `json:"user_ocid"`
-	Region      string `json:"region"`
-	Fingerprint string `json:"fingerprint"`
-	PrivateKey  string `json:"private_key"`
-	Compartment string `json:"compartment"`
-}
+import (
+	ociProvider "github.com/zopdev/zopdev/api/resources/providers/oci"
+)
+
+// Credentials are the credentials of an OCI cloud account, the same ones are used to sync its resources.
+type Credentials = ociProvider.Credentials
@@ -21,3 +21,8 @@ type Store interface {
 	UpdateResult(ctx *gofr.Context, result *store.Result) error
 	CreatePending(ctx *gofr.Context, result *store.Result) (*store.Result, error)
 }
+
+// CloudAccountClient fetches the credentials of the cloud accounts from the cloud-account module.
+type CloudAccountClient interface {
+	GetCloudCredentials(ctx *gofr.Context, cloudAccID int64) (*client.CloudAccount, error)
+}
@@ -152,3 +152,42 @@ func (mr *MockStoreMockRecorder) UpdateResult(ctx, result any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResult", reflect.TypeOf((*MockStore)(nil).UpdateResult), ctx, result)
 }
+
+// MockCloudAccountClient is a mock of CloudAccountClient interface.
+type MockCloudAccountClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudAccountClientMockRecorder
+	isgomock struct{}
+}
+
+// MockCloudAccountClientMockRecorder is the mock recorder for MockCloudAccountClient.
+type MockCloudAccountClientMockRecorder struct {
+	mock *MockCloudAccountClient
+}
+
+// NewMockCloudAccountClient creates a new mock instance.
+func NewMockCloudAccountClient(ctrl *gomock.Controller) *MockCloudAccountClient {
+	mock := &MockCloudAccountClient{ctrl: ctrl}
+	mock.recorder = &MockCloudAccountClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudAccountClient) EXPECT() *MockCloudAccountClientMockRecorder {
+	return m.recorder
+}
+
+// GetCloudCredentials mocks base method.
+func (m *MockCloudAccountClient) GetCloudCredentials(ctx *gofr.Context, cloudAccID int64) (*client.CloudAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCloudCredentials", ctx, cloudAccID)
+	ret0, _ := ret[0].(*client.CloudAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCloudCredentials indicates an expected call of GetCloudCredentials.
+func (mr *MockCloudAccountClientMockRecorder) GetCloudCredentials(ctx, cloudAccID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudCredentials", reflect.TypeOf((*MockCloudAccountClient)(nil).GetCloudCredentials), ctx, cloudAccID)
+}
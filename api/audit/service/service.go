@@ -6,7 +6,6 @@ import (
 	"gofr.dev/pkg/gofr"
 	gofrHttp "gofr.dev/pkg/gofr/http"
 
-	"github.com/zopdev/zopdev/api/audit/client"
 	"github.com/zopdev/zopdev/api/audit/rules/overprovision"
 	"github.com/zopdev/zopdev/api/audit/store"
 )
@@ -17,12 +16,14 @@ type Service struct {
 	rules           map[string]Rule
 	categoryRuleMap map[string][]Rule
 
-	store Store
+	store    Store
+	cloudAcc CloudAccountClient
 }
 
-func New(str Store) *Service {
+func New(str Store, cloudAcc CloudAccountClient) *Service {
 	s := &Service{
-		store: str,
+		store:    str,
+		cloudAcc: cloudAcc,
 
 		rules:           make(map[string]Rule),
 		categoryRuleMap: make(map[string][]Rule),
@@ -58,7 +59,7 @@ func (s *Service) RunByID(ctx *gofr.Context, ruleID string, cloudAccID int64) (*
 		return nil, gofrHttp.ErrorEntityNotFound{Name: "Rule", Value: ruleID}
 	}
 
-	ca, err := client.GetCloudCredentials(ctx, cloudAccID)
+	ca, err := s.cloudAcc.GetCloudCredentials(ctx, cloudAccID)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +95,7 @@ func (s *Service) RunByID(ctx *gofr.Context, ruleID string, cloudAccID int64) (*
 func (s *Service) RunByCategory(ctx *gofr.Context, category string, cloudAccID int64) ([]*store.Result, error) {
 	results := make([]*store.Result, 0)
 
-	ca, err := client.GetCloudCredentials(ctx, cloudAccID)
+	ca, err := s.cloudAcc.GetCloudCredentials(ctx, cloudAccID)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +140,7 @@ func (s *Service) RunByCategory(ctx *gofr.Context, category string, cloudAccID i
 func (s *Service) RunAll(ctx *gofr.Context, cloudAccID int64) (map[string][]*store.Result, error) {
 	results := make(map[string][]*store.Result)
 
-	ca, err := client.GetCloudCredentials(ctx, cloudAccID)
+	ca, err := s.cloudAcc.GetCloudCredentials(ctx, cloudAccID)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func TestService_RunByID(t *testing.T) {
 	ctx, ctrl, mockStore, mockRule, mock := InitlizeTests(t)
 	defer ctrl.Finish()
 
-	service := New(mockStore)
+	service := New(mockStore, newCloudAccountClient(ctrl))
 
 	// Mock rule registration
 	service.rules["rule-1"] = mockRule
@@ -63,7 +63,7 @@ func TestService_RunByID(t *testing.T) {
 			cloudAccID:    123,
 			expectedError: errMock,
 			mockCalls: func() {
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(nil, errMock)
 			},
 		},
@@ -78,7 +78,7 @@ func TestService_RunByID(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
 					Return(nil, errMock)
@@ -96,7 +96,7 @@ func TestService_RunByID(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
 					Return(mockRes, nil)
@@ -117,7 +117,7 @@ func TestService_RunByID(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
 					Return(mockRes, nil)
@@ -136,7 +136,7 @@ func TestService_RunByID(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
 					Return(mockRes, nil)
@@ -170,7 +170,7 @@ func TestService_RunByCategory(t *testing.T) {
 	mockContainer, mock := container.NewMockContainer(t, container.WithMockHTTPService("cloud-account"))
 	mockStore := NewMockStore(ctrl)
 	mockRule := NewMockRule(ctrl)
-	service := New(mockStore)
+	service := New(mockStore, newCloudAccountClient(ctrl))
 
 	// Mock rule registration
 	service.rules["rule-1"] = mockRule
@@ -207,7 +207,7 @@ func TestService_RunByCategory(t *testing.T) {
 			cloudAccID:    123,
 			expectedError: errMock,
 			mockCalls: func() {
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(nil, errMock)
 			},
 		},
@@ -222,7 +222,7 @@ func TestService_RunByCategory(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 			},
 		},
@@ -237,7 +237,7 @@ func TestService_RunByCategory(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockRule.EXPECT().GetName().Return("rule-1")
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
@@ -255,7 +255,7 @@ func TestService_RunByCategory(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
 					Return(mockRes, nil)
@@ -275,7 +275,7 @@ func TestService_RunByCategory(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
 					Return(mockRes, nil)
@@ -310,7 +310,7 @@ func TestService_RunAll(t *testing.T) {
 	mockContainer, mock := container.NewMockContainer(t, container.WithMockHTTPService("cloud-account"))
 	mockStore := NewMockStore(ctrl)
 	mockRule := NewMockRule(ctrl)
-	service := New(mockStore)
+	service := New(mockStore, newCloudAccountClient(ctrl))
 
 	// Mock rule registration
 	service.rules = map[string]Rule{
@@ -345,7 +345,7 @@ func TestService_RunAll(t *testing.T) {
 			cloudAccID:    123,
 			expectedError: errMock,
 			expectedCalls: func() {
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(nil, errMock)
 			},
 		},
@@ -360,7 +360,7 @@ func TestService_RunAll(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockRule.EXPECT().GetName().Return("rule-1")
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
@@ -377,7 +377,7 @@ func TestService_RunAll(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockRule.EXPECT().GetName().Return("rule-1")
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
@@ -398,7 +398,7 @@ func TestService_RunAll(t *testing.T) {
 					Body:       io.NopCloser(bytes.NewReader([]byte(`{"data": {"id": 123, "name": "Test Cloud Account"}}`))),
 				}
 
-				mock.HTTPService.EXPECT().Get(ctx, "cloud-accounts/123/credentials", nil).
+				mock.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/123/credentials", nil, gomock.Any()).
 					Return(resp, nil)
 				mockRule.EXPECT().GetName().Return("rule-1")
 				mockStore.EXPECT().CreatePending(ctx, gomock.Any()).
@@ -431,7 +431,7 @@ func TestService_GetResultById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := NewMockStore(ctrl)
-	service := New(mockStore)
+	service := New(mockStore, newCloudAccountClient(ctrl))
 
 	ctx := &gofr.Context{}
 
@@ -509,7 +509,7 @@ func TestService_GetResultsByCategory(t *testing.T) {
 
 	mockStore := NewMockStore(ctrl)
 	mockRule := NewMockRule(ctrl)
-	service := New(mockStore)
+	service := New(mockStore, newCloudAccountClient(ctrl))
 	service.rules = map[string]Rule{
 		"rule-1": mockRule,
 	}
@@ -582,6 +582,14 @@ func TestService_GetResultsByCategory(t *testing.T) {
 	}
 }
 
+// newCloudAccountClient returns a client fetching the credentials from the mocked cloud-account service.
+func newCloudAccountClient(ctrl *gomock.Controller) *client.Client {
+	auth := client.NewMockServiceAuth(ctrl)
+	auth.EXPECT().Headers(gomock.Any()).Return(map[string]string{"Authorization": "Bearer token"}).AnyTimes()
+
+	return client.New(nil, auth)
+}
+
 func InitlizeTests(t *testing.T) (*gofr.Context, *gomock.Controller, *MockStore, *MockRule, *container.Mocks) {
 	t.Helper()
 
@@ -0,0 +1,211 @@
+// Package auth defines the principals calling the zopdev API and the roles they are granted. A principal is
+// authenticated by an OIDC bearer token or by an API key, and acts in an organization, the tenant owning the cloud
+// accounts and the applications. It is granted roles on the whole organization, on an application or on a cloud
+// account. Every route requires a role on the scope it acts on.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Role is a set of permissions, each role includes the permissions of the roles below it.
+type Role string
+
+const (
+	// RoleViewer reads the applications, cloud accounts and resources.
+	RoleViewer Role = "viewer"
+	// RoleOperator also starts and stops resources, syncs them, runs audits and changes environments.
+	RoleOperator Role = "operator"
+	// RoleAdmin also manages cloud accounts and their credentials, applications, API keys and role bindings.
+	RoleAdmin Role = "admin"
+)
+
+// rank orders the roles by their permissions, it is 0 for an unknown role.
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2 //nolint:mnd // The roles are ranked in increasing order of permissions.
+	case RoleAdmin:
+		return 3 //nolint:mnd // The roles are ranked in increasing order of permissions.
+	default:
+		return 0
+	}
+}
+
+// Valid returns whether the role is known.
+func (r Role) Valid() bool {
+	return r.rank() > 0
+}
+
+// Includes returns whether the role grants the permissions of the required role.
+func (r Role) Includes(required Role) bool {
+	return r.Valid() && r.rank() >= required.rank()
+}
+
+// The types of scopes a role is granted on.
+const (
+	ScopeGlobal       = "global"
+	ScopeApplication  = "application"
+	ScopeCloudAccount = "cloud_account"
+	// ScopeInstallation is the scope of the whole installation, e.g. to manage its organizations. It cannot be granted,
+	// only the super admins hold roles on it.
+	ScopeInstallation = "installation"
+)
+
+// Scope is what a role is granted on: the whole organization, or a single application or cloud account.
+type Scope struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id,omitempty"`
+}
+
+// Global is the scope of the whole organization, it covers every application and cloud account of the organization.
+func Global() Scope {
+	return Scope{Type: ScopeGlobal}
+}
+
+// Application is the scope of an application and its environments.
+func Application(id int64) Scope {
+	return Scope{Type: ScopeApplication, ID: id}
+}
+
+// CloudAccount is the scope of a cloud account, its resources and audits.
+func CloudAccount(id int64) Scope {
+	return Scope{Type: ScopeCloudAccount, ID: id}
+}
+
+// Installation is the scope of the whole installation, across its organizations.
+func Installation() Scope {
+	return Scope{Type: ScopeInstallation}
+}
+
+// Valid returns whether the scope is global, or names an application or a cloud account.
+func (s Scope) Valid() bool {
+	switch s.Type {
+	case ScopeGlobal:
+		return s.ID == 0
+	case ScopeApplication, ScopeCloudAccount:
+		return s.ID > 0
+	default:
+		return false
+	}
+}
+
+// Covers returns whether a role granted on the scope applies to the other scope.
+func (s Scope) Covers(other Scope) bool {
+	return s.Type == ScopeGlobal || s == other
+}
+
+func (s Scope) String() string {
+	if s.Type == ScopeGlobal {
+		return s.Type
+	}
+
+	return s.Type + ":" + strconv.FormatInt(s.ID, 10)
+}
+
+// Binding grants a role on a scope.
+type Binding struct {
+	Role  Role  `json:"role"`
+	Scope Scope `json:"scope"`
+}
+
+// The methods authenticating a principal.
+const (
+	MethodOIDC   = "oidc"
+	MethodAPIKey = "api_key"
+	// MethodNone is the method of the anonymous principal of the requests when the authentication is disabled.
+	MethodNone = "none"
+)
+
+// Principal is an authenticated caller of the API along with the roles it is granted in the organization of the
+// request.
+type Principal struct {
+	// Subject is the user of an OIDC token, e.g. its email, or the subject of an API key.
+	Subject      string    `json:"subject"`
+	Method       string    `json:"method"`
+	Organization int64     `json:"organization"`
+	Bindings     []Binding `json:"bindings"`
+	// SuperAdmin is set for the admins of the installation, they are admins of every organization and create them.
+	SuperAdmin bool `json:"superAdmin,omitempty"`
+}
+
+// Can returns whether the principal is granted the role on the scope, or a role including it.
+func (p *Principal) Can(role Role, scope Scope) bool {
+	if scope.Type == ScopeInstallation {
+		return p.SuperAdmin
+	}
+
+	for _, b := range p.Bindings {
+		if b.Role.Includes(role) && b.Scope.Covers(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Anonymous is the principal of the requests when the authentication is disabled, it is granted every role.
+func Anonymous(organization int64) *Principal {
+	return &Principal{Subject: "anonymous", Method: MethodNone, Organization: organization,
+		Bindings: []Binding{{Role: RoleAdmin, Scope: Global()}}, SuperAdmin: true}
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of the context carrying the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of the request, it is not set for the jobs run by zopdev itself.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return p, ok && p != nil
+}
+
+// Can returns whether the principal of the request is granted the role on the scope. Everything is allowed when there
+// is no principal, i.e. for the jobs run by zopdev itself and the calls between its modules.
+func Can(ctx context.Context, role Role, scope Scope) bool {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	return p.Can(role, scope)
+}
+
+// UnauthenticatedError is returned when a request has no valid credentials.
+type UnauthenticatedError struct {
+	Err error
+}
+
+func (e UnauthenticatedError) Error() string {
+	return "unauthenticated: " + e.Err.Error()
+}
+
+func (e UnauthenticatedError) Unwrap() error {
+	return e.Err
+}
+
+func (UnauthenticatedError) StatusCode() int {
+	return http.StatusUnauthorized
+}
+
+// ForbiddenError is returned when the principal of a request is not granted the role required by the request.
+type ForbiddenError struct {
+	Role  Role
+	Scope Scope
+}
+
+func (e ForbiddenError) Error() string {
+	return "the " + string(e.Role) + " role is required on " + e.Scope.String()
+}
+
+func (ForbiddenError) StatusCode() int {
+	return http.StatusForbidden
+}
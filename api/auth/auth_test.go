@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Includes(t *testing.T) {
+	testCases := []struct {
+		role     Role
+		required Role
+		expected bool
+	}{
+		{RoleAdmin, RoleViewer, true},
+		{RoleAdmin, RoleAdmin, true},
+		{RoleOperator, RoleViewer, true},
+		{RoleOperator, RoleAdmin, false},
+		{RoleViewer, RoleOperator, false},
+		{Role("owner"), RoleViewer, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.role)+"/"+string(tc.required), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.role.Includes(tc.required))
+		})
+	}
+}
+
+func TestScope_Valid(t *testing.T) {
+	assert.True(t, Global().Valid())
+	assert.True(t, Application(1).Valid())
+	assert.True(t, CloudAccount(1).Valid())
+	assert.False(t, Scope{Type: ScopeGlobal, ID: 1}.Valid())
+	assert.False(t, CloudAccount(0).Valid())
+	assert.False(t, Scope{Type: "project", ID: 1}.Valid())
+}
+
+func TestScope_Covers(t *testing.T) {
+	assert.True(t, Global().Covers(CloudAccount(2)))
+	assert.True(t, CloudAccount(2).Covers(CloudAccount(2)))
+	assert.False(t, CloudAccount(2).Covers(CloudAccount(3)))
+	assert.False(t, CloudAccount(2).Covers(Application(2)))
+	assert.False(t, Application(2).Covers(Global()))
+}
+
+func TestPrincipal_Can(t *testing.T) {
+	p := &Principal{Subject: "dev@example.com", Bindings: []Binding{
+		{Role: RoleOperator, Scope: CloudAccount(1)},
+		{Role: RoleViewer, Scope: Global()},
+	}}
+
+	assert.True(t, p.Can(RoleOperator, CloudAccount(1)))
+	assert.True(t, p.Can(RoleViewer, Application(4)))
+	assert.False(t, p.Can(RoleOperator, CloudAccount(2)))
+	assert.False(t, p.Can(RoleAdmin, CloudAccount(1)))
+	assert.False(t, p.Can(RoleViewer, Installation()))
+	assert.True(t, Anonymous(1).Can(RoleAdmin, Global()))
+	assert.True(t, Anonymous(1).Can(RoleAdmin, Installation()))
+}
+
+func TestCan(t *testing.T) {
+	ctx := context.Background()
+
+	assert.True(t, Can(ctx, RoleAdmin, Global()), "the jobs run by zopdev have no principal")
+
+	ctx = NewContext(ctx, &Principal{Subject: "dev@example.com", Bindings: []Binding{{Role: RoleViewer, Scope: Application(1)}}})
+
+	assert.True(t, Can(ctx, RoleViewer, Application(1)))
+	assert.False(t, Can(ctx, RoleViewer, Application(2)))
+}
+
+func TestOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, DefaultOrganization, OrganizationID(ctx))
+	assert.Nil(t, OrganizationFilter(ctx), "the jobs run by zopdev see every organization")
+	assert.True(t, InOrganization(ctx, 3))
+
+	ctx = NewContext(ctx, &Principal{Subject: "dev@example.com", Organization: 2})
+
+	assert.Equal(t, int64(2), OrganizationID(ctx))
+	assert.Equal(t, int64(2), OrganizationFilter(ctx))
+	assert.True(t, InOrganization(ctx, 2))
+	assert.False(t, InOrganization(ctx, 3))
+}
+
+func TestCredentials_OrganizationID(t *testing.T) {
+	id, err := Credentials{Organization: " 4 "}.OrganizationID()
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), id)
+
+	id, err = Credentials{}.OrganizationID()
+
+	require.NoError(t, err)
+	assert.Zero(t, id)
+
+	_, err = Credentials{Organization: "payments"}.OrganizationID()
+
+	require.ErrorIs(t, err, errInvalidOrganization)
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// APIKeyPrefix starts every API key, so that they are told apart from the OIDC tokens and found by secret scanners.
+	APIKeyPrefix = "zop_"
+	// APIKeyHeader carries an API key, an API key may also be sent as a bearer token.
+	APIKeyHeader = "X-Api-Key"
+	// OrganizationHeader names the organization a request acts in, by its ID.
+	OrganizationHeader = "X-Organization-Id"
+
+	apiKeySize   = 32
+	bearerPrefix = "Bearer "
+	// apiKeySubjectPrefix prefixes the ID of an API key in the subject of its role bindings.
+	apiKeySubjectPrefix = "api-key:"
+)
+
+// Credentials are the credentials sent with a request, they are verified by the route the request is sent to.
+type Credentials struct {
+	Token  string
+	APIKey string
+	// Organization is the ID of the organization named by the request, it is empty when the request names none.
+	Organization string
+}
+
+type credentialsKey struct{}
+
+// Middleware reads the credentials of the requests, the handlers of gofr have no access to the headers.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creds := Credentials{APIKey: r.Header.Get(APIKeyHeader), Organization: r.Header.Get(OrganizationHeader)}
+
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix); ok {
+			if strings.HasPrefix(token, APIKeyPrefix) {
+				creds.APIKey = token
+			} else {
+				creds.Token = token
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), credentialsKey{}, creds)))
+	})
+}
+
+// CredentialsFromContext returns the credentials read from the request.
+func CredentialsFromContext(ctx context.Context) Credentials {
+	creds, _ := ctx.Value(credentialsKey{}).(Credentials)
+
+	return creds
+}
+
+// NewAPIKey generates an API key. Only its hash is stored, the key itself is shown once to its creator.
+func NewAPIKey() (string, error) {
+	b := make([]byte, apiKeySize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAPIKey returns the hash an API key is stored and looked up by. The keys are random, a fast hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeySubject returns the subject the roles of an API key are granted to.
+func APIKeySubject(id int64) string {
+	return apiKeySubjectPrefix + strconv.FormatInt(id, 10)
+}
+
+// IsAPIKeySubject returns whether the subject is the subject of an API key, the users cannot take it.
+func IsAPIKeySubject(subject string) bool {
+	return strings.HasPrefix(subject, apiKeySubjectPrefix)
+}
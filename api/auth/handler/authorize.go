@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/service"
+)
+
+var errNoPrincipal = errors.New("the request has no principal")
+
+// ScopeFunc returns the scope a request acts on, e.g. from its path parameters.
+type ScopeFunc func(ctx *gofr.Context) (auth.Scope, error)
+
+// Authorizer authenticates the requests and checks the roles they require before running their handlers. The
+// principal of a request is added to its context, for the handlers listing only what it is allowed to see.
+type Authorizer struct {
+	service  service.AuthService
+	disabled bool
+}
+
+// NewAuthorizer creates an Authorizer. Every request is run as the anonymous principal when disabled is set.
+func NewAuthorizer(svc service.AuthService, disabled bool) *Authorizer {
+	return &Authorizer{service: svc, disabled: disabled}
+}
+
+// Require returns a handler running next when the principal of the request is granted the role on the scope.
+func (a *Authorizer) Require(role auth.Role, scope ScopeFunc, next gofr.Handler) gofr.Handler {
+	return func(ctx *gofr.Context) (any, error) {
+		principal, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := scope(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if !principal.Can(role, s) {
+			return nil, auth.ForbiddenError{Role: role, Scope: s}
+		}
+
+		return next(ctx)
+	}
+}
+
+// Authenticated returns a handler running next for any authenticated principal, the handler checks the roles itself,
+// e.g. to list only the cloud accounts the principal may view.
+func (a *Authorizer) Authenticated(next gofr.Handler) gofr.Handler {
+	return func(ctx *gofr.Context) (any, error) {
+		if _, err := a.authenticate(ctx); err != nil {
+			return nil, err
+		}
+
+		return next(ctx)
+	}
+}
+
+func (a *Authorizer) authenticate(ctx *gofr.Context) (*auth.Principal, error) {
+	var (
+		principal *auth.Principal
+		err       error
+	)
+
+	if creds := auth.CredentialsFromContext(ctx); a.disabled {
+		principal, err = anonymous(creds)
+	} else {
+		principal, err = a.service.Authenticate(ctx, creds)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Context = auth.NewContext(ctx.Context, principal)
+
+	return principal, nil
+}
+
+// anonymous returns the principal of the requests when the authentication is disabled, in the organization named by
+// the request.
+func anonymous(creds auth.Credentials) (*auth.Principal, error) {
+	org, err := creds.OrganizationID()
+	if err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{auth.OrganizationHeader}}
+	}
+
+	if org == 0 {
+		org = auth.DefaultOrganization
+	}
+
+	return auth.Anonymous(org), nil
+}
+
+// Global is the scope of the requests acting on the whole organization, e.g. adding a cloud account.
+func Global(*gofr.Context) (auth.Scope, error) {
+	return auth.Global(), nil
+}
+
+// Installation is the scope of the requests acting across the organizations, e.g. creating an organization.
+func Installation(*gofr.Context) (auth.Scope, error) {
+	return auth.Installation(), nil
+}
+
+// CloudAccountParam is the scope of the requests on the cloud account named by a path parameter. The cloud accounts of
+// the other organizations are not found.
+func (a *Authorizer) CloudAccountParam(name string) ScopeFunc {
+	return a.param(name, a.service.CloudAccountScope)
+}
+
+// ApplicationParam is the scope of the requests on the application named by a path parameter. The applications of
+// the other organizations are not found.
+func (a *Authorizer) ApplicationParam(name string) ScopeFunc {
+	return a.param(name, a.service.ApplicationScope)
+}
+
+// EnvironmentParam is the scope of the requests on the environment named by a path parameter, i.e. the scope of its
+// application.
+func (a *Authorizer) EnvironmentParam(name string) ScopeFunc {
+	return a.param(name, a.service.EnvironmentScope)
+}
+
+func (*Authorizer) param(name string, scope func(ctx *gofr.Context, id int64) (auth.Scope, error)) ScopeFunc {
+	return func(ctx *gofr.Context) (auth.Scope, error) {
+		id, err := pathID(ctx, name)
+		if err != nil {
+			return auth.Scope{}, err
+		}
+
+		return scope(ctx, id)
+	}
+}
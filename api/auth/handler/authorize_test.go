@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	netHTTP "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/service"
+)
+
+// newContext returns the context of a request with the given path parameters and credentials.
+func newContext(vars map[string]string, creds auth.Credentials) *gofr.Context {
+	req := httptest.NewRequest(netHTTP.MethodGet, "/", netHTTP.NoBody)
+	req.Header.Set(auth.APIKeyHeader, creds.APIKey)
+	req.Header.Set(auth.OrganizationHeader, creds.Organization)
+
+	var ctx context.Context
+
+	auth.Middleware(netHTTP.HandlerFunc(func(_ netHTTP.ResponseWriter, r *netHTTP.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return &gofr.Context{Context: ctx, Request: http.NewRequest(mux.SetURLVars(req, vars))}
+}
+
+func TestAuthorizer_Require(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	authz := NewAuthorizer(mockService, false)
+
+	next := func(ctx *gofr.Context) (any, error) {
+		p, _ := auth.FromContext(ctx)
+
+		return p.Subject, nil
+	}
+
+	operator := &auth.Principal{Subject: "api-key:1", Method: auth.MethodAPIKey, Organization: 1,
+		Bindings: []auth.Binding{
+			{Role: auth.RoleOperator, Scope: auth.CloudAccount(2)},
+			{Role: auth.RoleViewer, Scope: auth.CloudAccount(3)},
+		}}
+
+	testCases := []struct {
+		name         string
+		role         auth.Role
+		accountID    string
+		mockBehavior func()
+		expected     any
+		expectedErr  error
+	}{
+		{
+			name:      "role granted on the account",
+			role:      auth.RoleViewer,
+			accountID: "2",
+			mockBehavior: func() {
+				mockService.EXPECT().Authenticate(gomock.Any(), auth.Credentials{APIKey: "zop_key"}).Return(operator, nil)
+				mockService.EXPECT().CloudAccountScope(gomock.Any(), int64(2)).Return(auth.CloudAccount(2), nil)
+			},
+			expected: "api-key:1",
+		},
+		{
+			name:      "role granted on another account",
+			role:      auth.RoleViewer,
+			accountID: "4",
+			mockBehavior: func() {
+				mockService.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(operator, nil)
+				mockService.EXPECT().CloudAccountScope(gomock.Any(), int64(4)).Return(auth.CloudAccount(4), nil)
+			},
+			expectedErr: auth.ForbiddenError{Role: auth.RoleViewer, Scope: auth.CloudAccount(4)},
+		},
+		{
+			name:      "lower role",
+			role:      auth.RoleOperator,
+			accountID: "3",
+			mockBehavior: func() {
+				mockService.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(operator, nil)
+				mockService.EXPECT().CloudAccountScope(gomock.Any(), int64(3)).Return(auth.CloudAccount(3), nil)
+			},
+			expectedErr: auth.ForbiddenError{Role: auth.RoleOperator, Scope: auth.CloudAccount(3)},
+		},
+		{
+			name:      "account of another organization",
+			role:      auth.RoleViewer,
+			accountID: "5",
+			mockBehavior: func() {
+				mockService.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(operator, nil)
+				mockService.EXPECT().CloudAccountScope(gomock.Any(), int64(5)).
+					Return(auth.Scope{}, http.ErrorEntityNotFound{Name: "cloud account", Value: "5"})
+			},
+			expectedErr: http.ErrorEntityNotFound{Name: "cloud account", Value: "5"},
+		},
+		{
+			name:      "unauthenticated",
+			role:      auth.RoleViewer,
+			accountID: "2",
+			mockBehavior: func() {
+				mockService.EXPECT().Authenticate(gomock.Any(), gomock.Any()).
+					Return(nil, auth.UnauthenticatedError{Err: assert.AnError})
+			},
+			expectedErr: auth.UnauthenticatedError{Err: assert.AnError},
+		},
+		{
+			name:      "invalid account ID",
+			role:      auth.RoleViewer,
+			accountID: "abc",
+			mockBehavior: func() {
+				mockService.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(operator, nil)
+			},
+			expectedErr: http.ErrorInvalidParam{Params: []string{"id"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			ctx := newContext(map[string]string{"id": tc.accountID}, auth.Credentials{APIKey: "zop_key"})
+
+			resp, err := authz.Require(tc.role, authz.CloudAccountParam("id"), next)(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestAuthorizer_Disabled(t *testing.T) {
+	authz := NewAuthorizer(nil, true)
+	handler := authz.Require(auth.RoleAdmin, Installation, func(ctx *gofr.Context) (any, error) {
+		p, _ := auth.FromContext(ctx)
+
+		return p, nil
+	})
+
+	resp, err := handler(newContext(nil, auth.Credentials{}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, auth.Anonymous(auth.DefaultOrganization), resp)
+
+	resp, err = handler(newContext(nil, auth.Credentials{Organization: "2"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, auth.Anonymous(2), resp)
+
+	_, err = handler(newContext(nil, auth.Credentials{Organization: "payments"}))
+
+	assert.Equal(t, http.ErrorInvalidParam{Params: []string{auth.OrganizationHeader}}, err)
+}
+
+func TestAuthorizer_Installation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	authz := NewAuthorizer(mockService, false)
+
+	mockService.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(&auth.Principal{Subject: "dev@example.com",
+		Organization: 1, Bindings: []auth.Binding{{Role: auth.RoleAdmin, Scope: auth.Global()}}}, nil)
+
+	_, err := authz.Require(auth.RoleAdmin, Installation, func(*gofr.Context) (any, error) {
+		return nil, nil
+	})(newContext(nil, auth.Credentials{}))
+
+	assert.Equal(t, auth.ForbiddenError{Role: auth.RoleAdmin, Scope: auth.Installation()}, err,
+		"the admins of an organization do not manage the organizations")
+}
+
+func TestAuthorizer_EnvironmentParam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	authz := NewAuthorizer(mockService, false)
+	ctx := newContext(map[string]string{"id": "7"}, auth.Credentials{})
+
+	mockService.EXPECT().EnvironmentScope(ctx, int64(7)).Return(auth.Application(2), nil)
+
+	scope, err := authz.EnvironmentParam("id")(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, auth.Application(2), scope)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/service"
+	"github.com/zopdev/zopdev/api/auth/store"
+)
+
+const nameLength = 255
+
+type Handler struct {
+	service service.AuthService
+}
+
+// New creates a new Handler with the provided AuthService.
+func New(svc service.AuthService) *Handler {
+	return &Handler{service: svc}
+}
+
+// GetPrincipal returns the principal of the request along with the roles it is granted.
+func (*Handler) GetPrincipal(ctx *gofr.Context) (any, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.UnauthenticatedError{Err: errNoPrincipal}
+	}
+
+	return principal, nil
+}
+
+// CreateAPIKey creates an API key, the key is only returned in the response.
+func (h *Handler) CreateAPIKey(ctx *gofr.Context) (any, error) {
+	var body struct {
+		Name string `json:"name"`
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if name == "" || len(name) > nameLength {
+		return nil, http.ErrorInvalidParam{Params: []string{"name"}}
+	}
+
+	return h.service.CreateAPIKey(ctx, name)
+}
+
+// ListAPIKeys lists the API keys that are not revoked.
+func (h *Handler) ListAPIKeys(ctx *gofr.Context) (any, error) {
+	return h.service.ListAPIKeys(ctx)
+}
+
+// RevokeAPIKey revokes an API key.
+func (h *Handler) RevokeAPIKey(ctx *gofr.Context) (any, error) {
+	id, err := pathID(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, h.service.RevokeAPIKey(ctx, id)
+}
+
+// AddRoleBinding grants a role to a user or an API key on a scope.
+func (h *Handler) AddRoleBinding(ctx *gofr.Context) (any, error) {
+	var binding store.RoleBinding
+
+	if err := ctx.Bind(&binding); err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	if err := validateRoleBinding(&binding); err != nil {
+		return nil, err
+	}
+
+	return h.service.AddRoleBinding(ctx, &binding)
+}
+
+// ListRoleBindings lists the role bindings, of a single subject when the subject query parameter is set.
+func (h *Handler) ListRoleBindings(ctx *gofr.Context) (any, error) {
+	return h.service.ListRoleBindings(ctx, strings.TrimSpace(ctx.Param("subject")))
+}
+
+// DeleteRoleBinding revokes a role.
+func (h *Handler) DeleteRoleBinding(ctx *gofr.Context) (any, error) {
+	id, err := pathID(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, h.service.DeleteRoleBinding(ctx, id)
+}
+
+// CreateOrganization creates an organization.
+func (h *Handler) CreateOrganization(ctx *gofr.Context) (any, error) {
+	var body struct {
+		Name string `json:"name"`
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if name == "" || len(name) > nameLength {
+		return nil, http.ErrorInvalidParam{Params: []string{"name"}}
+	}
+
+	return h.service.CreateOrganization(ctx, name)
+}
+
+// ListOrganizations lists the organizations the principal of the request may act in.
+func (h *Handler) ListOrganizations(ctx *gofr.Context) (any, error) {
+	return h.service.ListOrganizations(ctx)
+}
+
+func validateRoleBinding(binding *store.RoleBinding) error {
+	binding.Subject = strings.TrimSpace(binding.Subject)
+
+	params := []string{}
+
+	if binding.Subject == "" || len(binding.Subject) > nameLength {
+		params = append(params, "subject")
+	}
+
+	if !binding.Role.Valid() {
+		params = append(params, "role")
+	}
+
+	if !binding.Scope.Valid() {
+		params = append(params, "scope")
+	}
+
+	if len(params) > 0 {
+		return http.ErrorInvalidParam{Params: params}
+	}
+
+	return nil
+}
+
+func pathID(ctx *gofr.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(ctx.PathParam(name)), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, http.ErrorInvalidParam{Params: []string{name}}
+	}
+
+	return id, nil
+}
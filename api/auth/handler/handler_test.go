@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	netHTTP "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/service"
+	"github.com/zopdev/zopdev/api/auth/store"
+)
+
+func requestContext(body string, vars map[string]string) *gofr.Context {
+	req := httptest.NewRequest(netHTTP.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	return &gofr.Context{Context: context.Background(), Request: http.NewRequest(mux.SetURLVars(req, vars))}
+}
+
+func TestHandler_GetPrincipal(t *testing.T) {
+	h := New(nil)
+	ctx := requestContext("", nil)
+
+	_, err := h.GetPrincipal(ctx)
+
+	require.Equal(t, auth.UnauthenticatedError{Err: errNoPrincipal}, err)
+
+	ctx.Context = auth.NewContext(ctx.Context, auth.Anonymous(1))
+
+	resp, err := h.GetPrincipal(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, auth.Anonymous(1), resp)
+}
+
+func TestHandler_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	h := New(mockService)
+
+	mockService.EXPECT().CreateAPIKey(gomock.Any(), "ci").Return(&store.APIKey{ID: 1, Name: "ci", Key: "zop_key"}, nil)
+
+	resp, err := h.CreateAPIKey(requestContext(`{"name":" ci "}`, nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, &store.APIKey{ID: 1, Name: "ci", Key: "zop_key"}, resp)
+
+	_, err = h.CreateAPIKey(requestContext(`{"name":""}`, nil))
+
+	require.Equal(t, http.ErrorInvalidParam{Params: []string{"name"}}, err)
+}
+
+func TestHandler_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	h := New(mockService)
+
+	mockService.EXPECT().RevokeAPIKey(gomock.Any(), int64(3)).Return(nil)
+
+	_, err := h.RevokeAPIKey(requestContext("", map[string]string{"id": "3"}))
+
+	require.NoError(t, err)
+
+	_, err = h.RevokeAPIKey(requestContext("", map[string]string{"id": "0"}))
+
+	require.Equal(t, http.ErrorInvalidParam{Params: []string{"id"}}, err)
+}
+
+func TestHandler_AddRoleBinding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		name         string
+		body         string
+		mockBehavior func()
+		expectedErr  error
+	}{
+		{
+			name: "success",
+			body: `{"subject":"dev@example.com","role":"operator","scope":{"type":"cloud_account","id":2}}`,
+			mockBehavior: func() {
+				mockService.EXPECT().AddRoleBinding(gomock.Any(), &store.RoleBinding{Subject: "dev@example.com",
+					Role: auth.RoleOperator, Scope: auth.CloudAccount(2)}).Return(&store.RoleBinding{ID: 1}, nil)
+			},
+		},
+		{
+			name:         "invalid role and scope",
+			body:         `{"subject":"dev@example.com","role":"owner","scope":{"type":"cloud_account"}}`,
+			mockBehavior: func() {},
+			expectedErr:  http.ErrorInvalidParam{Params: []string{"role", "scope"}},
+		},
+		{
+			name:         "missing subject",
+			body:         `{"role":"viewer","scope":{"type":"global"}}`,
+			mockBehavior: func() {},
+			expectedErr:  http.ErrorInvalidParam{Params: []string{"subject"}},
+		},
+		{
+			name:         "invalid body",
+			body:         `{`,
+			mockBehavior: func() {},
+			expectedErr:  http.ErrorInvalidParam{Params: []string{"body"}},
+		},
+		{
+			name: "service error",
+			body: `{"subject":"dev@example.com","role":"viewer","scope":{"type":"global"}}`,
+			mockBehavior: func() {
+				mockService.EXPECT().AddRoleBinding(gomock.Any(), gomock.Any()).
+					Return(nil, auth.ForbiddenError{Role: auth.RoleAdmin, Scope: auth.Global()})
+			},
+			expectedErr: auth.ForbiddenError{Role: auth.RoleAdmin, Scope: auth.Global()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			_, err := h.AddRoleBinding(requestContext(tc.body, nil))
+
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestHandler_DeleteRoleBinding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	h := New(mockService)
+
+	mockService.EXPECT().DeleteRoleBinding(gomock.Any(), int64(4)).Return(nil)
+
+	_, err := h.DeleteRoleBinding(requestContext("", map[string]string{"id": "4"}))
+
+	require.NoError(t, err)
+}
+
+func TestHandler_CreateOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockAuthService(ctrl)
+	h := New(mockService)
+
+	mockService.EXPECT().CreateOrganization(gomock.Any(), "payments").Return(&store.Organization{ID: 2, Name: "payments"}, nil)
+
+	resp, err := h.CreateOrganization(requestContext(`{"name":"payments"}`, nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, &store.Organization{ID: 2, Name: "payments"}, resp)
+
+	_, err = h.CreateOrganization(requestContext(`{"name":" "}`, nil))
+
+	require.Equal(t, http.ErrorInvalidParam{Params: []string{"name"}}, err)
+}
@@ -0,0 +1,451 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // The hashes of the RS256 and ES256 signatures.
+	_ "crypto/sha512" // The hashes of the RS384, RS512 and ES384 signatures.
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+const (
+	issuerConfig       = "AUTH_OIDC_ISSUER"
+	audienceConfig     = "AUTH_OIDC_AUDIENCE"
+	jwksURLConfig      = "AUTH_OIDC_JWKS_URL"
+	subjectClaimConfig = "AUTH_OIDC_SUBJECT_CLAIM"
+	adminsConfig       = "AUTH_ADMINS"
+	disabledConfig     = "AUTH_DISABLED"
+
+	defaultSubjectClaim = "email"
+	discoveryPath       = "/.well-known/openid-configuration"
+	tokenParts          = 3
+	// clockSkew is the tolerance on the expiry and the start of the validity of the tokens.
+	clockSkew = time.Minute
+	// keysRefreshInterval is the minimum interval between two fetches of the signing keys, they are fetched again when
+	// a token is signed by an unknown key, e.g. after the issuer rotated its keys.
+	keysRefreshInterval = time.Minute
+	httpTimeout         = 10 * time.Second
+)
+
+var (
+	errMalformedToken       = errors.New("malformed token")
+	errUnsupportedAlgorithm = errors.New("unsupported token signing algorithm")
+	errInvalidSignature     = errors.New("invalid token signature")
+	errUnknownKey           = errors.New("token signed by an unknown key")
+	errInvalidIssuer        = errors.New("token issued by another issuer")
+	errInvalidAudience      = errors.New("token issued for another audience")
+	errExpiredToken         = errors.New("token has expired")
+	errTokenNotYetValid     = errors.New("token is not valid yet")
+	errMissingSubject       = errors.New("token has no subject claim")
+	errUnsupportedKey       = errors.New("unsupported signing key")
+
+	// ErrFetchingKeys is returned when the signing keys of the issuer cannot be fetched, the tokens cannot be verified
+	// until they are.
+	ErrFetchingKeys = errors.New("unable to fetch the signing keys of the issuer")
+)
+
+// Config is the configuration of the authentication.
+type Config struct {
+	// Disabled turns the authentication and the role checks off, e.g. for a local installation.
+	Disabled bool
+	// Admins are the subjects granted the admin role on the whole installation, e.g. to create the first role
+	// bindings and API keys.
+	Admins []string
+
+	// Issuer is the OIDC issuer of the bearer tokens, the tokens are only accepted when it is set.
+	Issuer   string
+	Audience string
+	// JWKSURL is the URL of the signing keys of the issuer, it is discovered from the issuer when not set.
+	JWKSURL string
+	// SubjectClaim is the claim of the tokens the roles are granted to.
+	SubjectClaim string
+}
+
+// LoadConfig reads the configuration of the authentication.
+func LoadConfig(cfg config.Config) Config {
+	c := Config{
+		Disabled:     strings.EqualFold(cfg.Get(disabledConfig), "true"),
+		Issuer:       cfg.Get(issuerConfig),
+		Audience:     cfg.Get(audienceConfig),
+		JWKSURL:      cfg.Get(jwksURLConfig),
+		SubjectClaim: cfg.GetOrDefault(subjectClaimConfig, defaultSubjectClaim),
+	}
+
+	for _, admin := range strings.Split(cfg.Get(adminsConfig), ",") {
+		if admin = strings.TrimSpace(admin); admin != "" {
+			c.Admins = append(c.Admins, admin)
+		}
+	}
+
+	return c
+}
+
+// Verifier verifies the OIDC tokens of an issuer with its published signing keys.
+type Verifier struct {
+	issuer       string
+	audience     string
+	subjectClaim string
+	client       *http.Client
+
+	mu        sync.Mutex
+	jwksURL   string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewVerifier creates a Verifier of the tokens of the configured issuer.
+func NewVerifier(cfg Config) *Verifier {
+	subjectClaim := cfg.SubjectClaim
+	if subjectClaim == "" {
+		subjectClaim = defaultSubjectClaim
+	}
+
+	return &Verifier{issuer: cfg.Issuer, audience: cfg.Audience, subjectClaim: subjectClaim, jwksURL: cfg.JWKSURL,
+		client: &http.Client{Timeout: httpTimeout}}
+}
+
+// Verify checks the signature, the issuer, the audience and the validity period of a token, and returns its subject.
+func (v *Verifier) Verify(ctx context.Context, token string) (string, error) {
+	return v.verify(ctx, token, time.Now())
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v *Verifier) verify(ctx context.Context, token string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != tokenParts {
+		return "", errMalformedToken
+	}
+
+	var header tokenHeader
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", errMalformedToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errMalformedToken
+	}
+
+	key, err := v.key(ctx, header.Kid, now)
+	if err != nil {
+		return "", err
+	}
+
+	if err = verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return "", err
+	}
+
+	var claims map[string]any
+
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return "", errMalformedToken
+	}
+
+	if err = v.validateClaims(claims, now); err != nil {
+		return "", err
+	}
+
+	subject, _ := claims[v.subjectClaim].(string)
+	if subject == "" {
+		return "", errMissingSubject
+	}
+
+	return subject, nil
+}
+
+func decodeSegment(segment string, out any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	return dec.Decode(out)
+}
+
+func (v *Verifier) validateClaims(claims map[string]any, now time.Time) error {
+	if iss, _ := claims["iss"].(string); iss != v.issuer {
+		return errInvalidIssuer
+	}
+
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return errInvalidAudience
+	}
+
+	exp, ok := numericDate(claims["exp"])
+	if !ok || now.After(exp.Add(clockSkew)) {
+		return errExpiredToken
+	}
+
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(clockSkew).Before(nbf) {
+		return errTokenNotYetValid
+	}
+
+	return nil
+}
+
+// hasAudience returns whether the aud claim, a string or an array of strings, contains the audience.
+func hasAudience(aud any, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		return slices.Contains(aud, any(audience))
+	default:
+		return false
+	}
+}
+
+func numericDate(v any) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(f), 0), true
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	hash, ok := algorithmHash(alg)
+	if !ok {
+		return errUnsupportedAlgorithm
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return errUnsupportedAlgorithm
+		}
+
+		if rsa.VerifyPKCS1v15(k, hash, digest, signature) != nil {
+			return errInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		// The signature is the concatenation of r and s, each the size of the curve.
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+			return errInvalidSignature
+		}
+
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errInvalidSignature
+		}
+	default:
+		return errUnsupportedAlgorithm
+	}
+
+	return nil
+}
+
+// algorithmHash returns the hash of the supported signing algorithms.
+func algorithmHash(alg string) (crypto.Hash, bool) {
+	switch alg {
+	case "RS256", "ES256":
+		return crypto.SHA256, true
+	case "RS384", "ES384":
+		return crypto.SHA384, true
+	case "RS512":
+		return crypto.SHA512, true
+	default:
+		return 0, false
+	}
+}
+
+// key returns the signing key of the issuer with the given ID. The keys are fetched again when the key is unknown,
+// at most once per keysRefreshInterval.
+func (v *Verifier) key(ctx context.Context, kid string, now time.Time) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+
+	if v.keys != nil && now.Sub(v.fetchedAt) < keysRefreshInterval {
+		return nil, errUnknownKey
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v.keys, v.fetchedAt = keys, now
+
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, errUnknownKey
+}
+
+// lookup returns the key with the given ID, or the only key of the issuer when the token names none.
+func (v *Verifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+
+	key, ok := v.keys[kid]
+
+	return key, ok
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (v *Verifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if v.jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+
+		if err := v.get(ctx, strings.TrimSuffix(v.issuer, "/")+discoveryPath, &discovery); err != nil {
+			return nil, err
+		}
+
+		if discovery.Issuer != v.issuer || discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("%w: invalid discovery document", ErrFetchingKeys)
+		}
+
+		v.jwksURL = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := v.get(ctx, v.jwksURL, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, k := range set.Keys {
+		// The keys of other uses and types are skipped, e.g. the encryption keys.
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		if key, err := k.publicKey(); err == nil {
+			keys[k.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+func (v *Verifier) get(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFetchingKeys, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned %d", ErrFetchingKeys, url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		return k.ecdsaKey()
+	default:
+		return nil, errUnsupportedKey
+	}
+}
+
+func (k *jwk) ecdsaKey() (crypto.PublicKey, error) {
+	var (
+		curve   elliptic.Curve
+		ecdhCrv ecdh.Curve
+	)
+
+	switch k.Crv {
+	case "P-256":
+		curve, ecdhCrv = elliptic.P256(), ecdh.P256()
+	case "P-384":
+		curve, ecdhCrv = elliptic.P384(), ecdh.P384()
+	default:
+		return nil, errUnsupportedKey
+	}
+
+	x, errX := base64.RawURLEncoding.DecodeString(k.X)
+	y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+
+	size := (curve.Params().BitSize + 7) / 8
+	if errX != nil || errY != nil || len(x) != size || len(y) != size {
+		return nil, errUnsupportedKey
+	}
+
+	// The point is checked to be on the curve by parsing it as an uncompressed point.
+	if _, err := ecdhCrv.NewPublicKey(slices.Concat([]byte{4}, x, y)); err != nil {
+		return nil, errUnsupportedKey
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
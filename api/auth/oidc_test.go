@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr/config"
+)
+
+type mapConfig map[string]string
+
+func (c mapConfig) Get(key string) string {
+	return c[key]
+}
+
+func (c mapConfig) GetOrDefault(key, def string) string {
+	if v, ok := c[key]; ok {
+		return v
+	}
+
+	return def
+}
+
+var _ config.Config = mapConfig{}
+
+// testIssuer serves the discovery document and the signing keys of an OIDC issuer.
+type testIssuer struct {
+	*httptest.Server
+
+	keys     []map[string]string
+	requests int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	issuer := &testIssuer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer.URL, "jwks_uri": issuer.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		issuer.requests++
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": issuer.keys})
+	})
+
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+func (i *testIssuer) addRSAKey(kid string, key *rsa.PublicKey) {
+	i.keys = append(i.keys, map[string]string{"kty": "RSA", "kid": kid, "use": "sig",
+		"n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())})
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := encode(header) + "." + encode(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signed + "." + encode(signature)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := newTestIssuer(t)
+	issuer.addRSAKey("key-1", &key.PublicKey)
+
+	now := time.Now()
+	claims := func(changes map[string]any) map[string]any {
+		c := map[string]any{"iss": issuer.URL, "aud": "zopdev", "sub": "1234", "email": "dev@example.com",
+			"exp": now.Add(time.Hour).Unix(), "nbf": now.Add(-time.Minute).Unix()}
+
+		for k, v := range changes {
+			c[k] = v
+		}
+
+		return c
+	}
+
+	testCases := []struct {
+		name        string
+		token       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "valid token", token: signRS256(t, key, "key-1", claims(nil)), expected: "dev@example.com"},
+		{name: "audience in an array", token: signRS256(t, key, "key-1", claims(map[string]any{"aud": []string{"other", "zopdev"}})),
+			expected: "dev@example.com"},
+		{name: "expired token", token: signRS256(t, key, "key-1", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})),
+			expectedErr: errExpiredToken},
+		{name: "token not valid yet", token: signRS256(t, key, "key-1", claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})),
+			expectedErr: errTokenNotYetValid},
+		{name: "missing expiry", token: signRS256(t, key, "key-1", claims(map[string]any{"exp": nil})),
+			expectedErr: errExpiredToken},
+		{name: "other issuer", token: signRS256(t, key, "key-1", claims(map[string]any{"iss": "https://example.com"})),
+			expectedErr: errInvalidIssuer},
+		{name: "other audience", token: signRS256(t, key, "key-1", claims(map[string]any{"aud": "other"})),
+			expectedErr: errInvalidAudience},
+		{name: "missing subject", token: signRS256(t, key, "key-1", claims(map[string]any{"email": nil})),
+			expectedErr: errMissingSubject},
+		{name: "unknown key", token: signRS256(t, key, "key-2", claims(nil)), expectedErr: errUnknownKey},
+		{name: "invalid signature", token: signRS256(t, otherKey, "key-1", claims(nil)), expectedErr: errInvalidSignature},
+		{name: "malformed token", token: "not-a-token", expectedErr: errMalformedToken},
+	}
+
+	v := NewVerifier(Config{Issuer: issuer.URL, Audience: "zopdev"})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subject, err := v.Verify(context.Background(), tc.token)
+
+			require.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, subject)
+		})
+	}
+
+	assert.Equal(t, 1, issuer.requests, "the keys are not fetched again within the refresh interval")
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := newTestIssuer(t)
+	v := NewVerifier(Config{Issuer: issuer.URL, JWKSURL: issuer.URL + "/keys", SubjectClaim: "sub"})
+	now := time.Now()
+	token := signRS256(t, key, "key-1", map[string]any{"iss": issuer.URL, "sub": "1234", "exp": now.Add(time.Hour).Unix()})
+
+	_, err = v.verify(context.Background(), token, now)
+
+	require.ErrorIs(t, err, errUnknownKey)
+
+	issuer.addRSAKey("key-1", &key.PublicKey)
+
+	_, err = v.verify(context.Background(), token, now.Add(time.Second))
+
+	require.ErrorIs(t, err, errUnknownKey, "the keys are fetched at most once per refresh interval")
+
+	subject, err := v.verify(context.Background(), token, now.Add(keysRefreshInterval))
+
+	require.NoError(t, err)
+	assert.Equal(t, "1234", subject)
+	assert.Equal(t, 2, issuer.requests)
+}
+
+func TestVerifier_FetchingKeysError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	v := NewVerifier(Config{Issuer: server.URL})
+
+	_, err := v.Verify(context.Background(), "eyJhbGciOiJSUzI1NiJ9.e30.c2ln")
+
+	require.ErrorIs(t, err, ErrFetchingKeys)
+}
+
+func TestVerifySignature_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signed := []byte("header.payload")
+	digest := sha256.Sum256(signed)
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	k := jwk{Kty: "EC", Crv: "P-256", X: encode(key.X.FillBytes(make([]byte, 32))), Y: encode(key.Y.FillBytes(make([]byte, 32)))}
+
+	pub, err := k.publicKey()
+	require.NoError(t, err)
+
+	require.NoError(t, verifySignature("ES256", pub, signed, signature))
+	require.ErrorIs(t, verifySignature("ES256", pub, []byte("header.other"), signature), errInvalidSignature)
+	require.ErrorIs(t, verifySignature("HS256", pub, signed, signature), errUnsupportedAlgorithm)
+	require.ErrorIs(t, verifySignature("RS256", pub, signed, signature), errInvalidSignature)
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg := LoadConfig(mapConfig{
+		issuerConfig: "https://accounts.example.com",
+		adminsConfig: "admin@example.com, ops@example.com,",
+	})
+
+	assert.Equal(t, Config{Issuer: "https://accounts.example.com", SubjectClaim: "email",
+		Admins: []string{"admin@example.com", "ops@example.com"}}, cfg)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefaultOrganization owns the data created before the organizations were introduced. It is the organization of the
+// requests naming none when their principal is not a member of a single other organization.
+const DefaultOrganization int64 = 1
+
+var errInvalidOrganization = errors.New("invalid organization ID")
+
+// OrganizationID returns the ID of the organization named by the credentials, 0 when they name none.
+func (c Credentials) OrganizationID() (int64, error) {
+	org := strings.TrimSpace(c.Organization)
+	if org == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(org, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errInvalidOrganization
+	}
+
+	return id, nil
+}
+
+// Organization returns the organization of the request. It is not set for the jobs run by zopdev itself and the
+// calls between its modules, they act on every organization.
+func Organization(ctx context.Context) (int64, bool) {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	return p.Organization, true
+}
+
+// OrganizationID returns the organization the data created by the request belongs to.
+func OrganizationID(ctx context.Context) int64 {
+	if org, ok := Organization(ctx); ok {
+		return org
+	}
+
+	return DefaultOrganization
+}
+
+// OrganizationFilter returns the argument of the organization filters of the store queries, written as
+// organization_id = COALESCE(?, organization_id). It is nil when the request has no organization, so that the jobs
+// run by zopdev see the data of every organization.
+func OrganizationFilter(ctx context.Context) any {
+	if org, ok := Organization(ctx); ok {
+		return org
+	}
+
+	return nil
+}
+
+// InOrganization returns whether the data of the organization is visible to the request.
+func InOrganization(ctx context.Context, organization int64) bool {
+	org, ok := Organization(ctx)
+
+	return !ok || org == organization
+}
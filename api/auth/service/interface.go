@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/store"
+)
+
+type AuthService interface {
+	Authenticate(ctx *gofr.Context, creds auth.Credentials) (*auth.Principal, error)
+	CloudAccountScope(ctx *gofr.Context, cloudAccountID int64) (auth.Scope, error)
+	ApplicationScope(ctx *gofr.Context, applicationID int64) (auth.Scope, error)
+	EnvironmentScope(ctx *gofr.Context, environmentID int64) (auth.Scope, error)
+
+	CreateOrganization(ctx *gofr.Context, name string) (*store.Organization, error)
+	ListOrganizations(ctx *gofr.Context) ([]store.Organization, error)
+
+	CreateAPIKey(ctx *gofr.Context, name string) (*store.APIKey, error)
+	ListAPIKeys(ctx *gofr.Context) ([]store.APIKey, error)
+	RevokeAPIKey(ctx *gofr.Context, id int64) error
+
+	AddRoleBinding(ctx *gofr.Context, binding *store.RoleBinding) (*store.RoleBinding, error)
+	ListRoleBindings(ctx *gofr.Context, subject string) ([]store.RoleBinding, error)
+	DeleteRoleBinding(ctx *gofr.Context, id int64) error
+}
+
+// TokenVerifier verifies the OIDC bearer tokens and returns the subject they authenticate.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (string, error)
+}
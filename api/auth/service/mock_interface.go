@@ -0,0 +1,261 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	auth "github.com/zopdev/zopdev/api/auth"
+	store "github.com/zopdev/zopdev/api/auth/store"
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+)
+
+// MockAuthService is a mock of AuthService interface.
+type MockAuthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthServiceMockRecorder is the mock recorder for MockAuthService.
+type MockAuthServiceMockRecorder struct {
+	mock *MockAuthService
+}
+
+// NewMockAuthService creates a new mock instance.
+func NewMockAuthService(ctrl *gomock.Controller) *MockAuthService {
+	mock := &MockAuthService{ctrl: ctrl}
+	mock.recorder = &MockAuthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
+	return m.recorder
+}
+
+// AddRoleBinding mocks base method.
+func (m *MockAuthService) AddRoleBinding(ctx *gofr.Context, binding *store.RoleBinding) (*store.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoleBinding", ctx, binding)
+	ret0, _ := ret[0].(*store.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRoleBinding indicates an expected call of AddRoleBinding.
+func (mr *MockAuthServiceMockRecorder) AddRoleBinding(ctx, binding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoleBinding", reflect.TypeOf((*MockAuthService)(nil).AddRoleBinding), ctx, binding)
+}
+
+// ApplicationScope mocks base method.
+func (m *MockAuthService) ApplicationScope(ctx *gofr.Context, applicationID int64) (auth.Scope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationScope", ctx, applicationID)
+	ret0, _ := ret[0].(auth.Scope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationScope indicates an expected call of ApplicationScope.
+func (mr *MockAuthServiceMockRecorder) ApplicationScope(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationScope", reflect.TypeOf((*MockAuthService)(nil).ApplicationScope), ctx, applicationID)
+}
+
+// Authenticate mocks base method.
+func (m *MockAuthService) Authenticate(ctx *gofr.Context, creds auth.Credentials) (*auth.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, creds)
+	ret0, _ := ret[0].(*auth.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAuthServiceMockRecorder) Authenticate(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthService)(nil).Authenticate), ctx, creds)
+}
+
+// CloudAccountScope mocks base method.
+func (m *MockAuthService) CloudAccountScope(ctx *gofr.Context, cloudAccountID int64) (auth.Scope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudAccountScope", ctx, cloudAccountID)
+	ret0, _ := ret[0].(auth.Scope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloudAccountScope indicates an expected call of CloudAccountScope.
+func (mr *MockAuthServiceMockRecorder) CloudAccountScope(ctx, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudAccountScope", reflect.TypeOf((*MockAuthService)(nil).CloudAccountScope), ctx, cloudAccountID)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAuthService) CreateAPIKey(ctx *gofr.Context, name string) (*store.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, name)
+	ret0, _ := ret[0].(*store.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAuthServiceMockRecorder) CreateAPIKey(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAuthService)(nil).CreateAPIKey), ctx, name)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockAuthService) CreateOrganization(ctx *gofr.Context, name string) (*store.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, name)
+	ret0, _ := ret[0].(*store.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockAuthServiceMockRecorder) CreateOrganization(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockAuthService)(nil).CreateOrganization), ctx, name)
+}
+
+// DeleteRoleBinding mocks base method.
+func (m *MockAuthService) DeleteRoleBinding(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoleBinding", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoleBinding indicates an expected call of DeleteRoleBinding.
+func (mr *MockAuthServiceMockRecorder) DeleteRoleBinding(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockAuthService)(nil).DeleteRoleBinding), ctx, id)
+}
+
+// EnvironmentScope mocks base method.
+func (m *MockAuthService) EnvironmentScope(ctx *gofr.Context, environmentID int64) (auth.Scope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentScope", ctx, environmentID)
+	ret0, _ := ret[0].(auth.Scope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentScope indicates an expected call of EnvironmentScope.
+func (mr *MockAuthServiceMockRecorder) EnvironmentScope(ctx, environmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentScope", reflect.TypeOf((*MockAuthService)(nil).EnvironmentScope), ctx, environmentID)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAuthService) ListAPIKeys(ctx *gofr.Context) ([]store.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]store.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAuthServiceMockRecorder) ListAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAuthService)(nil).ListAPIKeys), ctx)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockAuthService) ListOrganizations(ctx *gofr.Context) ([]store.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx)
+	ret0, _ := ret[0].([]store.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockAuthServiceMockRecorder) ListOrganizations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockAuthService)(nil).ListOrganizations), ctx)
+}
+
+// ListRoleBindings mocks base method.
+func (m *MockAuthService) ListRoleBindings(ctx *gofr.Context, subject string) ([]store.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoleBindings", ctx, subject)
+	ret0, _ := ret[0].([]store.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoleBindings indicates an expected call of ListRoleBindings.
+func (mr *MockAuthServiceMockRecorder) ListRoleBindings(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleBindings", reflect.TypeOf((*MockAuthService)(nil).ListRoleBindings), ctx, subject)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAuthService) RevokeAPIKey(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAuthServiceMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAuthService)(nil).RevokeAPIKey), ctx, id)
+}
+
+// MockTokenVerifier is a mock of TokenVerifier interface.
+type MockTokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenVerifierMockRecorder is the mock recorder for MockTokenVerifier.
+type MockTokenVerifierMockRecorder struct {
+	mock *MockTokenVerifier
+}
+
+// NewMockTokenVerifier creates a new mock instance.
+func NewMockTokenVerifier(ctrl *gomock.Controller) *MockTokenVerifier {
+	mock := &MockTokenVerifier{ctrl: ctrl}
+	mock.recorder = &MockTokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenVerifier) EXPECT() *MockTokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockTokenVerifier) Verify(ctx context.Context, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenVerifierMockRecorder) Verify(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenVerifier)(nil).Verify), ctx, token)
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/store"
+)
+
+// apiKeyPrefixLength is the length of the start of the API keys shown to tell them apart.
+const apiKeyPrefixLength = len(auth.APIKeyPrefix) + 4
+
+var (
+	errMissingCredentials = errors.New("missing bearer token or API key")
+	errInvalidAPIKey      = errors.New("invalid or revoked API key")
+	errTokensDisabled     = errors.New("bearer tokens are not accepted, no OIDC issuer is configured")
+	errReservedSubject    = errors.New("the subject of the token is reserved for the API keys")
+	errOtherOrganization  = errors.New("the API key belongs to another organization")
+)
+
+type Service struct {
+	store store.AuthStore
+	// verifier is nil when no OIDC issuer is configured, only the API keys are accepted then.
+	verifier TokenVerifier
+	// admins are the super admins of the installation, granted the admin role on every organization by the
+	// configuration.
+	admins []string
+}
+
+// New creates a new AuthService.
+func New(str store.AuthStore, verifier TokenVerifier, admins []string) AuthService {
+	return &Service{store: str, verifier: verifier, admins: admins}
+}
+
+// Authenticate verifies the credentials of a request and returns its principal along with the roles it is granted in
+// the organization of the request. An API key acts in its own organization, a user in the organization named by the
+// request, or else in the only organization it is a member of.
+func (s *Service) Authenticate(ctx *gofr.Context, creds auth.Credentials) (*auth.Principal, error) {
+	requested, err := creds.OrganizationID()
+	if err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{auth.OrganizationHeader}}
+	}
+
+	var principal *auth.Principal
+
+	switch {
+	case creds.APIKey != "":
+		key, er := s.store.GetAPIKeyByHash(ctx, auth.HashAPIKey(creds.APIKey))
+		if errors.Is(er, sql.ErrNoRows) {
+			return nil, auth.UnauthenticatedError{Err: errInvalidAPIKey}
+		}
+
+		if er != nil {
+			return nil, er
+		}
+
+		if requested != 0 && requested != key.OrganizationID {
+			return nil, auth.UnauthenticatedError{Err: errOtherOrganization}
+		}
+
+		principal = &auth.Principal{Subject: key.Subject, Method: auth.MethodAPIKey, Organization: key.OrganizationID}
+	case creds.Token != "":
+		subject, er := s.verifyToken(ctx, creds.Token)
+		if er != nil {
+			return nil, er
+		}
+
+		principal = &auth.Principal{Subject: subject, Method: auth.MethodOIDC, Organization: requested}
+	default:
+		return nil, auth.UnauthenticatedError{Err: errMissingCredentials}
+	}
+
+	// The request has no organization yet, the bindings of the subject in every organization are returned.
+	bindings, err := s.store.GetRoleBindings(ctx, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.selectOrganization(ctx, principal, bindings); err != nil {
+		return nil, err
+	}
+
+	principal.SuperAdmin = slices.Contains(s.admins, principal.Subject)
+	principal.Bindings = make([]auth.Binding, 0, len(bindings)+1)
+
+	if principal.SuperAdmin {
+		principal.Bindings = append(principal.Bindings, auth.Binding{Role: auth.RoleAdmin, Scope: auth.Global()})
+	}
+
+	for i := range bindings {
+		if bindings[i].OrganizationID == principal.Organization {
+			principal.Bindings = append(principal.Bindings, auth.Binding{Role: bindings[i].Role, Scope: bindings[i].Scope})
+		}
+	}
+
+	return principal, nil
+}
+
+// selectOrganization sets the organization of a principal naming none to the only organization it is granted roles
+// in, or to the default organization. The organization named by a request must exist.
+func (s *Service) selectOrganization(ctx *gofr.Context, principal *auth.Principal, bindings []store.RoleBinding) error {
+	if principal.Organization == 0 {
+		principal.Organization = auth.DefaultOrganization
+
+		orgs := make([]int64, 0, len(bindings))
+		for i := range bindings {
+			orgs = append(orgs, bindings[i].OrganizationID)
+		}
+
+		if orgs = slices.Compact(slices.Sorted(slices.Values(orgs))); len(orgs) == 1 {
+			principal.Organization = orgs[0]
+		}
+
+		return nil
+	}
+
+	if principal.Method == auth.MethodAPIKey {
+		return nil
+	}
+
+	_, err := s.store.GetOrganizationByID(ctx, principal.Organization)
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.ErrorEntityNotFound{Name: "organization", Value: strconv.FormatInt(principal.Organization, 10)}
+	}
+
+	return err
+}
+
+func (s *Service) verifyToken(ctx *gofr.Context, token string) (string, error) {
+	if s.verifier == nil {
+		return "", auth.UnauthenticatedError{Err: errTokensDisabled}
+	}
+
+	subject, err := s.verifier.Verify(ctx, token)
+
+	switch {
+	case errors.Is(err, auth.ErrFetchingKeys):
+		// The token may be valid, the issuer is unreachable.
+		return "", err
+	case err != nil:
+		return "", auth.UnauthenticatedError{Err: err}
+	case auth.IsAPIKeySubject(subject):
+		return "", auth.UnauthenticatedError{Err: errReservedSubject}
+	default:
+		return subject, nil
+	}
+}
+
+// CloudAccountScope returns the scope of a cloud account of the organization of the request.
+func (s *Service) CloudAccountScope(ctx *gofr.Context, cloudAccountID int64) (auth.Scope, error) {
+	organizationID, err := s.store.GetCloudAccountOrganization(ctx, cloudAccountID)
+	if err = inOrganization(ctx, "cloud account", cloudAccountID, organizationID, err); err != nil {
+		return auth.Scope{}, err
+	}
+
+	return auth.CloudAccount(cloudAccountID), nil
+}
+
+// ApplicationScope returns the scope of an application of the organization of the request.
+func (s *Service) ApplicationScope(ctx *gofr.Context, applicationID int64) (auth.Scope, error) {
+	organizationID, err := s.store.GetApplicationOrganization(ctx, applicationID)
+	if err = inOrganization(ctx, "application", applicationID, organizationID, err); err != nil {
+		return auth.Scope{}, err
+	}
+
+	return auth.Application(applicationID), nil
+}
+
+// EnvironmentScope returns the scope of an environment of the organization of the request, i.e. the scope of its
+// application.
+func (s *Service) EnvironmentScope(ctx *gofr.Context, environmentID int64) (auth.Scope, error) {
+	applicationID, organizationID, err := s.store.GetEnvironmentApplication(ctx, environmentID)
+	if err = inOrganization(ctx, "environment", environmentID, organizationID, err); err != nil {
+		return auth.Scope{}, err
+	}
+
+	return auth.Application(applicationID), nil
+}
+
+// inOrganization returns an error unless an entity was found in the organization of the request. The entities of the
+// other organizations are reported as missing, their existence is not disclosed.
+func inOrganization(ctx *gofr.Context, name string, id, organizationID int64, err error) error {
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !auth.InOrganization(ctx, organizationID)) {
+		return http.ErrorEntityNotFound{Name: name, Value: strconv.FormatInt(id, 10)}
+	}
+
+	return err
+}
+
+// CreateOrganization creates an organization, its members are added by granting them roles in it.
+func (s *Service) CreateOrganization(ctx *gofr.Context, name string) (*store.Organization, error) {
+	_, err := s.store.GetOrganizationByName(ctx, name)
+	if err == nil {
+		return nil, http.ErrorEntityAlreadyExist{}
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return s.store.InsertOrganization(ctx, &store.Organization{Name: name, CreatedBy: subject(ctx)})
+}
+
+// ListOrganizations returns the organizations the principal of the request is a member of, or every organization for
+// the super admins.
+func (s *Service) ListOrganizations(ctx *gofr.Context) ([]store.Organization, error) {
+	if p, ok := auth.FromContext(ctx); ok && !p.SuperAdmin {
+		return s.store.GetSubjectOrganizations(ctx, p.Subject)
+	}
+
+	return s.store.GetOrganizations(ctx)
+}
+
+// CreateAPIKey generates a new API key. The key is only returned by this call, it has no role until one is granted
+// to its subject.
+func (s *Service) CreateAPIKey(ctx *gofr.Context, name string) (*store.APIKey, error) {
+	key, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := s.store.InsertAPIKey(ctx, &store.APIKey{Name: name, Prefix: key[:apiKeyPrefixLength],
+		CreatedBy: subject(ctx)}, auth.HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey.Key = key
+
+	return apiKey, nil
+}
+
+// ListAPIKeys returns the API keys that are not revoked.
+func (s *Service) ListAPIKeys(ctx *gofr.Context) ([]store.APIKey, error) {
+	return s.store.GetAPIKeys(ctx)
+}
+
+// RevokeAPIKey revokes an API key, the requests sent with it are rejected from then on.
+func (s *Service) RevokeAPIKey(ctx *gofr.Context, id int64) error {
+	err := s.store.RevokeAPIKey(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.ErrorEntityNotFound{Name: "api key", Value: strconv.FormatInt(id, 10)}
+	}
+
+	return err
+}
+
+// AddRoleBinding grants a role to a subject. The principal of the request must be an admin of the scope of the role.
+func (s *Service) AddRoleBinding(ctx *gofr.Context, binding *store.RoleBinding) (*store.RoleBinding, error) {
+	if !auth.Can(ctx, auth.RoleAdmin, binding.Scope) {
+		return nil, auth.ForbiddenError{Role: auth.RoleAdmin, Scope: binding.Scope}
+	}
+
+	existing, err := s.store.GetRoleBindings(ctx, binding.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range existing {
+		if existing[i].Role == binding.Role && existing[i].Scope == binding.Scope {
+			return nil, http.ErrorEntityAlreadyExist{}
+		}
+	}
+
+	binding.CreatedBy = subject(ctx)
+
+	return s.store.InsertRoleBinding(ctx, binding)
+}
+
+// ListRoleBindings returns the role bindings of a subject, or of every subject when it is empty. Only the bindings
+// on the scopes the principal of the request administers, and its own bindings, are returned.
+func (s *Service) ListRoleBindings(ctx *gofr.Context, subj string) ([]store.RoleBinding, error) {
+	bindings, err := s.store.GetRoleBindings(ctx, subj)
+	if err != nil {
+		return nil, err
+	}
+
+	self := subject(ctx)
+
+	return slices.DeleteFunc(bindings, func(b store.RoleBinding) bool {
+		return b.Subject != self && !auth.Can(ctx, auth.RoleAdmin, b.Scope)
+	}), nil
+}
+
+// DeleteRoleBinding revokes a role. The principal of the request must be an admin of the scope of the role.
+func (s *Service) DeleteRoleBinding(ctx *gofr.Context, id int64) error {
+	notFound := http.ErrorEntityNotFound{Name: "role binding", Value: strconv.FormatInt(id, 10)}
+
+	binding, err := s.store.GetRoleBindingByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !auth.Can(ctx, auth.RoleAdmin, binding.Scope) {
+		return auth.ForbiddenError{Role: auth.RoleAdmin, Scope: binding.Scope}
+	}
+
+	err = s.store.DeleteRoleBinding(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+
+	return err
+}
+
+// subject returns the subject of the principal of the request, it is empty for the jobs run by zopdev.
+func subject(ctx *gofr.Context) string {
+	if p, ok := auth.FromContext(ctx); ok {
+		return p.Subject
+	}
+
+	return ""
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/auth/store"
+)
+
+type fakeVerifier struct {
+	subject string
+	err     error
+}
+
+func (v fakeVerifier) Verify(context.Context, string) (string, error) {
+	return v.subject, v.err
+}
+
+func contextOf(p *auth.Principal) *gofr.Context {
+	return &gofr.Context{Context: auth.NewContext(context.Background(), p)}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+
+	bindings := []store.RoleBinding{
+		{ID: 1, OrganizationID: 2, Subject: "dev@example.com", Role: auth.RoleOperator, Scope: auth.CloudAccount(2)},
+		{ID: 2, OrganizationID: 2, Subject: "dev@example.com", Role: auth.RoleViewer, Scope: auth.Global()},
+	}
+	twoOrganizations := append([]store.RoleBinding{{ID: 3, OrganizationID: 3, Subject: "dev@example.com",
+		Role: auth.RoleAdmin, Scope: auth.Global()}}, bindings...)
+
+	testCases := []struct {
+		name         string
+		verifier     TokenVerifier
+		creds        auth.Credentials
+		mockBehavior func()
+		expected     *auth.Principal
+		expectedErr  error
+	}{
+		{
+			name:     "member of a single organization",
+			verifier: fakeVerifier{subject: "dev@example.com"},
+			creds:    auth.Credentials{Token: "token"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(ctx, "dev@example.com").Return(bindings, nil)
+			},
+			expected: &auth.Principal{Subject: "dev@example.com", Method: auth.MethodOIDC, Organization: 2,
+				Bindings: []auth.Binding{
+					{Role: auth.RoleOperator, Scope: auth.CloudAccount(2)},
+					{Role: auth.RoleViewer, Scope: auth.Global()},
+				}},
+		},
+		{
+			name:     "organization named by the request",
+			verifier: fakeVerifier{subject: "dev@example.com"},
+			creds:    auth.Credentials{Token: "token", Organization: "3"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(ctx, "dev@example.com").Return(twoOrganizations, nil)
+				mockStore.EXPECT().GetOrganizationByID(ctx, int64(3)).Return(&store.Organization{ID: 3}, nil)
+			},
+			expected: &auth.Principal{Subject: "dev@example.com", Method: auth.MethodOIDC, Organization: 3,
+				Bindings: []auth.Binding{{Role: auth.RoleAdmin, Scope: auth.Global()}}},
+		},
+		{
+			name:     "member of several organizations",
+			verifier: fakeVerifier{subject: "dev@example.com"},
+			creds:    auth.Credentials{Token: "token"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(ctx, "dev@example.com").Return(twoOrganizations, nil)
+			},
+			expected: &auth.Principal{Subject: "dev@example.com", Method: auth.MethodOIDC, Organization: 1,
+				Bindings: []auth.Binding{}},
+		},
+		{
+			name:     "unknown organization",
+			verifier: fakeVerifier{subject: "dev@example.com"},
+			creds:    auth.Credentials{Token: "token", Organization: "9"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(ctx, "dev@example.com").Return(bindings, nil)
+				mockStore.EXPECT().GetOrganizationByID(ctx, int64(9)).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: http.ErrorEntityNotFound{Name: "organization", Value: "9"},
+		},
+		{
+			name:         "invalid organization",
+			creds:        auth.Credentials{Token: "token", Organization: "payments"},
+			mockBehavior: func() {},
+			expectedErr:  http.ErrorInvalidParam{Params: []string{auth.OrganizationHeader}},
+		},
+		{
+			name:     "super admin",
+			verifier: fakeVerifier{subject: "admin@example.com"},
+			creds:    auth.Credentials{Token: "token"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(ctx, "admin@example.com").Return(nil, nil)
+			},
+			expected: &auth.Principal{Subject: "admin@example.com", Method: auth.MethodOIDC, Organization: 1,
+				Bindings: []auth.Binding{{Role: auth.RoleAdmin, Scope: auth.Global()}}, SuperAdmin: true},
+		},
+		{
+			name:  "api key",
+			creds: auth.Credentials{APIKey: "zop_key"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetAPIKeyByHash(ctx, auth.HashAPIKey("zop_key")).
+					Return(&store.APIKey{ID: 3, OrganizationID: 2, Subject: "api-key:3"}, nil)
+				mockStore.EXPECT().GetRoleBindings(ctx, "api-key:3").Return(nil, nil)
+			},
+			expected: &auth.Principal{Subject: "api-key:3", Method: auth.MethodAPIKey, Organization: 2,
+				Bindings: []auth.Binding{}},
+		},
+		{
+			name:  "api key of another organization",
+			creds: auth.Credentials{APIKey: "zop_key", Organization: "3"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetAPIKeyByHash(ctx, auth.HashAPIKey("zop_key")).
+					Return(&store.APIKey{ID: 3, OrganizationID: 2, Subject: "api-key:3"}, nil)
+			},
+			expectedErr: auth.UnauthenticatedError{Err: errOtherOrganization},
+		},
+		{
+			name:  "revoked api key",
+			creds: auth.Credentials{APIKey: "zop_key"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetAPIKeyByHash(ctx, gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: auth.UnauthenticatedError{Err: errInvalidAPIKey},
+		},
+		{
+			name:         "missing credentials",
+			mockBehavior: func() {},
+			expectedErr:  auth.UnauthenticatedError{Err: errMissingCredentials},
+		},
+		{
+			name:         "no issuer configured",
+			creds:        auth.Credentials{Token: "token"},
+			mockBehavior: func() {},
+			expectedErr:  auth.UnauthenticatedError{Err: errTokensDisabled},
+		},
+		{
+			name:         "invalid token",
+			verifier:     fakeVerifier{err: assert.AnError},
+			creds:        auth.Credentials{Token: "token"},
+			mockBehavior: func() {},
+			expectedErr:  auth.UnauthenticatedError{Err: assert.AnError},
+		},
+		{
+			name:         "unreachable issuer",
+			verifier:     fakeVerifier{err: fmt.Errorf("%w: timeout", auth.ErrFetchingKeys)},
+			creds:        auth.Credentials{Token: "token"},
+			mockBehavior: func() {},
+			expectedErr:  fmt.Errorf("%w: timeout", auth.ErrFetchingKeys),
+		},
+		{
+			name:         "subject of an api key",
+			verifier:     fakeVerifier{subject: "api-key:3"},
+			creds:        auth.Credentials{Token: "token"},
+			mockBehavior: func() {},
+			expectedErr:  auth.UnauthenticatedError{Err: errReservedSubject},
+		},
+		{
+			name:     "store error",
+			verifier: fakeVerifier{subject: "dev@example.com"},
+			creds:    auth.Credentials{Token: "token"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(ctx, "dev@example.com").Return(nil, sql.ErrConnDone)
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			svc := New(mockStore, tc.verifier, []string{"admin@example.com"})
+
+			principal, err := svc.Authenticate(ctx, tc.creds)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, principal)
+		})
+	}
+}
+
+func TestService_EnvironmentScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	ctx := contextOf(&auth.Principal{Subject: "dev@example.com", Organization: 1})
+	svc := New(mockStore, nil, nil)
+
+	mockStore.EXPECT().GetEnvironmentApplication(ctx, int64(4)).Return(int64(2), int64(1), nil)
+	mockStore.EXPECT().GetEnvironmentApplication(ctx, int64(5)).Return(int64(0), int64(0), sql.ErrNoRows)
+	mockStore.EXPECT().GetEnvironmentApplication(ctx, int64(6)).Return(int64(3), int64(2), nil)
+
+	scope, err := svc.EnvironmentScope(ctx, 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, auth.Application(2), scope)
+
+	_, err = svc.EnvironmentScope(ctx, 5)
+
+	require.Equal(t, http.ErrorEntityNotFound{Name: "environment", Value: "5"}, err)
+
+	_, err = svc.EnvironmentScope(ctx, 6)
+
+	require.Equal(t, http.ErrorEntityNotFound{Name: "environment", Value: "6"}, err, "the environment of another organization")
+}
+
+func TestService_CloudAccountScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	ctx := contextOf(&auth.Principal{Subject: "dev@example.com", Organization: 2})
+	svc := New(mockStore, nil, nil)
+
+	mockStore.EXPECT().GetCloudAccountOrganization(ctx, int64(4)).Return(int64(2), nil)
+	mockStore.EXPECT().GetCloudAccountOrganization(ctx, int64(5)).Return(int64(1), nil)
+	mockStore.EXPECT().GetApplicationOrganization(ctx, int64(6)).Return(int64(0), sql.ErrConnDone)
+
+	scope, err := svc.CloudAccountScope(ctx, 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, auth.CloudAccount(4), scope)
+
+	_, err = svc.CloudAccountScope(ctx, 5)
+
+	require.Equal(t, http.ErrorEntityNotFound{Name: "cloud account", Value: "5"}, err)
+
+	_, err = svc.ApplicationScope(ctx, 6)
+
+	require.Equal(t, sql.ErrConnDone, err)
+}
+
+func TestService_CreateOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	ctx := contextOf(&auth.Principal{Subject: "admin@example.com", SuperAdmin: true})
+	svc := New(mockStore, nil, nil)
+
+	mockStore.EXPECT().GetOrganizationByName(ctx, "payments").Return(nil, sql.ErrNoRows)
+	mockStore.EXPECT().InsertOrganization(ctx, &store.Organization{Name: "payments", CreatedBy: "admin@example.com"}).
+		Return(&store.Organization{ID: 2, Name: "payments"}, nil)
+	mockStore.EXPECT().GetOrganizationByName(ctx, "default").Return(&store.Organization{ID: 1}, nil)
+
+	org, err := svc.CreateOrganization(ctx, "payments")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), org.ID)
+
+	_, err = svc.CreateOrganization(ctx, "default")
+
+	require.Equal(t, http.ErrorEntityAlreadyExist{}, err)
+}
+
+func TestService_ListOrganizations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	svc := New(mockStore, nil, nil)
+
+	member := contextOf(&auth.Principal{Subject: "dev@example.com", Organization: 2})
+	superAdmin := contextOf(&auth.Principal{Subject: "admin@example.com", SuperAdmin: true})
+
+	mockStore.EXPECT().GetSubjectOrganizations(member, "dev@example.com").Return([]store.Organization{{ID: 2}}, nil)
+	mockStore.EXPECT().GetOrganizations(superAdmin).Return([]store.Organization{{ID: 1}, {ID: 2}}, nil)
+
+	orgs, err := svc.ListOrganizations(member)
+
+	require.NoError(t, err)
+	assert.Len(t, orgs, 1)
+
+	orgs, err = svc.ListOrganizations(superAdmin)
+
+	require.NoError(t, err)
+	assert.Len(t, orgs, 2)
+}
+
+func TestService_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	ctx := contextOf(&auth.Principal{Subject: "admin@example.com"})
+	svc := New(mockStore, nil, nil)
+
+	var hash string
+
+	mockStore.EXPECT().InsertAPIKey(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *gofr.Context, key *store.APIKey, h string) (*store.APIKey, error) {
+			hash = h
+			key.ID = 1
+
+			return key, nil
+		})
+
+	key, err := svc.CreateAPIKey(ctx, "ci")
+
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", key.CreatedBy)
+	assert.Equal(t, key.Key[:8], key.Prefix)
+	assert.Equal(t, auth.HashAPIKey(key.Key), hash, "only the hash of the key is stored")
+}
+
+func TestService_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	svc := New(mockStore, nil, nil)
+
+	mockStore.EXPECT().RevokeAPIKey(ctx, int64(1)).Return(nil)
+	mockStore.EXPECT().RevokeAPIKey(ctx, int64(2)).Return(sql.ErrNoRows)
+
+	require.NoError(t, svc.RevokeAPIKey(ctx, 1))
+	require.Equal(t, http.ErrorEntityNotFound{Name: "api key", Value: "2"}, svc.RevokeAPIKey(ctx, 2))
+}
+
+func TestService_AddRoleBinding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	svc := New(mockStore, nil, nil)
+
+	accountAdmin := contextOf(&auth.Principal{Subject: "lead@example.com",
+		Bindings: []auth.Binding{{Role: auth.RoleAdmin, Scope: auth.CloudAccount(2)}}})
+
+	testCases := []struct {
+		name         string
+		binding      store.RoleBinding
+		mockBehavior func()
+		expectedErr  error
+	}{
+		{
+			name:    "admin of the scope",
+			binding: store.RoleBinding{Subject: "dev@example.com", Role: auth.RoleOperator, Scope: auth.CloudAccount(2)},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(accountAdmin, "dev@example.com").Return(nil, nil)
+				mockStore.EXPECT().InsertRoleBinding(accountAdmin, &store.RoleBinding{Subject: "dev@example.com",
+					Role: auth.RoleOperator, Scope: auth.CloudAccount(2), CreatedBy: "lead@example.com"}).
+					Return(&store.RoleBinding{ID: 1}, nil)
+			},
+		},
+		{
+			name:         "admin of another scope",
+			binding:      store.RoleBinding{Subject: "dev@example.com", Role: auth.RoleViewer, Scope: auth.CloudAccount(3)},
+			mockBehavior: func() {},
+			expectedErr:  auth.ForbiddenError{Role: auth.RoleAdmin, Scope: auth.CloudAccount(3)},
+		},
+		{
+			name:    "existing binding",
+			binding: store.RoleBinding{Subject: "dev@example.com", Role: auth.RoleOperator, Scope: auth.CloudAccount(2)},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetRoleBindings(accountAdmin, "dev@example.com").
+					Return([]store.RoleBinding{{Role: auth.RoleOperator, Scope: auth.CloudAccount(2)}}, nil)
+			},
+			expectedErr: http.ErrorEntityAlreadyExist{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			_, err := svc.AddRoleBinding(accountAdmin, &tc.binding)
+
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestService_ListRoleBindings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	svc := New(mockStore, nil, nil)
+
+	ctx := contextOf(&auth.Principal{Subject: "lead@example.com",
+		Bindings: []auth.Binding{{Role: auth.RoleAdmin, Scope: auth.CloudAccount(2)}}})
+
+	mockStore.EXPECT().GetRoleBindings(ctx, "").Return([]store.RoleBinding{
+		{ID: 1, Subject: "lead@example.com", Role: auth.RoleAdmin, Scope: auth.CloudAccount(2)},
+		{ID: 2, Subject: "dev@example.com", Role: auth.RoleViewer, Scope: auth.CloudAccount(2)},
+		{ID: 3, Subject: "dev@example.com", Role: auth.RoleViewer, Scope: auth.CloudAccount(3)},
+		{ID: 4, Subject: "admin@example.com", Role: auth.RoleAdmin, Scope: auth.Global()},
+	}, nil)
+
+	bindings, err := svc.ListRoleBindings(ctx, "")
+
+	require.NoError(t, err)
+	require.Len(t, bindings, 2)
+	assert.Equal(t, int64(1), bindings[0].ID)
+	assert.Equal(t, int64(2), bindings[1].ID)
+}
+
+func TestService_DeleteRoleBinding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := store.NewMockAuthStore(ctrl)
+	svc := New(mockStore, nil, nil)
+
+	ctx := contextOf(&auth.Principal{Subject: "lead@example.com",
+		Bindings: []auth.Binding{{Role: auth.RoleAdmin, Scope: auth.Application(2)}}})
+
+	mockStore.EXPECT().GetRoleBindingByID(ctx, int64(1)).
+		Return(&store.RoleBinding{ID: 1, Role: auth.RoleViewer, Scope: auth.Application(2)}, nil)
+	mockStore.EXPECT().DeleteRoleBinding(ctx, int64(1)).Return(nil)
+	mockStore.EXPECT().GetRoleBindingByID(ctx, int64(2)).
+		Return(&store.RoleBinding{ID: 2, Role: auth.RoleViewer, Scope: auth.Global()}, nil)
+	mockStore.EXPECT().GetRoleBindingByID(ctx, int64(3)).Return(nil, sql.ErrNoRows)
+
+	require.NoError(t, svc.DeleteRoleBinding(ctx, 1))
+	require.Equal(t, auth.ForbiddenError{Role: auth.RoleAdmin, Scope: auth.Global()}, svc.DeleteRoleBinding(ctx, 2))
+	require.Equal(t, http.ErrorEntityNotFound{Name: "role binding", Value: "3"}, svc.DeleteRoleBinding(ctx, 3))
+}
@@ -0,0 +1,25 @@
+package store
+
+import "gofr.dev/pkg/gofr"
+
+type AuthStore interface {
+	InsertAPIKey(ctx *gofr.Context, key *APIKey, hash string) (*APIKey, error)
+	GetAPIKeyByHash(ctx *gofr.Context, hash string) (*APIKey, error)
+	GetAPIKeys(ctx *gofr.Context) ([]APIKey, error)
+	RevokeAPIKey(ctx *gofr.Context, id int64) error
+
+	InsertRoleBinding(ctx *gofr.Context, binding *RoleBinding) (*RoleBinding, error)
+	GetRoleBindings(ctx *gofr.Context, subject string) ([]RoleBinding, error)
+	GetRoleBindingByID(ctx *gofr.Context, id int64) (*RoleBinding, error)
+	DeleteRoleBinding(ctx *gofr.Context, id int64) error
+
+	InsertOrganization(ctx *gofr.Context, org *Organization) (*Organization, error)
+	GetOrganizations(ctx *gofr.Context) ([]Organization, error)
+	GetSubjectOrganizations(ctx *gofr.Context, subject string) ([]Organization, error)
+	GetOrganizationByID(ctx *gofr.Context, id int64) (*Organization, error)
+	GetOrganizationByName(ctx *gofr.Context, name string) (*Organization, error)
+
+	GetCloudAccountOrganization(ctx *gofr.Context, cloudAccountID int64) (int64, error)
+	GetApplicationOrganization(ctx *gofr.Context, applicationID int64) (int64, error)
+	GetEnvironmentApplication(ctx *gofr.Context, environmentID int64) (applicationID, organizationID int64, err error)
+}
@@ -0,0 +1,280 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	
+//
+
+// Package store is a generated GoMock package.
+package store
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+)
+
+// MockAuthStore is a mock of AuthStore interface.
+type MockAuthStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthStoreMockRecorder is the mock recorder for MockAuthStore.
+type MockAuthStoreMockRecorder struct {
+	mock *MockAuthStore
+}
+
+// NewMockAuthStore creates a new mock instance.
+func NewMockAuthStore(ctrl *gomock.Controller) *MockAuthStore {
+	mock := &MockAuthStore{ctrl: ctrl}
+	mock.recorder = &MockAuthStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthStore) EXPECT() *MockAuthStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteRoleBinding mocks base method.
+func (m *MockAuthStore) DeleteRoleBinding(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoleBinding", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoleBinding indicates an expected call of DeleteRoleBinding.
+func (mr *MockAuthStoreMockRecorder) DeleteRoleBinding(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockAuthStore)(nil).DeleteRoleBinding), ctx, id)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockAuthStore) GetAPIKeyByHash(ctx *gofr.Context, hash string) (*APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, hash)
+	ret0, _ := ret[0].(*APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockAuthStoreMockRecorder) GetAPIKeyByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockAuthStore)(nil).GetAPIKeyByHash), ctx, hash)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAuthStore) GetAPIKeys(ctx *gofr.Context) ([]APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx)
+	ret0, _ := ret[0].([]APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAuthStoreMockRecorder) GetAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAuthStore)(nil).GetAPIKeys), ctx)
+}
+
+// GetApplicationOrganization mocks base method.
+func (m *MockAuthStore) GetApplicationOrganization(ctx *gofr.Context, applicationID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationOrganization", ctx, applicationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationOrganization indicates an expected call of GetApplicationOrganization.
+func (mr *MockAuthStoreMockRecorder) GetApplicationOrganization(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationOrganization", reflect.TypeOf((*MockAuthStore)(nil).GetApplicationOrganization), ctx, applicationID)
+}
+
+// GetCloudAccountOrganization mocks base method.
+func (m *MockAuthStore) GetCloudAccountOrganization(ctx *gofr.Context, cloudAccountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCloudAccountOrganization", ctx, cloudAccountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCloudAccountOrganization indicates an expected call of GetCloudAccountOrganization.
+func (mr *MockAuthStoreMockRecorder) GetCloudAccountOrganization(ctx, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudAccountOrganization", reflect.TypeOf((*MockAuthStore)(nil).GetCloudAccountOrganization), ctx, cloudAccountID)
+}
+
+// GetEnvironmentApplication mocks base method.
+func (m *MockAuthStore) GetEnvironmentApplication(ctx *gofr.Context, environmentID int64) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvironmentApplication", ctx, environmentID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEnvironmentApplication indicates an expected call of GetEnvironmentApplication.
+func (mr *MockAuthStoreMockRecorder) GetEnvironmentApplication(ctx, environmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironmentApplication", reflect.TypeOf((*MockAuthStore)(nil).GetEnvironmentApplication), ctx, environmentID)
+}
+
+// GetOrganizationByID mocks base method.
+func (m *MockAuthStore) GetOrganizationByID(ctx *gofr.Context, id int64) (*Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByID", ctx, id)
+	ret0, _ := ret[0].(*Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByID indicates an expected call of GetOrganizationByID.
+func (mr *MockAuthStoreMockRecorder) GetOrganizationByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByID", reflect.TypeOf((*MockAuthStore)(nil).GetOrganizationByID), ctx, id)
+}
+
+// GetOrganizationByName mocks base method.
+func (m *MockAuthStore) GetOrganizationByName(ctx *gofr.Context, name string) (*Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByName", ctx, name)
+	ret0, _ := ret[0].(*Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByName indicates an expected call of GetOrganizationByName.
+func (mr *MockAuthStoreMockRecorder) GetOrganizationByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByName", reflect.TypeOf((*MockAuthStore)(nil).GetOrganizationByName), ctx, name)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockAuthStore) GetOrganizations(ctx *gofr.Context) ([]Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", ctx)
+	ret0, _ := ret[0].([]Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockAuthStoreMockRecorder) GetOrganizations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockAuthStore)(nil).GetOrganizations), ctx)
+}
+
+// GetRoleBindingByID mocks base method.
+func (m *MockAuthStore) GetRoleBindingByID(ctx *gofr.Context, id int64) (*RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleBindingByID", ctx, id)
+	ret0, _ := ret[0].(*RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleBindingByID indicates an expected call of GetRoleBindingByID.
+func (mr *MockAuthStoreMockRecorder) GetRoleBindingByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleBindingByID", reflect.TypeOf((*MockAuthStore)(nil).GetRoleBindingByID), ctx, id)
+}
+
+// GetRoleBindings mocks base method.
+func (m *MockAuthStore) GetRoleBindings(ctx *gofr.Context, subject string) ([]RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleBindings", ctx, subject)
+	ret0, _ := ret[0].([]RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleBindings indicates an expected call of GetRoleBindings.
+func (mr *MockAuthStoreMockRecorder) GetRoleBindings(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleBindings", reflect.TypeOf((*MockAuthStore)(nil).GetRoleBindings), ctx, subject)
+}
+
+// GetSubjectOrganizations mocks base method.
+func (m *MockAuthStore) GetSubjectOrganizations(ctx *gofr.Context, subject string) ([]Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubjectOrganizations", ctx, subject)
+	ret0, _ := ret[0].([]Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubjectOrganizations indicates an expected call of GetSubjectOrganizations.
+func (mr *MockAuthStoreMockRecorder) GetSubjectOrganizations(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubjectOrganizations", reflect.TypeOf((*MockAuthStore)(nil).GetSubjectOrganizations), ctx, subject)
+}
+
+// InsertAPIKey mocks base method.
+func (m *MockAuthStore) InsertAPIKey(ctx *gofr.Context, key *APIKey, hash string) (*APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIKey", ctx, key, hash)
+	ret0, _ := ret[0].(*APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAPIKey indicates an expected call of InsertAPIKey.
+func (mr *MockAuthStoreMockRecorder) InsertAPIKey(ctx, key, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIKey", reflect.TypeOf((*MockAuthStore)(nil).InsertAPIKey), ctx, key, hash)
+}
+
+// InsertOrganization mocks base method.
+func (m *MockAuthStore) InsertOrganization(ctx *gofr.Context, org *Organization) (*Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrganization", ctx, org)
+	ret0, _ := ret[0].(*Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertOrganization indicates an expected call of InsertOrganization.
+func (mr *MockAuthStoreMockRecorder) InsertOrganization(ctx, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrganization", reflect.TypeOf((*MockAuthStore)(nil).InsertOrganization), ctx, org)
+}
+
+// InsertRoleBinding mocks base method.
+func (m *MockAuthStore) InsertRoleBinding(ctx *gofr.Context, binding *RoleBinding) (*RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRoleBinding", ctx, binding)
+	ret0, _ := ret[0].(*RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRoleBinding indicates an expected call of InsertRoleBinding.
+func (mr *MockAuthStoreMockRecorder) InsertRoleBinding(ctx, binding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRoleBinding", reflect.TypeOf((*MockAuthStore)(nil).InsertRoleBinding), ctx, binding)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAuthStore) RevokeAPIKey(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAuthStoreMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAuthStore)(nil).RevokeAPIKey), ctx, id)
+}
@@ -0,0 +1,39 @@
+// Package store persists the organizations, and the API keys and the role bindings of the principals calling the API.
+package store
+
+import "github.com/zopdev/zopdev/api/auth"
+
+// APIKey authenticates the automation calling the API. Its roles are granted to the subject auth.APIKeySubject(ID).
+type APIKey struct {
+	ID             int64  `json:"id"`
+	OrganizationID int64  `json:"organizationId"`
+	Name           string `json:"name"`
+	// Prefix is the start of the key, shown to tell the keys apart.
+	Prefix string `json:"prefix"`
+	// Key is only returned when the key is created, the store only keeps its hash.
+	Key       string `json:"key,omitempty"`
+	Subject   string `json:"subject"`
+	CreatedBy string `json:"createdBy,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	RevokedAt string `json:"revokedAt,omitempty"`
+}
+
+// RoleBinding grants a role to a subject on a scope.
+type RoleBinding struct {
+	ID             int64 `json:"id"`
+	OrganizationID int64 `json:"organizationId"`
+	// Subject is the user the role is granted to, as named by the subject claim of its tokens, or an API key.
+	Subject   string     `json:"subject"`
+	Role      auth.Role  `json:"role"`
+	Scope     auth.Scope `json:"scope"`
+	CreatedBy string     `json:"createdBy,omitempty"`
+	CreatedAt string     `json:"createdAt"`
+}
+
+// Organization is a tenant of the installation, it owns cloud accounts, applications, API keys and role bindings.
+type Organization struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	CreatedBy string `json:"createdBy,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
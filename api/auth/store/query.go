@@ -0,0 +1,39 @@
+package store
+
+// The queries of the data of an organization are filtered by organization_id = COALESCE(?, organization_id), given
+// auth.OrganizationFilter, so that they are not filtered for the jobs run by zopdev.
+const (
+	INSERTAPIKEYQUERY = "INSERT INTO api_key (organization_id, name, prefix, key_hash, created_by, created_at)" +
+		" VALUES (?, ?, ?, ?, ?, ?);"
+	// The API keys are looked up to authenticate the requests, across the organizations.
+	GETAPIKEYBYHASHQUERY = "SELECT id, organization_id, name, prefix, created_by, created_at FROM api_key" +
+		" WHERE key_hash = ? AND revoked_at IS NULL;"
+	GETAPIKEYSQUERY = "SELECT id, organization_id, name, prefix, created_by, created_at FROM api_key" +
+		" WHERE organization_id = COALESCE(?, organization_id) AND revoked_at IS NULL ORDER BY id;"
+	REVOKEAPIKEYQUERY = "UPDATE api_key SET revoked_at = ? WHERE id = ? AND organization_id = COALESCE(?, organization_id)" +
+		" AND revoked_at IS NULL;"
+
+	INSERTROLEBINDINGQUERY = "INSERT INTO role_binding (organization_id, subject, role, scope_type, scope_id, created_by," +
+		" created_at) VALUES (?, ?, ?, ?, ?, ?, ?);"
+	GETROLEBINDINGSQUERY = "SELECT id, organization_id, subject, role, scope_type, scope_id, created_by, created_at" +
+		" FROM role_binding WHERE organization_id = COALESCE(?, organization_id) ORDER BY id;"
+	GETROLEBINDINGSBYSUBJECTQUERY = "SELECT id, organization_id, subject, role, scope_type, scope_id, created_by," +
+		" created_at FROM role_binding WHERE subject = ? AND organization_id = COALESCE(?, organization_id) ORDER BY id;"
+	GETROLEBINDINGBYIDQUERY = "SELECT id, organization_id, subject, role, scope_type, scope_id, created_by, created_at" +
+		" FROM role_binding WHERE id = ? AND organization_id = COALESCE(?, organization_id);"
+	DELETEROLEBINDINGQUERY = "DELETE FROM role_binding WHERE id = ? AND organization_id = COALESCE(?, organization_id);"
+
+	INSERTORGANIZATIONQUERY      = "INSERT INTO organization (name, created_by, created_at) VALUES (?, ?, ?);"
+	GETORGANIZATIONSQUERY        = "SELECT id, name, created_by, created_at FROM organization ORDER BY id;"
+	GETORGANIZATIONBYIDQUERY     = "SELECT id, name, created_by, created_at FROM organization WHERE id = ?;"
+	GETORGANIZATIONBYNAMEQUERY   = "SELECT id, name, created_by, created_at FROM organization WHERE name = ?;"
+	GETSUBJECTORGANIZATIONSQUERY = "SELECT id, name, created_by, created_at FROM organization WHERE id IN" +
+		" (SELECT organization_id FROM role_binding WHERE subject = ?) ORDER BY id;"
+
+	// The organizations owning the scopes of the requests, the roles on an environment are the roles on its
+	// application.
+	GETCLOUDACCOUNTORGANIZATIONQUERY = "SELECT organization_id FROM cloud_account WHERE id = ? AND deleted_at IS NULL;"
+	GETAPPLICATIONORGANIZATIONQUERY  = "SELECT organization_id FROM application WHERE id = ? AND deleted_at IS NULL;"
+	GETENVIRONMENTAPPLICATIONQUERY   = "SELECT e.application_id, a.organization_id FROM environment e" +
+		" JOIN application a ON a.id = e.application_id WHERE e.id = ? AND e.deleted_at IS NULL AND a.deleted_at IS NULL;"
+)
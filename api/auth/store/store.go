@@ -0,0 +1,308 @@
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+type Store struct{}
+
+// New creates a new AuthStore.
+func New() AuthStore {
+	return &Store{}
+}
+
+// InsertAPIKey stores a new API key of the organization of the request by its hash.
+func (*Store) InsertAPIKey(ctx *gofr.Context, key *APIKey, hash string) (*APIKey, error) {
+	key.OrganizationID = auth.OrganizationID(ctx)
+	key.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	res, err := ctx.SQL.ExecContext(ctx, INSERTAPIKEYQUERY, key.OrganizationID, key.Name, key.Prefix, hash, key.CreatedBy,
+		key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	key.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	key.Subject = auth.APIKeySubject(key.ID)
+
+	return key, nil
+}
+
+// GetAPIKeyByHash returns the API key with the given hash, unless it is revoked, whatever its organization.
+func (*Store) GetAPIKeyByHash(ctx *gofr.Context, hash string) (*APIKey, error) {
+	row := ctx.SQL.QueryRowContext(ctx, GETAPIKEYBYHASHQUERY, hash)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	return scanAPIKey(row)
+}
+
+// GetAPIKeys returns the API keys of the organization of the request that are not revoked.
+func (*Store) GetAPIKeys(ctx *gofr.Context) ([]APIKey, error) {
+	rows, err := ctx.SQL.QueryContext(ctx, GETAPIKEYSQUERY, auth.OrganizationFilter(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]APIKey, 0)
+
+	for rows.Next() {
+		key, er := scanAPIKey(rows)
+		if er != nil {
+			return nil, er
+		}
+
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey revokes an API key, sql.ErrNoRows is returned when no key is revoked.
+func (*Store) RevokeAPIKey(ctx *gofr.Context, id int64) error {
+	res, err := ctx.SQL.ExecContext(ctx, REVOKEAPIKEYQUERY, time.Now().UTC().Format(time.RFC3339), id,
+		auth.OrganizationFilter(ctx))
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(res)
+}
+
+// InsertRoleBinding stores a new role binding in the organization of the request.
+func (*Store) InsertRoleBinding(ctx *gofr.Context, binding *RoleBinding) (*RoleBinding, error) {
+	binding.OrganizationID = auth.OrganizationID(ctx)
+	binding.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	res, err := ctx.SQL.ExecContext(ctx, INSERTROLEBINDINGQUERY, binding.OrganizationID, binding.Subject,
+		string(binding.Role), binding.Scope.Type, binding.Scope.ID, binding.CreatedBy, binding.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	binding.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return binding, nil
+}
+
+// GetRoleBindings returns the role bindings of a subject in the organization of the request, or every role binding
+// of the organization when the subject is empty. The bindings of every organization are returned when the request
+// has no organization, e.g. to authenticate it.
+func (*Store) GetRoleBindings(ctx *gofr.Context, subject string) ([]RoleBinding, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if subject == "" {
+		rows, err = ctx.SQL.QueryContext(ctx, GETROLEBINDINGSQUERY, auth.OrganizationFilter(ctx))
+	} else {
+		rows, err = ctx.SQL.QueryContext(ctx, GETROLEBINDINGSBYSUBJECTQUERY, subject, auth.OrganizationFilter(ctx))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bindings := make([]RoleBinding, 0)
+
+	for rows.Next() {
+		binding, er := scanRoleBinding(rows)
+		if er != nil {
+			return nil, er
+		}
+
+		bindings = append(bindings, *binding)
+	}
+
+	return bindings, rows.Err()
+}
+
+// GetRoleBindingByID returns a role binding of the organization of the request.
+func (*Store) GetRoleBindingByID(ctx *gofr.Context, id int64) (*RoleBinding, error) {
+	row := ctx.SQL.QueryRowContext(ctx, GETROLEBINDINGBYIDQUERY, id, auth.OrganizationFilter(ctx))
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	return scanRoleBinding(row)
+}
+
+// DeleteRoleBinding deletes a role binding, sql.ErrNoRows is returned when no binding is deleted.
+func (*Store) DeleteRoleBinding(ctx *gofr.Context, id int64) error {
+	res, err := ctx.SQL.ExecContext(ctx, DELETEROLEBINDINGQUERY, id, auth.OrganizationFilter(ctx))
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(res)
+}
+
+// InsertOrganization stores a new organization.
+func (*Store) InsertOrganization(ctx *gofr.Context, org *Organization) (*Organization, error) {
+	org.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	res, err := ctx.SQL.ExecContext(ctx, INSERTORGANIZATIONQUERY, org.Name, org.CreatedBy, org.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	org.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// GetOrganizations returns every organization.
+func (*Store) GetOrganizations(ctx *gofr.Context) ([]Organization, error) {
+	return queryOrganizations(ctx, GETORGANIZATIONSQUERY)
+}
+
+// GetSubjectOrganizations returns the organizations a subject is granted a role in.
+func (*Store) GetSubjectOrganizations(ctx *gofr.Context, subject string) ([]Organization, error) {
+	return queryOrganizations(ctx, GETSUBJECTORGANIZATIONSQUERY, subject)
+}
+
+// GetOrganizationByID returns an organization.
+func (*Store) GetOrganizationByID(ctx *gofr.Context, id int64) (*Organization, error) {
+	row := ctx.SQL.QueryRowContext(ctx, GETORGANIZATIONBYIDQUERY, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	return scanOrganization(row)
+}
+
+// GetOrganizationByName returns the organization with the given name.
+func (*Store) GetOrganizationByName(ctx *gofr.Context, name string) (*Organization, error) {
+	row := ctx.SQL.QueryRowContext(ctx, GETORGANIZATIONBYNAMEQUERY, name)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	return scanOrganization(row)
+}
+
+// GetCloudAccountOrganization returns the ID of the organization owning a cloud account.
+func (*Store) GetCloudAccountOrganization(ctx *gofr.Context, cloudAccountID int64) (int64, error) {
+	var organizationID int64
+
+	err := ctx.SQL.QueryRowContext(ctx, GETCLOUDACCOUNTORGANIZATIONQUERY, cloudAccountID).Scan(&organizationID)
+
+	return organizationID, err
+}
+
+// GetApplicationOrganization returns the ID of the organization owning an application.
+func (*Store) GetApplicationOrganization(ctx *gofr.Context, applicationID int64) (int64, error) {
+	var organizationID int64
+
+	err := ctx.SQL.QueryRowContext(ctx, GETAPPLICATIONORGANIZATIONQUERY, applicationID).Scan(&organizationID)
+
+	return organizationID, err
+}
+
+// GetEnvironmentApplication returns the IDs of the application of an environment and of the organization owning it.
+func (*Store) GetEnvironmentApplication(ctx *gofr.Context, environmentID int64) (applicationID, organizationID int64,
+	err error) {
+	err = ctx.SQL.QueryRowContext(ctx, GETENVIRONMENTAPPLICATIONQUERY, environmentID).Scan(&applicationID, &organizationID)
+
+	return applicationID, organizationID, err
+}
+
+func queryOrganizations(ctx *gofr.Context, query string, args ...any) ([]Organization, error) {
+	rows, err := ctx.SQL.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgs := make([]Organization, 0)
+
+	for rows.Next() {
+		org, er := scanOrganization(rows)
+		if er != nil {
+			return nil, er
+		}
+
+		orgs = append(orgs, *org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// scanner is a single row or the current row of a result set.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row scanner) (*APIKey, error) {
+	var key APIKey
+
+	if err := row.Scan(&key.ID, &key.OrganizationID, &key.Name, &key.Prefix, &key.CreatedBy, &key.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	key.Subject = auth.APIKeySubject(key.ID)
+
+	return &key, nil
+}
+
+func scanRoleBinding(row scanner) (*RoleBinding, error) {
+	var (
+		binding RoleBinding
+		role    string
+	)
+
+	err := row.Scan(&binding.ID, &binding.OrganizationID, &binding.Subject, &role, &binding.Scope.Type,
+		&binding.Scope.ID, &binding.CreatedBy, &binding.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	binding.Role = auth.Role(role)
+
+	return &binding, nil
+}
+
+func scanOrganization(row scanner) (*Organization, error) {
+	var org Organization
+
+	if err := row.Scan(&org.ID, &org.Name, &org.CreatedBy, &org.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func requireAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/auth"
+)
+
+// organizationContext returns the context of a request in the organization.
+func organizationContext(t *testing.T, organization int64) (*gofr.Context, *container.Mocks) {
+	t.Helper()
+
+	mockContainer, mock := container.NewMockContainer(t)
+	p := &auth.Principal{Subject: "admin@example.com", Organization: organization}
+
+	return &gofr.Context{Context: auth.NewContext(context.Background(), p), Container: mockContainer}, mock
+}
+
+func TestStore_InsertAPIKey(t *testing.T) {
+	ctx, mock := organizationContext(t, 2)
+
+	mock.SQL.ExpectExec(INSERTAPIKEYQUERY).WithArgs(int64(2), "ci", "zop_abcd", "hash", "admin@example.com", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	key, err := New().InsertAPIKey(ctx, &APIKey{Name: "ci", Prefix: "zop_abcd", CreatedBy: "admin@example.com"}, "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), key.ID)
+	assert.Equal(t, int64(2), key.OrganizationID)
+	assert.Equal(t, "api-key:3", key.Subject)
+	assert.NotEmpty(t, key.CreatedAt)
+
+	mock.SQL.ExpectExec(INSERTAPIKEYQUERY).WillReturnError(sql.ErrConnDone)
+
+	_, err = New().InsertAPIKey(ctx, &APIKey{Name: "ci"}, "hash")
+
+	require.Equal(t, sql.ErrConnDone, err)
+}
+
+func TestStore_GetAPIKeyByHash(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mock.SQL.ExpectQuery(GETAPIKEYBYHASHQUERY).WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "name", "prefix", "created_by", "created_at"}).
+			AddRow(3, 2, "ci", "zop_abcd", "admin@example.com", "2025-07-01T10:00:00Z"))
+
+	key, err := New().GetAPIKeyByHash(ctx, "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, &APIKey{ID: 3, OrganizationID: 2, Name: "ci", Prefix: "zop_abcd", Subject: "api-key:3",
+		CreatedBy: "admin@example.com", CreatedAt: "2025-07-01T10:00:00Z"}, key)
+
+	mock.SQL.ExpectQuery(GETAPIKEYBYHASHQUERY).WithArgs("revoked").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "name", "prefix", "created_by", "created_at"}))
+
+	_, err = New().GetAPIKeyByHash(ctx, "revoked")
+
+	require.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestStore_GetAPIKeys(t *testing.T) {
+	ctx, mock := organizationContext(t, 1)
+
+	mock.SQL.ExpectQuery(GETAPIKEYSQUERY).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "name", "prefix", "created_by", "created_at"}).
+			AddRow(1, 1, "ci", "zop_abcd", "", "2025-07-01T10:00:00Z").
+			AddRow(2, 1, "terraform", "zop_efgh", "", "2025-07-01T11:00:00Z"))
+
+	keys, err := New().GetAPIKeys(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "api-key:2", keys[1].Subject)
+}
+
+func TestStore_RevokeAPIKey(t *testing.T) {
+	ctx, mock := organizationContext(t, 1)
+
+	mock.SQL.ExpectExec(REVOKEAPIKEYQUERY).WithArgs(sqlmock.AnyArg(), int64(1), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec(REVOKEAPIKEYQUERY).WithArgs(sqlmock.AnyArg(), int64(2), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, New().RevokeAPIKey(ctx, 1))
+	require.Equal(t, sql.ErrNoRows, New().RevokeAPIKey(ctx, 2))
+}
+
+func TestStore_InsertRoleBinding(t *testing.T) {
+	ctx, mock := organizationContext(t, 2)
+
+	mock.SQL.ExpectExec(INSERTROLEBINDINGQUERY).
+		WithArgs(int64(2), "dev@example.com", "operator", "cloud_account", int64(4), "admin@example.com", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	binding, err := New().InsertRoleBinding(ctx, &RoleBinding{Subject: "dev@example.com", Role: auth.RoleOperator,
+		Scope: auth.CloudAccount(4), CreatedBy: "admin@example.com"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), binding.ID)
+}
+
+func TestStore_GetRoleBindings(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	columns := []string{"id", "organization_id", "subject", "role", "scope_type", "scope_id", "created_by", "created_at"}
+
+	// The bindings of every organization are returned to authenticate a request.
+	mock.SQL.ExpectQuery(GETROLEBINDINGSBYSUBJECTQUERY).WithArgs("dev@example.com", nil).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 1, "dev@example.com", "viewer", "global", 0, "", "2025-07-01T10:00:00Z").
+			AddRow(2, 3, "dev@example.com", "admin", "application", 5, "", "2025-07-01T10:00:00Z"))
+
+	bindings, err := New().GetRoleBindings(ctx, "dev@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, []RoleBinding{
+		{ID: 1, OrganizationID: 1, Subject: "dev@example.com", Role: auth.RoleViewer, Scope: auth.Global(),
+			CreatedAt: "2025-07-01T10:00:00Z"},
+		{ID: 2, OrganizationID: 3, Subject: "dev@example.com", Role: auth.RoleAdmin, Scope: auth.Application(5),
+			CreatedAt: "2025-07-01T10:00:00Z"},
+	}, bindings)
+
+	ctx, mock = organizationContext(t, 3)
+
+	mock.SQL.ExpectQuery(GETROLEBINDINGSQUERY).WithArgs(int64(3)).WillReturnError(sql.ErrConnDone)
+
+	_, err = New().GetRoleBindings(ctx, "")
+
+	require.Equal(t, sql.ErrConnDone, err)
+}
+
+func TestStore_GetRoleBindingByID(t *testing.T) {
+	ctx, mock := organizationContext(t, 1)
+
+	mock.SQL.ExpectQuery(GETROLEBINDINGBYIDQUERY).WithArgs(int64(2), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "subject", "role", "scope_type", "scope_id",
+			"created_by", "created_at"}).AddRow(2, 1, "api-key:1", "viewer", "cloud_account", 3, "", "2025-07-01T10:00:00Z"))
+
+	binding, err := New().GetRoleBindingByID(ctx, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, auth.CloudAccount(3), binding.Scope)
+}
+
+func TestStore_DeleteRoleBinding(t *testing.T) {
+	ctx, mock := organizationContext(t, 1)
+
+	mock.SQL.ExpectExec(DELETEROLEBINDINGQUERY).WithArgs(int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec(DELETEROLEBINDINGQUERY).WithArgs(int64(2), int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, New().DeleteRoleBinding(ctx, 1))
+	require.Equal(t, sql.ErrNoRows, New().DeleteRoleBinding(ctx, 2))
+}
+
+func TestStore_GetEnvironmentApplication(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mock.SQL.ExpectQuery(GETENVIRONMENTAPPLICATIONQUERY).WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"application_id", "organization_id"}).AddRow(5, 2))
+
+	applicationID, organizationID, err := New().GetEnvironmentApplication(ctx, 9)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), applicationID)
+	assert.Equal(t, int64(2), organizationID)
+}
+
+func TestStore_GetCloudAccountOrganization(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mock.SQL.ExpectQuery(GETCLOUDACCOUNTORGANIZATIONQUERY).WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"organization_id"}).AddRow(2))
+	mock.SQL.ExpectQuery(GETAPPLICATIONORGANIZATIONQUERY).WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"organization_id"}))
+
+	organizationID, err := New().GetCloudAccountOrganization(ctx, 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), organizationID)
+
+	_, err = New().GetApplicationOrganization(ctx, 5)
+
+	require.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestStore_InsertOrganization(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mock.SQL.ExpectExec(INSERTORGANIZATIONQUERY).WithArgs("payments", "admin@example.com", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+
+	org, err := New().InsertOrganization(ctx, &Organization{Name: "payments", CreatedBy: "admin@example.com"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), org.ID)
+	assert.NotEmpty(t, org.CreatedAt)
+}
+
+func TestStore_GetOrganizations(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	columns := []string{"id", "name", "created_by", "created_at"}
+
+	mock.SQL.ExpectQuery(GETORGANIZATIONSQUERY).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "default", "", "2025-07-03T10:00:00Z").
+			AddRow(2, "payments", "admin@example.com", "2025-07-03T11:00:00Z"))
+	mock.SQL.ExpectQuery(GETSUBJECTORGANIZATIONSQUERY).WithArgs("dev@example.com").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "payments", "admin@example.com", "2025-07-03T11:00:00Z"))
+	mock.SQL.ExpectQuery(GETORGANIZATIONBYIDQUERY).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows(columns))
+	mock.SQL.ExpectQuery(GETORGANIZATIONBYNAMEQUERY).WithArgs("payments").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "payments", "admin@example.com", "2025-07-03T11:00:00Z"))
+
+	orgs, err := New().GetOrganizations(ctx)
+
+	require.NoError(t, err)
+	assert.Len(t, orgs, 2)
+
+	orgs, err = New().GetSubjectOrganizations(ctx, "dev@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, []Organization{{ID: 2, Name: "payments", CreatedBy: "admin@example.com",
+		CreatedAt: "2025-07-03T11:00:00Z"}}, orgs)
+
+	_, err = New().GetOrganizationByID(ctx, 3)
+
+	require.Equal(t, sql.ErrNoRows, err)
+
+	org, err := New().GetOrganizationByName(ctx, "payments")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), org.ID)
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/http"
 
+	"github.com/zopdev/zopdev/api/auth"
 	"github.com/zopdev/zopdev/api/cloudaccounts/service"
 	"github.com/zopdev/zopdev/api/cloudaccounts/store"
 )
@@ -47,6 +49,8 @@ func (h *Handler) AddCloudAccount(ctx *gofr.Context) (interface{}, error) {
 	return resp, nil
 }
 
+// GetCredentials returns the decrypted credentials of a cloud account. It is only registered on an internal route,
+// reachable by the other zopdev services.
 func (h *Handler) GetCredentials(ctx *gofr.Context) (interface{}, error) {
 	id := strings.TrimSpace(ctx.PathParam("id"))
 
@@ -63,6 +67,23 @@ func (h *Handler) GetCredentials(ctx *gofr.Context) (interface{}, error) {
 	return credentials, nil
 }
 
+// GetCredentialsMetadata returns a cloud account along with its redacted credentials.
+func (h *Handler) GetCredentialsMetadata(ctx *gofr.Context) (interface{}, error) {
+	id := strings.TrimSpace(ctx.PathParam("id"))
+
+	cloudAccountID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{"id"}}
+	}
+
+	credentials, err := h.service.FetchCredentialsMetadata(ctx, cloudAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
 // ListCloudAccounts retrieves all existing CloudAccounts using the service layer.
 func (h *Handler) ListCloudAccounts(ctx *gofr.Context) (interface{}, error) {
 	resp, err := h.service.FetchAllCloudAccounts(ctx)
@@ -70,9 +91,120 @@ func (h *Handler) ListCloudAccounts(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
+	// Only the cloud accounts the principal of the request may view are listed.
+	return slices.DeleteFunc(resp, func(acc store.CloudAccount) bool {
+		return !auth.Can(ctx, auth.RoleViewer, auth.CloudAccount(acc.ID))
+	}), nil
+}
+
+// UpdateCloudAccount renames a CloudAccount or replaces its provider details.
+func (h *Handler) UpdateCloudAccount(ctx *gofr.Context) (interface{}, error) {
+	cloudAccountID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var update service.CloudAccountUpdate
+
+	if err = ctx.Bind(&update); err != nil {
+		ctx.Error(err.Error())
+		return nil, http.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" || len(name) > nameLength {
+			return nil, http.ErrorInvalidParam{Params: []string{"name"}}
+		}
+
+		update.Name = &name
+	}
+
+	resp, err := h.service.UpdateCloudAccount(ctx, cloudAccountID, &update)
+	if err != nil {
+		return nil, err
+	}
+
 	return resp, nil
 }
 
+// RotateCredentials replaces the credentials of a CloudAccount with new credentials of the same provider account.
+func (h *Handler) RotateCredentials(ctx *gofr.Context) (interface{}, error) {
+	cloudAccountID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Credentials any `json:"credentials"`
+	}
+
+	if err = ctx.Bind(&body); err != nil {
+		ctx.Error(err.Error())
+		return nil, http.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	if body.Credentials == nil {
+		return nil, http.ErrorMissingParam{Params: []string{"credentials"}}
+	}
+
+	resp, err := h.service.RotateCredentials(ctx, cloudAccountID, body.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExternalID is the external ID the trust policy of the role of an AWS cloud account must require.
+type ExternalID struct {
+	ExternalID string `json:"externalId"`
+}
+
+// GetExternalID returns the external ID of the next AWS cloud account of the organization, to set in the trust policy
+// of its role before the account is added.
+func (h *Handler) GetExternalID(ctx *gofr.Context) (interface{}, error) {
+	externalID, err := h.service.GetExternalID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExternalID{ExternalID: externalID}, nil
+}
+
+// DeleteCloudAccount deletes a CloudAccount. The deployment spaces, resources, resource groups and audit results of the
+// account are deleted along with it when the cascade query parameter is true, otherwise they prevent the deletion.
+func (h *Handler) DeleteCloudAccount(ctx *gofr.Context) (interface{}, error) {
+	cloudAccountID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cascade := false
+
+	if param := ctx.Param("cascade"); param != "" {
+		cascade, err = strconv.ParseBool(param)
+		if err != nil {
+			return nil, http.ErrorInvalidParam{Params: []string{"cascade"}}
+		}
+	}
+
+	if err = h.service.DeleteCloudAccount(ctx, cloudAccountID, cascade); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getCloudAccountID(ctx *gofr.Context) (int64, error) {
+	cloudAccountID, err := strconv.ParseInt(strings.TrimSpace(ctx.PathParam("id")), 10, 64)
+	if err != nil {
+		return 0, http.ErrorInvalidParam{Params: []string{"id"}}
+	}
+
+	return cloudAccountID, nil
+}
+
 func (h *Handler) ListDeploymentSpace(ctx *gofr.Context) (interface{}, error) {
 	id := ctx.PathParam("id")
 	id = strings.TrimSpace(id)
@@ -106,7 +238,9 @@ func (h *Handler) ListNamespaces(ctx *gofr.Context) (interface{}, error) {
 		return nil, http.ErrorInvalidParam{Params: []string{"cluster"}}
 	}
 
-	res, err := h.service.ListNamespaces(ctx, cloudAccountID, clusterName, clusterRegion)
+	clusterProject := strings.TrimSpace(ctx.Param("project"))
+
+	res, err := h.service.ListNamespaces(ctx, cloudAccountID, clusterName, clusterRegion, clusterProject)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
@@ -15,6 +16,7 @@ import (
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/http"
 
+	"github.com/zopdev/zopdev/api/auth"
 	"github.com/zopdev/zopdev/api/cloudaccounts/service"
 	"github.com/zopdev/zopdev/api/cloudaccounts/store"
 )
@@ -149,3 +151,284 @@ func TestHandler_ListCloudAccounts(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ListCloudAccounts_Viewable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCloudAccountService(ctrl)
+	handler := New(mockService)
+
+	mockService.EXPECT().FetchAllCloudAccounts(gomock.Any()).
+		Return([]store.CloudAccount{{ID: 1, Name: "prod"}, {ID: 2, Name: "staging"}}, nil)
+
+	principal := &auth.Principal{Subject: "dev@example.com",
+		Bindings: []auth.Binding{{Role: auth.RoleViewer, Scope: auth.CloudAccount(2)}}}
+	req := httptest.NewRequest(netHTTP.MethodGet, "/list", netHTTP.NoBody)
+	ctx := &gofr.Context{Context: auth.NewContext(context.Background(), principal), Request: http.NewRequest(req)}
+
+	resp, err := handler.ListCloudAccounts(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, []store.CloudAccount{{ID: 2, Name: "staging"}}, resp)
+}
+
+func TestHandler_GetCredentialsMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCloudAccountService(ctrl)
+
+	handler := New(mockService)
+	account := &store.CloudAccount{ID: 1, Provider: "AWS", Credentials: map[string]string{"aws_secret_access_key": "********"}}
+
+	testCases := []struct {
+		name          string
+		id            string
+		mockBehavior  func()
+		expected      any
+		expectedError error
+	}{
+		{
+			name: "success",
+			id:   "1",
+			mockBehavior: func() {
+				mockService.EXPECT().FetchCredentialsMetadata(gomock.Any(), int64(1)).Return(account, nil)
+			},
+			expected: account,
+		},
+		{
+			name:          "invalid id",
+			id:            "abc",
+			mockBehavior:  func() {},
+			expectedError: http.ErrorInvalidParam{Params: []string{"id"}},
+		},
+		{
+			name: "service error",
+			id:   "1",
+			mockBehavior: func() {
+				mockService.EXPECT().FetchCredentialsMetadata(gomock.Any(), int64(1)).Return(nil, errTest)
+			},
+			expectedError: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			req := httptest.NewRequest(netHTTP.MethodGet, "/cloud-accounts/"+tc.id+"/credentials", netHTTP.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+			ctx := &gofr.Context{Context: context.Background(), Request: http.NewRequest(req)}
+
+			resp, err := handler.GetCredentialsMetadata(ctx)
+
+			require.Equal(t, tc.expectedError, err)
+
+			if tc.expectedError == nil {
+				require.Equal(t, tc.expected, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateCloudAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCloudAccountService(ctrl)
+
+	handler := New(mockService)
+	account := &store.CloudAccount{ID: 1, Name: "Renamed"}
+	name := "Renamed"
+
+	testCases := []struct {
+		name          string
+		id            string
+		body          string
+		mockBehavior  func()
+		expectedError error
+	}{
+		{
+			name: "success",
+			id:   "1",
+			body: `{"name":"  Renamed "}`,
+			mockBehavior: func() {
+				mockService.EXPECT().UpdateCloudAccount(gomock.Any(), int64(1), &service.CloudAccountUpdate{Name: &name}).
+					Return(account, nil)
+			},
+		},
+		{
+			name:          "empty name",
+			id:            "1",
+			body:          `{"name":" "}`,
+			mockBehavior:  func() {},
+			expectedError: http.ErrorInvalidParam{Params: []string{"name"}},
+		},
+		{
+			name:          "invalid id",
+			id:            "abc",
+			body:          `{"name":"Renamed"}`,
+			mockBehavior:  func() {},
+			expectedError: http.ErrorInvalidParam{Params: []string{"id"}},
+		},
+		{
+			name: "service error",
+			id:   "1",
+			body: `{"name":"Renamed"}`,
+			mockBehavior: func() {
+				mockService.EXPECT().UpdateCloudAccount(gomock.Any(), int64(1), gomock.Any()).Return(nil, errTest)
+			},
+			expectedError: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			req := httptest.NewRequest(netHTTP.MethodPatch, "/cloud-accounts/"+tc.id, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+			ctx := &gofr.Context{Context: context.Background(), Request: http.NewRequest(req)}
+
+			resp, err := handler.UpdateCloudAccount(ctx)
+
+			require.Equal(t, tc.expectedError, err)
+
+			if tc.expectedError == nil {
+				require.Equal(t, account, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_RotateCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCloudAccountService(ctrl)
+
+	handler := New(mockService)
+
+	testCases := []struct {
+		name          string
+		body          string
+		mockBehavior  func()
+		expectedError error
+	}{
+		{
+			name: "success",
+			body: `{"credentials":{"project_id":"project-1"}}`,
+			mockBehavior: func() {
+				mockService.EXPECT().RotateCredentials(gomock.Any(), int64(1), map[string]any{"project_id": "project-1"}).
+					Return(&store.CloudAccount{ID: 1}, nil)
+			},
+		},
+		{
+			name:          "missing credentials",
+			body:          `{}`,
+			mockBehavior:  func() {},
+			expectedError: http.ErrorMissingParam{Params: []string{"credentials"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			req := httptest.NewRequest(netHTTP.MethodPut, "/cloud-accounts/1/credentials", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			ctx := &gofr.Context{Context: context.Background(), Request: http.NewRequest(req)}
+
+			_, err := handler.RotateCredentials(ctx)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestHandler_GetExternalID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCloudAccountService(ctrl)
+	handler := New(mockService)
+	ctx := &gofr.Context{Context: context.Background()}
+
+	mockService.EXPECT().GetExternalID(ctx).Return("5f0c8e3a", nil)
+
+	resp, err := handler.GetExternalID(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, ExternalID{ExternalID: "5f0c8e3a"}, resp)
+
+	mockService.EXPECT().GetExternalID(ctx).Return("", errTest)
+
+	resp, err = handler.GetExternalID(ctx)
+
+	require.Nil(t, resp)
+	require.Equal(t, errTest, err)
+}
+
+func TestHandler_DeleteCloudAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCloudAccountService(ctrl)
+
+	handler := New(mockService)
+
+	testCases := []struct {
+		name          string
+		query         string
+		mockBehavior  func()
+		expectedError error
+	}{
+		{
+			name: "success",
+			mockBehavior: func() {
+				mockService.EXPECT().DeleteCloudAccount(gomock.Any(), int64(1), false).Return(nil)
+			},
+		},
+		{
+			name:  "cascade",
+			query: "?cascade=true",
+			mockBehavior: func() {
+				mockService.EXPECT().DeleteCloudAccount(gomock.Any(), int64(1), true).Return(nil)
+			},
+		},
+		{
+			name:          "invalid cascade",
+			query:         "?cascade=maybe",
+			mockBehavior:  func() {},
+			expectedError: http.ErrorInvalidParam{Params: []string{"cascade"}},
+		},
+		{
+			name: "service error",
+			mockBehavior: func() {
+				mockService.EXPECT().DeleteCloudAccount(gomock.Any(), int64(1), false).Return(errTest)
+			},
+			expectedError: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			req := httptest.NewRequest(netHTTP.MethodDelete, "/cloud-accounts/1"+tc.query, netHTTP.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			ctx := &gofr.Context{Context: context.Background(), Request: http.NewRequest(req)}
+
+			_, err := handler.DeleteCloudAccount(ctx)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+	ociProvider "github.com/zopdev/zopdev/api/resources/providers/oci"
+)
+
+// credentialsCheckError is returned when the permissions of the credentials of a cloud account cannot be checked,
+// usually because the provider rejects the credentials.
+type credentialsCheckError struct {
+	err error
+}
+
+func (e credentialsCheckError) Error() string {
+	return "unable to check the credentials with the provider: " + e.err.Error()
+}
+
+func (credentialsCheckError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// requirements are the permissions each capability requires, in the terms of the provider.
+type requirements struct {
+	listSQL        []string
+	listCompute    []string
+	startStop      []string
+	readMonitoring []string
+}
+
+func (r requirements) all() []string {
+	all := slices.Concat(r.listSQL, r.listCompute, r.startStop, r.readMonitoring)
+	slices.Sort(all)
+
+	return slices.Compact(all)
+}
+
+// getRequirements returns the permissions used by zopdev to list, start and stop the resources of a provider and to
+// read their metrics.
+func getRequirements(provider string) requirements {
+	switch provider {
+	case gcp:
+		return requirements{
+			listSQL:        []string{"cloudsql.instances.list"},
+			listCompute:    []string{"compute.instances.list"},
+			startStop:      []string{"compute.instances.start", "compute.instances.stop", "cloudsql.instances.update"},
+			readMonitoring: []string{"monitoring.timeSeries.list"},
+		}
+	case aws:
+		return requirements{
+			listSQL:     []string{"rds:DescribeDBInstances"},
+			listCompute: []string{"ec2:DescribeInstances"},
+			startStop: []string{"ec2:StartInstances", "ec2:StopInstances", "rds:StartDBInstance",
+				"rds:StopDBInstance"},
+			readMonitoring: []string{"cloudwatch:GetMetricStatistics"},
+		}
+	case azureCloud:
+		return requirements{
+			listSQL: []string{"Microsoft.DBforMySQL/flexibleServers/read",
+				"Microsoft.DBforPostgreSQL/flexibleServers/read"},
+			listCompute: []string{"Microsoft.Compute/virtualMachines/read"},
+			startStop: []string{"Microsoft.Compute/virtualMachines/start/action",
+				"Microsoft.Compute/virtualMachines/deallocate/action", "Microsoft.DBforMySQL/flexibleServers/start/action",
+				"Microsoft.DBforMySQL/flexibleServers/stop/action", "Microsoft.DBforPostgreSQL/flexibleServers/start/action",
+				"Microsoft.DBforPostgreSQL/flexibleServers/stop/action"},
+			readMonitoring: []string{"Microsoft.Insights/metrics/read"},
+		}
+	case oci:
+		return requirements{
+			listSQL:        []string{ociProvider.PermissionDBSystemInspect},
+			listCompute:    []string{ociProvider.PermissionInstanceInspect},
+			startStop:      []string{ociProvider.PermissionInstancePowerActions, ociProvider.PermissionDBNodePowerActions},
+			readMonitoring: []string{ociProvider.PermissionMetricInspect},
+		}
+	default:
+		return requirements{}
+	}
+}
+
+// setCapabilities checks the capabilities of a cloud account with its provider, when the permissions of the provider
+// can be checked.
+func (s *Service) setCapabilities(ctx *gofr.Context, provider string, cloudAccount *store.CloudAccount) error {
+	checker, ok := s.permissions[provider]
+	if !ok {
+		return nil
+	}
+
+	capabilities, err := checkCapabilities(ctx, checker, cloudAccount, provider)
+	if err != nil {
+		return err
+	}
+
+	cloudAccount.Capabilities = capabilities
+
+	return nil
+}
+
+// checkCapabilities tests the permissions of the credentials of a cloud account with its provider and returns its
+// capability matrix.
+func checkCapabilities(ctx *gofr.Context, checker PermissionChecker, cloudAccount *store.CloudAccount,
+	provider string) (*store.Capabilities, error) {
+	req := getRequirements(provider)
+
+	granted, err := checker.TestPermissions(ctx, cloudAccount.Credentials, req.all())
+	if err != nil {
+		return nil, credentialsCheckError{err: err}
+	}
+
+	return &store.Capabilities{
+		ListSQL:        newCapability(req.listSQL, granted),
+		ListCompute:    newCapability(req.listCompute, granted),
+		StartStop:      newCapability(req.startStop, granted),
+		ReadMonitoring: newCapability(req.readMonitoring, granted),
+		CheckedAt:      time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// newCapability returns the status of a capability from the permissions granted to the credentials, a permission
+// missing from them could not be checked.
+func newCapability(required []string, granted map[string]bool) store.Capability {
+	capability := store.Capability{Status: store.CapabilityGranted}
+
+	for _, permission := range required {
+		isGranted, checked := granted[permission]
+
+		switch {
+		case !checked:
+			capability.Status = store.CapabilityUnknown
+		case !isGranted:
+			capability.Missing = append(capability.Missing, permission)
+		}
+	}
+
+	if len(capability.Missing) > 0 {
+		capability.Status = store.CapabilityDenied
+	}
+
+	return capability
+}
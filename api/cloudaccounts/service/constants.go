@@ -4,4 +4,6 @@ const (
 	gcp = "GCP"
 	oci = "OCI"
 	aws = "AWS"
+	// azure is the name of the Azure provider package.
+	azureCloud = "AZURE"
 )
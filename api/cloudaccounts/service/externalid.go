@@ -0,0 +1,98 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+)
+
+// externalIDSize is the number of random bytes of the external IDs of the AWS roles.
+const externalIDSize = 16
+
+// GetExternalID returns the external ID the trust policy of the role of the next AWS cloud account of the organization
+// must require. The external IDs are generated by zopdev, so that an organization can not make zopdev assume the role
+// of another one by giving its role ARN and external ID. The same external ID is returned until an account is added.
+func (s *Service) GetExternalID(ctx *gofr.Context) (string, error) {
+	externalID, err := s.store.GetPendingExternalID(ctx)
+	if err == nil {
+		return externalID, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	b := make([]byte, externalIDSize)
+
+	if _, err = rand.Read(b); err != nil {
+		return "", err
+	}
+
+	externalID = hex.EncodeToString(b)
+
+	if err = s.store.InsertExternalID(ctx, externalID); err != nil {
+		return "", err
+	}
+
+	return externalID, nil
+}
+
+// assignExternalID sets the external ID of the credentials of an AWS role, any external ID sent by the client is
+// replaced. The external ID of the account is kept, a new account is given the pending external ID of the
+// organization. It returns the external ID to bind to the account once it is stored, if any.
+func (s *Service) assignExternalID(ctx *gofr.Context, account *store.CloudAccount, current string) (string, error) {
+	creds, ok := roleCredentials(account.Credentials)
+	if !ok {
+		return "", nil
+	}
+
+	if current != "" {
+		creds["external_id"] = current
+		account.Credentials = creds
+
+		return "", nil
+	}
+
+	externalID, err := s.GetExternalID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	creds["external_id"] = externalID
+	account.Credentials = creds
+
+	return externalID, nil
+}
+
+// rotateExternalID sets the external ID of the rotated credentials of an AWS role, i.e. the external ID of the current
+// credentials of the account if they are of a role too.
+func (s *Service) rotateExternalID(ctx *gofr.Context, cloudAccountID int64, rotated *store.CloudAccount) (string, error) {
+	current, err := s.store.GetCredentials(ctx, cloudAccountID)
+	if err != nil {
+		return "", err
+	}
+
+	fields, _ := current.(map[string]string)
+
+	return s.assignExternalID(ctx, rotated, fields["external_id"])
+}
+
+// roleCredentials returns the fields of the credentials of an AWS account when they define a role to assume.
+func roleCredentials(credentials any) (map[string]any, bool) {
+	var creds map[string]any
+
+	b, err := json.Marshal(credentials)
+	if err != nil || json.Unmarshal(b, &creds) != nil {
+		return nil, false
+	}
+
+	roleARN, _ := creds["role_arn"].(string)
+
+	return creds, roleARN != ""
+}
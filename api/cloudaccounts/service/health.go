@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"gofr.dev/pkg/gofr"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/googleapi"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+	awsProvider "github.com/zopdev/zopdev/api/resources/providers/aws"
+	"github.com/zopdev/zopdev/api/resources/providers/azure"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+	gcpProvider "github.com/zopdev/zopdev/api/resources/providers/gcp"
+	ociProvider "github.com/zopdev/zopdev/api/resources/providers/oci"
+)
+
+const (
+	// maxHealthFailures is the number of consecutive failed health checks after which the scheduled work of a cloud
+	// account is paused.
+	maxHealthFailures = 3
+	// maxParallelHealthChecks is the number of cloud accounts checked at the same time.
+	maxParallelHealthChecks = 5
+	healthCheckTimeout      = 30 * time.Second
+)
+
+// statusCoder is implemented by the errors of the providers carrying the HTTP status of their response.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// CheckHealth is a cron job validating the credentials of every cloud account with a cheap authenticated call to its
+// provider. The result is stored with the account, and its scheduled work is paused after repeated failures.
+func (s *Service) CheckHealth(ctx *gofr.Context) {
+	accounts, err := s.store.GetALLCloudAccounts(ctx)
+	if err != nil {
+		ctx.Errorf("failed to get cloud accounts for health checks: %v", err)
+
+		return
+	}
+
+	var grp errgroup.Group
+
+	grp.SetLimit(maxParallelHealthChecks)
+
+	for i := range accounts {
+		grp.Go(func() error {
+			s.checkHealth(ctx, &accounts[i])
+
+			return nil
+		})
+	}
+
+	_ = grp.Wait()
+}
+
+// checkHealth checks the credentials of a cloud account and stores the result.
+func (s *Service) checkHealth(ctx *gofr.Context, cloudAccount *store.CloudAccount) {
+	checker, ok := s.health[strings.ToUpper(cloudAccount.Provider)]
+	if !ok {
+		return
+	}
+
+	// The credentials not being readable is not a failure of the account at the provider.
+	creds, err := s.store.GetCredentials(ctx, cloudAccount.ID)
+	if err != nil {
+		ctx.Errorf("failed to get the credentials of cloud account %d for its health check: %v", cloudAccount.ID, err)
+
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	health := newHealth(cloudAccount.Health, checker.CheckHealth(checkCtx, creds), time.Now().UTC())
+
+	switch {
+	case health.Paused && (cloudAccount.Health == nil || !cloudAccount.Health.Paused):
+		ctx.Warnf("pausing the scheduled work of cloud account %d after %d failed health checks: %s",
+			cloudAccount.ID, health.ConsecutiveFailures, health.Error)
+	case !health.Paused && cloudAccount.Health != nil && cloudAccount.Health.Paused:
+		ctx.Infof("resuming the scheduled work of cloud account %d", cloudAccount.ID)
+	}
+
+	if err = s.store.UpdateHealth(ctx, cloudAccount.ID, health); err != nil {
+		ctx.Errorf("failed to store the health of cloud account %d: %v", cloudAccount.ID, err)
+	}
+}
+
+// newHealth returns the health of a cloud account after a check, from its previous health and the error of the check.
+func newHealth(prev *store.Health, err error, now time.Time) *store.Health {
+	health := &store.Health{CheckedAt: now.Format(time.RFC3339)}
+
+	if prev != nil {
+		health.LastSuccessAt = prev.LastSuccessAt
+		health.LastFailureAt = prev.LastFailureAt
+		health.ConsecutiveFailures = prev.ConsecutiveFailures
+	}
+
+	if err == nil {
+		health.Status = store.HealthHealthy
+		health.LastSuccessAt = health.CheckedAt
+		health.ConsecutiveFailures = 0
+
+		return health
+	}
+
+	health.Status = store.HealthUnhealthy
+	health.ErrorClass = classifyError(err)
+	health.Error = err.Error()
+	health.LastFailureAt = health.CheckedAt
+	health.ConsecutiveFailures++
+	health.Paused = health.ConsecutiveFailures >= maxHealthFailures
+
+	return health
+}
+
+// classifyError returns the class of the error of a health check, from the errors of the SDKs of the providers.
+func classifyError(err error) string {
+	var (
+		retrieveErr *oauth2.RetrieveError
+		awsErr      awserr.Error
+		googleErr   *googleapi.Error
+		armErr      *arm.Error
+		coder       statusCoder
+		netErr      net.Error
+	)
+
+	// The token endpoints reject invalid, expired and revoked credentials.
+	if errors.As(err, &retrieveErr) || errors.Is(err, gcpProvider.ErrInvalidCredentials) ||
+		errors.Is(err, awsProvider.ErrInvalidCredentials) || errors.Is(err, azure.ErrInvalidCredentials) ||
+		errors.Is(err, ociProvider.ErrInvalidCredentials) {
+		return store.HealthErrorAuth
+	}
+
+	if errors.Is(err, azure.ErrSubscriptionState) {
+		return store.HealthErrorPermission
+	}
+
+	if errors.As(err, &awsErr) {
+		if class, ok := classifyAWSError(awsErr); ok {
+			return class
+		}
+	}
+
+	if serviceErr, ok := common.IsServiceError(err); ok {
+		return classifyStatus(serviceErr.GetHTTPStatusCode())
+	}
+
+	if errors.As(err, &googleErr) {
+		return classifyStatus(googleErr.Code)
+	}
+
+	// The status of an Azure error is only reported as is for the callers of zopdev when it is a client error.
+	if errors.As(err, &armErr) {
+		return classifyStatus(armErr.Status)
+	}
+
+	if errors.As(err, &coder) {
+		return classifyStatus(coder.StatusCode())
+	}
+
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return store.HealthErrorNetwork
+	}
+
+	return store.HealthErrorUnknown
+}
+
+// classifyAWSError returns the class of the AWS errors whose HTTP status is not specific enough, e.g. STS rejects
+// invalid keys with 403.
+func classifyAWSError(awsErr awserr.Error) (string, bool) {
+	switch {
+	case request.IsErrorThrottle(awsErr):
+		return store.HealthErrorQuota, true
+	case request.IsErrorExpiredCreds(awsErr):
+		return store.HealthErrorAuth, true
+	}
+
+	switch awsErr.Code() {
+	case "InvalidClientTokenId", "UnrecognizedClientException", "SignatureDoesNotMatch", "IncompleteSignature",
+		"AuthFailure":
+		return store.HealthErrorAuth, true
+	case request.ErrCodeRequestError:
+		// The request could not be sent, the network error is not unwrapped by the SDK.
+		return store.HealthErrorNetwork, true
+	default:
+		return "", false
+	}
+}
+
+func classifyStatus(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return store.HealthErrorAuth
+	case http.StatusForbidden, http.StatusNotFound:
+		// OCI and Resource Manager report a missing permission as not found.
+		return store.HealthErrorPermission
+	case http.StatusTooManyRequests:
+		return store.HealthErrorQuota
+	default:
+		return store.HealthErrorUnknown
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+	"github.com/zopdev/zopdev/api/resources/providers/azure"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+)
+
+func TestService_CheckHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, _ := container.NewMockContainer(t)
+	mockStore := store.NewMockCloudAccountStore(ctrl)
+	mockChecker := NewMockHealthChecker(ctrl)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	service := &Service{store: mockStore, health: map[string]HealthChecker{gcp: mockChecker}}
+	creds := map[string]string{"project_id": "project-1"}
+
+	mockStore.EXPECT().GetALLCloudAccounts(ctx).Return([]store.CloudAccount{
+		{ID: 1, Provider: "GCP"},
+		{ID: 2, Provider: "gcp", Health: &store.Health{Status: store.HealthUnhealthy, ConsecutiveFailures: 2}},
+		{ID: 3, Provider: "UNKNOWN"},
+	}, nil)
+	mockStore.EXPECT().GetCredentials(ctx, int64(1)).Return(creds, nil)
+	mockStore.EXPECT().GetCredentials(ctx, int64(2)).Return(creds, nil)
+	mockChecker.EXPECT().CheckHealth(gomock.Any(), creds).Return(nil)
+	mockChecker.EXPECT().CheckHealth(gomock.Any(), creds).Return(&googleapi.Error{Code: http.StatusForbidden})
+	mockStore.EXPECT().UpdateHealth(ctx, int64(1), gomock.Any()).DoAndReturn(
+		func(_ *gofr.Context, _ int64, health *store.Health) error {
+			assert.Equal(t, store.HealthHealthy, health.Status)
+			assert.False(t, health.Paused)
+
+			return nil
+		})
+	mockStore.EXPECT().UpdateHealth(ctx, int64(2), gomock.Any()).DoAndReturn(
+		func(_ *gofr.Context, _ int64, health *store.Health) error {
+			assert.Equal(t, store.HealthErrorPermission, health.ErrorClass)
+			assert.Equal(t, 3, health.ConsecutiveFailures)
+			assert.True(t, health.Paused)
+
+			return nil
+		})
+
+	service.CheckHealth(ctx)
+}
+
+func Test_newHealth(t *testing.T) {
+	now := time.Date(2025, 6, 28, 9, 30, 0, 0, time.UTC)
+	prev := &store.Health{Status: store.HealthUnhealthy, ErrorClass: store.HealthErrorAuth, Error: "expired",
+		CheckedAt: "2025-06-28T09:15:00Z", LastSuccessAt: "2025-06-27T09:00:00Z", LastFailureAt: "2025-06-28T09:15:00Z",
+		ConsecutiveFailures: 3, Paused: true}
+
+	testCases := []struct {
+		name     string
+		prev     *store.Health
+		err      error
+		expected *store.Health
+	}{
+		{
+			name: "first success",
+			expected: &store.Health{Status: store.HealthHealthy, CheckedAt: "2025-06-28T09:30:00Z",
+				LastSuccessAt: "2025-06-28T09:30:00Z"},
+		},
+		{
+			name: "first failure",
+			err:  &oauth2.RetrieveError{ErrorCode: "invalid_grant", ErrorDescription: "revoked"},
+			expected: &store.Health{Status: store.HealthUnhealthy, ErrorClass: store.HealthErrorAuth,
+				Error: `oauth2: "invalid_grant" "revoked"`, CheckedAt: "2025-06-28T09:30:00Z",
+				LastFailureAt: "2025-06-28T09:30:00Z", ConsecutiveFailures: 1},
+		},
+		{
+			name: "success resumes a paused account",
+			prev: prev,
+			expected: &store.Health{Status: store.HealthHealthy, CheckedAt: "2025-06-28T09:30:00Z",
+				LastSuccessAt: "2025-06-28T09:30:00Z", LastFailureAt: "2025-06-28T09:15:00Z"},
+		},
+		{
+			name: "repeated failure stays paused",
+			prev: prev,
+			err:  &googleapi.Error{Code: http.StatusTooManyRequests, Message: "quota"},
+			expected: &store.Health{Status: store.HealthUnhealthy, ErrorClass: store.HealthErrorQuota,
+				Error: "googleapi: Error 429: quota", CheckedAt: "2025-06-28T09:30:00Z",
+				LastSuccessAt: "2025-06-27T09:00:00Z", LastFailureAt: "2025-06-28T09:30:00Z", ConsecutiveFailures: 4,
+				Paused: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, newHealth(tc.prev, tc.err, now))
+		})
+	}
+}
+
+func Test_classifyError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "token rejected", err: &oauth2.RetrieveError{ErrorCode: "invalid_client"}, expected: store.HealthErrorAuth},
+		{name: "invalid credentials", err: azure.ErrInvalidCredentials, expected: store.HealthErrorAuth},
+		{name: "disabled subscription", err: azure.ErrSubscriptionState, expected: store.HealthErrorPermission},
+		{name: "aws invalid keys", err: awserr.NewRequestFailure(awserr.New("InvalidClientTokenId", "invalid", nil),
+			http.StatusForbidden, "1"), expected: store.HealthErrorAuth},
+		{name: "aws access denied", err: awserr.NewRequestFailure(awserr.New("AccessDenied", "denied", nil),
+			http.StatusForbidden, "1"), expected: store.HealthErrorPermission},
+		{name: "aws throttling", err: awserr.New("Throttling", "slow down", nil), expected: store.HealthErrorQuota},
+		{name: "aws network", err: awserr.New("RequestError", "send request failed", &net.OpError{Op: "dial"}),
+			expected: store.HealthErrorNetwork},
+		{name: "google unauthorized", err: &googleapi.Error{Code: http.StatusUnauthorized},
+			expected: store.HealthErrorAuth},
+		{name: "azure forbidden", err: &arm.Error{Status: http.StatusForbidden}, expected: store.HealthErrorPermission},
+		{name: "azure throttling", err: &arm.Error{Status: http.StatusTooManyRequests}, expected: store.HealthErrorQuota},
+		{name: "network", err: &net.OpError{Op: "dial", Err: assert.AnError},
+			expected: store.HealthErrorNetwork},
+		{name: "timeout", err: context.DeadlineExceeded, expected: store.HealthErrorNetwork},
+		{name: "unknown", err: assert.AnError, expected: store.HealthErrorUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
+
+func TestService_CheckHealth_storeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, _ := container.NewMockContainer(t)
+	mockStore := store.NewMockCloudAccountStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	service := &Service{store: mockStore}
+
+	mockStore.EXPECT().GetALLCloudAccounts(ctx).Return(nil, assert.AnError)
+
+	require.NotPanics(t, func() { service.CheckHealth(ctx) })
+}
@@ -1,17 +1,48 @@
 package service
 
 import (
+	"context"
+
 	"gofr.dev/pkg/gofr"
 
 	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+	"github.com/zopdev/zopdev/api/resources/providers/azure"
 )
 
 type CloudAccountService interface {
 	AddCloudAccount(ctx *gofr.Context, accounts *store.CloudAccount) (*store.CloudAccount, error)
 	FetchAllCloudAccounts(ctx *gofr.Context) ([]store.CloudAccount, error)
+	UpdateCloudAccount(ctx *gofr.Context, cloudAccountID int64, update *CloudAccountUpdate) (*store.CloudAccount, error)
+	RotateCredentials(ctx *gofr.Context, cloudAccountID int64, credentials any) (*store.CloudAccount, error)
+	GetExternalID(ctx *gofr.Context) (string, error)
+	DeleteCloudAccount(ctx *gofr.Context, cloudAccountID int64, cascade bool) error
 
 	FetchDeploymentSpace(ctx *gofr.Context, cloudAccountID int64) (interface{}, error)
-	ListNamespaces(ctx *gofr.Context, id int64, clusterName, clusterRegion string) (interface{}, error)
+	ListNamespaces(ctx *gofr.Context, id int64, clusterName, clusterRegion, clusterProject string) (interface{}, error)
 	FetchDeploymentSpaceOptions(ctx *gofr.Context, id int64) ([]DeploymentSpaceOptions, error)
 	FetchCredentials(ctx *gofr.Context, cloudAccountID int64) (interface{}, error)
+	FetchCredentialsMetadata(ctx *gofr.Context, cloudAccountID int64) (*store.CloudAccount, error)
+	ResealCredentials(ctx *gofr.Context) error
+	CheckHealth(ctx *gofr.Context)
+}
+
+// AzureClient validates the service principal credentials of Azure cloud accounts.
+type AzureClient interface {
+	GetSubscription(ctx context.Context, creds any) (*azure.Subscription, error)
+}
+
+// AWSClient validates the credentials of AWS cloud accounts.
+type AWSClient interface {
+	GetAccountID(ctx context.Context, creds any) (string, error)
+}
+
+// PermissionChecker tests which of the given permissions the credentials of a cloud account are granted. A permission
+// missing from the result could not be checked.
+type PermissionChecker interface {
+	TestPermissions(ctx context.Context, creds any, permissions []string) (map[string]bool, error)
+}
+
+// HealthChecker validates the credentials of a cloud account with a cheap authenticated call to its provider.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context, creds any) error
 }
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	netHTTP "net/http"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+)
+
+// providerIDMismatchError is returned when rotated credentials belong to another account of the provider.
+type providerIDMismatchError struct {
+	expected, actual string
+}
+
+func (e providerIDMismatchError) Error() string {
+	return fmt.Sprintf("the credentials belong to %q instead of %q", e.actual, e.expected)
+}
+
+func (providerIDMismatchError) StatusCode() int {
+	return netHTTP.StatusBadRequest
+}
+
+// dependentsError is returned when a cloud account with dependent entities is deleted without cascading.
+type dependentsError struct {
+	dependents *store.Dependents
+}
+
+func (e dependentsError) Error() string {
+	return fmt.Sprintf("the cloud account has %d deployment spaces, %d resources, %d resource groups and %d audit results, "+
+		"delete them or delete the cloud account with cascade", e.dependents.DeploymentSpaces, e.dependents.Resources,
+		e.dependents.ResourceGroups, e.dependents.AuditResults)
+}
+
+func (dependentsError) StatusCode() int {
+	return netHTTP.StatusConflict
+}
+
+// UpdateCloudAccount renames a cloud account or replaces its provider details.
+func (s *Service) UpdateCloudAccount(ctx *gofr.Context, cloudAccountID int64,
+	update *CloudAccountUpdate) (*store.CloudAccount, error) {
+	cloudAccount, err := s.getCloudAccount(ctx, cloudAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		cloudAccount.Name = *update.Name
+	}
+
+	if update.ProviderDetails != nil {
+		cloudAccount.ProviderDetails, err = marshalProviderDetails(update.ProviderDetails)
+		if err != nil {
+			return nil, http.ErrorInvalidParam{Params: []string{"providerDetails"}}
+		}
+	}
+
+	if err = s.store.UpdateCloudAccount(ctx, cloudAccount); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetCloudAccountByID(ctx, cloudAccountID)
+}
+
+// RotateCredentials replaces the credentials of a cloud account, e.g. after a key has leaked. The new credentials are
+// checked with the provider the same way as the credentials of a new account, and they must belong to the same
+// account of the provider.
+func (s *Service) RotateCredentials(ctx *gofr.Context, cloudAccountID int64, credentials any) (*store.CloudAccount, error) {
+	cloudAccount, err := s.getCloudAccount(ctx, cloudAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	providerName := strings.ToUpper(cloudAccount.Provider)
+	rotated := &store.CloudAccount{Provider: cloudAccount.Provider, Credentials: credentials}
+
+	var externalID string
+
+	if providerName == aws {
+		if externalID, err = s.rotateExternalID(ctx, cloudAccountID, rotated); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.validateProviderDetails(ctx, providerName, rotated); err != nil {
+		return nil, err
+	}
+
+	if rotated.ProviderID != cloudAccount.ProviderID {
+		return nil, providerIDMismatchError{expected: cloudAccount.ProviderID, actual: rotated.ProviderID}
+	}
+
+	if err = s.setCapabilities(ctx, providerName, rotated); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.UpdateCredentials(ctx, cloudAccountID, rotated.Credentials, rotated.Capabilities); err != nil {
+		return nil, err
+	}
+
+	if externalID != "" {
+		if err = s.store.BindExternalID(ctx, externalID, cloudAccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.store.GetCloudAccountByID(ctx, cloudAccountID)
+}
+
+// DeleteCloudAccount soft deletes a cloud account. A cloud account with deployment spaces, resources or resource
+// groups is only deleted with cascade, which deletes them along with it.
+func (s *Service) DeleteCloudAccount(ctx *gofr.Context, cloudAccountID int64, cascade bool) error {
+	if _, err := s.getCloudAccount(ctx, cloudAccountID); err != nil {
+		return err
+	}
+
+	dependents, err := s.store.GetDependents(ctx, cloudAccountID)
+	if err != nil {
+		return err
+	}
+
+	if dependents.Total() > 0 && !cascade {
+		return dependentsError{dependents: dependents}
+	}
+
+	return s.store.DeleteCloudAccount(ctx, cloudAccountID, cascade)
+}
+
+// getCloudAccount returns a cloud account that is not deleted.
+func (s *Service) getCloudAccount(ctx *gofr.Context, cloudAccountID int64) (*store.CloudAccount, error) {
+	cloudAccount, err := s.store.GetCloudAccountByID(ctx, cloudAccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, http.ErrorEntityNotFound{Name: "cloud account", Value: strconv.FormatInt(cloudAccountID, 10)}
+	}
+
+	return cloudAccount, err
+}
+
+// marshalProviderDetails returns the provider details as they are stored, i.e. as a JSON document.
+func marshalProviderDetails(details any) (string, error) {
+	if s, ok := details.(string); ok {
+		return s, nil
+	}
+
+	b, err := json.Marshal(details)
+
+	return string(b), err
+}
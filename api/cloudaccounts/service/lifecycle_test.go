@@ -0,0 +1,226 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+)
+
+func TestService_UpdateCloudAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockCloudAccountStore(ctrl)
+	ctx := &gofr.Context{}
+	service := &Service{store: mockStore}
+	name := "Renamed"
+
+	mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).
+		Return(&store.CloudAccount{ID: 1, Name: "Dev", ProviderDetails: `{"region":"us-east1"}`}, nil)
+	mockStore.EXPECT().UpdateCloudAccount(ctx, &store.CloudAccount{ID: 1, Name: "Renamed",
+		ProviderDetails: `{"region":"us-west1"}`}).Return(nil)
+	mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(&store.CloudAccount{ID: 1, Name: "Renamed"}, nil)
+
+	acc, err := service.UpdateCloudAccount(ctx, 1, &CloudAccountUpdate{Name: &name,
+		ProviderDetails: map[string]string{"region": "us-west1"}})
+
+	require.NoError(t, err)
+	require.Equal(t, "Renamed", acc.Name)
+
+	mockStore.EXPECT().GetCloudAccountByID(ctx, int64(2)).Return(nil, sql.ErrNoRows)
+
+	_, err = service.UpdateCloudAccount(ctx, 2, &CloudAccountUpdate{Name: &name})
+
+	require.Equal(t, http.ErrorEntityNotFound{Name: "cloud account", Value: "2"}, err)
+}
+
+func TestService_RotateCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockCloudAccountStore(ctrl)
+	mockChecker := NewMockPermissionChecker(ctrl)
+	ctx := &gofr.Context{}
+	service := &Service{store: mockStore, permissions: map[string]PermissionChecker{gcp: mockChecker}}
+	account := &store.CloudAccount{ID: 1, Name: "Dev", Provider: "GCP", ProviderID: "project-1"}
+	creds := map[string]string{"project_id": "project-1", "private_key": "rotated"}
+
+	testCases := []struct {
+		name          string
+		creds         any
+		mockBehavior  func()
+		expectedError error
+	}{
+		{
+			name:  "success",
+			creds: creds,
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(account, nil)
+				mockChecker.EXPECT().TestPermissions(ctx, creds, getRequirements(gcp).all()).
+					Return(map[string]bool{}, nil)
+				mockStore.EXPECT().UpdateCredentials(ctx, int64(1), creds, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, _ int64, _ any, capabilities *store.Capabilities) error {
+						require.Equal(t, store.CapabilityUnknown, capabilities.ListSQL.Status)
+
+						return nil
+					})
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(account, nil)
+			},
+		},
+		{
+			name:  "credentials of another project",
+			creds: map[string]string{"project_id": "project-2"},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(account, nil)
+			},
+			expectedError: providerIDMismatchError{expected: "project-1", actual: "project-2"},
+		},
+		{
+			name:  "credentials rejected by the provider",
+			creds: creds,
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(account, nil)
+				mockChecker.EXPECT().TestPermissions(ctx, creds, getRequirements(gcp).all()).Return(nil, errTest)
+			},
+			expectedError: credentialsCheckError{err: errTest},
+		},
+		{
+			name:  "invalid credentials",
+			creds: map[string]string{},
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(account, nil)
+			},
+			expectedError: http.ErrorInvalidParam{Params: []string{"credentials"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			_, err := service.RotateCredentials(ctx, 1, tc.creds)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_RotateAWSCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockCloudAccountStore(ctrl)
+	mockAWS := NewMockAWSClient(ctrl)
+	mockChecker := NewMockPermissionChecker(ctrl)
+	ctx := &gofr.Context{}
+	service := &Service{store: mockStore, aws: mockAWS, permissions: map[string]PermissionChecker{aws: mockChecker}}
+	account := &store.CloudAccount{ID: 1, Name: "Prod", Provider: "AWS", ProviderID: "123456789012"}
+	// The external ID sent by the client is ignored.
+	creds := map[string]string{"role_arn": "arn:aws:iam::123456789012:role/zopdev-v2", "external_id": "ext-1"}
+
+	testCases := []struct {
+		name         string
+		current      map[string]string
+		externalID   string
+		mockBehavior func()
+	}{
+		{
+			name:         "role of the account",
+			current:      map[string]string{"role_arn": "arn:aws:iam::123456789012:role/zopdev", "external_id": "bound-1"},
+			externalID:   "bound-1",
+			mockBehavior: func() {},
+		},
+		{
+			name:       "keys replaced by a role",
+			current:    map[string]string{"aws_access_key_id": "AKIA", "aws_secret_access_key": "secret"},
+			externalID: "issued-2",
+			mockBehavior: func() {
+				mockStore.EXPECT().GetPendingExternalID(ctx).Return("issued-2", nil)
+				mockStore.EXPECT().BindExternalID(ctx, "issued-2", int64(1)).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assumed := map[string]any{"role_arn": "arn:aws:iam::123456789012:role/zopdev-v2", "external_id": tc.externalID}
+
+			mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(account, nil).Times(2)
+			mockStore.EXPECT().GetCredentials(ctx, int64(1)).Return(tc.current, nil)
+			mockAWS.EXPECT().GetAccountID(ctx, assumed).Return("123456789012", nil)
+			mockChecker.EXPECT().TestPermissions(ctx, assumed, getRequirements(aws).all()).Return(map[string]bool{}, nil)
+			mockStore.EXPECT().UpdateCredentials(ctx, int64(1), assumed, gomock.Any()).Return(nil)
+			tc.mockBehavior()
+
+			_, err := service.RotateCredentials(ctx, 1, creds)
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_DeleteCloudAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockCloudAccountStore(ctrl)
+	ctx := &gofr.Context{}
+	service := &Service{store: mockStore}
+	dependents := &store.Dependents{Resources: 3}
+
+	testCases := []struct {
+		name          string
+		cascade       bool
+		mockBehavior  func()
+		expectedError error
+	}{
+		{
+			name: "no dependents",
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(&store.CloudAccount{ID: 1}, nil)
+				mockStore.EXPECT().GetDependents(ctx, int64(1)).Return(&store.Dependents{}, nil)
+				mockStore.EXPECT().DeleteCloudAccount(ctx, int64(1), false).Return(nil)
+			},
+		},
+		{
+			name: "blocked by dependents",
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(&store.CloudAccount{ID: 1}, nil)
+				mockStore.EXPECT().GetDependents(ctx, int64(1)).Return(dependents, nil)
+			},
+			expectedError: dependentsError{dependents: dependents},
+		},
+		{
+			name:    "cascade",
+			cascade: true,
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(&store.CloudAccount{ID: 1}, nil)
+				mockStore.EXPECT().GetDependents(ctx, int64(1)).Return(dependents, nil)
+				mockStore.EXPECT().DeleteCloudAccount(ctx, int64(1), true).Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			mockBehavior: func() {
+				mockStore.EXPECT().GetCloudAccountByID(ctx, int64(1)).Return(nil, sql.ErrNoRows)
+			},
+			expectedError: http.ErrorEntityNotFound{Name: "cloud account", Value: "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := service.DeleteCloudAccount(ctx, 1, tc.cascade)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
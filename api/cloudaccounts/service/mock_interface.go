@@ -10,9 +10,11 @@
 package service
 
 import (
+	context "context"
 	reflect "reflect"
 
 	store "github.com/zopdev/zopdev/api/cloudaccounts/store"
+	azure "github.com/zopdev/zopdev/api/resources/providers/azure"
 	gomock "go.uber.org/mock/gomock"
 	gofr "gofr.dev/pkg/gofr"
 )
@@ -56,6 +58,32 @@ func (mr *MockCloudAccountServiceMockRecorder) AddCloudAccount(ctx, accounts any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCloudAccount", reflect.TypeOf((*MockCloudAccountService)(nil).AddCloudAccount), ctx, accounts)
 }
 
+// CheckHealth mocks base method.
+func (m *MockCloudAccountService) CheckHealth(ctx *gofr.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CheckHealth", ctx)
+}
+
+// CheckHealth indicates an expected call of CheckHealth.
+func (mr *MockCloudAccountServiceMockRecorder) CheckHealth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckHealth", reflect.TypeOf((*MockCloudAccountService)(nil).CheckHealth), ctx)
+}
+
+// DeleteCloudAccount mocks base method.
+func (m *MockCloudAccountService) DeleteCloudAccount(ctx *gofr.Context, cloudAccountID int64, cascade bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCloudAccount", ctx, cloudAccountID, cascade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCloudAccount indicates an expected call of DeleteCloudAccount.
+func (mr *MockCloudAccountServiceMockRecorder) DeleteCloudAccount(ctx, cloudAccountID, cascade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCloudAccount", reflect.TypeOf((*MockCloudAccountService)(nil).DeleteCloudAccount), ctx, cloudAccountID, cascade)
+}
+
 // FetchAllCloudAccounts mocks base method.
 func (m *MockCloudAccountService) FetchAllCloudAccounts(ctx *gofr.Context) ([]store.CloudAccount, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +114,21 @@ func (mr *MockCloudAccountServiceMockRecorder) FetchCredentials(ctx, cloudAccoun
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCredentials", reflect.TypeOf((*MockCloudAccountService)(nil).FetchCredentials), ctx, cloudAccountID)
 }
 
+// FetchCredentialsMetadata mocks base method.
+func (m *MockCloudAccountService) FetchCredentialsMetadata(ctx *gofr.Context, cloudAccountID int64) (*store.CloudAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCredentialsMetadata", ctx, cloudAccountID)
+	ret0, _ := ret[0].(*store.CloudAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCredentialsMetadata indicates an expected call of FetchCredentialsMetadata.
+func (mr *MockCloudAccountServiceMockRecorder) FetchCredentialsMetadata(ctx, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCredentialsMetadata", reflect.TypeOf((*MockCloudAccountService)(nil).FetchCredentialsMetadata), ctx, cloudAccountID)
+}
+
 // FetchDeploymentSpace mocks base method.
 func (m *MockCloudAccountService) FetchDeploymentSpace(ctx *gofr.Context, cloudAccountID int64) (any, error) {
 	m.ctrl.T.Helper()
@@ -116,17 +159,231 @@ func (mr *MockCloudAccountServiceMockRecorder) FetchDeploymentSpaceOptions(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeploymentSpaceOptions", reflect.TypeOf((*MockCloudAccountService)(nil).FetchDeploymentSpaceOptions), ctx, id)
 }
 
+// GetExternalID mocks base method.
+func (m *MockCloudAccountService) GetExternalID(ctx *gofr.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalID", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalID indicates an expected call of GetExternalID.
+func (mr *MockCloudAccountServiceMockRecorder) GetExternalID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalID", reflect.TypeOf((*MockCloudAccountService)(nil).GetExternalID), ctx)
+}
+
 // ListNamespaces mocks base method.
-func (m *MockCloudAccountService) ListNamespaces(ctx *gofr.Context, id int64, clusterName, clusterRegion string) (any, error) {
+func (m *MockCloudAccountService) ListNamespaces(ctx *gofr.Context, id int64, clusterName, clusterRegion, clusterProject string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNamespaces", ctx, id, clusterName, clusterRegion)
+	ret := m.ctrl.Call(m, "ListNamespaces", ctx, id, clusterName, clusterRegion, clusterProject)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListNamespaces indicates an expected call of ListNamespaces.
-func (mr *MockCloudAccountServiceMockRecorder) ListNamespaces(ctx, id, clusterName, clusterRegion any) *gomock.Call {
+func (mr *MockCloudAccountServiceMockRecorder) ListNamespaces(ctx, id, clusterName, clusterRegion, clusterProject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNamespaces", reflect.TypeOf((*MockCloudAccountService)(nil).ListNamespaces), ctx, id, clusterName, clusterRegion, clusterProject)
+}
+
+// ResealCredentials mocks base method.
+func (m *MockCloudAccountService) ResealCredentials(ctx *gofr.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResealCredentials", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResealCredentials indicates an expected call of ResealCredentials.
+func (mr *MockCloudAccountServiceMockRecorder) ResealCredentials(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResealCredentials", reflect.TypeOf((*MockCloudAccountService)(nil).ResealCredentials), ctx)
+}
+
+// RotateCredentials mocks base method.
+func (m *MockCloudAccountService) RotateCredentials(ctx *gofr.Context, cloudAccountID int64, credentials any) (*store.CloudAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateCredentials", ctx, cloudAccountID, credentials)
+	ret0, _ := ret[0].(*store.CloudAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateCredentials indicates an expected call of RotateCredentials.
+func (mr *MockCloudAccountServiceMockRecorder) RotateCredentials(ctx, cloudAccountID, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateCredentials", reflect.TypeOf((*MockCloudAccountService)(nil).RotateCredentials), ctx, cloudAccountID, credentials)
+}
+
+// UpdateCloudAccount mocks base method.
+func (m *MockCloudAccountService) UpdateCloudAccount(ctx *gofr.Context, cloudAccountID int64, update *CloudAccountUpdate) (*store.CloudAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCloudAccount", ctx, cloudAccountID, update)
+	ret0, _ := ret[0].(*store.CloudAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCloudAccount indicates an expected call of UpdateCloudAccount.
+func (mr *MockCloudAccountServiceMockRecorder) UpdateCloudAccount(ctx, cloudAccountID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCloudAccount", reflect.TypeOf((*MockCloudAccountService)(nil).UpdateCloudAccount), ctx, cloudAccountID, update)
+}
+
+// MockAzureClient is a mock of AzureClient interface.
+type MockAzureClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAzureClientMockRecorder
+	isgomock struct{}
+}
+
+// MockAzureClientMockRecorder is the mock recorder for MockAzureClient.
+type MockAzureClientMockRecorder struct {
+	mock *MockAzureClient
+}
+
+// NewMockAzureClient creates a new mock instance.
+func NewMockAzureClient(ctrl *gomock.Controller) *MockAzureClient {
+	mock := &MockAzureClient{ctrl: ctrl}
+	mock.recorder = &MockAzureClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAzureClient) EXPECT() *MockAzureClientMockRecorder {
+	return m.recorder
+}
+
+// GetSubscription mocks base method.
+func (m *MockAzureClient) GetSubscription(ctx context.Context, creds any) (*azure.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, creds)
+	ret0, _ := ret[0].(*azure.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockAzureClientMockRecorder) GetSubscription(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockAzureClient)(nil).GetSubscription), ctx, creds)
+}
+
+// MockAWSClient is a mock of AWSClient interface.
+type MockAWSClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAWSClientMockRecorder
+	isgomock struct{}
+}
+
+// MockAWSClientMockRecorder is the mock recorder for MockAWSClient.
+type MockAWSClientMockRecorder struct {
+	mock *MockAWSClient
+}
+
+// NewMockAWSClient creates a new mock instance.
+func NewMockAWSClient(ctrl *gomock.Controller) *MockAWSClient {
+	mock := &MockAWSClient{ctrl: ctrl}
+	mock.recorder = &MockAWSClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAWSClient) EXPECT() *MockAWSClientMockRecorder {
+	return m.recorder
+}
+
+// GetAccountID mocks base method.
+func (m *MockAWSClient) GetAccountID(ctx context.Context, creds any) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountID", ctx, creds)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountID indicates an expected call of GetAccountID.
+func (mr *MockAWSClientMockRecorder) GetAccountID(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountID", reflect.TypeOf((*MockAWSClient)(nil).GetAccountID), ctx, creds)
+}
+
+// MockPermissionChecker is a mock of PermissionChecker interface.
+type MockPermissionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockPermissionCheckerMockRecorder is the mock recorder for MockPermissionChecker.
+type MockPermissionCheckerMockRecorder struct {
+	mock *MockPermissionChecker
+}
+
+// NewMockPermissionChecker creates a new mock instance.
+func NewMockPermissionChecker(ctrl *gomock.Controller) *MockPermissionChecker {
+	mock := &MockPermissionChecker{ctrl: ctrl}
+	mock.recorder = &MockPermissionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionChecker) EXPECT() *MockPermissionCheckerMockRecorder {
+	return m.recorder
+}
+
+// TestPermissions mocks base method.
+func (m *MockPermissionChecker) TestPermissions(ctx context.Context, creds any, permissions []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestPermissions", ctx, creds, permissions)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestPermissions indicates an expected call of TestPermissions.
+func (mr *MockPermissionCheckerMockRecorder) TestPermissions(ctx, creds, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestPermissions", reflect.TypeOf((*MockPermissionChecker)(nil).TestPermissions), ctx, creds, permissions)
+}
+
+// MockHealthChecker is a mock of HealthChecker interface.
+type MockHealthChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthCheckerMockRecorder is the mock recorder for MockHealthChecker.
+type MockHealthCheckerMockRecorder struct {
+	mock *MockHealthChecker
+}
+
+// NewMockHealthChecker creates a new mock instance.
+func NewMockHealthChecker(ctrl *gomock.Controller) *MockHealthChecker {
+	mock := &MockHealthChecker{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthChecker) EXPECT() *MockHealthCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckHealth mocks base method.
+func (m *MockHealthChecker) CheckHealth(ctx context.Context, creds any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckHealth", ctx, creds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckHealth indicates an expected call of CheckHealth.
+func (mr *MockHealthCheckerMockRecorder) CheckHealth(ctx, creds any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNamespaces", reflect.TypeOf((*MockCloudAccountService)(nil).ListNamespaces), ctx, id, clusterName, clusterRegion)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckHealth", reflect.TypeOf((*MockHealthChecker)(nil).CheckHealth), ctx, creds)
 }
@@ -1,5 +1,8 @@
 package service
 
+// gcpCredentials are the key of a service account, along with the scope of the cloud account when it covers more than
+// the project of the key. The projects are listed as a comma separated string, as the credentials are stored as a map
+// of strings.
 type gcpCredentials struct {
 	Type                    string `json:"type"`
 	ProjectID               string `json:"project_id"`
@@ -12,6 +15,37 @@ type gcpCredentials struct {
 	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
 	ClientX509CertURL       string `json:"client_x509_cert_url"`
 	UniverseDomain          string `json:"universe_domain"`
+
+	Projects                  string `json:"projects"`
+	FolderID                  string `json:"folder_id"`
+	OrganizationID            string `json:"organization_id"`
+	ImpersonateServiceAccount string `json:"impersonate_service_account"`
+}
+
+// providerID returns the folder or the organization of the cloud account as a resource name, or the project of the
+// key otherwise, the listed projects are only a selection within the reach of the key.
+func (c *gcpCredentials) providerID() string {
+	switch {
+	case c.FolderID != "":
+		return "folders/" + c.FolderID
+	case c.OrganizationID != "":
+		return "organizations/" + c.OrganizationID
+	default:
+		return c.ProjectID
+	}
+}
+
+// hasSingleScope returns whether at most one of the projects, the folder and the organization is given.
+func (c *gcpCredentials) hasSingleScope() bool {
+	scopes := 0
+
+	for _, scope := range []string{c.Projects, c.FolderID, c.OrganizationID} {
+		if scope != "" {
+			scopes++
+		}
+	}
+
+	return scopes <= 1
 }
 
 type ociCredentials struct {
@@ -23,9 +57,10 @@ type ociCredentials struct {
 	Compartment string `json:"compartment"`
 }
 
-type awsCredentials struct {
-	AccessKey    string `json:"aws_access_key_id"`
-	AccessSecret string `json:"aws_secret_access_key"`
+// CloudAccountUpdate is the update of a cloud account, the fields that are nil are left unchanged.
+type CloudAccountUpdate struct {
+	Name            *string `json:"name"`
+	ProviderDetails any     `json:"providerDetails"`
 }
 
 type DeploymentSpaceOptions struct {
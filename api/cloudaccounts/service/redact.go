@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/cloudaccounts/store"
+)
+
+const (
+	redacted = "********"
+	// maskedSuffixLength is the number of trailing characters left visible by maskCredential.
+	maskedSuffixLength = 4
+)
+
+// FetchCredentialsMetadata retrieves a cloud account along with its redacted credentials. Only the fields identifying
+// the credentials, e.g. the client email of a service account, are returned as is.
+func (s *Service) FetchCredentialsMetadata(ctx *gofr.Context, cloudAccountID int64) (*store.CloudAccount, error) {
+	creds, err := s.store.GetCredentials(ctx, cloudAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudAcc, err := s.store.GetCloudAccountByID(ctx, cloudAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, _ := creds.(map[string]string)
+	cloudAcc.Credentials = redactCredentials(fields)
+
+	return cloudAcc, nil
+}
+
+func redactCredentials(creds map[string]string) map[string]string {
+	// The fields identifying the credentials or their scope, they grant no access on their own.
+	public := map[string]bool{
+		"type": true, "project_id": true, "client_email": true, "client_id": true, "auth_uri": true, "token_uri": true,
+		"auth_provider_x509_cert_url": true, "client_x509_cert_url": true, "universe_domain": true,
+		"tenancy_ocid": true, "user_ocid": true, "region": true, "fingerprint": true, "compartment": true,
+		"tenant_id": true, "subscription_id": true, "role_arn": true, "external_id": true, "projects": true, "folder_id": true,
+		"organization_id": true, "impersonate_service_account": true,
+	}
+
+	res := make(map[string]string, len(creds))
+
+	for k, v := range creds {
+		switch {
+		case public[k]:
+			res[k] = v
+		case k == "aws_access_key_id":
+			res[k] = maskCredential(v)
+		default:
+			res[k] = redacted
+		}
+	}
+
+	return res
+}
+
+// maskCredential hides all but the last characters of a credential, e.g. to tell access keys apart.
+func maskCredential(v string) string {
+	if len(v) <= maskedSuffixLength {
+		return redacted
+	}
+
+	return strings.Repeat("*", len(v)-maskedSuffixLength) + v[len(v)-maskedSuffixLength:]
+}
@@ -8,49 +8,67 @@ import (
 	"strings"
 	"time"
 
-	awsSDK "github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/sts"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/http"
 
 	"github.com/zopdev/zopdev/api/cloudaccounts/store"
 	"github.com/zopdev/zopdev/api/provider"
+	awsProvider "github.com/zopdev/zopdev/api/resources/providers/aws"
+	"github.com/zopdev/zopdev/api/resources/providers/azure"
+	gcpProvider "github.com/zopdev/zopdev/api/resources/providers/gcp"
+	ociProvider "github.com/zopdev/zopdev/api/resources/providers/oci"
 )
 
 type Service struct {
 	store           store.CloudAccountStore
 	deploymentSpace provider.Provider
+	azure           AzureClient
+	aws             AWSClient
+	// permissions check the permissions of the credentials of the cloud accounts, by provider.
+	permissions map[string]PermissionChecker
+	// health checks the credentials of the cloud accounts periodically, by provider.
+	health map[string]HealthChecker
 }
 
 // New creates a new CloudAccountService with the provided CloudAccountStore.
 func New(clStore store.CloudAccountStore, deploySpace provider.Provider) CloudAccountService {
-	return &Service{store: clStore, deploymentSpace: deploySpace}
+	azureClient := azure.New()
+	awsClient := awsProvider.New()
+	gcpClient := gcpProvider.New()
+	ociClient := ociProvider.New()
+
+	return &Service{store: clStore, deploymentSpace: deploySpace, azure: azureClient, aws: awsClient,
+		permissions: map[string]PermissionChecker{
+			gcp:        gcpClient,
+			aws:        awsClient,
+			oci:        ociClient,
+			azureCloud: azureClient,
+		},
+		health: map[string]HealthChecker{
+			gcp:        gcpClient,
+			aws:        awsClient,
+			oci:        ociClient,
+			azureCloud: azureClient,
+		}}
 }
 
-// AddCloudAccount adds a new cloud account to the store if it doesn't already exist.
+// AddCloudAccount adds a new cloud account to the store if it doesn't already exist. The credentials are checked with
+// the provider, and the capabilities their permissions allow are stored with the account.
 func (s *Service) AddCloudAccount(ctx *gofr.Context, cloudAccount *store.CloudAccount) (*store.CloudAccount, error) {
-	// TODO : validation is only checking if the values are present - we also need to check if the values are valid
-	// and able to connect to a cloud account, we would need to keep that code in a separate package where all gcp, aws code is present.
-	switch strings.ToUpper(cloudAccount.Provider) {
-	case gcp:
-		err := fetchGCPProviderDetails(ctx, cloudAccount)
-		if err != nil {
-			return nil, err
-		}
-	case aws:
-		err := validateAWSProviderDetails(ctx, cloudAccount)
-		if err != nil {
-			return nil, err
-		}
-	case oci:
-		err := fetchOCIProviderDetails(ctx, cloudAccount)
-		if err != nil {
+	providerName := strings.ToUpper(cloudAccount.Provider)
+
+	var externalID string
+
+	if providerName == aws {
+		var err error
+
+		if externalID, err = s.assignExternalID(ctx, cloudAccount, ""); err != nil {
 			return nil, err
 		}
-	default:
-		return nil, http.ErrorInvalidParam{Params: []string{"provider"}}
+	}
+
+	if err := s.validateProviderDetails(ctx, providerName, cloudAccount); err != nil {
+		return nil, err
 	}
 
 	tempCloudAccount, err := s.store.GetCloudAccountByProvider(ctx, cloudAccount.Provider, cloudAccount.ProviderID)
@@ -64,9 +82,40 @@ func (s *Service) AddCloudAccount(ctx *gofr.Context, cloudAccount *store.CloudAc
 		return nil, http.ErrorEntityAlreadyExist{}
 	}
 
+	if err = s.setCapabilities(ctx, providerName, cloudAccount); err != nil {
+		return nil, err
+	}
+
 	cloudAccount.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 
-	return s.store.InsertCloudAccount(ctx, cloudAccount)
+	added, err := s.store.InsertCloudAccount(ctx, cloudAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if externalID != "" {
+		if err = s.store.BindExternalID(ctx, externalID, added.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return added, nil
+}
+
+// validateProviderDetails validates the credentials of a cloud account and sets the provider ID they belong to.
+func (s *Service) validateProviderDetails(ctx *gofr.Context, providerName string, cloudAccount *store.CloudAccount) error {
+	switch providerName {
+	case gcp:
+		return fetchGCPProviderDetails(ctx, cloudAccount)
+	case aws:
+		return s.validateAWSProviderDetails(ctx, cloudAccount)
+	case oci:
+		return fetchOCIProviderDetails(ctx, cloudAccount)
+	case azureCloud:
+		return s.validateAzureProviderDetails(ctx, cloudAccount)
+	default:
+		return http.ErrorInvalidParam{Params: []string{"provider"}}
+	}
 }
 
 // FetchAllCloudAccounts retrieves all cloud accounts from the store.
@@ -74,7 +123,8 @@ func (s *Service) FetchAllCloudAccounts(ctx *gofr.Context) ([]store.CloudAccount
 	return s.store.GetALLCloudAccounts(ctx)
 }
 
-// fetchGCPProviderDetails retrieves and assigns GCP details for a cloud account.
+// fetchGCPProviderDetails retrieves and assigns GCP details for a cloud account. An account covers either the project of
+// its key, a list of projects, a folder or an organization.
 func fetchGCPProviderDetails(ctx *gofr.Context, cloudAccount *store.CloudAccount) error {
 	var gcpCred gcpCredentials
 
@@ -93,59 +143,26 @@ func fetchGCPProviderDetails(ctx *gofr.Context, cloudAccount *store.CloudAccount
 		return http.ErrorInvalidParam{Params: []string{"credentials"}}
 	}
 
-	cloudAccount.ProviderID = gcpCred.ProjectID
-
-	return nil
-}
-
-func validateAWSProviderDetails(_ *gofr.Context, account *store.CloudAccount) error {
-	var awsCred awsCredentials
-
-	awsCredBody, _ := json.Marshal(account.Credentials)
-
-	err := json.Unmarshal(awsCredBody, &awsCred)
-	if err != nil {
-		return err
-	}
-
-	if awsCred.AccessKey == "" && awsCred.AccessSecret == "" {
-		return http.ErrorMissingParam{Params: []string{"AWSAccessKeyID", "AWSecretAccessKey"}}
-	}
-
-	if awsCred.AccessKey == "" {
-		return http.ErrorMissingParam{Params: []string{"AWSAccessKeyID"}}
+	if !gcpCred.hasSingleScope() {
+		return http.ErrorInvalidParam{Params: []string{"projects", "folder_id", "organization_id"}}
 	}
 
-	if awsCred.AccessSecret == "" {
-		return http.ErrorMissingParam{Params: []string{"AWSecretAccessKey"}}
-	}
-
-	account.ProviderID, err = getAWSAccountID(awsCred)
-	if err != nil {
-		return err
-	}
+	cloudAccount.ProviderID = gcpCred.providerID()
 
 	return nil
 }
 
-// TODO this logic will be strictly moved after the resource pause PR is merged.
-func getAWSAccountID(awsCred awsCredentials) (string, error) {
-	sess, err := session.NewSession(&awsSDK.Config{
-		Credentials: credentials.NewStaticCredentials(awsCred.AccessKey, awsCred.AccessSecret, "")})
+// validateAWSProviderDetails checks the credentials of an AWS cloud account, i.e. the keys of an IAM user or a role to
+// assume, the ID of the AWS account they belong to is then the provider ID of the account.
+func (s *Service) validateAWSProviderDetails(ctx *gofr.Context, account *store.CloudAccount) error {
+	accountID, err := s.aws.GetAccountID(ctx, account.Credentials)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// Create an STS client
-	svc := sts.New(sess)
-
-	// Get the caller identity
-	resp, err := svc.GetCallerIdentity(nil)
-	if err != nil {
-		return "", err
-	}
+	account.ProviderID = accountID
 
-	return *resp.Account, nil
+	return nil
 }
 
 // fetchOCIProviderDetails retrieves and assigns OCI details for a cloud account.
@@ -173,6 +190,19 @@ func fetchOCIProviderDetails(ctx *gofr.Context, cloudAccount *store.CloudAccount
 	return nil
 }
 
+// validateAzureProviderDetails checks that the service principal of an Azure cloud account can read its subscription,
+// the subscription ID is then the provider ID of the account.
+func (s *Service) validateAzureProviderDetails(ctx *gofr.Context, cloudAccount *store.CloudAccount) error {
+	sub, err := s.azure.GetSubscription(ctx, cloudAccount.Credentials)
+	if err != nil {
+		return err
+	}
+
+	cloudAccount.ProviderID = sub.ID
+
+	return nil
+}
+
 func (s *Service) FetchDeploymentSpace(ctx *gofr.Context, cloudAccountID int64) (interface{}, error) {
 	cloudAccount, err := s.store.GetCloudAccountByID(ctx, cloudAccountID)
 	if err != nil {
@@ -200,7 +230,10 @@ func (s *Service) FetchDeploymentSpace(ctx *gofr.Context, cloudAccountID int64)
 	return clusters, nil
 }
 
-func (s *Service) ListNamespaces(ctx *gofr.Context, id int64, clusterName, clusterRegion string) (interface{}, error) {
+// ListNamespaces lists the namespaces of a cluster of a cloud account. The project of the cluster is only set for the
+// GCP accounts covering several projects.
+func (s *Service) ListNamespaces(ctx *gofr.Context, id int64, clusterName, clusterRegion,
+	clusterProject string) (interface{}, error) {
 	cloudAccount, err := s.store.GetCloudAccountByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -220,8 +253,9 @@ func (s *Service) ListNamespaces(ctx *gofr.Context, id int64, clusterName, clust
 	}
 
 	cluster := provider.Cluster{
-		Name:   clusterName,
-		Region: clusterRegion,
+		Name:    clusterName,
+		Region:  clusterRegion,
+		Project: clusterProject,
 	}
 
 	res, err := s.deploymentSpace.ListNamespace(ctx, &cluster, &deploymentSpaceAccount, creds)
@@ -264,3 +298,18 @@ func (s *Service) FetchCredentials(ctx *gofr.Context, cloudAccountID int64) (int
 
 	return cloudAcc, nil
 }
+
+// ResealCredentials encrypts the credentials stored in plaintext and re-wraps the credentials encrypted with a previous
+// master key. It runs on start, so a new master key is applied to the stored credentials once every instance uses it.
+func (s *Service) ResealCredentials(ctx *gofr.Context) error {
+	n, err := s.store.ResealCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		ctx.Infof("resealed the credentials of %d cloud accounts", n)
+	}
+
+	return nil
+}
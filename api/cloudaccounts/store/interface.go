@@ -1,6 +1,10 @@
 package store
 
-import "gofr.dev/pkg/gofr"
+import (
+	"context"
+
+	"gofr.dev/pkg/gofr"
+)
 
 type CloudAccountStore interface {
 	InsertCloudAccount(ctx *gofr.Context, config *CloudAccount) (*CloudAccount, error)
@@ -8,4 +12,22 @@ type CloudAccountStore interface {
 	GetCloudAccountByProvider(ctx *gofr.Context, providerType, providerID string) (*CloudAccount, error)
 	GetCloudAccountByID(ctx *gofr.Context, cloudAccountID int64) (*CloudAccount, error)
 	GetCredentials(ctx *gofr.Context, cloudAccountID int64) (interface{}, error)
+	ResealCredentials(ctx *gofr.Context) (int, error)
+	UpdateCloudAccount(ctx *gofr.Context, cloudAccount *CloudAccount) error
+	UpdateCredentials(ctx *gofr.Context, cloudAccountID int64, credentials any, capabilities *Capabilities) error
+	GetDependents(ctx *gofr.Context, cloudAccountID int64) (*Dependents, error)
+	UpdateHealth(ctx *gofr.Context, cloudAccountID int64, health *Health) error
+	DeleteCloudAccount(ctx *gofr.Context, cloudAccountID int64, cascade bool) error
+
+	GetPendingExternalID(ctx *gofr.Context) (string, error)
+	InsertExternalID(ctx *gofr.Context, externalID string) error
+	BindExternalID(ctx *gofr.Context, externalID string, cloudAccountID int64) error
+}
+
+// Sealer encrypts the credentials of the cloud accounts before they are stored.
+type Sealer interface {
+	KeyID() string
+	Seal(ctx context.Context, plaintext, additionalData []byte) (string, error)
+	Open(ctx context.Context, sealed string, additionalData []byte) ([]byte, error)
+	Rewrap(ctx context.Context, sealed string, additionalData []byte) (string, error)
 }
@@ -10,6 +10,7 @@
 package store
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,34 @@ func (m *MockCloudAccountStore) EXPECT() *MockCloudAccountStoreMockRecorder {
 	return m.recorder
 }
 
+// BindExternalID mocks base method.
+func (m *MockCloudAccountStore) BindExternalID(ctx *gofr.Context, externalID string, cloudAccountID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindExternalID", ctx, externalID, cloudAccountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindExternalID indicates an expected call of BindExternalID.
+func (mr *MockCloudAccountStoreMockRecorder) BindExternalID(ctx, externalID, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindExternalID", reflect.TypeOf((*MockCloudAccountStore)(nil).BindExternalID), ctx, externalID, cloudAccountID)
+}
+
+// DeleteCloudAccount mocks base method.
+func (m *MockCloudAccountStore) DeleteCloudAccount(ctx *gofr.Context, cloudAccountID int64, cascade bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCloudAccount", ctx, cloudAccountID, cascade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCloudAccount indicates an expected call of DeleteCloudAccount.
+func (mr *MockCloudAccountStoreMockRecorder) DeleteCloudAccount(ctx, cloudAccountID, cascade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCloudAccount", reflect.TypeOf((*MockCloudAccountStore)(nil).DeleteCloudAccount), ctx, cloudAccountID, cascade)
+}
+
 // GetALLCloudAccounts mocks base method.
 func (m *MockCloudAccountStore) GetALLCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +129,36 @@ func (mr *MockCloudAccountStoreMockRecorder) GetCredentials(ctx, cloudAccountID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockCloudAccountStore)(nil).GetCredentials), ctx, cloudAccountID)
 }
 
+// GetDependents mocks base method.
+func (m *MockCloudAccountStore) GetDependents(ctx *gofr.Context, cloudAccountID int64) (*Dependents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependents", ctx, cloudAccountID)
+	ret0, _ := ret[0].(*Dependents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependents indicates an expected call of GetDependents.
+func (mr *MockCloudAccountStoreMockRecorder) GetDependents(ctx, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependents", reflect.TypeOf((*MockCloudAccountStore)(nil).GetDependents), ctx, cloudAccountID)
+}
+
+// GetPendingExternalID mocks base method.
+func (m *MockCloudAccountStore) GetPendingExternalID(ctx *gofr.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingExternalID", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingExternalID indicates an expected call of GetPendingExternalID.
+func (mr *MockCloudAccountStoreMockRecorder) GetPendingExternalID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingExternalID", reflect.TypeOf((*MockCloudAccountStore)(nil).GetPendingExternalID), ctx)
+}
+
 // InsertCloudAccount mocks base method.
 func (m *MockCloudAccountStore) InsertCloudAccount(ctx *gofr.Context, config *CloudAccount) (*CloudAccount, error) {
 	m.ctrl.T.Helper()
@@ -114,3 +173,157 @@ func (mr *MockCloudAccountStoreMockRecorder) InsertCloudAccount(ctx, config any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCloudAccount", reflect.TypeOf((*MockCloudAccountStore)(nil).InsertCloudAccount), ctx, config)
 }
+
+// InsertExternalID mocks base method.
+func (m *MockCloudAccountStore) InsertExternalID(ctx *gofr.Context, externalID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertExternalID", ctx, externalID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertExternalID indicates an expected call of InsertExternalID.
+func (mr *MockCloudAccountStoreMockRecorder) InsertExternalID(ctx, externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertExternalID", reflect.TypeOf((*MockCloudAccountStore)(nil).InsertExternalID), ctx, externalID)
+}
+
+// ResealCredentials mocks base method.
+func (m *MockCloudAccountStore) ResealCredentials(ctx *gofr.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResealCredentials", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResealCredentials indicates an expected call of ResealCredentials.
+func (mr *MockCloudAccountStoreMockRecorder) ResealCredentials(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResealCredentials", reflect.TypeOf((*MockCloudAccountStore)(nil).ResealCredentials), ctx)
+}
+
+// UpdateCloudAccount mocks base method.
+func (m *MockCloudAccountStore) UpdateCloudAccount(ctx *gofr.Context, cloudAccount *CloudAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCloudAccount", ctx, cloudAccount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCloudAccount indicates an expected call of UpdateCloudAccount.
+func (mr *MockCloudAccountStoreMockRecorder) UpdateCloudAccount(ctx, cloudAccount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCloudAccount", reflect.TypeOf((*MockCloudAccountStore)(nil).UpdateCloudAccount), ctx, cloudAccount)
+}
+
+// UpdateCredentials mocks base method.
+func (m *MockCloudAccountStore) UpdateCredentials(ctx *gofr.Context, cloudAccountID int64, credentials any, capabilities *Capabilities) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentials", ctx, cloudAccountID, credentials, capabilities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredentials indicates an expected call of UpdateCredentials.
+func (mr *MockCloudAccountStoreMockRecorder) UpdateCredentials(ctx, cloudAccountID, credentials, capabilities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentials", reflect.TypeOf((*MockCloudAccountStore)(nil).UpdateCredentials), ctx, cloudAccountID, credentials, capabilities)
+}
+
+// UpdateHealth mocks base method.
+func (m *MockCloudAccountStore) UpdateHealth(ctx *gofr.Context, cloudAccountID int64, health *Health) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHealth", ctx, cloudAccountID, health)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHealth indicates an expected call of UpdateHealth.
+func (mr *MockCloudAccountStoreMockRecorder) UpdateHealth(ctx, cloudAccountID, health any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealth", reflect.TypeOf((*MockCloudAccountStore)(nil).UpdateHealth), ctx, cloudAccountID, health)
+}
+
+// MockSealer is a mock of Sealer interface.
+type MockSealer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSealerMockRecorder
+	isgomock struct{}
+}
+
+// MockSealerMockRecorder is the mock recorder for MockSealer.
+type MockSealerMockRecorder struct {
+	mock *MockSealer
+}
+
+// NewMockSealer creates a new mock instance.
+func NewMockSealer(ctrl *gomock.Controller) *MockSealer {
+	mock := &MockSealer{ctrl: ctrl}
+	mock.recorder = &MockSealerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSealer) EXPECT() *MockSealerMockRecorder {
+	return m.recorder
+}
+
+// KeyID mocks base method.
+func (m *MockSealer) KeyID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// KeyID indicates an expected call of KeyID.
+func (mr *MockSealerMockRecorder) KeyID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyID", reflect.TypeOf((*MockSealer)(nil).KeyID))
+}
+
+// Open mocks base method.
+func (m *MockSealer) Open(ctx context.Context, sealed string, additionalData []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, sealed, additionalData)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockSealerMockRecorder) Open(ctx, sealed, additionalData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockSealer)(nil).Open), ctx, sealed, additionalData)
+}
+
+// Rewrap mocks base method.
+func (m *MockSealer) Rewrap(ctx context.Context, sealed string, additionalData []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewrap", ctx, sealed, additionalData)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rewrap indicates an expected call of Rewrap.
+func (mr *MockSealerMockRecorder) Rewrap(ctx, sealed, additionalData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewrap", reflect.TypeOf((*MockSealer)(nil).Rewrap), ctx, sealed, additionalData)
+}
+
+// Seal mocks base method.
+func (m *MockSealer) Seal(ctx context.Context, plaintext, additionalData []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", ctx, plaintext, additionalData)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MockSealerMockRecorder) Seal(ctx, plaintext, additionalData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockSealer)(nil).Seal), ctx, plaintext, additionalData)
+}
@@ -5,6 +5,9 @@ type CloudAccount struct {
 	// ID is a unique identifier for the cloud account.
 	ID int64 `json:"id,omitempty"`
 
+	// OrganizationID is the identifier of the organization owning the cloud account.
+	OrganizationID int64 `json:"organizationId,omitempty"`
+
 	// Name is the name of the cloud account.
 	Name string `json:"name"`
 
@@ -28,4 +31,88 @@ type CloudAccount struct {
 
 	// Credentials hold authentication information for access to the provider.
 	Credentials interface{} `json:"credentials,omitempty"`
+
+	// Capabilities is what the credentials allow zopdev to do, as checked when they were added.
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+
+	// Health is the result of the last periodic check of the credentials, it is not set until the first check.
+	Health *Health `json:"health,omitempty"`
+}
+
+const (
+	// CapabilityGranted is the status of a capability whose permissions are all granted.
+	CapabilityGranted = "GRANTED"
+	// CapabilityDenied is the status of a capability missing at least one of its permissions.
+	CapabilityDenied = "DENIED"
+	// CapabilityUnknown is the status of a capability whose permissions could not all be checked, e.g. the provider
+	// has no way to test a permission without using it.
+	CapabilityUnknown = "UNKNOWN"
+)
+
+// Capabilities is the capability matrix of a cloud account, i.e. which features of zopdev the permissions of its
+// credentials allow. An account that can list its resources but not start or stop them can be audited but not paused.
+type Capabilities struct {
+	ListSQL        Capability `json:"listSql"`
+	ListCompute    Capability `json:"listCompute"`
+	StartStop      Capability `json:"startStop"`
+	ReadMonitoring Capability `json:"readMonitoring"`
+
+	// CheckedAt is the time the permissions were checked, in RFC 3339.
+	CheckedAt string `json:"checkedAt"`
+}
+
+// Capability is the status of a capability along with the permissions it requires that are not granted.
+type Capability struct {
+	Status  string   `json:"status"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+const (
+	// HealthHealthy is the status of a cloud account whose last health check succeeded.
+	HealthHealthy = "HEALTHY"
+	// HealthUnhealthy is the status of a cloud account whose last health check failed.
+	HealthUnhealthy = "UNHEALTHY"
+)
+
+// Classes of the errors of the health checks.
+const (
+	// HealthErrorAuth is used when the provider rejects the credentials, e.g. they expired or were revoked.
+	HealthErrorAuth = "AUTH"
+	// HealthErrorPermission is used when the credentials are valid but not allowed the call.
+	HealthErrorPermission = "PERMISSION"
+	// HealthErrorQuota is used when the provider throttles the calls.
+	HealthErrorQuota = "QUOTA"
+	// HealthErrorNetwork is used when the provider cannot be reached.
+	HealthErrorNetwork = "NETWORK"
+	// HealthErrorUnknown is used for the other errors.
+	HealthErrorUnknown = "UNKNOWN"
+)
+
+// Health is the result of the health checks of a cloud account. The scheduled work of the account is paused after
+// repeated failures, and resumes once a check succeeds.
+type Health struct {
+	Status     string `json:"status"`
+	ErrorClass string `json:"errorClass,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	// The times are in RFC 3339.
+	CheckedAt     string `json:"checkedAt"`
+	LastSuccessAt string `json:"lastSuccessAt,omitempty"`
+	LastFailureAt string `json:"lastFailureAt,omitempty"`
+
+	ConsecutiveFailures int  `json:"consecutiveFailures"`
+	Paused              bool `json:"paused"`
+}
+
+// Dependents counts the entities of the other modules that belong to a cloud account, they are deleted along with it.
+type Dependents struct {
+	DeploymentSpaces int `json:"deploymentSpaces"`
+	Resources        int `json:"resources"`
+	ResourceGroups   int `json:"resourceGroups"`
+	AuditResults     int `json:"auditResults"`
+}
+
+// Total returns the number of dependent entities.
+func (d *Dependents) Total() int {
+	return d.DeploymentSpaces + d.Resources + d.ResourceGroups + d.AuditResults
 }
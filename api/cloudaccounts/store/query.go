@@ -1,15 +1,61 @@
 package store
 
+// The queries run for the requests are filtered by organization_id = COALESCE(?, organization_id), given
+// auth.OrganizationFilter, the jobs run by zopdev act on the cloud accounts of every organization.
 const (
-	INSERTQUERY = "INSERT INTO cloud_account (name, provider,provider_id,provider_details ,credentials) values(? , ?, ?, ? ,?);"
-	GETALLQUERY = "SELECT id, name, provider, provider_id, provider_details, created_at, updated_at FROM cloud_account" +
-		" WHERE deleted_at IS NULL;"
-	GETBYPROVIDERQUERY = "SELECT id, name, provider, provider_id, provider_details, created_at," +
-		" updated_at FROM cloud_account WHERE provider = ? " +
-		"AND provider_id = ? AND deleted_at IS NULL;"
-	GETBYPROVIDERIDQUERY = "SELECT id, name, provider, provider_id, provider_details, created_at," +
-		" updated_at FROM cloud_account WHERE " +
-		"id = ? AND deleted_at IS NULL;"
-	//nolint:gosec //query
-	GETCREDENTIALSQUERY = "SELECT credentials from cloud_account WHERE id = ? AND deleted_at IS NULL;"
+	INSERTQUERY = "INSERT INTO cloud_account (organization_id, name, provider,provider_id,provider_details ,credentials," +
+		" credentials_key_id, capabilities) values(?, ? , ?, ?, ? ,?, ?, ?);"
+	GETALLQUERY = "SELECT id, organization_id, name, provider, provider_id, provider_details, capabilities, health," +
+		" created_at, updated_at FROM cloud_account WHERE organization_id = COALESCE(?, organization_id)" +
+		" AND deleted_at IS NULL;"
+	// The same project may be added by several organizations.
+	GETBYPROVIDERQUERY = "SELECT id, organization_id, name, provider, provider_id, provider_details, capabilities, health," +
+		" created_at, updated_at FROM cloud_account WHERE provider = ? " +
+		"AND provider_id = ? AND organization_id = COALESCE(?, organization_id) AND deleted_at IS NULL;"
+	GETBYPROVIDERIDQUERY = "SELECT id, organization_id, name, provider, provider_id, provider_details, capabilities," +
+		" health, created_at, updated_at FROM cloud_account WHERE " +
+		"id = ? AND organization_id = COALESCE(?, organization_id) AND deleted_at IS NULL;"
+	//nolint:gosec //query
+	GETCREDENTIALSQUERY = "SELECT credentials from cloud_account WHERE id = ? AND organization_id = COALESCE(?, organization_id)" +
+		" AND deleted_at IS NULL;"
+	//nolint:gosec //query
+	// The credentials sealed before they were bound to their cloud account, with the format enc:v1, are stale as well.
+	GETSTALECREDENTIALSQUERY = "SELECT id, credentials FROM cloud_account WHERE credentials IS NOT NULL" +
+		" AND (credentials_key_id IS NULL OR credentials_key_id != ? OR credentials LIKE 'enc:v1:%');"
+	//nolint:gosec //query
+	UPDATECREDENTIALSQUERY = "UPDATE cloud_account SET credentials = ?, credentials_key_id = ? WHERE id = ?;"
+	//nolint:gosec //query
+	// New credentials have not been checked yet, the account is not paused anymore until they fail.
+	ROTATECREDENTIALSQUERY = "UPDATE cloud_account SET credentials = ?, credentials_key_id = ?, capabilities = ?," +
+		" health = NULL, updated_at = ? WHERE id = ? AND organization_id = COALESCE(?, organization_id)" +
+		" AND deleted_at IS NULL;"
+	UPDATEQUERY = "UPDATE cloud_account SET name = ?, provider_details = ?, updated_at = ? WHERE id = ?" +
+		" AND organization_id = COALESCE(?, organization_id) AND deleted_at IS NULL;"
+	DELETEQUERY = "UPDATE cloud_account SET deleted_at = ? WHERE id = ? AND organization_id = COALESCE(?, organization_id)" +
+		" AND deleted_at IS NULL;"
+	// The health is not a change of the account, its updated_at is left as is.
+	UPDATEHEALTHQUERY = "UPDATE cloud_account SET health = ? WHERE id = ? AND deleted_at IS NULL;"
+
+	// The entities of the other modules that belong to a cloud account. The audits are run on request, a cloud account
+	// has no audit schedules, only their results.
+	GETDEPENDENTSQUERY = "SELECT" +
+		" (SELECT COUNT(*) FROM deployment_space WHERE cloud_account_id = ? AND deleted_at IS NULL)," +
+		" (SELECT COUNT(*) FROM resources WHERE cloud_account_id = ? AND deleted_at IS NULL)," +
+		" (SELECT COUNT(*) FROM resource_groups WHERE cloud_account_id = ? AND deleted_at IS NULL)," +
+		" (SELECT COUNT(*) FROM results WHERE cloud_account_id = ?);"
+	DELETEDEPLOYMENTSPACESQUERY = "UPDATE deployment_space SET deleted_at = ? WHERE cloud_account_id = ?" +
+		" AND deleted_at IS NULL;"
+	DELETERESOURCESQUERY      = "UPDATE resources SET deleted_at = ? WHERE cloud_account_id = ? AND deleted_at IS NULL;"
+	DELETERESOURCEGROUPSQUERY = "UPDATE resource_groups SET deleted_at = ? WHERE cloud_account_id = ?" +
+		" AND deleted_at IS NULL;"
+	// The results of the audits are deleted rather than marked as deleted, the table has no deleted_at.
+	DELETERESULTSQUERY = "DELETE FROM results WHERE cloud_account_id = ?;"
+
+	// The external IDs of the AWS roles are issued to the organization of the request, the pending one is bound to
+	// the next cloud account added with a role.
+	GETPENDINGEXTERNALIDQUERY = "SELECT external_id FROM aws_external_ids WHERE organization_id = ?" +
+		" AND cloud_account_id IS NULL ORDER BY id DESC LIMIT 1;"
+	INSERTEXTERNALIDQUERY = "INSERT INTO aws_external_ids (organization_id, external_id) VALUES (?, ?);"
+	BINDEXTERNALIDQUERY   = "UPDATE aws_external_ids SET cloud_account_id = ? WHERE external_id = ? AND organization_id = ?" +
+		" AND cloud_account_id IS NULL;"
 )
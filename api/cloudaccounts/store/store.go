@@ -2,42 +2,88 @@ package store
 
 import (
 	"encoding/json"
+	"strconv"
+	"time"
 
 	"database/sql"
 
 	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/secrets"
 )
 
 type Store struct {
+	sealer Sealer
 }
 
-// New creates a new instance of CloudAccountStore.
-func New() CloudAccountStore {
-	return &Store{}
+// New creates a new instance of CloudAccountStore, the credentials are encrypted with the given sealer.
+func New(sealer Sealer) CloudAccountStore {
+	return &Store{sealer: sealer}
 }
 
-// InsertCloudAccount inserts a new cloud account into the database.
-func (*Store) InsertCloudAccount(ctx *gofr.Context, cloudAccount *CloudAccount) (*CloudAccount, error) {
+// InsertCloudAccount inserts a new cloud account of the organization of the request into the database. The credentials
+// are bound to the ID of the cloud account, hence they are sealed once it is inserted, in the same transaction.
+func (s *Store) InsertCloudAccount(ctx *gofr.Context, cloudAccount *CloudAccount) (*CloudAccount, error) {
 	jsonCredentials, err := json.Marshal(cloudAccount.Credentials)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := ctx.SQL.ExecContext(ctx, INSERTQUERY, cloudAccount.Name, cloudAccount.Provider, cloudAccount.ProviderID,
-		cloudAccount.ProviderDetails, jsonCredentials)
+	capabilities, err := marshalCapabilities(cloudAccount.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudAccount.OrganizationID = auth.OrganizationID(ctx)
+
+	tx, err := ctx.SQL.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, INSERTQUERY, cloudAccount.OrganizationID, cloudAccount.Name, cloudAccount.Provider,
+		cloudAccount.ProviderID, cloudAccount.ProviderDetails, nil, nil, capabilities)
 	if err != nil {
+		_ = tx.Rollback()
+
 		return nil, err
 	}
 
 	cloudAccount.ID, err = res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	credentials, err := s.sealer.Seal(ctx, jsonCredentials, credentialsAAD(cloudAccount.ID))
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, UPDATECREDENTIALSQUERY, credentials, s.sealer.KeyID(), cloudAccount.ID); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
 	cloudAccount.Credentials = nil
 
-	return cloudAccount, err
+	return cloudAccount, tx.Commit()
 }
 
-// GetALLCloudAccounts retrieves all cloud accounts from the database.
+// credentialsAAD is the additional data the credentials of a cloud account are sealed with, so that the credentials
+// copied to another cloud account can not be opened.
+func credentialsAAD(cloudAccountID int64) []byte {
+	return []byte("cloud_account:" + strconv.FormatInt(cloudAccountID, 10))
+}
+
+// GetALLCloudAccounts retrieves all cloud accounts of the organization of the request from the database.
 func (*Store) GetALLCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
-	rows, err := ctx.SQL.QueryContext(ctx, GETALLQUERY)
+	rows, err := ctx.SQL.QueryContext(ctx, GETALLQUERY, auth.OrganizationFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -49,21 +95,12 @@ func (*Store) GetALLCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
 	cloudAccounts := make([]CloudAccount, 0)
 
 	for rows.Next() {
-		cloudAccount := CloudAccount{}
-
-		var providerDetails sql.NullString
-
-		err := rows.Scan(&cloudAccount.ID, &cloudAccount.Name, &cloudAccount.Provider, &cloudAccount.ProviderID,
-			&providerDetails, &cloudAccount.CreatedAt, &cloudAccount.UpdatedAt)
+		cloudAccount, err := scanCloudAccount(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		if providerDetails.Valid {
-			cloudAccount.ProviderDetails = providerDetails.String
-		}
-
-		cloudAccounts = append(cloudAccounts, cloudAccount)
+		cloudAccounts = append(cloudAccounts, *cloudAccount)
 	}
 
 	return cloudAccounts, nil
@@ -71,18 +108,38 @@ func (*Store) GetALLCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
 
 // GetCloudAccountByProvider retrieves a cloud account by provider type and provider Identifier.
 func (*Store) GetCloudAccountByProvider(ctx *gofr.Context, providerType, providerID string) (*CloudAccount, error) {
-	row := ctx.SQL.QueryRowContext(ctx, GETBYPROVIDERQUERY, providerType, providerID)
+	row := ctx.SQL.QueryRowContext(ctx, GETBYPROVIDERQUERY, providerType, providerID, auth.OrganizationFilter(ctx))
+
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	return scanCloudAccount(row)
+}
+
+// GetCloudAccountByID retrieves a cloud account by id.
+func (*Store) GetCloudAccountByID(ctx *gofr.Context, cloudAccountID int64) (*CloudAccount, error) {
+	row := ctx.SQL.QueryRowContext(ctx, GETBYPROVIDERIDQUERY, cloudAccountID, auth.OrganizationFilter(ctx))
 
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
 
+	return scanCloudAccount(row)
+}
+
+// scanner is a row of the cloud_account table, either a single row or the current row of a result set.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCloudAccount(row scanner) (*CloudAccount, error) {
 	cloudAccount := CloudAccount{}
 
-	var providerDetails sql.NullString
+	var providerDetails, capabilities, health sql.NullString
 
-	err := row.Scan(&cloudAccount.ID, &cloudAccount.Name, &cloudAccount.Provider, &cloudAccount.ProviderID,
-		&providerDetails, &cloudAccount.CreatedAt, &cloudAccount.UpdatedAt)
+	err := row.Scan(&cloudAccount.ID, &cloudAccount.OrganizationID, &cloudAccount.Name, &cloudAccount.Provider,
+		&cloudAccount.ProviderID, &providerDetails, &capabilities, &health, &cloudAccount.CreatedAt, &cloudAccount.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -91,36 +148,57 @@ func (*Store) GetCloudAccountByProvider(ctx *gofr.Context, providerType, provide
 		cloudAccount.ProviderDetails = providerDetails.String
 	}
 
-	return &cloudAccount, nil
-}
+	// The capabilities of the accounts added before they were checked are unknown.
+	if capabilities.Valid {
+		cloudAccount.Capabilities = &Capabilities{}
 
-// GetCloudAccountByID retrieves a cloud account by id.
-func (*Store) GetCloudAccountByID(ctx *gofr.Context, cloudAccountID int64) (*CloudAccount, error) {
-	row := ctx.SQL.QueryRowContext(ctx, GETBYPROVIDERIDQUERY, cloudAccountID)
+		if err = json.Unmarshal([]byte(capabilities.String), cloudAccount.Capabilities); err != nil {
+			return nil, err
+		}
+	}
 
-	if row.Err() != nil {
-		return nil, row.Err()
+	// The health is unknown until the account is checked for the first time.
+	if health.Valid {
+		cloudAccount.Health = &Health{}
+
+		if err = json.Unmarshal([]byte(health.String), cloudAccount.Health); err != nil {
+			return nil, err
+		}
 	}
 
-	cloudAccount := CloudAccount{}
+	return &cloudAccount, nil
+}
 
-	var providerDetails sql.NullString
+// marshalCapabilities returns the JSON stored for a capability matrix, NULL when it was not checked.
+func marshalCapabilities(capabilities *Capabilities) (sql.NullString, error) {
+	if capabilities == nil {
+		return sql.NullString{}, nil
+	}
 
-	err := row.Scan(&cloudAccount.ID, &cloudAccount.Name, &cloudAccount.Provider, &cloudAccount.ProviderID,
-		&providerDetails, &cloudAccount.CreatedAt, &cloudAccount.UpdatedAt)
+	b, err := json.Marshal(capabilities)
 	if err != nil {
-		return nil, err
+		return sql.NullString{}, err
 	}
 
-	if providerDetails.Valid {
-		cloudAccount.ProviderDetails = providerDetails.String
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+// UpdateHealth stores the result of the last health check of a cloud account.
+func (*Store) UpdateHealth(ctx *gofr.Context, cloudAccountID int64, health *Health) error {
+	b, err := json.Marshal(health)
+	if err != nil {
+		return err
 	}
 
-	return &cloudAccount, nil
+	_, err = ctx.SQL.ExecContext(ctx, UPDATEHEALTHQUERY, string(b), cloudAccountID)
+
+	return err
 }
 
-func (*Store) GetCredentials(ctx *gofr.Context, cloudAccountID int64) (interface{}, error) {
-	row := ctx.SQL.QueryRowContext(ctx, GETCREDENTIALSQUERY, cloudAccountID)
+// GetCredentials retrieves the decrypted credentials of a cloud account. The credentials stored before they were
+// encrypted are read as plaintext until they are resealed.
+func (s *Store) GetCredentials(ctx *gofr.Context, cloudAccountID int64) (interface{}, error) {
+	row := ctx.SQL.QueryRowContext(ctx, GETCREDENTIALSQUERY, cloudAccountID, auth.OrganizationFilter(ctx))
 
 	if row.Err() != nil {
 		return nil, row.Err()
@@ -133,12 +211,191 @@ func (*Store) GetCredentials(ctx *gofr.Context, cloudAccountID int64) (interface
 		return nil, err
 	}
 
+	plaintext := []byte(credentials)
+
+	if secrets.IsSealed(credentials) {
+		plaintext, err = s.sealer.Open(ctx, credentials, credentialsAAD(cloudAccountID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var jsonCred map[string]string
 
-	err = json.Unmarshal([]byte(credentials), &jsonCred)
+	err = json.Unmarshal(plaintext, &jsonCred)
 	if err != nil {
 		return nil, err
 	}
 
 	return jsonCred, nil
 }
+
+// ResealCredentials encrypts the credentials stored in plaintext and re-wraps the credentials encrypted with a previous
+// master key, so that the previous master keys can be retired. The credentials not bound to their cloud account yet
+// are bound to it. It returns the number of cloud accounts updated.
+func (s *Store) ResealCredentials(ctx *gofr.Context) (int, error) {
+	stale, err := s.getStaleCredentials(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range stale {
+		var sealed string
+
+		if secrets.IsSealed(c.credentials) {
+			sealed, err = s.sealer.Rewrap(ctx, c.credentials, credentialsAAD(c.id))
+		} else {
+			sealed, err = s.sealer.Seal(ctx, []byte(c.credentials), credentialsAAD(c.id))
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = ctx.SQL.ExecContext(ctx, UPDATECREDENTIALSQUERY, sealed, s.sealer.KeyID(), c.id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(stale), nil
+}
+
+type storedCredentials struct {
+	id          int64
+	credentials string
+}
+
+// getStaleCredentials returns the credentials not encrypted with the current master key.
+func (s *Store) getStaleCredentials(ctx *gofr.Context) ([]storedCredentials, error) {
+	rows, err := ctx.SQL.QueryContext(ctx, GETSTALECREDENTIALSQUERY, s.sealer.KeyID())
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stale := make([]storedCredentials, 0)
+
+	for rows.Next() {
+		var c storedCredentials
+
+		if err = rows.Scan(&c.id, &c.credentials); err != nil {
+			return nil, err
+		}
+
+		stale = append(stale, c)
+	}
+
+	return stale, rows.Err()
+}
+
+// UpdateCloudAccount updates the name and the provider details of a cloud account.
+func (*Store) UpdateCloudAccount(ctx *gofr.Context, cloudAccount *CloudAccount) error {
+	_, err := ctx.SQL.ExecContext(ctx, UPDATEQUERY, cloudAccount.Name, cloudAccount.ProviderDetails, time.Now().UTC(),
+		cloudAccount.ID, auth.OrganizationFilter(ctx))
+
+	return err
+}
+
+// UpdateCredentials replaces the credentials of a cloud account, along with the capabilities they allow.
+func (s *Store) UpdateCredentials(ctx *gofr.Context, cloudAccountID int64, credentials any,
+	capabilities *Capabilities) error {
+	jsonCredentials, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := s.sealer.Seal(ctx, jsonCredentials, credentialsAAD(cloudAccountID))
+	if err != nil {
+		return err
+	}
+
+	jsonCapabilities, err := marshalCapabilities(capabilities)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctx.SQL.ExecContext(ctx, ROTATECREDENTIALSQUERY, sealed, s.sealer.KeyID(), jsonCapabilities,
+		time.Now().UTC(), cloudAccountID, auth.OrganizationFilter(ctx))
+
+	return err
+}
+
+// GetDependents counts the deployment spaces, resources, resource groups and audit results of a cloud account.
+func (*Store) GetDependents(ctx *gofr.Context, cloudAccountID int64) (*Dependents, error) {
+	var d Dependents
+
+	err := ctx.SQL.QueryRowContext(ctx, GETDEPENDENTSQUERY, cloudAccountID, cloudAccountID, cloudAccountID, cloudAccountID).
+		Scan(&d.DeploymentSpaces, &d.Resources, &d.ResourceGroups, &d.AuditResults)
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// DeleteCloudAccount marks a cloud account as deleted. With cascade, its deployment spaces, resources and resource
+// groups are marked as deleted and its audit results are deleted in the same transaction.
+func (*Store) DeleteCloudAccount(ctx *gofr.Context, cloudAccountID int64, cascade bool) error {
+	tx, err := ctx.SQL.Begin()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	if _, err = tx.ExecContext(ctx, DELETEQUERY, now, cloudAccountID, auth.OrganizationFilter(ctx)); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	queries := []string{}
+	if cascade {
+		queries = append(queries, DELETEDEPLOYMENTSPACESQUERY, DELETERESOURCESQUERY, DELETERESOURCEGROUPSQUERY)
+	}
+
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query, now, cloudAccountID); err != nil {
+			_ = tx.Rollback()
+
+			return err
+		}
+	}
+
+	if cascade {
+		if _, err = tx.ExecContext(ctx, DELETERESULTSQUERY, cloudAccountID); err != nil {
+			_ = tx.Rollback()
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPendingExternalID returns the external ID issued to the organization of the request that is not bound to a cloud
+// account yet.
+func (*Store) GetPendingExternalID(ctx *gofr.Context) (string, error) {
+	var externalID string
+
+	err := ctx.SQL.QueryRowContext(ctx, GETPENDINGEXTERNALIDQUERY, auth.OrganizationID(ctx)).Scan(&externalID)
+
+	return externalID, err
+}
+
+// InsertExternalID issues an external ID to the organization of the request.
+func (*Store) InsertExternalID(ctx *gofr.Context, externalID string) error {
+	_, err := ctx.SQL.ExecContext(ctx, INSERTEXTERNALIDQUERY, auth.OrganizationID(ctx), externalID)
+
+	return err
+}
+
+// BindExternalID binds a pending external ID of the organization of the request to a cloud account, so that it is
+// not issued again.
+func (*Store) BindExternalID(ctx *gofr.Context, externalID string, cloudAccountID int64) error {
+	_, err := ctx.SQL.ExecContext(ctx, BINDEXTERNALIDQUERY, cloudAccountID, externalID, auth.OrganizationID(ctx))
+
+	return err
+}
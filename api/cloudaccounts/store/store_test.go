@@ -4,19 +4,29 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"gofr.dev/pkg/gofr/container"
 
 	"github.com/DATA-DOG/go-sqlmock"
 
 	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
 )
 
+var errSeal = errors.New("seal error")
+
 func TestInsertCloudAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSealer := NewMockSealer(ctrl)
 	mockContainer, mock := container.NewMockContainer(t)
 	ctx := &gofr.Context{
 		Context:   context.Background(),
@@ -44,9 +54,60 @@ func TestInsertCloudAccount(t *testing.T) {
 			expectedError: false,
 			mockBehavior: func() {
 				jsonCredentials, _ := json.Marshal(cloudAccount.Credentials)
-				mock.SQL.ExpectExec("INSERT INTO cloud_account (name, provider,provider_id,provider_details ,credentials) values(? , ?, ?, ? ,?);").
-					WithArgs(cloudAccount.Name, cloudAccount.Provider, cloudAccount.ProviderID, cloudAccount.ProviderDetails, jsonCredentials).
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec("INSERT INTO cloud_account (organization_id, name, provider,provider_id,provider_details "+
+					",credentials, credentials_key_id, capabilities) values(?, ? , ?, ?, ? ,?, ?, ?);").
+					WithArgs(auth.DefaultOrganization, cloudAccount.Name, cloudAccount.Provider, cloudAccount.ProviderID, cloudAccount.ProviderDetails,
+						nil, nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockSealer.EXPECT().Seal(ctx, jsonCredentials, []byte("cloud_account:1")).Return("enc:v2:sealed", nil)
+				mockSealer.EXPECT().KeyID().Return("k1")
+				mock.SQL.ExpectExec(UPDATECREDENTIALSQUERY).WithArgs("enc:v2:sealed", "k1", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectCommit()
+			},
+		},
+		{
+			name: "success with capabilities",
+			cloudAccount: &CloudAccount{Name: "Test Account", Provider: "GCP", Credentials: map[string]string{"key": "value"},
+				Capabilities: &Capabilities{ListSQL: Capability{Status: CapabilityGranted}}},
+			mockBehavior: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(INSERTQUERY).
+					WithArgs(auth.DefaultOrganization, "Test Account", "GCP", "", nil, nil, nil,
+						`{"listSql":{"status":"GRANTED"},"listCompute":{"status":""},"startStop":{"status":""},`+
+							`"readMonitoring":{"status":""},"checkedAt":""}`).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mockSealer.EXPECT().Seal(ctx, []byte(`{"key":"value"}`), []byte("cloud_account:2")).Return("enc:v2:sealed", nil)
+				mockSealer.EXPECT().KeyID().Return("k1")
+				mock.SQL.ExpectExec(UPDATECREDENTIALSQUERY).WithArgs("enc:v2:sealed", "k1", int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectCommit()
+			},
+		},
+		{
+			name:          "failure on sealing credentials",
+			cloudAccount:  &CloudAccount{Name: "Test Account", Provider: "GCP", Credentials: map[string]string{"key": "value"}},
+			expectedError: true,
+			mockBehavior: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(INSERTQUERY).
+					WithArgs(auth.DefaultOrganization, "Test Account", "GCP", "", nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mockSealer.EXPECT().Seal(ctx, []byte(`{"key":"value"}`), []byte("cloud_account:3")).Return("", errSeal)
+				mock.SQL.ExpectRollback()
+			},
+		},
+		{
+			name:          "failure on inserting",
+			cloudAccount:  &CloudAccount{Name: "Test Account", Provider: "GCP", Credentials: map[string]string{"key": "value"}},
+			expectedError: true,
+			mockBehavior: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(INSERTQUERY).
+					WithArgs(auth.DefaultOrganization, "Test Account", "GCP", "", nil, nil, nil, nil).
+					WillReturnError(sql.ErrConnDone)
+				mock.SQL.ExpectRollback()
 			},
 		},
 		{
@@ -63,7 +124,7 @@ func TestInsertCloudAccount(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior()
 
-			store := New()
+			store := New(mockSealer)
 			_, err := store.InsertCloudAccount(ctx, tc.cloudAccount)
 
 			if tc.expectedError {
@@ -71,6 +132,8 @@ func TestInsertCloudAccount(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 			}
+
+			require.NoError(t, mock.SQL.ExpectationsWereMet())
 		})
 	}
 }
@@ -92,9 +155,11 @@ func TestGetALLCloudAccounts(t *testing.T) {
 		{
 			name: "success",
 			mockBehavior: func() {
-				mockRows := sqlmock.NewRows([]string{"id", "name", "provider", "provider_id", "provider_details", "created_at", "updated_at"}).
-					AddRow(1, "Test Account", "GCP", "gcp-project-id", `{"region":"us-central1"}`, time.Now(), time.Now())
-				mock.SQL.ExpectQuery(GETALLQUERY).
+				mockRows := sqlmock.NewRows([]string{"id", "organization_id", "name", "provider", "provider_id", "provider_details",
+					"capabilities", "health", "created_at", "updated_at"}).
+					AddRow(1, 1, "Test Account", "GCP", "gcp-project-id", `{"region":"us-central1"}`, `{"listSql":{"status":"GRANTED"}}`,
+						`{"status":"UNHEALTHY","errorClass":"AUTH","consecutiveFailures":3,"paused":true}`, time.Now(), time.Now())
+				mock.SQL.ExpectQuery(GETALLQUERY).WithArgs(nil).
 					WillReturnRows(mockRows)
 			},
 			expectedError: false,
@@ -115,7 +180,7 @@ func TestGetALLCloudAccounts(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior()
 
-			store := New()
+			store := New(nil)
 			cloudAccounts, err := store.GetALLCloudAccounts(ctx)
 
 			if tc.expectedError {
@@ -124,6 +189,12 @@ func TestGetALLCloudAccounts(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Len(t, cloudAccounts, tc.expectedCount)
+
+				for _, acc := range cloudAccounts {
+					require.Equal(t, CapabilityGranted, acc.Capabilities.ListSQL.Status)
+					require.Equal(t, &Health{Status: HealthUnhealthy, ErrorClass: HealthErrorAuth, ConsecutiveFailures: 3,
+						Paused: true}, acc.Health)
+				}
 			}
 		})
 	}
@@ -151,10 +222,11 @@ func TestGetCloudAccountByProvider(t *testing.T) {
 			providerType: "GCP",
 			providerID:   "gcp-project-id",
 			mockBehavior: func() {
-				mockRow := sqlmock.NewRows([]string{"id", "name", "provider", "provider_id", "provider_details", "created_at", "updated_at"}).
-					AddRow(1, "Test Account", "GCP", "gcp-project-id", `{"region":"us-central1"}`, time.Now(), time.Now())
+				mockRow := sqlmock.NewRows([]string{"id", "organization_id", "name", "provider", "provider_id", "provider_details",
+					"capabilities", "health", "created_at", "updated_at"}).
+					AddRow(1, 1, "Test Account", "GCP", "gcp-project-id", `{"region":"us-central1"}`, nil, nil, time.Now(), time.Now())
 				mock.SQL.ExpectQuery(GETBYPROVIDERQUERY).
-					WithArgs("GCP", "gcp-project-id").
+					WithArgs("GCP", "gcp-project-id", nil).
 					WillReturnRows(mockRow)
 			},
 			expectedError: false,
@@ -167,7 +239,7 @@ func TestGetCloudAccountByProvider(t *testing.T) {
 			providerID:   "non-existent-id",
 			mockBehavior: func() {
 				mock.SQL.ExpectQuery(GETBYPROVIDERQUERY).
-					WithArgs("GCP", "non-existent-id").
+					WithArgs("GCP", "non-existent-id", nil).
 					WillReturnRows(sqlmock.NewRows(nil))
 			},
 			expectedError: true,
@@ -180,7 +252,7 @@ func TestGetCloudAccountByProvider(t *testing.T) {
 			providerID:   "gcp-project-id",
 			mockBehavior: func() {
 				mock.SQL.ExpectQuery(GETBYPROVIDERQUERY).
-					WithArgs("GCP", "gcp-project-id").
+					WithArgs("GCP", "gcp-project-id", nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectedError: true,
@@ -193,7 +265,7 @@ func TestGetCloudAccountByProvider(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior()
 
-			store := New()
+			store := New(nil)
 			cloudAccount, err := store.GetCloudAccountByProvider(ctx, tc.providerType, tc.providerID)
 
 			if tc.expectedError {
@@ -212,3 +284,260 @@ func TestGetCloudAccountByProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSealer := NewMockSealer(ctrl)
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	testCases := []struct {
+		name         string
+		stored       string
+		mockBehavior func()
+		expected     any
+		expectedErr  error
+	}{
+		{
+			name:   "sealed credentials",
+			stored: "enc:v2:sealed",
+			mockBehavior: func() {
+				mockSealer.EXPECT().Open(ctx, "enc:v2:sealed", []byte("cloud_account:1")).Return([]byte(`{"key":"value"}`), nil)
+			},
+			expected: map[string]string{"key": "value"},
+		},
+		{
+			name:         "plaintext credentials",
+			stored:       `{"key":"value"}`,
+			mockBehavior: func() {},
+			expected:     map[string]string{"key": "value"},
+		},
+		{
+			name:   "failure on opening credentials",
+			stored: "enc:v2:sealed",
+			mockBehavior: func() {
+				mockSealer.EXPECT().Open(ctx, "enc:v2:sealed", []byte("cloud_account:1")).Return(nil, errSeal)
+			},
+			expectedErr: errSeal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.SQL.ExpectQuery(GETCREDENTIALSQUERY).WithArgs(int64(1), nil).
+				WillReturnRows(sqlmock.NewRows([]string{"credentials"}).AddRow(tc.stored))
+			tc.mockBehavior()
+
+			creds, err := New(mockSealer).GetCredentials(ctx, 1)
+
+			require.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr == nil {
+				require.Equal(t, tc.expected, creds)
+			}
+		})
+	}
+}
+
+func TestResealCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSealer := NewMockSealer(ctrl)
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mockSealer.EXPECT().KeyID().Return("k2").AnyTimes()
+
+	t.Run("plaintext and previous key", func(t *testing.T) {
+		mock.SQL.ExpectQuery(GETSTALECREDENTIALSQUERY).WithArgs("k2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "credentials"}).
+				AddRow(1, `{"key":"value"}`).
+				AddRow(2, "enc:v1:old"))
+		mockSealer.EXPECT().Seal(ctx, []byte(`{"key":"value"}`), []byte("cloud_account:1")).Return("enc:v2:new1", nil)
+		mock.SQL.ExpectExec(UPDATECREDENTIALSQUERY).WithArgs("enc:v2:new1", "k2", int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSealer.EXPECT().Rewrap(ctx, "enc:v1:old", []byte("cloud_account:2")).Return("enc:v2:new2", nil)
+		mock.SQL.ExpectExec(UPDATECREDENTIALSQUERY).WithArgs("enc:v2:new2", "k2", int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := New(mockSealer).ResealCredentials(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+	})
+
+	t.Run("failure on rewrapping", func(t *testing.T) {
+		mock.SQL.ExpectQuery(GETSTALECREDENTIALSQUERY).WithArgs("k2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "credentials"}).AddRow(2, "enc:v1:old"))
+		mockSealer.EXPECT().Rewrap(ctx, "enc:v1:old", []byte("cloud_account:2")).Return("", errSeal)
+
+		_, err := New(mockSealer).ResealCredentials(ctx)
+
+		require.Equal(t, errSeal, err)
+	})
+
+	t.Run("failure on query", func(t *testing.T) {
+		mock.SQL.ExpectQuery(GETSTALECREDENTIALSQUERY).WithArgs("k2").WillReturnError(sql.ErrConnDone)
+
+		_, err := New(mockSealer).ResealCredentials(ctx)
+
+		require.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+func TestUpdateCloudAccount(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	p := &auth.Principal{Subject: "admin@example.com", Organization: 2}
+	ctx := &gofr.Context{Context: auth.NewContext(context.Background(), p), Container: mockContainer}
+
+	mock.SQL.ExpectExec(UPDATEQUERY).WithArgs("Renamed", `{"region":"us-east1"}`, sqlmock.AnyArg(), int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := New(nil).UpdateCloudAccount(ctx, &CloudAccount{ID: 1, Name: "Renamed", ProviderDetails: `{"region":"us-east1"}`})
+
+	require.NoError(t, err)
+}
+
+func TestUpdateHealth(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mock.SQL.ExpectExec(UPDATEHEALTHQUERY).
+		WithArgs(`{"status":"HEALTHY","checkedAt":"2025-06-28T09:30:00Z","lastSuccessAt":"2025-06-28T09:30:00Z",`+
+			`"consecutiveFailures":0,"paused":false}`, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := New(nil).UpdateHealth(ctx, 1, &Health{Status: HealthHealthy, CheckedAt: "2025-06-28T09:30:00Z",
+		LastSuccessAt: "2025-06-28T09:30:00Z"})
+
+	require.NoError(t, err)
+}
+
+func TestUpdateCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSealer := NewMockSealer(ctrl)
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	store := New(mockSealer)
+
+	mockSealer.EXPECT().Seal(ctx, []byte(`{"key":"rotated"}`), []byte("cloud_account:1")).Return("enc:v1:rotated", nil)
+	mockSealer.EXPECT().KeyID().Return("k1")
+	mock.SQL.ExpectExec(ROTATECREDENTIALSQUERY).
+		WithArgs("enc:v1:rotated", "k1", `{"listSql":{"status":"DENIED","missing":["cloudsql.instances.list"]},`+
+			`"listCompute":{"status":""},"startStop":{"status":""},"readMonitoring":{"status":""},"checkedAt":""}`,
+			sqlmock.AnyArg(), int64(1), nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := store.UpdateCredentials(ctx, 1, map[string]string{"key": "rotated"}, &Capabilities{
+		ListSQL: Capability{Status: CapabilityDenied, Missing: []string{"cloudsql.instances.list"}}})
+	require.NoError(t, err)
+
+	mockSealer.EXPECT().Seal(ctx, []byte(`{"key":"rotated"}`), []byte("cloud_account:1")).Return("", errSeal)
+
+	err = store.UpdateCredentials(ctx, 1, map[string]string{"key": "rotated"}, nil)
+	require.Equal(t, errSeal, err)
+}
+
+func TestGetDependents(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	mock.SQL.ExpectQuery(GETDEPENDENTSQUERY).WithArgs(int64(1), int64(1), int64(1), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"deployment_spaces", "resources", "resource_groups", "results"}).
+			AddRow(1, 5, 2, 4))
+
+	dependents, err := New(nil).GetDependents(ctx, 1)
+
+	require.NoError(t, err)
+	require.Equal(t, &Dependents{DeploymentSpaces: 1, Resources: 5, ResourceGroups: 2, AuditResults: 4}, dependents)
+	require.Equal(t, 12, dependents.Total())
+}
+
+func TestDeleteCloudAccount(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	store := New(nil)
+
+	t.Run("without cascade", func(t *testing.T) {
+		mock.SQL.ExpectBegin()
+		mock.SQL.ExpectExec(DELETEQUERY).WithArgs(sqlmock.AnyArg(), int64(1), nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.SQL.ExpectCommit()
+
+		require.NoError(t, store.DeleteCloudAccount(ctx, 1, false))
+	})
+
+	t.Run("with cascade", func(t *testing.T) {
+		mock.SQL.ExpectBegin()
+
+		mock.SQL.ExpectExec(DELETEQUERY).WithArgs(sqlmock.AnyArg(), int64(1), nil).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		for _, query := range []string{DELETEDEPLOYMENTSPACESQUERY, DELETERESOURCESQUERY, DELETERESOURCEGROUPSQUERY} {
+			mock.SQL.ExpectExec(query).WithArgs(sqlmock.AnyArg(), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		mock.SQL.ExpectExec(DELETERESULTSQUERY).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.SQL.ExpectCommit()
+
+		require.NoError(t, store.DeleteCloudAccount(ctx, 1, true))
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		mock.SQL.ExpectBegin()
+		mock.SQL.ExpectExec(DELETEQUERY).WithArgs(sqlmock.AnyArg(), int64(1), nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.SQL.ExpectExec(DELETEDEPLOYMENTSPACESQUERY).WithArgs(sqlmock.AnyArg(), int64(1)).WillReturnError(sql.ErrConnDone)
+		mock.SQL.ExpectRollback()
+
+		require.Equal(t, sql.ErrConnDone, store.DeleteCloudAccount(ctx, 1, true))
+	})
+
+	t.Run("rollback on results error", func(t *testing.T) {
+		mock.SQL.ExpectBegin()
+		mock.SQL.ExpectExec(DELETEQUERY).WithArgs(sqlmock.AnyArg(), int64(1), nil).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		for _, query := range []string{DELETEDEPLOYMENTSPACESQUERY, DELETERESOURCESQUERY, DELETERESOURCEGROUPSQUERY} {
+			mock.SQL.ExpectExec(query).WithArgs(sqlmock.AnyArg(), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		mock.SQL.ExpectExec(DELETERESULTSQUERY).WithArgs(int64(1)).WillReturnError(sql.ErrConnDone)
+		mock.SQL.ExpectRollback()
+
+		require.Equal(t, sql.ErrConnDone, store.DeleteCloudAccount(ctx, 1, true))
+	})
+
+	require.NoError(t, mock.SQL.ExpectationsWereMet())
+}
+
+func TestExternalIDs(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	p := &auth.Principal{Subject: "admin@example.com", Organization: 2}
+	ctx := &gofr.Context{Context: auth.NewContext(context.Background(), p), Container: mockContainer}
+	store := New(nil)
+
+	mock.SQL.ExpectQuery(GETPENDINGEXTERNALIDQUERY).WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"external_id"}).AddRow("issued-1"))
+
+	externalID, err := store.GetPendingExternalID(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, "issued-1", externalID)
+
+	mock.SQL.ExpectQuery(GETPENDINGEXTERNALIDQUERY).WithArgs(int64(2)).WillReturnError(sql.ErrNoRows)
+
+	_, err = store.GetPendingExternalID(ctx)
+
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	mock.SQL.ExpectExec(INSERTEXTERNALIDQUERY).WithArgs(int64(2), "issued-2").WillReturnResult(sqlmock.NewResult(2, 1))
+
+	require.NoError(t, store.InsertExternalID(ctx, "issued-2"))
+
+	mock.SQL.ExpectExec(BINDEXTERNALIDQUERY).WithArgs(int64(7), "issued-2", int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, store.BindExternalID(ctx, "issued-2", 7))
+	require.NoError(t, mock.SQL.ExpectationsWereMet())
+}
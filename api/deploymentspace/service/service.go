@@ -102,9 +102,23 @@ func (s *Service) Add(ctx *gofr.Context, deploymentSpace *DeploymentSpace, envir
 		return nil, err
 	}
 
+	// The clusters of a GCP account covering several projects are listed along with their project.
+	var clusterProject struct {
+		Project string `json:"project"`
+	}
+
+	err = json.Unmarshal(bytes, &clusterProject)
+	if err != nil {
+		return nil, err
+	}
+
 	cl.Provider = deploymentSpace.CloudAccount.Provider
 	cl.ProviderID = deploymentSpace.CloudAccount.ProviderID
 
+	if clusterProject.Project != "" {
+		cl.ProviderID = clusterProject.Project
+	}
+
 	_, err = s.clusterService.DuplicateCheck(ctx, &cl)
 	if err != nil {
 		return nil, err
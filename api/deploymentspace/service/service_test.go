@@ -164,6 +164,33 @@ func TestService_AddDeploymentSpace(t *testing.T) {
 	}
 }
 
+func TestService_AddDeploymentSpace_ClusterProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockDeploymentSpaceStore(ctrl)
+	mockClusterService := deploymentspace.NewMockDeploymentEntity(ctrl)
+	ctx := &gofr.Context{}
+	svc := New(mockStore, mockClusterService, nil, nil)
+
+	deploymentSpace := &DeploymentSpace{
+		CloudAccount:    CloudAccount{ID: 1, Provider: "GCP", ProviderID: "organizations/1"},
+		Type:            Type{Name: "gke"},
+		DeploymentSpace: map[string]any{"name": "prod-cluster", "region": "us-central1", "project": "zopdev-prod"},
+	}
+	expCluster := &clusterStore.Cluster{Name: "prod-cluster", Region: "us-central1", Provider: "GCP",
+		ProviderID: "zopdev-prod"}
+
+	mockStore.EXPECT().GetByEnvironmentID(ctx, 1).Return(nil, nil)
+	mockClusterService.EXPECT().DuplicateCheck(ctx, expCluster).Return(nil, nil)
+	mockStore.EXPECT().Insert(ctx, gomock.Any()).Return(&store.DeploymentSpace{ID: 1}, nil)
+	mockClusterService.EXPECT().Add(ctx, gomock.Any()).Return(expCluster, nil)
+
+	_, err := svc.Add(ctx, deploymentSpace, 1)
+
+	require.NoError(t, err)
+}
+
 func TestService_FetchDeploymentSpace(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -99,10 +99,10 @@ func (h *Handler) List(ctx *gofr.Context) (interface{}, error) {
 	return res, nil
 }
 
-// Update handles the HTTP request to update multiple environments.
+// Update handles the HTTP request to update multiple environments of an application.
 //
-// The method binds the input from the request body, validates the data, and
-// delegates the update operation to the service layer.
+// The method binds the input from the request body, validates the data, checks that every environment belongs to
+// the application of the path, and delegates the update operation to the service layer.
 //
 // Parameters:
 //   - ctx: The HTTP context, which includes the request and response data.
@@ -111,9 +111,14 @@ func (h *Handler) List(ctx *gofr.Context) (interface{}, error) {
 //   - interface{}: A slice of updated environment records.
 //   - error: An error if the operation fails.
 func (h *Handler) Update(ctx *gofr.Context) (interface{}, error) {
+	applicationID, err := strconv.ParseInt(strings.TrimSpace(ctx.PathParam("id")), 10, 64)
+	if err != nil {
+		return nil, http.ErrorInvalidParam{Params: []string{"id"}}
+	}
+
 	environments := []store.Environment{}
 
-	err := ctx.Bind(&environments)
+	err = ctx.Bind(&environments)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +128,11 @@ func (h *Handler) Update(ctx *gofr.Context) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// The role of the request is checked on the application of the path only.
+		if environments[i].ApplicationID != applicationID {
+			return nil, http.ErrorInvalidParam{Params: []string{"application_id"}}
+		}
 	}
 
 	res, err := h.service.Update(ctx, environments)
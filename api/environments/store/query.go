@@ -6,7 +6,10 @@ const (
 		"and deleted_at IS NULL;"
 	GETBYNAMEQUERY = "SELECT id, name,level,application_id, created_at, updated_at FROM environment WHERE name = ? " +
 		"and application_id = ? and deleted_at IS NULL;"
-	UPDATEQUERY = "UPDATE environment SET name = ?, level = ?, updated_at = UTC_TIMESTAMP() WHERE id = ?;"
+	// UPDATEQUERY matches the application of the environment too, so that the environments of the other applications
+	// are not updated through the route of an application.
+	UPDATEQUERY = "UPDATE environment SET name = ?, level = ?, updated_at = UTC_TIMESTAMP() WHERE id = ? " +
+		"and application_id = ? and deleted_at IS NULL;"
 	GETMAXLEVEL = `
     SELECT MAX(level) AS highest_level
     FROM environment
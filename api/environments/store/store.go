@@ -1,9 +1,11 @@
 package store
 
 import (
+	"strconv"
 	"time"
 
 	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http"
 )
 
 type Store struct {
@@ -102,7 +104,7 @@ func (*Store) GetByName(ctx *gofr.Context, applicationID int, name string) (*Env
 	return &environment, nil
 }
 
-// Update modifies an existing environment record in the datastore.
+// Update modifies an existing environment record of its application in the datastore.
 //
 // Parameters:
 //   - ctx: The request context, which includes database connection and logging.
@@ -110,13 +112,24 @@ func (*Store) GetByName(ctx *gofr.Context, applicationID int, name string) (*Env
 //
 // Returns:
 //   - *Environment: A pointer to the updated environment record.
-//   - error: An error if the operation fails.
+//   - error: An error if the operation fails, or http.ErrorEntityNotFound when the application has no such
+//     environment.
 func (*Store) Update(ctx *gofr.Context, environment *Environment) (*Environment, error) {
-	_, err := ctx.SQL.ExecContext(ctx, UPDATEQUERY, environment.Name, environment.Level, environment.ID)
+	res, err := ctx.SQL.ExecContext(ctx, UPDATEQUERY, environment.Name, environment.Level, environment.ID,
+		environment.ApplicationID)
 	if err != nil {
 		return nil, err
 	}
 
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, http.ErrorEntityNotFound{Name: "environment", Value: strconv.FormatInt(environment.ID, 10)}
+	}
+
 	return environment, nil
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/http"
 )
 
 func TestStore_Insert(t *testing.T) {
@@ -137,7 +138,7 @@ func TestStore_Update(t *testing.T) {
 	}
 
 	mock.SQL.ExpectExec(UPDATEQUERY).
-		WithArgs(environment.Name, environment.Level, environment.ID).
+		WithArgs(environment.Name, environment.Level, environment.ID, environment.ApplicationID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	res, err := store.Update(ctx, environment)
@@ -145,5 +146,13 @@ func TestStore_Update(t *testing.T) {
 	require.NotNil(t, res)
 	require.Equal(t, "Updated Environment", res.Name)
 
+	// The environment belongs to another application.
+	mock.SQL.ExpectExec(UPDATEQUERY).
+		WithArgs(environment.Name, environment.Level, environment.ID, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err = store.Update(ctx, &Environment{ID: 1, Name: "Updated Environment", Level: 2, ApplicationID: 2})
+	require.Equal(t, http.ErrorEntityNotFound{Name: "environment", Value: "1"}, err)
+
 	require.NoError(t, mock.SQL.ExpectationsWereMet())
 }
@@ -0,0 +1,138 @@
+// Package internalauth authenticates the calls between the zopdev modules, e.g. the resources module fetching the
+// credentials of a cloud account, when they run as separate services. The calling service sends a short-lived token
+// signed with a secret shared by all the services, the routes under /internal/ reject the calls without one.
+package internalauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+const (
+	// PathPrefix is the prefix of the routes only reachable by the zopdev services.
+	PathPrefix = "/internal/"
+
+	secretConfig = "INTERNAL_AUTH_SECRET"
+	secretSize   = 32
+	tokenTTL     = time.Minute
+	tokenParts   = 3
+	bearerPrefix = "Bearer "
+)
+
+var (
+	errMalformedToken = errors.New("malformed service token")
+	errInvalidToken   = errors.New("invalid service token signature")
+	errExpiredToken   = errors.New("service token has expired")
+	errMissingToken   = errors.New("missing service token")
+)
+
+// Authenticator signs and verifies the service tokens with the shared secret.
+type Authenticator struct {
+	secret []byte
+}
+
+// New creates an Authenticator from the shared secret.
+func New(secret []byte) *Authenticator {
+	return &Authenticator{secret: secret}
+}
+
+// FromConfig creates an Authenticator from the INTERNAL_AUTH_SECRET shared by the services. A random secret is used
+// when none is configured, the internal routes are then only reachable from the same instance.
+func FromConfig(cfg config.Config) (*Authenticator, error) {
+	if secret := cfg.Get(secretConfig); secret != "" {
+		return New([]byte(secret)), nil
+	}
+
+	secret := make([]byte, secretSize)
+
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return New(secret), nil
+}
+
+// Sign returns a token authenticating the given service until a minute from now.
+func (a *Authenticator) Sign(service string, now time.Time) string {
+	payload := service + "." + strconv.FormatInt(now.Add(tokenTTL).Unix(), 10)
+
+	return payload + "." + a.signature(payload)
+}
+
+// Headers returns the headers authenticating a call made by the given service.
+func (a *Authenticator) Headers(service string) map[string]string {
+	return map[string]string{"Authorization": bearerPrefix + a.Sign(service, time.Now())}
+}
+
+// Verify checks a token and returns the service it authenticates.
+func (a *Authenticator) Verify(token string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != tokenParts || parts[0] == "" {
+		return "", errMalformedToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(a.signature(payload))) {
+		return "", errInvalidToken
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", errMalformedToken
+	}
+
+	if now.After(time.Unix(expiry, 0)) {
+		return "", errExpiredToken
+	}
+
+	return parts[0], nil
+}
+
+func (a *Authenticator) signature(payload string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Middleware rejects the calls to the internal routes that are not authenticated by a service token.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, PathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if !ok {
+			unauthorized(w, errMissingToken)
+			return
+		}
+
+		if _, err := a.Verify(token, time.Now()); err != nil {
+			unauthorized(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+
+	body, _ := json.Marshal(map[string]any{"error": map[string]string{"message": err.Error()}})
+
+	_, _ = w.Write(body)
+}
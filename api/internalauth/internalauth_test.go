@@ -0,0 +1,106 @@
+package internalauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapConfig map[string]string
+
+func (c mapConfig) Get(key string) string {
+	return c[key]
+}
+
+func (c mapConfig) GetOrDefault(key, def string) string {
+	if v, ok := c[key]; ok {
+		return v
+	}
+
+	return def
+}
+
+func TestAuthenticator_Verify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	a := New([]byte("secret"))
+	token := a.Sign("resources", now)
+
+	testCases := []struct {
+		name       string
+		token      string
+		at         time.Time
+		expService string
+		expErr     error
+	}{
+		{name: "valid token", token: token, at: now, expService: "resources"},
+		{name: "expired token", token: token, at: now.Add(2 * tokenTTL), expErr: errExpiredToken},
+		{name: "other secret", token: New([]byte("other")).Sign("resources", now), at: now, expErr: errInvalidToken},
+		{name: "tampered service", token: strings.Replace(token, "resources", "audit", 1), at: now, expErr: errInvalidToken},
+		{name: "malformed token", token: "resources", at: now, expErr: errMalformedToken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, err := a.Verify(tc.token, tc.at)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expService, service)
+		})
+	}
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	a := New([]byte("secret"))
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := a.Middleware(next)
+
+	testCases := []struct {
+		name      string
+		path      string
+		headers   map[string]string
+		expStatus int
+	}{
+		{name: "public route", path: "/cloud-accounts", expStatus: http.StatusOK},
+		{name: "internal route with token", path: "/internal/cloud-accounts/1/credentials",
+			headers: a.Headers("audit"), expStatus: http.StatusOK},
+		{name: "internal route without token", path: "/internal/cloud-accounts/1/credentials",
+			expStatus: http.StatusUnauthorized},
+		{name: "internal route with invalid token", path: "/internal/cloud-accounts/1/credentials",
+			headers: New([]byte("other")).Headers("audit"), expStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
+
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expStatus, rec.Code)
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	a, err := FromConfig(mapConfig{secretConfig: "shared"})
+	require.NoError(t, err)
+
+	token := New([]byte("shared")).Sign("audit", time.Now())
+
+	service, err := a.Verify(token, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "audit", service)
+
+	generated, err := FromConfig(mapConfig{})
+	require.NoError(t, err)
+	assert.Len(t, generated.secret, secretSize)
+}
@@ -3,10 +3,21 @@ package main
 import (
 	"gofr.dev/pkg/gofr"
 
+	"github.com/zopdev/zopdev/api/activity"
+	activityHandler "github.com/zopdev/zopdev/api/activity/handler"
+	activityService "github.com/zopdev/zopdev/api/activity/service"
+	activityStore "github.com/zopdev/zopdev/api/activity/store"
+
 	appHandler "github.com/zopdev/zopdev/api/applications/handler"
 	appService "github.com/zopdev/zopdev/api/applications/service"
 	appStore "github.com/zopdev/zopdev/api/applications/store"
 
+	"github.com/zopdev/zopdev/api/auth"
+	authHandler "github.com/zopdev/zopdev/api/auth/handler"
+	authService "github.com/zopdev/zopdev/api/auth/service"
+	authStore "github.com/zopdev/zopdev/api/auth/store"
+
+	auditClient "github.com/zopdev/zopdev/api/audit/client"
 	auditHandler "github.com/zopdev/zopdev/api/audit/handler"
 	auditService "github.com/zopdev/zopdev/api/audit/service"
 	auditStore "github.com/zopdev/zopdev/api/audit/store"
@@ -25,13 +36,18 @@ import (
 	envHandler "github.com/zopdev/zopdev/api/environments/handler"
 	envService "github.com/zopdev/zopdev/api/environments/service"
 	envStore "github.com/zopdev/zopdev/api/environments/store"
+	"github.com/zopdev/zopdev/api/internalauth"
 	"github.com/zopdev/zopdev/api/migrations"
 	"github.com/zopdev/zopdev/api/provider/gcp"
+	"github.com/zopdev/zopdev/api/secrets"
 
 	resourceClient "github.com/zopdev/zopdev/api/resources/client"
 	resourceHandler "github.com/zopdev/zopdev/api/resources/handler/resource"
+	"github.com/zopdev/zopdev/api/resources/pricing"
 	"github.com/zopdev/zopdev/api/resources/providers/aws"
+	"github.com/zopdev/zopdev/api/resources/providers/azure"
 	gcpResource "github.com/zopdev/zopdev/api/resources/providers/gcp"
+	"github.com/zopdev/zopdev/api/resources/providers/oci"
 	resourceService "github.com/zopdev/zopdev/api/resources/service/resource"
 	resourceStore "github.com/zopdev/zopdev/api/resources/store/resource"
 
@@ -48,10 +64,33 @@ func main() {
 
 	gkeSvc := gcp.New()
 
-	cloudAccountStore := caStore.New()
+	kms, err := secrets.LoadLocalKMS(app.Config)
+	if err != nil {
+		app.Logger().Fatalf("failed to load the credentials master keys: %v", err)
+	}
+
+	cloudAccountStore := caStore.New(secrets.New(kms))
 	cloudAccountService := caService.New(cloudAccountStore, gkeSvc)
 	cloudAccountHandler := caHandler.New(cloudAccountService)
 
+	app.OnStart(cloudAccountService.ResealCredentials)
+	app.AddCronJob("*/15 * * * *", "cloud-account-health", cloudAccountService.CheckHealth)
+
+	// The credentials are fetched in-process by the other modules, the internal routes serve the modules deployed
+	// as separate services.
+	serviceAuth, err := internalauth.FromConfig(app.Config)
+	if err != nil {
+		app.Logger().Fatalf("failed to load the internal auth secret: %v", err)
+	}
+
+	app.UseMiddleware(serviceAuth.Middleware, auth.Middleware, activity.Middleware)
+
+	authz, authHld := newAuthorizer(app)
+
+	activitySvc := activityService.New(activityStore.New())
+	rec := activityHandler.NewRecorder(activitySvc)
+	activityHld := activityHandler.New(activitySvc)
+
 	deploymentStore := deployStore.New()
 	clusterStore := clStore.New()
 	clusterService := clService.New(clusterStore)
@@ -69,72 +108,166 @@ func main() {
 
 	app.AddHTTPService("cloud-account", "http://localhost:8000")
 
-	app.POST("/cloud-accounts", cloudAccountHandler.AddCloudAccount)
-	app.GET("/cloud-accounts", cloudAccountHandler.ListCloudAccounts)
-	app.GET("/cloud-accounts/{id}/deployment-space/clusters", cloudAccountHandler.ListDeploymentSpace)
-	app.GET("/cloud-accounts/{id}/deployment-space/namespaces", cloudAccountHandler.ListNamespaces)
-	app.GET("/cloud-accounts/{id}/deployment-space/options", cloudAccountHandler.ListDeploymentSpaceOptions)
-	app.GET("/cloud-accounts/{id}/credentials", cloudAccountHandler.GetCredentials)
-
-	app.POST("/applications", applicationHandler.AddApplication)
-	app.GET("/applications", applicationHandler.ListApplications)
-	app.GET("/applications/{id}", applicationHandler.GetApplication)
-
-	app.POST("/applications/{id}/environments", environmentHandler.Add)
-	app.GET("/applications/{id}/environments", environmentHandler.List)
-	app.PATCH("/applications/{id}/environments", environmentHandler.Update)
-
-	app.POST("/environments/{id}/deploymentspace", deploymentHandler.Add)
-	app.GET("/environments/{id}/deploymentspace/service/{name}", deploymentHandler.GetService)
-	app.GET("/environments/{id}/deploymentspace/service", deploymentHandler.ListServices)
-	app.GET("/environments/{id}/deploymentspace/deployment/{name}", deploymentHandler.GetDeployment)
-	app.GET("/environments/{id}/deploymentspace/deployment", deploymentHandler.ListDeployments)
-	app.GET("/environments/{id}/deploymentspace/pod/{name}", deploymentHandler.GetPod)
-	app.GET("/environments/{id}/deploymentspace/pod", deploymentHandler.ListPods)
-	app.GET("/environments/{id}/deploymentspace/cronjob/{name}", deploymentHandler.GetCronJob)
-	app.GET("/environments/{id}/deploymentspace/cronjob", deploymentHandler.ListCronJobs)
-
-	registerAuditAPIRoutes(app)
-	registerCloudResourceRoutes(app)
+	// Every route requires a role on the scope it acts on, in the organization of the request. The internal routes are
+	// authenticated by the service tokens. The changes are recorded in the activity log, along with who made them.
+	global := authHandler.Global
+	account := authz.CloudAccountParam("id")
+	application := authz.ApplicationParam("id")
+	environment := authz.EnvironmentParam("id")
+
+	app.GET("/me", authz.Authenticated(authHld.GetPrincipal))
+	app.POST("/organizations", rec.Record("organization.create", "",
+		authz.Require(auth.RoleAdmin, authHandler.Installation, authHld.CreateOrganization)))
+	app.GET("/organizations", authz.Authenticated(authHld.ListOrganizations))
+	app.POST("/api-keys", rec.Record("api_key.create", "", authz.Require(auth.RoleAdmin, global, authHld.CreateAPIKey)))
+	app.GET("/api-keys", authz.Require(auth.RoleAdmin, global, authHld.ListAPIKeys))
+	app.DELETE("/api-keys/{id}", rec.Record("api_key.revoke", "id", authz.Require(auth.RoleAdmin, global, authHld.RevokeAPIKey)))
+	app.POST("/role-bindings", rec.Record("role_binding.create", "", authz.Authenticated(authHld.AddRoleBinding)))
+	app.GET("/role-bindings", authz.Authenticated(authHld.ListRoleBindings))
+	app.GET("/activity", authz.Require(auth.RoleAdmin, global, activityHld.ListActivity))
+	app.GET("/activity/export", authz.Require(auth.RoleAdmin, global, activityHld.ExportActivity))
+	app.DELETE("/role-bindings/{id}", rec.Record("role_binding.delete", "id", authz.Authenticated(authHld.DeleteRoleBinding)))
+
+	app.POST("/cloud-accounts", rec.Record("cloud_account.create", "",
+		authz.Require(auth.RoleAdmin, global, cloudAccountHandler.AddCloudAccount)))
+	app.GET("/cloud-accounts", authz.Authenticated(cloudAccountHandler.ListCloudAccounts))
+	app.GET("/cloud-accounts/aws/external-id", authz.Require(auth.RoleAdmin, global, cloudAccountHandler.GetExternalID))
+	app.PATCH("/cloud-accounts/{id}", rec.Record("cloud_account.update", "id",
+		authz.Require(auth.RoleAdmin, account, cloudAccountHandler.UpdateCloudAccount)))
+	app.DELETE("/cloud-accounts/{id}", rec.Record("cloud_account.delete", "id",
+		authz.Require(auth.RoleAdmin, account, cloudAccountHandler.DeleteCloudAccount)))
+	app.PUT("/cloud-accounts/{id}/credentials", rec.Record("cloud_account.rotate_credentials", "id",
+		authz.Require(auth.RoleAdmin, account, cloudAccountHandler.RotateCredentials)))
+	app.GET("/cloud-accounts/{id}/deployment-space/clusters",
+		authz.Require(auth.RoleViewer, account, cloudAccountHandler.ListDeploymentSpace))
+	app.GET("/cloud-accounts/{id}/deployment-space/namespaces",
+		authz.Require(auth.RoleViewer, account, cloudAccountHandler.ListNamespaces))
+	app.GET("/cloud-accounts/{id}/deployment-space/options",
+		authz.Require(auth.RoleViewer, account, cloudAccountHandler.ListDeploymentSpaceOptions))
+	app.GET("/cloud-accounts/{id}/credentials", authz.Require(auth.RoleAdmin, account, cloudAccountHandler.GetCredentialsMetadata))
+	app.GET("/internal/cloud-accounts", cloudAccountHandler.ListCloudAccounts)
+	app.GET("/internal/cloud-accounts/{id}/credentials", cloudAccountHandler.GetCredentials)
+
+	app.POST("/applications", rec.Record("application.create", "",
+		authz.Require(auth.RoleAdmin, global, applicationHandler.AddApplication)))
+	app.GET("/applications", authz.Authenticated(applicationHandler.ListApplications))
+	app.GET("/applications/{id}", authz.Require(auth.RoleViewer, application, applicationHandler.GetApplication))
+
+	app.POST("/applications/{id}/environments", rec.Record("environment.create", "",
+		authz.Require(auth.RoleOperator, application, environmentHandler.Add)))
+	app.GET("/applications/{id}/environments", authz.Require(auth.RoleViewer, application, environmentHandler.List))
+	app.PATCH("/applications/{id}/environments", rec.Record("application.update_environments", "id",
+		authz.Require(auth.RoleOperator, application, environmentHandler.Update)))
+
+	app.POST("/environments/{id}/deploymentspace", rec.Record("environment.add_deployment_space", "id",
+		authz.Require(auth.RoleAdmin, environment, deploymentHandler.Add)))
+
+	for path, handler := range map[string]gofr.Handler{
+		"/environments/{id}/deploymentspace/service/{name}":    deploymentHandler.GetService,
+		"/environments/{id}/deploymentspace/service":           deploymentHandler.ListServices,
+		"/environments/{id}/deploymentspace/deployment/{name}": deploymentHandler.GetDeployment,
+		"/environments/{id}/deploymentspace/deployment":        deploymentHandler.ListDeployments,
+		"/environments/{id}/deploymentspace/pod/{name}":        deploymentHandler.GetPod,
+		"/environments/{id}/deploymentspace/pod":               deploymentHandler.ListPods,
+		"/environments/{id}/deploymentspace/cronjob/{name}":    deploymentHandler.GetCronJob,
+		"/environments/{id}/deploymentspace/cronjob":           deploymentHandler.ListCronJobs,
+	} {
+		app.GET(path, authz.Require(auth.RoleViewer, environment, handler))
+	}
+
+	registerAuditAPIRoutes(app, authz, rec, cloudAccountService, serviceAuth)
+	registerCloudResourceRoutes(app, authz, rec, cloudAccountService, serviceAuth)
 
 	app.Run()
 }
 
-func registerAuditAPIRoutes(app *gofr.App) {
+// newAuthorizer creates the Authorizer checking the roles of the requests and the handler of the API keys and the
+// role bindings.
+func newAuthorizer(app *gofr.App) (*authHandler.Authorizer, *authHandler.Handler) {
+	cfg := auth.LoadConfig(app.Config)
+
+	// Only the API keys are accepted when no OIDC issuer is configured.
+	var verifier authService.TokenVerifier
+	if cfg.Issuer != "" {
+		verifier = auth.NewVerifier(cfg)
+	}
+
+	if cfg.Disabled {
+		app.Logger().Warnf("the authentication is disabled, every request is allowed")
+	}
+
+	svc := authService.New(authStore.New(), verifier, cfg.Admins)
+
+	return authHandler.NewAuthorizer(svc, cfg.Disabled), authHandler.New(svc)
+}
+
+func registerAuditAPIRoutes(app *gofr.App, authz *authHandler.Authorizer, rec *activityHandler.Recorder,
+	creds auditClient.CredentialProvider, serviceAuth auditClient.ServiceAuth) {
 	adStore := auditStore.New()
-	adSvc := auditService.New(adStore)
+	adSvc := auditService.New(adStore, auditClient.New(creds, serviceAuth))
 	adHandler := auditHandler.New(adSvc)
-
-	app.POST("/audit/cloud-accounts/{id}/all", adHandler.RunAll)
-	app.POST("/audit/cloud-accounts/{id}/category/{category}", adHandler.RunByCategory)
-	app.POST("/audit/cloud-accounts/{id}/rule/{ruleId}", adHandler.RunByID)
-	app.GET("/audit/cloud-accounts/{id}/results", adHandler.GetAllResults)
-	app.GET("/audit/cloud-accounts/{id}/results/{ruleId}", adHandler.GetResultByID)
+	account := authz.CloudAccountParam("id")
+	run := func(h gofr.Handler) gofr.Handler {
+		return rec.Record("cloud_account.run_audit", "id", authz.Require(auth.RoleOperator, account, h))
+	}
+
+	app.POST("/audit/cloud-accounts/{id}/all", run(adHandler.RunAll))
+	app.POST("/audit/cloud-accounts/{id}/category/{category}", run(adHandler.RunByCategory))
+	app.POST("/audit/cloud-accounts/{id}/rule/{ruleId}", run(adHandler.RunByID))
+	app.GET("/audit/cloud-accounts/{id}/results", authz.Require(auth.RoleViewer, account, adHandler.GetAllResults))
+	app.GET("/audit/cloud-accounts/{id}/results/{ruleId}", authz.Require(auth.RoleViewer, account, adHandler.GetResultByID))
 }
 
-func registerCloudResourceRoutes(app *gofr.App) {
-	client := resourceClient.New()
+func registerCloudResourceRoutes(app *gofr.App, authz *authHandler.Authorizer, rec *activityHandler.Recorder,
+	creds resourceClient.CredentialProvider, serviceAuth resourceClient.ServiceAuth) {
+	client := resourceClient.New(creds, serviceAuth)
 	gcpClient := gcpResource.New()
 	awsClient := aws.New()
+	azureClient := azure.New()
+	ociClient := oci.New()
 	resStore := resourceStore.New()
-	resSvc := resourceService.New(gcpClient, awsClient, client, resStore)
+	resSvc := resourceService.New(gcpClient, awsClient, azureClient, ociClient, client, resStore)
 	resHld := resourceHandler.New(resSvc)
 
+	// The estimated prices shipped with zopdev can be replaced, e.g. with negotiated prices or another currency.
+	if path := app.Config.Get("PRICE_CATALOG_PATH"); path != "" {
+		catalog, err := pricing.LoadFile(path)
+		if err != nil {
+			app.Logger().Fatalf("failed to load the price catalog %s: %v", path, err)
+		}
+
+		resSvc.SetPriceCatalog(catalog)
+	}
+
 	// TODO: Figure out a way to sync resources on startup.
 
 	app.AddCronJob("0 * * * *", "resource-sync", resSvc.SyncCron)
 
-	app.GET("/cloud-account/{id}/resources", resHld.GetResources)
-	app.POST("/cloud-account/{id}/resources/state", resHld.ChangeState)
-	app.POST("/cloud-account/{id}/resources/sync", resHld.SyncResources)
+	account := authz.CloudAccountParam("id")
+	viewer := func(h gofr.Handler) gofr.Handler { return authz.Require(auth.RoleViewer, account, h) }
+	operator := func(action, target string, h gofr.Handler) gofr.Handler {
+		return rec.Record(action, target, authz.Require(auth.RoleOperator, account, h))
+	}
+
+	app.GET("/resources", authz.Authenticated(resHld.SearchResources))
+	app.GET("/cloud-account/{id}/resources", viewer(resHld.GetResources))
+	app.POST("/cloud-account/{id}/resources/state", operator("cloud_account.change_resource_state", "id", resHld.ChangeState))
+	app.POST("/cloud-account/{id}/resources/sync", operator("cloud_account.sync_resources", "id", resHld.SyncResources))
+	app.GET("/cloud-account/{id}/resources/sync-status", viewer(resHld.GetSyncStatus))
+	app.GET("/cloud-account/{id}/resources/events", viewer(resHld.GetEvents))
+	app.GET("/cloud-account/{id}/resources/{resourceID}/events", viewer(resHld.GetResourceEvents))
+	app.GET("/cloud-account/{id}/resources/{resourceID}/cost", viewer(resHld.GetResourceCost))
+	app.GET("/cloud-account/{id}/cost", viewer(resHld.GetCost))
 
 	rgStr := resGroupStore.New()
 	rgSvc := resGroupService.New(rgStr, resSvc)
 	rgHld := resGroupHandler.New(rgSvc)
 
-	app.GET("/cloud-account/{id}/resource-groups", rgHld.GetAllResourceGroups)
-	app.GET("/cloud-account/{id}/resource-groups/{rgID}", rgHld.GetResourceGroup)
-	app.POST("/cloud-account/{id}/resource-groups", rgHld.CreateResourceGroup)
-	app.PUT("/cloud-account/{id}/resource-groups/{rgID}", rgHld.UpdateResourceGroup)
-	app.DELETE("/cloud-account/{id}/resource-groups/{rgID}", rgHld.DeleteResourceGroup)
+	app.GET("/cloud-account/{id}/resource-groups", viewer(rgHld.GetAllResourceGroups))
+	app.GET("/cloud-account/{id}/resource-groups/{rgID}", viewer(rgHld.GetResourceGroup))
+	app.POST("/cloud-account/{id}/resource-groups", operator("resource_group.create", "", rgHld.CreateResourceGroup))
+	app.PUT("/cloud-account/{id}/resource-groups/{rgID}", operator("resource_group.update", "rgID", rgHld.UpdateResourceGroup))
+	app.DELETE("/cloud-account/{id}/resource-groups/{rgID}", operator("resource_group.delete", "rgID", rgHld.DeleteResourceGroup))
+	app.POST("/cloud-account/{id}/resource-groups/{rgID}/state", operator("resource_group.change_state", "rgID", rgHld.ChangeState))
+	app.GET("/cloud-account/{id}/resource-groups/{rgID}/cost", viewer(rgHld.GetCost))
 }
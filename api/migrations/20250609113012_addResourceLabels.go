@@ -0,0 +1,21 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+func addResourceLabels() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE resources ADD COLUMN labels TEXT DEFAULT NULL`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`ALTER TABLE resource_groups ADD COLUMN label_selector TEXT DEFAULT NULL`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+func addResourceEvents() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			// resource_id is not a foreign key as the events must outlive the resources that are removed from the inventory.
+			_, err := d.SQL.Exec(`CREATE TABLE IF NOT EXISTS resource_events (
+										id INTEGER PRIMARY KEY AUTOINCREMENT,
+										cloud_account_id BIGINT NOT NULL,
+										resource_id BIGINT NOT NULL,
+										resource_uid VARCHAR(255) NOT NULL,
+										resource_name VARCHAR(255) NOT NULL,
+										resource_type VARCHAR(50) NOT NULL,
+										event_type VARCHAR(50) NOT NULL,
+										source VARCHAR(50) NOT NULL,
+										old_value TEXT DEFAULT NULL,
+										new_value TEXT DEFAULT NULL,
+										created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP)`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_resource_events_account 
+										ON resource_events (cloud_account_id, created_at)`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_resource_events_resource 
+										ON resource_events (resource_id, created_at)`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
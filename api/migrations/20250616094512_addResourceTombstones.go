@@ -0,0 +1,16 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+func addResourceTombstones() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE resources ADD COLUMN deleted_at TIMESTAMP DEFAULT NULL`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
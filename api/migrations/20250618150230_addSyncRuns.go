@@ -0,0 +1,35 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+func addSyncRuns() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`CREATE TABLE IF NOT EXISTS sync_runs (
+										id INTEGER PRIMARY KEY AUTOINCREMENT,
+										cloud_account_id BIGINT NOT NULL,
+										resource_types TEXT DEFAULT NULL,
+										regions TEXT DEFAULT NULL,
+										status VARCHAR(50) NOT NULL,
+										discovered INTEGER NOT NULL DEFAULT 0,
+										created INTEGER NOT NULL DEFAULT 0,
+										updated INTEGER NOT NULL DEFAULT 0,
+										deleted INTEGER NOT NULL DEFAULT 0,
+										restored INTEGER NOT NULL DEFAULT 0,
+										errors TEXT DEFAULT NULL,
+										started_at TIMESTAMP NOT NULL,
+										ended_at TIMESTAMP DEFAULT NULL)`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_sync_runs_account 
+										ON sync_runs (cloud_account_id, started_at)`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
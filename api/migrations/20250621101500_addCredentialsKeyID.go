@@ -0,0 +1,18 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// addCredentialsKeyID records the master key wrapping the credentials of every cloud account. The credentials stored
+// before are left with no key and are encrypted on the next start, once the master keys are loaded.
+func addCredentialsKeyID() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE cloud_account ADD COLUMN credentials_key_id VARCHAR(255) DEFAULT NULL`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
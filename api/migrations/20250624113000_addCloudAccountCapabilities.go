@@ -0,0 +1,18 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// addCloudAccountCapabilities stores the capability matrix of every cloud account as JSON. It is NULL for the accounts
+// added before, whose capabilities are unknown.
+func addCloudAccountCapabilities() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE cloud_account ADD COLUMN capabilities TEXT DEFAULT NULL`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// addResourceProject records the project of the resources of the GCP cloud accounts covering several projects. It is
+// empty for the resources synced before, and for the providers without projects.
+func addResourceProject() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE resources ADD COLUMN project VARCHAR(255) NOT NULL DEFAULT ''`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
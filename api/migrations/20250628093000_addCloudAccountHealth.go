@@ -0,0 +1,18 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// addCloudAccountHealth stores the result of the last health check of every cloud account as JSON. It is NULL until
+// the account is checked for the first time.
+func addCloudAccountHealth() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE cloud_account ADD COLUMN health TEXT DEFAULT NULL`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// createAuthTables stores the API keys used by the automation and the roles granted to the users and the API keys.
+// Only the hash of an API key is stored, its prefix is kept to tell the keys apart.
+func createAuthTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`CREATE TABLE IF NOT EXISTS api_key (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP DEFAULT NULL);`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE TABLE IF NOT EXISTS role_binding (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    scope_type VARCHAR(32) NOT NULL,
+    scope_id INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(subject, role, scope_type, scope_id));`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_role_binding_subject ON role_binding(subject);`)
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// addOrganizations adds the organizations owning the cloud accounts, the applications, the API keys and the role
+// bindings. The other tables belong to an organization through the cloud account or the application they reference.
+// The existing data is moved to the default organization.
+func addOrganizations() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			queries := []string{
+				`CREATE TABLE IF NOT EXISTS organization (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);`,
+				`INSERT OR IGNORE INTO organization (id, name) VALUES (1, 'default');`,
+				`ALTER TABLE cloud_account ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1`,
+				`ALTER TABLE application ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1`,
+				`ALTER TABLE api_key ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1`,
+				// The role bindings are unique per organization, a user may be an admin of several organizations. The
+				// unique constraint cannot be altered, the table is rebuilt.
+				`CREATE TABLE role_binding_by_organization (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    organization_id INTEGER NOT NULL DEFAULT 1,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    scope_type VARCHAR(32) NOT NULL,
+    scope_id INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(organization_id, subject, role, scope_type, scope_id));`,
+				`INSERT INTO role_binding_by_organization (id, subject, role, scope_type, scope_id, created_by, created_at)
+    SELECT id, subject, role, scope_type, scope_id, created_by, created_at FROM role_binding;`,
+				`DROP TABLE role_binding;`,
+				`ALTER TABLE role_binding_by_organization RENAME TO role_binding;`,
+				`CREATE INDEX IF NOT EXISTS idx_role_binding_subject ON role_binding(subject);`,
+				`CREATE INDEX IF NOT EXISTS idx_cloud_account_organization ON cloud_account(organization_id);`,
+				`CREATE INDEX IF NOT EXISTS idx_application_organization ON application(organization_id);`,
+			}
+
+			for _, query := range queries {
+				if _, err := d.SQL.Exec(query); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
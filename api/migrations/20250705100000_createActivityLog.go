@@ -0,0 +1,38 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// createActivityLog stores the changes made through the API along with who made them. The log is only appended to,
+// the secrets of the parameters are redacted before they are stored.
+func createActivityLog() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`CREATE TABLE IF NOT EXISTS activity_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    organization_id INTEGER NOT NULL DEFAULT 1,
+    actor VARCHAR(255) NOT NULL,
+    actor_method VARCHAR(32) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(64) NOT NULL,
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    method VARCHAR(16) NOT NULL,
+    path VARCHAR(1024) NOT NULL,
+    parameters TEXT,
+    outcome VARCHAR(16) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_activity_log_organization ON activity_log(organization_id, id);`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_activity_log_actor ON activity_log(actor);`)
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// createAWSExternalIDs stores the external IDs generated for the AWS roles assumed by zopdev. An external ID is issued
+// to an organization before its role is created, and bound to the cloud account added with the role.
+func createAWSExternalIDs() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`CREATE TABLE IF NOT EXISTS aws_external_ids (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    organization_id INTEGER NOT NULL DEFAULT 1,
+    external_id VARCHAR(64) NOT NULL UNIQUE,
+    cloud_account_id INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);`)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.SQL.Exec(`CREATE INDEX IF NOT EXISTS idx_aws_external_ids_organization
+    ON aws_external_ids(organization_id, cloud_account_id);`)
+
+			return err
+		},
+	}
+}
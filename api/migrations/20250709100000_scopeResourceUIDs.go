@@ -0,0 +1,46 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// scopeResourceUIDs makes the UIDs of the resources unique per cloud account rather than across all of them, as
+// several cloud accounts, of the same organization or not, may cover the same project or account. The unique constraint
+// of a column cannot be dropped, the table is rebuilt.
+func scopeResourceUIDs() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			queries := []string{
+				`CREATE TABLE resources_by_cloud_account (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    resource_uid VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    state CHAR(10) NOT NULL,
+    region VARCHAR(50) NOT NULL,
+    cloud_account_id BIGINT NOT NULL,
+    cloud_provider VARCHAR(50) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    settings TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    labels TEXT DEFAULT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    project VARCHAR(255) NOT NULL DEFAULT '',
+    UNIQUE(cloud_account_id, resource_uid));`,
+				// The IDs are kept, they are referenced by the memberships of the resource groups and by the events.
+				`INSERT INTO resources_by_cloud_account (id, resource_uid, name, state, region, cloud_account_id, cloud_provider,
+    resource_type, settings, created_at, updated_at, labels, deleted_at, project)
+    SELECT id, resource_uid, name, state, region, cloud_account_id, cloud_provider, resource_type, settings, created_at,
+    updated_at, labels, deleted_at, project FROM resources;`,
+				`DROP TABLE resources;`,
+				`ALTER TABLE resources_by_cloud_account RENAME TO resources;`,
+			}
+
+			for _, query := range queries {
+				if _, err := d.SQL.Exec(query); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import "gofr.dev/pkg/gofr/migration"
+
+// addResourceEventActor records who made the changes made through zopdev. It is empty for the changes detected by the
+// syncs, and for the changes recorded before.
+func addResourceEventActor() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`ALTER TABLE resource_events ADD COLUMN actor VARCHAR(255) NOT NULL DEFAULT ''`)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
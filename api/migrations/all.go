@@ -16,5 +16,19 @@ func All() map[int64]migration.Migrate {
 		20250506162207: createTableAuditResults(),
 		20250526163931: addResourcesTable(),
 		20250531164921: addResourceGroup(),
+		20250609113012: addResourceLabels(),
+		20250612103045: addResourceEvents(),
+		20250616094512: addResourceTombstones(),
+		20250618150230: addSyncRuns(),
+		20250621101500: addCredentialsKeyID(),
+		20250624113000: addCloudAccountCapabilities(),
+		20250626101500: addResourceProject(),
+		20250628093000: addCloudAccountHealth(),
+		20250701100000: createAuthTables(),
+		20250703100000: addOrganizations(),
+		20250705100000: createActivityLog(),
+		20250707100000: createAWSExternalIDs(),
+		20250709100000: scopeResourceUIDs(),
+		20250711100000: addResourceEventActor(),
 	}
 }
@@ -15,12 +15,12 @@ import (
 
 func (g *GCP) ListDeployments(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAccount *provider.CloudAccount, credentials interface{}, namespace string) (interface{}, error) {
-	credBody, err := g.getCredGCP(credentials)
+	gCreds, err := g.getCredGCP(ctx, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -37,7 +37,7 @@ func (g *GCP) ListDeployments(ctx *gofr.Context, cluster *provider.Cluster,
 		Items []provider.DeploymentData `json:"items"`
 	}
 
-	err = g.fetchDeployments(ctx, client, credBody, apiEndpoint, &depResponse)
+	err = g.fetchDeployments(ctx, client, gCreds, apiEndpoint, &depResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch deployments: %w", err)
 	}
@@ -52,19 +52,10 @@ func (g *GCP) ListDeployments(ctx *gofr.Context, cluster *provider.Cluster,
 }
 
 // fetchDeployments fetches Kubernetes deployments from the specified namespace using the provided HTTP client.
-func (*GCP) fetchDeployments(ctx *gofr.Context, client *http.Client, credBody []byte,
+func (*GCP) fetchDeployments(ctx *gofr.Context, client *http.Client, gCreds *google.Credentials,
 	apiEndpoint string, depREsp any) error {
-	// Generate a JWT token from the credentials
-	config, err := google.JWTConfigFromJSON(credBody, "https://www.googleapis.com/auth/cloud-platform")
-	if err != nil {
-		return fmt.Errorf("failed to create JWT config: %w", err)
-	}
-
-	// Create a TokenSource
-	tokenSource := config.TokenSource(ctx)
-
 	// Get a token
-	token, err := tokenSource.Token()
+	token, err := gCreds.TokenSource.Token()
 	if err != nil {
 		ctx.Errorf("failed to get token: %v", err)
 		return err
@@ -109,12 +100,12 @@ func (*GCP) fetchDeployments(ctx *gofr.Context, client *http.Client, credBody []
 
 func (g *GCP) GetDeployment(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAcc *provider.CloudAccount, creds any, namespace, name string) (any, error) {
-	credBody, err := g.getCredGCP(creds)
+	gCreds, err := g.getCredGCP(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAcc, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAcc, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -128,7 +119,7 @@ func (g *GCP) GetDeployment(ctx *gofr.Context, cluster *provider.Cluster,
 
 	var depResponse provider.DeploymentData
 
-	err = g.fetchDeployments(ctx, client, credBody, apiEndpoint, &depResponse)
+	err = g.fetchDeployments(ctx, client, gCreds, apiEndpoint, &depResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -17,54 +17,92 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/zopdev/zopdev/api/provider"
+	gcpProvider "github.com/zopdev/zopdev/api/resources/providers/gcp"
 	"gofr.dev/pkg/gofr"
 	"golang.org/x/oauth2/google"
 	apiContainer "google.golang.org/api/container/v1"
 	"google.golang.org/api/option"
 )
 
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 // GCP implements the provider.Provider interface for Google Cloud Platform.
 type GCP struct {
+	// client authenticates the cloud accounts, possibly by impersonation, and resolves their projects.
+	client *gcpProvider.Client
 }
 
 // New initializes and returns a new GCP provider.
 func New() provider.Provider {
-	return &GCP{}
+	return &GCP{client: gcpProvider.New()}
 }
 
-// ListAllClusters lists all clusters available for a given cloud account in GCP.
-// It uses the GCP credentials to authenticate and fetch the cluster details.
+// ListAllClusters lists all clusters available for a given cloud account in GCP, across all the projects of the
+// account. It uses the GCP credentials to authenticate and fetch the cluster details.
 func (g *GCP) ListAllClusters(ctx *gofr.Context, cloudAccount *provider.CloudAccount,
 	credentials interface{}) (*provider.ClusterResponse, error) {
-	credBody, err := g.getCredGCP(credentials)
+	gCreds, err := g.getCredGCP(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := g.client.GetProjects(ctx, credentials)
 	if err != nil {
 		return nil, err
 	}
 
-	client, err := g.getClusterManagerClientGCP(ctx, credBody)
+	client, err := g.getClusterManagerClientGCP(ctx, gCreds)
 	if err != nil {
 		return nil, err
 	}
 
 	defer client.Close()
 
-	req := &containerpb.ListClustersRequest{
-		Parent: fmt.Sprintf("projects/%s/locations/-", cloudAccount.ProviderID),
+	gkeClusters := make([]provider.Cluster, 0)
+
+	for _, projectID := range projects {
+		req := &containerpb.ListClustersRequest{
+			Parent: fmt.Sprintf("projects/%s/locations/-", projectID),
+		}
+
+		resp, er := client.ListClusters(ctx, req)
+		if er != nil {
+			return nil, er
+		}
+
+		gkeClusters = append(gkeClusters, newClusters(projectID, resp.Clusters)...)
 	}
 
-	resp, err := client.ListClusters(ctx, req)
-	if err != nil {
-		return nil, err
+	response := &provider.ClusterResponse{
+		Clusters: gkeClusters,
+		Next: provider.Next{
+			Name: "Namespace",
+			Path: fmt.Sprintf("/cloud-accounts/%v/deployment-space/namespaces", cloudAccount.ID),
+			Params: map[string]string{
+				"region":  "region",
+				"name":    "name",
+				"project": "project",
+			},
+		},
+		Metadata: provider.Metadata{
+			Name: "GKE Cluster",
+		},
 	}
 
-	gkeClusters := make([]provider.Cluster, 0)
+	return response, nil
+}
+
+// newClusters converts the GKE clusters of a project.
+func newClusters(projectID string, clusters []*containerpb.Cluster) []provider.Cluster {
+	gkeClusters := make([]provider.Cluster, 0, len(clusters))
 
-	for _, cl := range resp.Clusters {
+	for _, cl := range clusters {
 		gkeCluster := provider.Cluster{
 			Name:       cl.Name,
 			Identifier: cl.Id,
 			Region:     cl.Location,
 			Locations:  cl.Locations,
+			Project:    projectID,
 			Type:       "deploymentSpace",
 		}
 
@@ -84,35 +122,20 @@ func (g *GCP) ListAllClusters(ctx *gofr.Context, cloudAccount *provider.CloudAcc
 		gkeClusters = append(gkeClusters, gkeCluster)
 	}
 
-	response := &provider.ClusterResponse{
-		Clusters: gkeClusters,
-		Next: provider.Next{
-			Name: "Namespace",
-			Path: fmt.Sprintf("/cloud-accounts/%v/deployment-space/namespaces", cloudAccount.ID),
-			Params: map[string]string{
-				"region": "region",
-				"name":   "name",
-			},
-		},
-		Metadata: provider.Metadata{
-			Name: "GKE Cluster",
-		},
-	}
-
-	return response, nil
+	return gkeClusters
 }
 
 // ListNamespace fetches namespaces from the Kubernetes API for a given GKE cluster.
 func (g *GCP) ListNamespace(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAccount *provider.CloudAccount, credentials interface{}) (interface{}, error) {
 	// Step 1: Get GCP credentials
-	credBody, err := g.getCredGCP(credentials)
+	gCreds, err := g.getCredGCP(ctx, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
 	// Step 2: Get cluster information
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -126,7 +149,7 @@ func (g *GCP) ListNamespace(ctx *gofr.Context, cluster *provider.Cluster,
 	// Step 4: Fetch namespaces from the Kubernetes API
 	apiEndpoint := fmt.Sprintf("https://%s/api/v1/namespaces", gkeCluster.Endpoint)
 
-	namespaces, err := g.fetchNamespaces(ctx, client, credBody, apiEndpoint)
+	namespaces, err := g.fetchNamespaces(ctx, client, gCreds, apiEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch namespaces: %w", err)
 	}
@@ -136,16 +159,23 @@ func (g *GCP) ListNamespace(ctx *gofr.Context, cluster *provider.Cluster,
 
 // getClusterInfo retrieves detailed information about a specific GKE cluster.
 func (*GCP) getClusterInfo(ctx *gofr.Context, cluster *provider.Cluster,
-	cloudAccount *provider.CloudAccount, credBody []byte) (*apiContainer.Cluster, error) {
+	cloudAccount *provider.CloudAccount, gCreds *google.Credentials) (*apiContainer.Cluster, error) {
 	// Create the GCP Container service
-	containerService, err := apiContainer.NewService(ctx, option.WithCredentialsJSON(credBody))
+	containerService, err := apiContainer.NewService(ctx, option.WithCredentials(gCreds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container service: %w", err)
 	}
 
+	// The clusters of an account covering several projects are in their own project, the provider ID of the account
+	// is the project of the other clusters.
+	projectID := cluster.Project
+	if projectID == "" {
+		projectID = cloudAccount.ProviderID
+	}
+
 	// Construct the full cluster name
 	clusterFullName := fmt.Sprintf("projects/%s/locations/%s/clusters/%s",
-		cloudAccount.ProviderID, cluster.Region, cluster.Name)
+		projectID, cluster.Region, cluster.Name)
 
 	// Get the GCP cluster details
 	gkeCluster, err := containerService.Projects.Locations.Clusters.Get(clusterFullName).
@@ -185,19 +215,10 @@ func (*GCP) createTLSConfiguredClient(caCertificate string) (*http.Client, error
 }
 
 // fetchNamespaces fetches Kubernetes namespaces from the specified API endpoint using the provided HTTP client.
-func (*GCP) fetchNamespaces(ctx *gofr.Context, client *http.Client, credBody []byte,
+func (*GCP) fetchNamespaces(ctx *gofr.Context, client *http.Client, gCreds *google.Credentials,
 	apiEndpoint string) (*provider.NamespaceResponse, error) {
-	// Generate a JWT token from the credentials
-	config, err := google.JWTConfigFromJSON(credBody, "https://www.googleapis.com/auth/cloud-platform")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create JWT config: %w", err)
-	}
-
-	// Create a TokenSource
-	tokenSource := config.TokenSource(ctx)
-
 	// Get a token
-	token, err := tokenSource.Token()
+	token, err := gCreds.TokenSource.Token()
 	if err != nil {
 		ctx.Errorf("failed to get token: %v", err)
 		return nil, err
@@ -268,26 +289,16 @@ func (*GCP) fetchNamespaces(ctx *gofr.Context, client *http.Client, credBody []b
 	}, nil
 }
 
-// getCredGCP extracts and marshals the credentials into the appropriate format for GCP authentication.
-func (*GCP) getCredGCP(credentials any) ([]byte, error) {
-	var cred gcpCredentials
-
-	credBody, err := json.Marshal(credentials)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(credBody, &cred)
-	if err != nil {
-		return nil, err
-	}
-
-	return json.Marshal(cred)
+// getCredGCP returns the Google credentials of a cloud account, the tokens are created for the impersonated service
+// account if any.
+func (g *GCP) getCredGCP(ctx *gofr.Context, credentials any) (*google.Credentials, error) {
+	return g.client.NewGoogleCredentials(ctx, credentials, cloudPlatformScope)
 }
 
 // getClusterManagerClientGCP creates a client for interacting with the GKE Cluster Manager API.
-func (*GCP) getClusterManagerClientGCP(ctx *gofr.Context, credentials []byte) (*container.ClusterManagerClient, error) {
-	client, err := container.NewClusterManagerClient(ctx, option.WithCredentialsJSON(credentials))
+func (*GCP) getClusterManagerClientGCP(ctx *gofr.Context,
+	credentials *google.Credentials) (*container.ClusterManagerClient, error) {
+	client, err := container.NewClusterManagerClient(ctx, option.WithCredentials(credentials))
 	if err != nil {
 		return nil, err
 	}
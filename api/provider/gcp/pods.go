@@ -10,12 +10,12 @@ import (
 
 func (g *GCP) ListPods(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAccount *provider.CloudAccount, credentials interface{}, namespace string) (interface{}, error) {
-	credBody, err := g.getCredGCP(credentials)
+	gCreds, err := g.getCredGCP(ctx, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -31,7 +31,7 @@ func (g *GCP) ListPods(ctx *gofr.Context, cluster *provider.Cluster,
 		Items []provider.PodData `json:"items"`
 	}
 
-	err = g.fetchDeployments(ctx, client, credBody, apiEndpoint, &podResponse)
+	err = g.fetchDeployments(ctx, client, gCreds, apiEndpoint, &podResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch pods: %w", err)
 	}
@@ -47,12 +47,12 @@ func (g *GCP) ListPods(ctx *gofr.Context, cluster *provider.Cluster,
 
 func (g *GCP) GetPod(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAcc *provider.CloudAccount, creds any, namespace, name string) (any, error) {
-	credBody, err := g.getCredGCP(creds)
+	gCreds, err := g.getCredGCP(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAcc, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAcc, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -66,7 +66,7 @@ func (g *GCP) GetPod(ctx *gofr.Context, cluster *provider.Cluster,
 
 	var podResponse provider.PodData
 
-	err = g.fetchDeployments(ctx, client, credBody, apiEndpoint, &podResponse)
+	err = g.fetchDeployments(ctx, client, gCreds, apiEndpoint, &podResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -15,13 +15,13 @@ import (
 func (g *GCP) ListServices(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAccount *provider.CloudAccount, credentials interface{}, namespace string) (interface{}, error) {
 	// Step 1: Get GCP credentials
-	credBody, err := g.getCredGCP(credentials)
+	gCreds, err := g.getCredGCP(ctx, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
 	// Step 2: Get cluster information
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -40,7 +40,7 @@ func (g *GCP) ListServices(ctx *gofr.Context, cluster *provider.Cluster,
 		Items []provider.Service `json:"items"`
 	}
 
-	err = g.fetchServices(ctx, client, credBody, apiEndpoint, &serviceResponse)
+	err = g.fetchServices(ctx, client, gCreds, apiEndpoint, &serviceResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch services: %w", err)
 	}
@@ -55,19 +55,10 @@ func (g *GCP) ListServices(ctx *gofr.Context, cluster *provider.Cluster,
 }
 
 // fetchServices fetches Kubernetes services from the specified namespace using the provided HTTP client.
-func (*GCP) fetchServices(ctx *gofr.Context, client *http.Client, credBody []byte,
+func (*GCP) fetchServices(ctx *gofr.Context, client *http.Client, gCreds *google.Credentials,
 	apiEndpoint string, i any) error {
-	// Generate a JWT token from the credentials
-	config, err := google.JWTConfigFromJSON(credBody, "https://www.googleapis.com/auth/cloud-platform")
-	if err != nil {
-		return fmt.Errorf("failed to create JWT config: %w", err)
-	}
-
-	// Create a TokenSource
-	tokenSource := config.TokenSource(ctx)
-
 	// Get a token
-	token, err := tokenSource.Token()
+	token, err := gCreds.TokenSource.Token()
 	if err != nil {
 		ctx.Errorf("failed to get token: %v", err)
 		return err
@@ -114,12 +105,12 @@ func (*GCP) fetchServices(ctx *gofr.Context, client *http.Client, credBody []byt
 
 func (g *GCP) GetService(ctx *gofr.Context, cluster *provider.Cluster,
 	cloudAccount *provider.CloudAccount, credentials interface{}, namespace, name string) (interface{}, error) {
-	credBody, err := g.getCredGCP(credentials)
+	gCreds, err := g.getCredGCP(ctx, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
-	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, credBody)
+	gkeCluster, err := g.getClusterInfo(ctx, cluster, cloudAccount, gCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
@@ -133,7 +124,7 @@ func (g *GCP) GetService(ctx *gofr.Context, cluster *provider.Cluster,
 
 	var serviceResponse provider.Service
 
-	err = g.fetchServices(ctx, client, credBody, apiEndpoint, &serviceResponse)
+	err = g.fetchServices(ctx, client, gCreds, apiEndpoint, &serviceResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch service %s: %w", name, err)
 	}
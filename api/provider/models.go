@@ -55,6 +55,9 @@ type Cluster struct {
 	// Region specifies the region where the cluster is located.
 	Region string `json:"region"`
 
+	// Project is the GCP project of the cluster, when the cloud account covers several projects.
+	Project string `json:"project,omitempty"`
+
 	// NodePools is a list of node pools associated with the cluster.
 	NodePools []NodePool `json:"nodePools"`
 }
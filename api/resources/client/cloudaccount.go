@@ -16,20 +16,30 @@ var (
 	errInvalidResponse             = errors.New("invalid response from cloud account service")
 )
 
-type Client struct{}
+// serviceName identifies this module in the service tokens sent to the cloud-account service.
+const serviceName = "resources"
 
-func New() *Client {
-	return &Client{}
+type Client struct {
+	creds CredentialProvider
+	auth  ServiceAuth
 }
 
-// GetCloudCredentials retrieves cloud credentials for a specific cloud account ID using the cloud-account service.
-func (*Client) GetCloudCredentials(ctx *gofr.Context, cloudAccID int64) (*CloudAccount, error) {
-	// Fetch the cloud credentials from the cloud-account entity
-	// This is a placeholder function and should be implemented based on the cloud provider
-	endpoint := fmt.Sprintf("cloud-accounts/%d/credentials", cloudAccID)
+// New creates a Client. The credentials are fetched in-process when a CredentialProvider is given, i.e. when the
+// cloud-account module runs in the same binary, and from the internal route of the cloud-account service otherwise.
+func New(creds CredentialProvider, auth ServiceAuth) *Client {
+	return &Client{creds: creds, auth: auth}
+}
+
+// GetCloudCredentials retrieves cloud credentials for a specific cloud account ID from the cloud-account module.
+func (c *Client) GetCloudCredentials(ctx *gofr.Context, cloudAccID int64) (*CloudAccount, error) {
+	if c.creds != nil {
+		return c.fetchCredentials(ctx, cloudAccID)
+	}
+
+	endpoint := fmt.Sprintf("internal/cloud-accounts/%d/credentials", cloudAccID)
 
 	resp, err := ctx.GetHTTPService("cloud-account").
-		Get(ctx, endpoint, nil)
+		GetWithHeaders(ctx, endpoint, nil, c.auth.Headers(serviceName))
 	if err != nil {
 		return nil, err
 	}
@@ -57,10 +67,11 @@ func (*Client) GetCloudCredentials(ctx *gofr.Context, cloudAccID int64) (*CloudA
 	return credentials.Data, nil
 }
 
-// GetAllCloudAccounts retrieves all cloud account IDs from the cloud-account service.
-func (*Client) GetAllCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
+// GetAllCloudAccounts retrieves all cloud account IDs from the internal route of the cloud-account service, the
+// public route only lists the cloud accounts of the caller.
+func (c *Client) GetAllCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
 	resp, err := ctx.GetHTTPService("cloud-account").
-		Get(ctx, "cloud-accounts", nil)
+		GetWithHeaders(ctx, "internal/cloud-accounts", nil, c.auth.Headers(serviceName))
 	if err != nil {
 		return nil, err
 	}
@@ -87,3 +98,26 @@ func (*Client) GetAllCloudAccounts(ctx *gofr.Context) ([]CloudAccount, error) {
 
 	return cloudAccounts.Data, nil
 }
+
+// fetchCredentials fetches the credentials in-process, the cloud account is converted through its JSON form just like
+// the response of the cloud-account service.
+func (c *Client) fetchCredentials(ctx *gofr.Context, cloudAccID int64) (*CloudAccount, error) {
+	acc, err := c.creds.FetchCredentials(ctx, cloudAccID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	var ca CloudAccount
+
+	err = json.Unmarshal(b, &ca)
+	if err != nil {
+		return nil, errInvalidResponse
+	}
+
+	return &ca, nil
+}
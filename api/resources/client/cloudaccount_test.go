@@ -25,14 +25,17 @@ func Test_GetCloudCredentials_Success(t *testing.T) {
 	ctx := &gofr.Context{
 		Container: cont,
 	}
-	c := New()
+	headers := map[string]string{"Authorization": "Bearer token"}
+	auth := NewMockServiceAuth(ctrl)
+	auth.EXPECT().Headers(serviceName).Return(headers).AnyTimes()
+	c := New(nil, auth)
 	cloudAccID := int64(12345)
 	body := []byte(`{"data": {"id": 12345, "name": "Test Cloud Account"}}`)
 
 	resp := generateHTTPResponse(body, http.StatusOK)
 	defer resp.Body.Close()
 
-	mocks.HTTPService.EXPECT().Get(ctx, "cloud-accounts/12345/credentials", nil).
+	mocks.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts/12345/credentials", nil, headers).
 		Return(resp, nil)
 
 	credentials, err := c.GetCloudCredentials(ctx, cloudAccID)
@@ -54,7 +57,10 @@ func Test_GetCloudCredentials_Error(t *testing.T) {
 	ctx := &gofr.Context{
 		Container: cont,
 	}
-	c := New()
+	headers := map[string]string{"Authorization": "Bearer token"}
+	auth := NewMockServiceAuth(ctrl)
+	auth.EXPECT().Headers(serviceName).Return(headers).AnyTimes()
+	c := New(nil, auth)
 
 	testCases := []struct {
 		name          string
@@ -89,7 +95,7 @@ func Test_GetCloudCredentials_Error(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			resp := generateHTTPResponse(tc.body, tc.statusCode)
-			mocks.HTTPService.EXPECT().Get(ctx, fmt.Sprintf("cloud-accounts/%d/credentials", tc.cloudAccID), nil).
+			mocks.HTTPService.EXPECT().GetWithHeaders(ctx, fmt.Sprintf("internal/cloud-accounts/%d/credentials", tc.cloudAccID), nil, headers).
 				Return(resp, tc.serviceError)
 
 			credentials, err := c.GetCloudCredentials(ctx, tc.cloudAccID)
@@ -116,7 +122,10 @@ func TestClient_GetAllCloudAccounts_Success(t *testing.T) {
 	ctx := &gofr.Context{
 		Container: cont,
 	}
-	c := New()
+	headers := map[string]string{"Authorization": "Bearer token"}
+	auth := NewMockServiceAuth(ctrl)
+	auth.EXPECT().Headers(serviceName).Return(headers)
+	c := New(nil, auth)
 	body := []byte(`{"data" : [{"id" : 123,"provider" : "gcp"},{"id" : 456,"provider" : "aws"}]}`)
 	expRes := []CloudAccount{
 		{ID: 123, Provider: "gcp"},
@@ -126,7 +135,7 @@ func TestClient_GetAllCloudAccounts_Success(t *testing.T) {
 
 	defer resp.Body.Close()
 
-	mocks.HTTPService.EXPECT().Get(ctx, "cloud-accounts", nil).
+	mocks.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts", nil, headers).
 		Return(resp, nil)
 
 	res, err := c.GetAllCloudAccounts(ctx)
@@ -143,7 +152,10 @@ func Test_GetAllCloudAccounts_Error(t *testing.T) {
 	ctx := &gofr.Context{
 		Container: cont,
 	}
-	c := New()
+	headers := map[string]string{"Authorization": "Bearer token"}
+	auth := NewMockServiceAuth(ctrl)
+	auth.EXPECT().Headers(serviceName).Return(headers).AnyTimes()
+	c := New(nil, auth)
 
 	testCases := []struct {
 		name          string
@@ -174,7 +186,7 @@ func Test_GetAllCloudAccounts_Error(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			resp := generateHTTPResponse(tc.body, tc.statusCode)
-			mocks.HTTPService.EXPECT().Get(ctx, "cloud-accounts", nil).
+			mocks.HTTPService.EXPECT().GetWithHeaders(ctx, "internal/cloud-accounts", nil, headers).
 				Return(resp, tc.serviceError)
 
 			credentials, err := c.GetAllCloudAccounts(ctx)
@@ -193,6 +205,29 @@ func Test_GetAllCloudAccounts_Error(t *testing.T) {
 	}
 }
 
+func Test_GetCloudCredentials_InProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCreds := NewMockCredentialProvider(ctrl)
+	ctx := &gofr.Context{}
+	c := New(mockCreds, nil)
+
+	mockCreds.EXPECT().FetchCredentials(ctx, int64(12345)).
+		Return(map[string]any{"id": 12345, "provider": "GCP", "credentials": map[string]string{"key": "value"}}, nil)
+
+	ca, err := c.GetCloudCredentials(ctx, 12345)
+
+	require.NoError(t, err)
+	assert.Equal(t, &CloudAccount{ID: 12345, Provider: "GCP", Credentials: map[string]any{"key": "value"}}, ca)
+
+	mockCreds.EXPECT().FetchCredentials(ctx, int64(12345)).Return(nil, errService)
+
+	_, err = c.GetCloudCredentials(ctx, 12345)
+
+	require.ErrorIs(t, err, errService)
+}
+
 func generateHTTPResponse(body []byte, statusCode int) *http.Response {
 	return &http.Response{
 		StatusCode: statusCode,
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mock_interface.go -package=client
+//
+
+// Package client is a generated GoMock package.
+package client
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+)
+
+// MockCredentialProvider is a mock of CredentialProvider interface.
+type MockCredentialProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialProviderMockRecorder is the mock recorder for MockCredentialProvider.
+type MockCredentialProviderMockRecorder struct {
+	mock *MockCredentialProvider
+}
+
+// NewMockCredentialProvider creates a new mock instance.
+func NewMockCredentialProvider(ctrl *gomock.Controller) *MockCredentialProvider {
+	mock := &MockCredentialProvider{ctrl: ctrl}
+	mock.recorder = &MockCredentialProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialProvider) EXPECT() *MockCredentialProviderMockRecorder {
+	return m.recorder
+}
+
+// FetchCredentials mocks base method.
+func (m *MockCredentialProvider) FetchCredentials(ctx *gofr.Context, cloudAccountID int64) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCredentials", ctx, cloudAccountID)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCredentials indicates an expected call of FetchCredentials.
+func (mr *MockCredentialProviderMockRecorder) FetchCredentials(ctx, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCredentials", reflect.TypeOf((*MockCredentialProvider)(nil).FetchCredentials), ctx, cloudAccountID)
+}
+
+// MockServiceAuth is a mock of ServiceAuth interface.
+type MockServiceAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceAuthMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceAuthMockRecorder is the mock recorder for MockServiceAuth.
+type MockServiceAuthMockRecorder struct {
+	mock *MockServiceAuth
+}
+
+// NewMockServiceAuth creates a new mock instance.
+func NewMockServiceAuth(ctrl *gomock.Controller) *MockServiceAuth {
+	mock := &MockServiceAuth{ctrl: ctrl}
+	mock.recorder = &MockServiceAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceAuth) EXPECT() *MockServiceAuthMockRecorder {
+	return m.recorder
+}
+
+// Headers mocks base method.
+func (m *MockServiceAuth) Headers(service string) map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Headers", service)
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Headers indicates an expected call of Headers.
+func (mr *MockServiceAuthMockRecorder) Headers(service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Headers", reflect.TypeOf((*MockServiceAuth)(nil).Headers), service)
+}
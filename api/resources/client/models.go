@@ -1,8 +1,23 @@
 package client
 
 type CloudAccount struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Provider    string `json:"provider"`
-	Credentials any    `json:"credentials"`
+	ID             int64   `json:"id"`
+	OrganizationID int64   `json:"organizationId"`
+	Name           string  `json:"name"`
+	Provider       string  `json:"provider"`
+	Credentials    any     `json:"credentials"`
+	Health         *Health `json:"health,omitempty"`
+}
+
+// Health is the result of the last health check of a cloud account, it is nil until the account is checked.
+type Health struct {
+	Status     string `json:"status"`
+	ErrorClass string `json:"errorClass"`
+	// Paused is set after repeated failures, the scheduled work of the account is skipped until a check succeeds.
+	Paused bool `json:"paused"`
+}
+
+// IsPaused returns whether the scheduled work of the cloud account is paused by its health checks.
+func (c *CloudAccount) IsPaused() bool {
+	return c.Health != nil && c.Health.Paused
 }
@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// GetCost returns the uptime and the estimated spend and savings of the resources of a cloud account over a period,
+// e.g. ?from=2025-01-01&to=2025-02-01. The period defaults to the last 30 days.
+func (h *Handler) GetCost(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := getCostPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.svc.GetAccountCost(ctx, accID, period)
+}
+
+// GetResourceCost returns the uptime and the estimated spend and savings of a single resource of a cloud account.
+func (h *Handler) GetResourceCost(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resID, err := strconv.ParseInt(ctx.PathParam("resourceID"), 10, 64)
+	if err != nil {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"resourceID"}}
+	}
+
+	period, err := getCostPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.svc.GetResourcesCost(ctx, accID, []int64{resID}, period)
+}
+
+func getCostPeriod(ctx *gofr.Context) (models.CostPeriod, error) {
+	period, err := models.ParseCostPeriod(ctx.Param("from"), ctx.Param("to"), time.Now())
+
+	var periodErr *models.CostPeriodError
+	if errors.As(err, &periodErr) {
+		return models.CostPeriod{}, gofrHttp.ErrorInvalidParam{Params: periodErr.Params}
+	}
+
+	return period, err
+}
@@ -0,0 +1,149 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestHandler_GetCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockService(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	h := New(mockSvc)
+	period := models.CostPeriod{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mockReport := &models.CostReport{CostPeriod: period, Currency: "USD", EstimatedSpend: 12.5}
+
+	testCases := []struct {
+		name        string
+		id          string
+		query       string
+		expectedErr error
+		mockCall    func()
+	}{
+		{
+			name:  "valid request",
+			id:    "1",
+			query: "from=2025-01-01&to=2025-02-01",
+			mockCall: func() {
+				mockSvc.EXPECT().GetAccountCost(ctx, int64(1), period).Return(mockReport, nil)
+			},
+		},
+		{
+			name:        "invalid from",
+			id:          "1",
+			query:       "from=last-month",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"from"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "period out of order",
+			id:          "1",
+			query:       "from=2025-02-01&to=2025-01-01",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"from", "to"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "invalid id",
+			id:          "a",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"id"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "error in service",
+			id:          "1",
+			query:       "from=2025-01-01&to=2025-02-01",
+			expectedErr: errMock,
+			mockCall: func() {
+				mockSvc.EXPECT().GetAccountCost(ctx, int64(1), period).Return(nil, errMock)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			req := httptest.NewRequest(http.MethodGet, "/cloud-account/{id}/cost?"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			resp, err := h.GetCost(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr == nil {
+				assert.Equal(t, mockReport, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_GetResourceCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockService(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	h := New(mockSvc)
+	period := models.CostPeriod{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	mockReport := &models.CostReport{CostPeriod: period, Resources: []models.ResourceCost{{ResourceID: 5}}}
+
+	testCases := []struct {
+		name        string
+		resourceID  string
+		expectedErr error
+		mockCall    func()
+	}{
+		{
+			name:       "valid request",
+			resourceID: "5",
+			mockCall: func() {
+				mockSvc.EXPECT().GetResourcesCost(ctx, int64(1), []int64{5}, period).Return(mockReport, nil)
+			},
+		},
+		{
+			name:        "invalid resource id",
+			resourceID:  "abc",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"resourceID"}},
+			mockCall:    func() {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			req := httptest.NewRequest(http.MethodGet,
+				"/cloud-account/1/resources/"+tc.resourceID+"/cost?from=2025-01-01&to=2025-01-02", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "resourceID": tc.resourceID})
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			resp, err := h.GetResourceCost(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr == nil {
+				assert.Equal(t, mockReport, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package resource
+
+import (
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// GetEvents returns the inventory history of a cloud account.
+func (h *Handler) GetEvents(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := getEventFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.svc.GetEvents(ctx, accID, filter)
+}
+
+// GetResourceEvents returns the inventory history of a single resource of a cloud account.
+func (h *Handler) GetResourceEvents(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resID, err := strconv.ParseInt(ctx.PathParam("resourceID"), 10, 64)
+	if err != nil {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"resourceID"}}
+	}
+
+	filter, err := getEventFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.ResourceID = resID
+
+	return h.svc.GetEvents(ctx, accID, filter)
+}
+
+func getEventFilter(ctx *gofr.Context) (*models.EventFilter, error) {
+	filter := &models.EventFilter{EventTypes: ctx.Params("type")}
+
+	if since := ctx.Param("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, gofrHttp.ErrorInvalidParam{Params: []string{"since"}}
+		}
+
+		filter.Since = t
+	}
+
+	if limit := ctx.Param("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 0 {
+			return nil, gofrHttp.ErrorInvalidParam{Params: []string{"limit"}}
+		}
+
+		filter.Limit = l
+	}
+
+	return filter, nil
+}
+
+func getCloudAccountID(ctx *gofr.Context) (int64, error) {
+	id := ctx.PathParam("id")
+	if id == "" {
+		return 0, gofrHttp.ErrorMissingParam{Params: []string{"id"}}
+	}
+
+	accID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, gofrHttp.ErrorInvalidParam{Params: []string{"id"}}
+	}
+
+	return accID, nil
+}
@@ -0,0 +1,144 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestHandler_GetEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockService(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	h := New(mockSvc)
+	mockEvents := []models.ResourceEvent{{ID: 1, EventType: models.EventDeleted}}
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		id          string
+		query       string
+		expectedErr error
+		expResp     any
+		mockCall    func()
+	}{
+		{
+			name:    "valid request",
+			id:      "1",
+			query:   "type=CREATED&type=DELETED&since=2025-06-01T00:00:00Z&limit=10",
+			expResp: mockEvents,
+			mockCall: func() {
+				mockSvc.EXPECT().GetEvents(ctx, int64(1), &models.EventFilter{
+					EventTypes: []string{models.EventCreated, models.EventDeleted}, Since: since, Limit: 10}).
+					Return(mockEvents, nil)
+			},
+		},
+		{
+			name:        "invalid since",
+			id:          "1",
+			query:       "since=yesterday",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"since"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "invalid limit",
+			id:          "1",
+			query:       "limit=-1",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"limit"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "invalid id",
+			id:          "a",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"id"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "error in service",
+			id:          "1",
+			expectedErr: errMock,
+			mockCall: func() {
+				mockSvc.EXPECT().GetEvents(ctx, int64(1), &models.EventFilter{}).Return(nil, errMock)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			req := httptest.NewRequest(http.MethodGet, "/cloud-account/{id}/resources/events?"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			resp, err := h.GetEvents(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expResp, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_GetResourceEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockService(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	h := New(mockSvc)
+	mockEvents := []models.ResourceEvent{{ID: 1, ResourceID: 5, EventType: models.EventStatusChanged}}
+
+	testCases := []struct {
+		name        string
+		resourceID  string
+		expectedErr error
+		mockCall    func()
+	}{
+		{
+			name:       "valid request",
+			resourceID: "5",
+			mockCall: func() {
+				mockSvc.EXPECT().GetEvents(ctx, int64(1), &models.EventFilter{ResourceID: 5}).Return(mockEvents, nil)
+			},
+		},
+		{
+			name:        "invalid resource id",
+			resourceID:  "abc",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"resourceID"}},
+			mockCall:    func() {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			req := httptest.NewRequest(http.MethodGet, "/cloud-account/1/resources/"+tc.resourceID+"/events", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "resourceID": tc.resourceID})
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			resp, err := h.GetResourceEvents(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr == nil {
+				assert.Equal(t, mockEvents, resp)
+			}
+		})
+	}
+}
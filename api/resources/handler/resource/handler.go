@@ -2,10 +2,12 @@ package resource
 
 import (
 	"strconv"
+	"strings"
 
 	"gofr.dev/pkg/gofr"
 	gofrHttp "gofr.dev/pkg/gofr/http"
 
+	"github.com/zopdev/zopdev/api/resources/models"
 	"github.com/zopdev/zopdev/api/resources/service/resource"
 )
 
@@ -17,20 +19,37 @@ func New(svc Service) *Handler {
 	return &Handler{svc: svc}
 }
 
+// GetResources returns a page of the resources of a cloud account, e.g.
+// ?type=EC2&status=RUNNING&region=us-east-1&name=web&labels=env%3Dprod&sort=updated_at&order=desc&limit=50.
+// The next page is requested with the next_cursor of the response as the cursor.
 func (h *Handler) GetResources(ctx *gofr.Context) (any, error) {
-	id := ctx.PathParam("id")
-	if id == "" {
-		return nil, gofrHttp.ErrorMissingParam{Params: []string{"id"}}
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	accID, err := strconv.ParseInt(id, 10, 64)
+	filter, page, err := getResourceQuery(ctx)
 	if err != nil {
-		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"id"}}
+		return nil, err
+	}
+
+	res, err := h.svc.List(ctx, accID, filter, page)
+	if err != nil {
+		return nil, err
 	}
 
-	resourceType := ctx.Params("type")
+	return res, nil
+}
+
+// SearchResources returns a page of the resources of all the cloud accounts, it supports the filters of GetResources
+// along with a full-text match on the name, UID and labels, e.g. ?q=checkout&provider=aws.
+func (h *Handler) SearchResources(ctx *gofr.Context) (any, error) {
+	filter, page, err := getResourceQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := h.svc.GetAll(ctx, accID, resourceType)
+	res, err := h.svc.Search(ctx, filter, page)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +57,57 @@ func (h *Handler) GetResources(ctx *gofr.Context) (any, error) {
 	return res, nil
 }
 
+func getResourceQuery(ctx *gofr.Context) (*models.ResourceFilter, *models.ResourcePage, error) {
+	selector, err := models.ParseLabelSelector(ctx.Param("labels"))
+	if err != nil {
+		return nil, nil, gofrHttp.ErrorInvalidParam{Params: []string{"labels"}}
+	}
+
+	filter := &models.ResourceFilter{
+		ResourceTypes: ctx.Params("type"),
+		Statuses:      ctx.Params("status"),
+		Regions:       ctx.Params("region"),
+		Providers:     ctx.Params("provider"),
+		Name:          ctx.Param("name"),
+		Query:         ctx.Param("q"),
+		Labels:        selector,
+	}
+
+	page, err := getResourcePage(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filter, page, nil
+}
+
+func getResourcePage(ctx *gofr.Context) (*models.ResourcePage, error) {
+	page := &models.ResourcePage{
+		SortBy: ctx.Param("sort"),
+		Order:  strings.ToLower(ctx.Param("order")),
+		Cursor: ctx.Param("cursor"),
+	}
+
+	if page.SortBy != "" && !models.IsSortField(page.SortBy) {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"sort"}}
+	}
+
+	if page.Order != "" && page.Order != models.SortAsc && page.Order != models.SortDesc {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"order"}}
+	}
+
+	if limit := ctx.Param("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l <= 0 || l > models.MaxPageSize {
+			return nil, gofrHttp.ErrorInvalidParam{Params: []string{"limit"}}
+		}
+
+		page.Limit = l
+	}
+
+	return page, nil
+}
+
 func (h *Handler) ChangeState(ctx *gofr.Context) (any, error) {
 	var resDetails resource.ResourceDetails
 
@@ -77,7 +147,28 @@ func (h *Handler) SyncResources(ctx *gofr.Context) (any, error) {
 		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"id"}}
 	}
 
-	res, err := h.svc.SyncResources(ctx, accID)
+	// The sync can be limited to some resource types and regions, e.g. ?type=SQL&region=us-east-1.
+	scope := &models.SyncScope{
+		ResourceTypes: ctx.Params("type"),
+		Regions:       ctx.Params("region"),
+	}
+
+	res, err := h.svc.SyncResources(ctx, accID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetSyncStatus returns whether the resources of a cloud account are being synced along with the latest sync runs.
+func (h *Handler) GetSyncStatus(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := h.svc.GetSyncStatus(ctx, accID)
 	if err != nil {
 		return nil, err
 	}
@@ -30,8 +30,9 @@ func TestHandler_GetResources(t *testing.T) {
 	ctx := &gofr.Context{
 		Context: context.Background(),
 	}
-	mockResp := []models.Resource{
-		{Name: "sql-instance-1"}, {Name: "sql-instance-2"},
+	mockResp := &models.ResourceList{
+		Resources: []models.Resource{{Name: "sql-instance-1"}, {Name: "sql-instance-2"}},
+		Total:     2,
 	}
 	h := New(mockSvc)
 
@@ -49,7 +50,7 @@ func TestHandler_GetResources(t *testing.T) {
 			id:           "1",
 			expectedResp: mockResp,
 			mockCall: func() {
-				mockSvc.EXPECT().GetAll(ctx, int64(1), []string{"sql"}).
+				mockSvc.EXPECT().List(ctx, int64(1), &models.ResourceFilter{ResourceTypes: []string{"sql"}}, &models.ResourcePage{}).
 					Return(mockResp, nil)
 			},
 		},
@@ -59,17 +60,69 @@ func TestHandler_GetResources(t *testing.T) {
 			id:           "1",
 			expectedResp: mockResp,
 			mockCall: func() {
-				mockSvc.EXPECT().GetAll(ctx, int64(1), []string{"sql", "redis"}).
+				mockSvc.EXPECT().List(ctx, int64(1), &models.ResourceFilter{ResourceTypes: []string{"sql", "redis"}}, &models.ResourcePage{}).
+					Return(mockResp, nil)
+			},
+		},
+		{
+			name:         "label selector",
+			typeQuery:    "type=sql&labels=env%3Dprod,team",
+			id:           "1",
+			expectedResp: mockResp,
+			mockCall: func() {
+				sel, _ := models.ParseLabelSelector("env=prod,team")
+
+				mockSvc.EXPECT().List(ctx, int64(1), &models.ResourceFilter{ResourceTypes: []string{"sql"}, Labels: sel}, &models.ResourcePage{}).
+					Return(mockResp, nil)
+			},
+		},
+		{
+			name:         "filters, sort and page",
+			typeQuery:    "status=RUNNING&region=us-east-1&provider=aws&name=web&sort=updated_at&order=DESC&limit=50&cursor=abc",
+			id:           "1",
+			expectedResp: mockResp,
+			mockCall: func() {
+				mockSvc.EXPECT().List(ctx, int64(1), &models.ResourceFilter{Statuses: []string{"RUNNING"},
+					Regions: []string{"us-east-1"}, Providers: []string{"aws"}, Name: "web"},
+					&models.ResourcePage{SortBy: "updated_at", Order: "desc", Cursor: "abc", Limit: 50}).
 					Return(mockResp, nil)
 			},
 		},
+		{
+			name:        "invalid sort field",
+			typeQuery:   "sort=settings",
+			id:          "1",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"sort"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "invalid order",
+			typeQuery:   "order=up",
+			id:          "1",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"order"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "limit above the page size",
+			typeQuery:   "limit=5000",
+			id:          "1",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"limit"}},
+			mockCall:    func() {},
+		},
+		{
+			name:        "invalid label selector",
+			typeQuery:   "labels=%3Dprod",
+			id:          "1",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"labels"}},
+			mockCall:    func() {},
+		},
 		{
 			name:        "error in service",
 			typeQuery:   "type=sql",
 			id:          "1",
 			expectedErr: errMock,
 			mockCall: func() {
-				mockSvc.EXPECT().GetAll(ctx, int64(1), []string{"sql"}).
+				mockSvc.EXPECT().List(ctx, int64(1), &models.ResourceFilter{ResourceTypes: []string{"sql"}}, &models.ResourcePage{}).
 					Return(nil, errMock)
 			},
 		},
@@ -108,6 +161,64 @@ func TestHandler_GetResources(t *testing.T) {
 	}
 }
 
+func TestHandler_SearchResources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockService(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	h := New(mockSvc)
+	mockResp := &models.ResourceList{Resources: []models.Resource{{Name: "checkout",
+		CloudAccount: models.CloudAccount{ID: 1, Type: "aws", Name: "prod"}}}, Total: 1}
+
+	testCases := []struct {
+		name        string
+		query       string
+		expectedErr error
+		expectedRes any
+		mockCall    func()
+	}{
+		{
+			name:        "Success",
+			query:       "q=checkout&provider=aws&type=EC2&limit=10",
+			expectedRes: mockResp,
+			mockCall: func() {
+				mockSvc.EXPECT().Search(ctx, &models.ResourceFilter{ResourceTypes: []string{"EC2"}, Providers: []string{"aws"},
+					Query: "checkout"}, &models.ResourcePage{Limit: 10}).Return(mockResp, nil)
+			},
+		},
+		{
+			name:        "Service error",
+			query:       "q=checkout",
+			expectedErr: errMock,
+			mockCall: func() {
+				mockSvc.EXPECT().Search(ctx, &models.ResourceFilter{Query: "checkout"}, &models.ResourcePage{}).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name:        "Invalid limit",
+			query:       "limit=0",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"limit"}},
+			mockCall:    func() {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			req := httptest.NewRequest(http.MethodGet, "/resources?"+tc.query, http.NoBody)
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			resp, err := h.SearchResources(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedRes, resp)
+		})
+	}
+}
+
 func TestHandler_ChangeState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -204,6 +315,7 @@ func TestHandler_SyncResources(t *testing.T) {
 	testCases := []struct {
 		name        string
 		pathParam   string
+		query       string
 		expectedErr error
 		expectedRes any
 		mockCall    func()
@@ -213,7 +325,18 @@ func TestHandler_SyncResources(t *testing.T) {
 			pathParam:   "123",
 			expectedRes: mockResp,
 			mockCall: func() {
-				mockSvc.EXPECT().SyncResources(ctx, int64(123)).Return(mockResp, nil)
+				mockSvc.EXPECT().SyncResources(ctx, int64(123), &models.SyncScope{}).Return(mockResp, nil)
+			},
+		},
+		{
+			name:        "Scoped sync",
+			pathParam:   "123",
+			query:       "?type=SQL,EC2&region=us-east-1",
+			expectedRes: mockResp,
+			mockCall: func() {
+				mockSvc.EXPECT().SyncResources(ctx, int64(123), &models.SyncScope{
+					ResourceTypes: models.StringList{"SQL", "EC2"}, Regions: models.StringList{"us-east-1"}}).
+					Return(mockResp, nil)
 			},
 		},
 		{
@@ -221,7 +344,7 @@ func TestHandler_SyncResources(t *testing.T) {
 			pathParam:   "123",
 			expectedErr: errMock,
 			mockCall: func() {
-				mockSvc.EXPECT().SyncResources(ctx, int64(123)).Return(nil, errMock)
+				mockSvc.EXPECT().SyncResources(ctx, int64(123), &models.SyncScope{}).Return(nil, errMock)
 			},
 		},
 		{
@@ -242,7 +365,7 @@ func TestHandler_SyncResources(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			req := httptest.NewRequest(http.MethodPost, "/cloud-account/{id}/resources/sync", http.NoBody)
+			req := httptest.NewRequest(http.MethodPost, "/cloud-account/{id}/resources/sync"+tc.query, http.NoBody)
 			req = mux.SetURLVars(req, map[string]string{"id": tc.pathParam})
 			req.Header.Set("content-type", "application/json")
 			ctx.Request = gofrHttp.NewRequest(req)
@@ -254,3 +377,59 @@ func TestHandler_SyncResources(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetSyncStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockService(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	h := New(mockSvc)
+	mockResp := &models.SyncStatus{InProgress: true, Runs: []models.SyncRun{{ID: 1, Status: models.SyncRunning}}}
+
+	testCases := []struct {
+		name        string
+		pathParam   string
+		expectedErr error
+		expectedRes any
+		mockCall    func()
+	}{
+		{
+			name:        "Success",
+			pathParam:   "123",
+			expectedRes: mockResp,
+			mockCall: func() {
+				mockSvc.EXPECT().GetSyncStatus(ctx, int64(123)).Return(mockResp, nil)
+			},
+		},
+		{
+			name:        "Service error",
+			pathParam:   "123",
+			expectedErr: errMock,
+			mockCall: func() {
+				mockSvc.EXPECT().GetSyncStatus(ctx, int64(123)).Return(nil, errMock)
+			},
+		},
+		{
+			name:        "Invalid id",
+			pathParam:   "a",
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"id"}},
+			mockCall:    func() {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			req := httptest.NewRequest(http.MethodGet, "/cloud-account/{id}/resources/sync-status", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.pathParam})
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			resp, err := h.GetSyncStatus(ctx)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedRes, resp)
+		})
+	}
+}
@@ -8,7 +8,12 @@ import (
 )
 
 type Service interface {
-	GetAll(ctx *gofr.Context, id int64, resourceType []string) ([]models.Resource, error)
-	SyncResources(ctx *gofr.Context, id int64) ([]models.Resource, error)
+	List(ctx *gofr.Context, id int64, filter *models.ResourceFilter, page *models.ResourcePage) (*models.ResourceList, error)
+	Search(ctx *gofr.Context, filter *models.ResourceFilter, page *models.ResourcePage) (*models.ResourceList, error)
+	SyncResources(ctx *gofr.Context, id int64, scope *models.SyncScope) ([]models.Resource, error)
+	GetSyncStatus(ctx *gofr.Context, id int64) (*models.SyncStatus, error)
 	ChangeState(ctx *gofr.Context, resDetails resource.ResourceDetails) error
+	GetEvents(ctx *gofr.Context, cloudAccID int64, filter *models.EventFilter) ([]models.ResourceEvent, error)
+	GetAccountCost(ctx *gofr.Context, cloudAccID int64, period models.CostPeriod) (*models.CostReport, error)
+	GetResourcesCost(ctx *gofr.Context, cloudAccID int64, ids []int64, period models.CostPeriod) (*models.CostReport, error)
 }
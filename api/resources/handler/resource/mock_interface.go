@@ -10,10 +10,10 @@
 package resource
 
 import (
-	service "github.com/zopdev/zopdev/api/resources/service/resource"
 	reflect "reflect"
 
 	models "github.com/zopdev/zopdev/api/resources/models"
+	resource "github.com/zopdev/zopdev/api/resources/service/resource"
 	gomock "go.uber.org/mock/gomock"
 	gofr "gofr.dev/pkg/gofr"
 )
@@ -43,7 +43,7 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // ChangeState mocks base method.
-func (m *MockService) ChangeState(ctx *gofr.Context, resDetails service.ResourceDetails) error {
+func (m *MockService) ChangeState(ctx *gofr.Context, resDetails resource.ResourceDetails) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ChangeState", ctx, resDetails)
 	ret0, _ := ret[0].(error)
@@ -56,32 +56,107 @@ func (mr *MockServiceMockRecorder) ChangeState(ctx, resDetails any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeState", reflect.TypeOf((*MockService)(nil).ChangeState), ctx, resDetails)
 }
 
-// GetAll mocks base method.
-func (m *MockService) GetAll(ctx *gofr.Context, id int64, resourceType []string) ([]models.Resource, error) {
+// GetAccountCost mocks base method.
+func (m *MockService) GetAccountCost(ctx *gofr.Context, cloudAccID int64, period models.CostPeriod) (*models.CostReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, id, resourceType)
-	ret0, _ := ret[0].([]models.Resource)
+	ret := m.ctrl.Call(m, "GetAccountCost", ctx, cloudAccID, period)
+	ret0, _ := ret[0].(*models.CostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountCost indicates an expected call of GetAccountCost.
+func (mr *MockServiceMockRecorder) GetAccountCost(ctx, cloudAccID, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountCost", reflect.TypeOf((*MockService)(nil).GetAccountCost), ctx, cloudAccID, period)
+}
+
+// GetEvents mocks base method.
+func (m *MockService) GetEvents(ctx *gofr.Context, cloudAccID int64, filter *models.EventFilter) ([]models.ResourceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, cloudAccID, filter)
+	ret0, _ := ret[0].([]models.ResourceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockServiceMockRecorder) GetEvents(ctx, cloudAccID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockService)(nil).GetEvents), ctx, cloudAccID, filter)
+}
+
+// GetResourcesCost mocks base method.
+func (m *MockService) GetResourcesCost(ctx *gofr.Context, cloudAccID int64, ids []int64, period models.CostPeriod) (*models.CostReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcesCost", ctx, cloudAccID, ids, period)
+	ret0, _ := ret[0].(*models.CostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcesCost indicates an expected call of GetResourcesCost.
+func (mr *MockServiceMockRecorder) GetResourcesCost(ctx, cloudAccID, ids, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesCost", reflect.TypeOf((*MockService)(nil).GetResourcesCost), ctx, cloudAccID, ids, period)
+}
+
+// GetSyncStatus mocks base method.
+func (m *MockService) GetSyncStatus(ctx *gofr.Context, id int64) (*models.SyncStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncStatus", ctx, id)
+	ret0, _ := ret[0].(*models.SyncStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncStatus indicates an expected call of GetSyncStatus.
+func (mr *MockServiceMockRecorder) GetSyncStatus(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncStatus", reflect.TypeOf((*MockService)(nil).GetSyncStatus), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx *gofr.Context, id int64, filter *models.ResourceFilter, page *models.ResourcePage) (*models.ResourceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, id, filter, page)
+	ret0, _ := ret[0].(*models.ResourceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, id, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, id, filter, page)
+}
+
+// Search mocks base method.
+func (m *MockService) Search(ctx *gofr.Context, filter *models.ResourceFilter, page *models.ResourcePage) (*models.ResourceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filter, page)
+	ret0, _ := ret[0].(*models.ResourceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(ctx, id, resourceType any) *gomock.Call {
+// Search indicates an expected call of Search.
+func (mr *MockServiceMockRecorder) Search(ctx, filter, page any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, id, resourceType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockService)(nil).Search), ctx, filter, page)
 }
 
 // SyncResources mocks base method.
-func (m *MockService) SyncResources(ctx *gofr.Context, id int64) ([]models.Resource, error) {
+func (m *MockService) SyncResources(ctx *gofr.Context, id int64, scope *models.SyncScope) ([]models.Resource, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SyncResources", ctx, id)
+	ret := m.ctrl.Call(m, "SyncResources", ctx, id, scope)
 	ret0, _ := ret[0].([]models.Resource)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SyncResources indicates an expected call of SyncResources.
-func (mr *MockServiceMockRecorder) SyncResources(ctx, id any) *gomock.Call {
+func (mr *MockServiceMockRecorder) SyncResources(ctx, id, scope any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncResources", reflect.TypeOf((*MockService)(nil).SyncResources), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncResources", reflect.TypeOf((*MockService)(nil).SyncResources), ctx, id, scope)
 }
@@ -0,0 +1,39 @@
+package resourcegroup
+
+import (
+	"errors"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// GetCost returns the uptime and the estimated spend and savings of the members of a resource group over a period,
+// e.g. ?from=2025-01-01&to=2025-02-01. The period defaults to the last 30 days.
+func (h *Handler) GetCost(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rgID, err := getResourceGroupID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := models.ParseCostPeriod(ctx.Param("from"), ctx.Param("to"), time.Now())
+
+	var periodErr *models.CostPeriodError
+	if errors.As(err, &periodErr) {
+		return nil, gofrHttp.ErrorInvalidParam{Params: periodErr.Params}
+	}
+
+	res, err := h.svc.GetCost(ctx, accID, rgID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
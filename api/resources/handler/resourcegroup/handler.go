@@ -121,6 +121,33 @@ func (h *Handler) DeleteResourceGroup(ctx *gofr.Context) (any, error) {
 	return nil, nil
 }
 
+// ChangeState starts or suspends all the resources of a resource group and reports the result for each of them.
+func (h *Handler) ChangeState(ctx *gofr.Context) (any, error) {
+	accID, err := getCloudAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rgID, err := getResourceGroupID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var req models.RGStateChange
+
+	err = ctx.Bind(&req)
+	if err != nil {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	res, err := h.svc.ChangeState(ctx, accID, rgID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func getResourceGroupID(ctx *gofr.Context) (int64, error) {
 	rgIDStr := ctx.PathParam("rgID")
 	if rgIDStr == "" {
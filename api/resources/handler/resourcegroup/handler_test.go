@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -519,3 +520,155 @@ func TestHandler_DeleteResourceGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ChangeState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSvc := NewMockService(ctrl)
+	h := New(mSvc)
+	ctx := &gofr.Context{Context: context.Background()}
+	stateReq := &models.RGStateChange{State: "START", Ordered: true, Concurrency: 2}
+	sampleRes := &models.RGStateResult{
+		ResourceGroupID: 1,
+		State:           "START",
+		Status:          "SUCCEEDED",
+		Members: []models.RGMemberResult{
+			{ResourceID: 1, Name: "MySQL Database", Type: "SQL", Status: "SUCCEEDED"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		accID     string
+		groupID   string
+		body      string
+		expErr    error
+		expRes    any
+		mockCalls []*gomock.Call
+	}{
+		{
+			name:    "success",
+			accID:   "1",
+			groupID: "1",
+			body:    `{"state":"START", "ordered":true, "concurrency":2}`,
+			expRes:  sampleRes,
+			mockCalls: []*gomock.Call{
+				mSvc.EXPECT().ChangeState(ctx, int64(1), int64(1), stateReq).
+					Return(sampleRes, nil),
+			},
+		},
+		{
+			name:   "missing cloud account ID",
+			accID:  "",
+			expErr: gofrHttp.ErrorMissingParam{Params: []string{"id"}},
+		},
+		{
+			name:    "invalid resource group ID",
+			accID:   "1",
+			groupID: "invalid",
+			expErr:  gofrHttp.ErrorInvalidParam{Params: []string{"rgId"}},
+		},
+		{
+			name:    "invalid bind",
+			accID:   "1",
+			groupID: "1",
+			body:    `{`,
+			expErr:  gofrHttp.ErrorInvalidParam{Params: []string{"body"}},
+		},
+		{
+			name:    "service error",
+			accID:   "1",
+			groupID: "1",
+			body:    `{"state":"START", "ordered":true, "concurrency":2}`,
+			expErr:  assert.AnError,
+			mockCalls: []*gomock.Call{
+				mSvc.EXPECT().ChangeState(ctx, int64(1), int64(1), stateReq).
+					Return(nil, assert.AnError),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost,
+				"/cloud-account/{id}/resource-groups/{rgID}/state", bytes.NewBufferString(tc.body))
+			req = mux.SetURLVars(req, map[string]string{"id": tc.accID, "rgID": tc.groupID})
+
+			req.Header.Set("Content-Type", "application/json")
+
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			res, err := h.ChangeState(ctx)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestHandler_GetCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSvc := NewMockService(ctrl)
+	h := New(mSvc)
+	ctx := &gofr.Context{Context: context.Background()}
+	period := models.CostPeriod{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	report := &models.CostReport{CostPeriod: period, Currency: "USD", EstimatedSavings: 3}
+
+	testCases := []struct {
+		name      string
+		groupID   string
+		query     string
+		expErr    error
+		expRes    any
+		mockCalls []*gomock.Call
+	}{
+		{
+			name:    "success",
+			groupID: "1",
+			query:   "from=2025-01-01&to=2025-02-01",
+			expRes:  report,
+			mockCalls: []*gomock.Call{
+				mSvc.EXPECT().GetCost(ctx, int64(1), int64(1), period).Return(report, nil),
+			},
+		},
+		{
+			name:    "invalid resource group ID",
+			groupID: "invalid",
+			expErr:  gofrHttp.ErrorInvalidParam{Params: []string{"rgId"}},
+		},
+		{
+			name:    "invalid period",
+			groupID: "1",
+			query:   "to=tomorrow",
+			expErr:  gofrHttp.ErrorInvalidParam{Params: []string{"to"}},
+		},
+		{
+			name:    "service error",
+			groupID: "1",
+			query:   "from=2025-01-01&to=2025-02-01",
+			expErr:  assert.AnError,
+			mockCalls: []*gomock.Call{
+				mSvc.EXPECT().GetCost(ctx, int64(1), int64(1), period).Return(nil, assert.AnError),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/cloud-account/{id}/resource-groups/{rgID}/cost?"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "rgID": tc.groupID})
+			ctx.Request = gofrHttp.NewRequest(req)
+
+			res, err := h.GetCost(ctx)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expRes, res)
+		})
+	}
+}
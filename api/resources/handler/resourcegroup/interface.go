@@ -12,4 +12,6 @@ type Service interface {
 	CreateResourceGroup(ctx *gofr.Context, rg *models.RGCreate) (*models.ResourceGroupData, error)
 	UpdateResourceGroup(ctx *gofr.Context, rg *models.RGUpdate) (*models.ResourceGroupData, error)
 	DeleteResourceGroup(ctx *gofr.Context, cloudAccID, id int64) error
+	ChangeState(ctx *gofr.Context, cloudAccID, id int64, req *models.RGStateChange) (*models.RGStateResult, error)
+	GetCost(ctx *gofr.Context, cloudAccID, id int64, period models.CostPeriod) (*models.CostReport, error)
 }
@@ -41,6 +41,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangeState mocks base method.
+func (m *MockService) ChangeState(ctx *gofr.Context, cloudAccID, id int64, req *models.RGStateChange) (*models.RGStateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeState", ctx, cloudAccID, id, req)
+	ret0, _ := ret[0].(*models.RGStateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeState indicates an expected call of ChangeState.
+func (mr *MockServiceMockRecorder) ChangeState(ctx, cloudAccID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeState", reflect.TypeOf((*MockService)(nil).ChangeState), ctx, cloudAccID, id, req)
+}
+
 // CreateResourceGroup mocks base method.
 func (m *MockService) CreateResourceGroup(ctx *gofr.Context, rg *models.RGCreate) (*models.ResourceGroupData, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +100,21 @@ func (mr *MockServiceMockRecorder) GetAllResourceGroups(ctx, cloudAccID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllResourceGroups", reflect.TypeOf((*MockService)(nil).GetAllResourceGroups), ctx, cloudAccID)
 }
 
+// GetCost mocks base method.
+func (m *MockService) GetCost(ctx *gofr.Context, cloudAccID, id int64, period models.CostPeriod) (*models.CostReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCost", ctx, cloudAccID, id, period)
+	ret0, _ := ret[0].(*models.CostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCost indicates an expected call of GetCost.
+func (mr *MockServiceMockRecorder) GetCost(ctx, cloudAccID, id, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCost", reflect.TypeOf((*MockService)(nil).GetCost), ctx, cloudAccID, id, period)
+}
+
 // GetResourceGroupByID mocks base method.
 func (m *MockService) GetResourceGroupByID(ctx *gofr.Context, cloudAccID, id int64) (*models.ResourceGroupData, error) {
 	m.ctrl.T.Helper()
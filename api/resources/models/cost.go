@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultCostPeriod is the length of a cost period when its start is not given.
+const DefaultCostPeriod = 30 * 24 * time.Hour
+
+// CostPeriodError is returned when the bounds of a cost period can not be parsed or are out of order.
+type CostPeriodError struct {
+	Params []string
+}
+
+func (e *CostPeriodError) Error() string {
+	return "invalid cost period: " + strings.Join(e.Params, ", ")
+}
+
+// CostPeriod is the date range of a cost report, the end is exclusive.
+type CostPeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ParseCostPeriod parses the bounds of a cost period given either as RFC 3339 times or as dates. The period ends now
+// when no end is given and lasts DefaultCostPeriod when no start is given.
+func ParseCostPeriod(from, to string, now time.Time) (CostPeriod, error) {
+	period := CostPeriod{To: now}
+
+	if to != "" {
+		t, ok := parseCostTime(to)
+		if !ok {
+			return CostPeriod{}, &CostPeriodError{Params: []string{"to"}}
+		}
+
+		period.To = t
+	}
+
+	period.From = period.To.Add(-DefaultCostPeriod)
+
+	if from != "" {
+		t, ok := parseCostTime(from)
+		if !ok {
+			return CostPeriod{}, &CostPeriodError{Params: []string{"from"}}
+		}
+
+		period.From = t
+	}
+
+	if !period.From.Before(period.To) {
+		return CostPeriod{}, &CostPeriodError{Params: []string{"from", "to"}}
+	}
+
+	return period, nil
+}
+
+func parseCostTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ResourceCost is the time a resource spent running and stopped over a period along with its estimated spend,
+// and the estimated savings of the time it was stopped.
+type ResourceCost struct {
+	ResourceID int64  `json:"resource_id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Region     string `json:"region"`
+	Tier       string `json:"tier,omitempty"`
+	// Units is the number of priced units of the resource, e.g. the nodes of a node pool.
+	Units            int     `json:"units"`
+	RunningHours     float64 `json:"running_hours"`
+	StoppedHours     float64 `json:"stopped_hours"`
+	EstimatedSpend   float64 `json:"estimated_spend"`
+	EstimatedSavings float64 `json:"estimated_savings"`
+	// Priced is false when the price catalog has no price for the resource, its spend and savings are then zero.
+	Priced bool `json:"priced"`
+}
+
+// CostReport is the estimated spend and savings of a set of resources over a period, e.g. a cloud account.
+type CostReport struct {
+	CostPeriod
+	Currency         string         `json:"currency"`
+	RunningHours     float64        `json:"running_hours"`
+	StoppedHours     float64        `json:"stopped_hours"`
+	EstimatedSpend   float64        `json:"estimated_spend"`
+	EstimatedSavings float64        `json:"estimated_savings"`
+	Resources        []ResourceCost `json:"resources"`
+}
+
+// Add adds the cost of a resource to the report.
+func (r *CostReport) Add(c *ResourceCost) {
+	r.RunningHours += c.RunningHours
+	r.StoppedHours += c.StoppedHours
+	r.EstimatedSpend += c.EstimatedSpend
+	r.EstimatedSavings += c.EstimatedSavings
+	r.Resources = append(r.Resources, *c)
+}
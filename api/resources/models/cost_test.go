@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCostPeriod(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		from, to  string
+		expPeriod CostPeriod
+		expErr    error
+	}{
+		{name: "default period", expPeriod: CostPeriod{From: now.Add(-DefaultCostPeriod), To: now}},
+		{name: "dates", from: "2025-01-01", to: "2025-02-01", expPeriod: CostPeriod{From: jan, To: feb}},
+		{name: "times", from: "2025-01-01T00:00:00Z", to: "2025-02-01T00:00:00Z", expPeriod: CostPeriod{From: jan, To: feb}},
+		{name: "start only", from: "2025-01-01", expPeriod: CostPeriod{From: jan, To: now}},
+		{name: "invalid start", from: "yesterday", expErr: &CostPeriodError{Params: []string{"from"}}},
+		{name: "invalid end", to: "01/02/2025", expErr: &CostPeriodError{Params: []string{"to"}}},
+		{name: "end before start", from: "2025-02-01", to: "2025-01-01", expErr: &CostPeriodError{Params: []string{"from", "to"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			period, err := ParseCostPeriod(tc.from, tc.to, now)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expPeriod, period)
+		})
+	}
+}
+
+func TestCostReport_Add(t *testing.T) {
+	report := &CostReport{}
+
+	report.Add(&ResourceCost{ResourceID: 1, RunningHours: 2, StoppedHours: 1, EstimatedSpend: 3, EstimatedSavings: 1})
+	report.Add(&ResourceCost{ResourceID: 2, RunningHours: 1, EstimatedSpend: 1})
+
+	assert.Equal(t, &CostReport{RunningHours: 3, StoppedHours: 1, EstimatedSpend: 4, EstimatedSavings: 1, Resources: []ResourceCost{
+		{ResourceID: 1, RunningHours: 2, StoppedHours: 1, EstimatedSpend: 3, EstimatedSavings: 1},
+		{ResourceID: 2, RunningHours: 1, EstimatedSpend: 1},
+	}}, report)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of the changes recorded in the inventory history of a resource.
+const (
+	EventCreated         = "CREATED"
+	EventDeleted         = "DELETED"
+	EventRestored        = "RESTORED"
+	EventStatusChanged   = "STATUS_CHANGED"
+	EventSettingsChanged = "SETTINGS_CHANGED"
+	EventLabelsChanged   = "LABELS_CHANGED"
+)
+
+// Sources of the changes recorded in the inventory history of a resource.
+const (
+	// EventSourceSync is used for the changes detected while syncing the resources with the cloud provider.
+	EventSourceSync = "SYNC"
+	// EventSourceAPI is used for the changes made through zopdev, e.g. starting or suspending a resource.
+	EventSourceAPI = "API"
+)
+
+// ResourceEvent is a single change in the inventory of a cloud account.
+// The resource details are copied to the event so that it can still be read once the resource is removed.
+type ResourceEvent struct {
+	ID             int64  `json:"id"`
+	CloudAccountID int64  `json:"cloud_account_id"`
+	ResourceID     int64  `json:"resource_id"`
+	ResourceUID    string `json:"resource_uid"`
+	ResourceName   string `json:"resource_name"`
+	ResourceType   string `json:"resource_type"`
+	EventType      string `json:"event_type"`
+	Source         string `json:"source"`
+	// Actor is the subject of the principal who made a change through zopdev, it is empty for the changes detected by
+	// the syncs.
+	Actor     string          `json:"actor,omitempty"`
+	OldValue  json.RawMessage `json:"old_value,omitempty"`
+	NewValue  json.RawMessage `json:"new_value,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// EventFilter holds the criteria used to query the inventory history of a cloud account.
+type EventFilter struct {
+	// ResourceID limits the events to a single resource when set.
+	ResourceID int64
+	EventTypes []string
+	Since      time.Time
+	Limit      int
+}
@@ -12,12 +12,16 @@ type Resource struct {
 	Type         string       `json:"type"`
 	CloudAccount CloudAccount `json:"cloud_account"`
 	Region       string       `json:"region"`
+	Project      string       `json:"project,omitempty"`
 	CreationTime string       `json:"creation_time"`
 	Status       string       `json:"status"`
 	UID          string       `json:"uid"`
 	Settings     Settings     `json:"settings"`
+	Labels       Labels       `json:"labels,omitempty"`
 	CreatedAt    time.Time    `json:"created_at"`
 	UpdatedAt    time.Time    `json:"updated_at"`
+	// DeletedAt is set when the resource is no longer present on the cloud, the resource is restored if it reappears.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type Settings map[string]any
@@ -42,4 +46,6 @@ func (s *Settings) Scan(value any) error {
 type CloudAccount struct {
 	ID   int64  `json:"id"`
 	Type string `json:"type"`
+	// Name is only set on the resources searched across the cloud accounts.
+	Name string `json:"name,omitempty"`
 }
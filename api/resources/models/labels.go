@@ -0,0 +1,135 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var errInvalidSelector = errors.New("invalid label selector")
+
+// Labels are the key-value pairs attached to a resource on the cloud, e.g. labels on GCP and tags on AWS.
+type Labels map[string]string
+
+func (l Labels) Value() (driver.Value, error) {
+	if len(l) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(l)
+}
+
+func (l *Labels) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return driver.ErrSkip
+	}
+}
+
+// SelectorOperator is the operator of a single requirement in a label selector.
+type SelectorOperator string
+
+const (
+	SelectorEquals    SelectorOperator = "="
+	SelectorNotEquals SelectorOperator = "!="
+	SelectorExists    SelectorOperator = "exists"
+	SelectorNotExists SelectorOperator = "!exists"
+)
+
+// LabelRequirement is a single requirement of a label selector, the value is only set for the equality operators.
+type LabelRequirement struct {
+	Key      string
+	Operator SelectorOperator
+	Value    string
+}
+
+// LabelSelector is a list of requirements that all need to be satisfied by the labels of a resource.
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parses a comma separated list of requirements, e.g. `env=staging,team!=data`.
+// The supported requirements are `key=value`, `key==value`, `key!=value`, `key` (label exists) and
+// `!key` (label does not exist). An empty string results in a selector that matches everything.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return nil, nil
+	}
+
+	terms := strings.Split(selector, ",")
+	ls := make(LabelSelector, 0, len(terms))
+
+	for _, term := range terms {
+		req, err := parseRequirement(strings.TrimSpace(term))
+		if err != nil {
+			return nil, err
+		}
+
+		ls = append(ls, req)
+	}
+
+	return ls, nil
+}
+
+func parseRequirement(term string) (LabelRequirement, error) {
+	var req LabelRequirement
+
+	switch {
+	case strings.Contains(term, "!="):
+		key, value, _ := strings.Cut(term, "!=")
+		req = LabelRequirement{Key: key, Operator: SelectorNotEquals, Value: value}
+	case strings.Contains(term, "=="):
+		key, value, _ := strings.Cut(term, "==")
+		req = LabelRequirement{Key: key, Operator: SelectorEquals, Value: value}
+	case strings.Contains(term, "="):
+		key, value, _ := strings.Cut(term, "=")
+		req = LabelRequirement{Key: key, Operator: SelectorEquals, Value: value}
+	case strings.HasPrefix(term, "!"):
+		req = LabelRequirement{Key: strings.TrimPrefix(term, "!"), Operator: SelectorNotExists}
+	default:
+		req = LabelRequirement{Key: term, Operator: SelectorExists}
+	}
+
+	req.Key = strings.TrimSpace(req.Key)
+	req.Value = strings.TrimSpace(req.Value)
+
+	if req.Key == "" || strings.ContainsAny(req.Key, "!=") || strings.ContainsAny(req.Value, "!=") {
+		return req, errInvalidSelector
+	}
+
+	return req, nil
+}
+
+// Matches reports whether the given labels satisfy all the requirements of the selector.
+func (ls LabelSelector) Matches(labels Labels) bool {
+	for _, req := range ls {
+		value, ok := labels[req.Key]
+
+		switch req.Operator {
+		case SelectorEquals:
+			if !ok || value != req.Value {
+				return false
+			}
+		case SelectorNotEquals:
+			if ok && value == req.Value {
+				return false
+			}
+		case SelectorExists:
+			if !ok {
+				return false
+			}
+		case SelectorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	testCases := []struct {
+		name     string
+		selector string
+		expected LabelSelector
+		expErr   error
+	}{
+		{
+			name:     "empty selector",
+			selector: " ",
+		},
+		{
+			name:     "all operators",
+			selector: "env=prod, team==core,tier!=db,owner,!temp",
+			expected: LabelSelector{
+				{Key: "env", Operator: SelectorEquals, Value: "prod"},
+				{Key: "team", Operator: SelectorEquals, Value: "core"},
+				{Key: "tier", Operator: SelectorNotEquals, Value: "db"},
+				{Key: "owner", Operator: SelectorExists},
+				{Key: "temp", Operator: SelectorNotExists},
+			},
+		},
+		{
+			name:     "missing key",
+			selector: "=prod",
+			expErr:   errInvalidSelector,
+		},
+		{
+			name:     "invalid value",
+			selector: "env=prod=dev",
+			expErr:   errInvalidSelector,
+		},
+		{
+			name:     "empty requirement",
+			selector: "env=prod,,team",
+			expErr:   errInvalidSelector,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sel, err := ParseLabelSelector(tc.selector)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expected, sel)
+		})
+	}
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := Labels{"env": "prod", "team": "core"}
+
+	testCases := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "", expected: true},
+		{selector: "env=prod", expected: true},
+		{selector: "env=dev", expected: false},
+		{selector: "env!=dev", expected: true},
+		{selector: "team!=core", expected: false},
+		{selector: "team", expected: true},
+		{selector: "owner", expected: false},
+		{selector: "!owner", expected: true},
+		{selector: "!env", expected: false},
+		{selector: "env=prod,team=core", expected: true},
+		{selector: "env=prod,team=infra", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			sel, err := ParseLabelSelector(tc.selector)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, sel.Matches(labels))
+		})
+	}
+}
+
+func TestLabels_Scan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    any
+		expected Labels
+		expErr   error
+	}{
+		{name: "nil", value: nil},
+		{name: "bytes", value: []byte(`{"env":"prod"}`), expected: Labels{"env": "prod"}},
+		{name: "string", value: `{"env":"prod"}`, expected: Labels{"env": "prod"}},
+		{name: "unsupported type", value: 1, expErr: driver.ErrSkip},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var l Labels
+
+			err := l.Scan(tc.value)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expected, l)
+		})
+	}
+}
+
+func TestLabels_Value(t *testing.T) {
+	v, err := Labels{"env": "prod"}.Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"env":"prod"}`), v)
+
+	v, err = Labels(nil).Value()
+
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
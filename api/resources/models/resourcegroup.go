@@ -6,6 +6,8 @@ type ResourceGroup struct {
 	Name           string `json:"name"`
 	Description    string `json:"description,omitempty"`
 	Status         string `json:"status,omitempty"`
+	// LabelSelector makes the group dynamic, its members are all the resources whose labels match the selector.
+	LabelSelector string `json:"label_selector,omitempty"`
 }
 
 type ResourceGroupData struct {
@@ -18,6 +20,7 @@ type RGCreate struct {
 	Description    string  `json:"description"`
 	CloudAccountID int64   `json:"cloud_account_id"`
 	ResourceIDs    []int64 `json:"resource_ids"`
+	LabelSelector  string  `json:"label_selector"`
 }
 
 type RGUpdate struct {
@@ -26,4 +29,31 @@ type RGUpdate struct {
 	Description    string  `json:"description"`
 	CloudAccountID int64   `json:"cloud_account_id"`
 	ResourceIDs    []int64 `json:"resource_ids"`
+	LabelSelector  string  `json:"label_selector"`
+}
+
+// RGStateChange is the request to start or suspend all the resources of a resource group.
+type RGStateChange struct {
+	State string `json:"state"`
+	// Ordered changes the state of the resources in stages based on their type, e.g. databases are started
+	// before VMs and suspended after them.
+	Ordered bool `json:"ordered"`
+	// Concurrency is the maximum number of resources whose state is changed at the same time.
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+// RGStateResult is the outcome of a state change of a resource group along with the result for every member.
+type RGStateResult struct {
+	ResourceGroupID int64            `json:"resource_group_id"`
+	State           string           `json:"state"`
+	Status          string           `json:"status"`
+	Members         []RGMemberResult `json:"members"`
+}
+
+type RGMemberResult struct {
+	ResourceID int64  `json:"resource_id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
 }
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Fields the resources can be sorted by.
+const (
+	SortByName      = "name"
+	SortByRegion    = "region"
+	SortByStatus    = "status"
+	SortByUpdatedAt = "updated_at"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	// DefaultPageSize is the number of resources returned when no limit is given.
+	DefaultPageSize = 100
+	// MaxPageSize is the maximum number of resources returned in a single page.
+	MaxPageSize = 1000
+)
+
+// ErrInvalidCursor is returned when the cursor is malformed or was issued for another sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ResourceFilter holds the criteria used to filter the resources of a cloud account.
+// The resources have to match all the criteria, and any of the values of a criterion.
+type ResourceFilter struct {
+	ResourceTypes []string
+	Statuses      []string
+	Regions       []string
+	Providers     []string
+	// Name matches the resources whose name contains it, case-insensitively.
+	Name string
+	// Query matches the resources whose name, UID, or any label key or value contains it, case-insensitively.
+	Query  string
+	Labels LabelSelector
+}
+
+// Matches reports whether the resource matches the filter.
+func (f *ResourceFilter) Matches(res *Resource) bool {
+	return matchesAny(f.ResourceTypes, res.Type) &&
+		matchesAny(f.Statuses, res.Status) &&
+		matchesAny(f.Regions, res.Region) &&
+		matchesAny(f.Providers, res.CloudAccount.Type) &&
+		strings.Contains(strings.ToLower(res.Name), strings.ToLower(f.Name)) &&
+		matchesQuery(res, f.Query) &&
+		f.Labels.Matches(res.Labels)
+}
+
+func matchesQuery(res *Resource, query string) bool {
+	query = strings.ToLower(query)
+
+	contains := func(s string) bool {
+		return strings.Contains(strings.ToLower(s), query)
+	}
+
+	if contains(res.Name) || contains(res.UID) {
+		return true
+	}
+
+	for k, v := range res.Labels {
+		if contains(k) || contains(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAny(list []string, val string) bool {
+	return len(list) == 0 || containsFold(list, val)
+}
+
+// ResourcePage selects a page of the sorted resources.
+type ResourcePage struct {
+	SortBy string
+	Order  string
+	// Cursor is the position after which the page starts, it is the next cursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+// IsSortField reports whether the resources can be sorted by the field.
+func IsSortField(field string) bool {
+	switch field {
+	case SortByName, SortByRegion, SortByStatus, SortByUpdatedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResourceList is a page of the resources of one or more cloud accounts along with the facets of all the matching resources.
+type ResourceList struct {
+	Resources []Resource `json:"resources"`
+	// NextCursor is the cursor of the next page, it is empty on the last page.
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Total      int            `json:"total"`
+	Facets     ResourceFacets `json:"facets"`
+}
+
+// ResourceFacets are the number of matching resources per type, status and region.
+type ResourceFacets struct {
+	Types    map[string]int `json:"type"`
+	Statuses map[string]int `json:"status"`
+	Regions  map[string]int `json:"region"`
+}
+
+// cursor is the position of a resource in the sorted resources, the ID breaks the ties of the sort key.
+type cursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Key    string `json:"k"`
+	ID     int64  `json:"id"`
+}
+
+// WithDefaults returns the page with the sort field, the order and the limit defaulted, the limit is capped to
+// MaxPageSize.
+func (p *ResourcePage) WithDefaults() ResourcePage {
+	page := *p
+
+	if page.SortBy == "" {
+		page.SortBy = SortByName
+	}
+
+	if page.Order == "" {
+		page.Order = SortAsc
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = DefaultPageSize
+	}
+
+	page.Limit = min(page.Limit, MaxPageSize)
+
+	return page
+}
+
+// After returns the sort key and the ID of the resource after which the page starts, ok is false on the first page.
+// ErrInvalidCursor is returned when the cursor is malformed or was issued for another sort order.
+func (p *ResourcePage) After() (key string, id int64, ok bool, err error) {
+	if p.Cursor == "" {
+		return "", 0, false, nil
+	}
+
+	c, err := decodeCursor(p.Cursor)
+	if err != nil || c.SortBy != p.SortBy || c.Order != p.Order {
+		return "", 0, false, ErrInvalidCursor
+	}
+
+	return c.Key, c.ID, true, nil
+}
+
+// NextCursor returns the cursor of the page starting after the resource with the given sort key and ID.
+func (p *ResourcePage) NextCursor(key string, id int64) string {
+	return encodeCursor(cursor{SortBy: p.SortBy, Order: p.Order, Key: key, ID: id})
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+
+	err = json.Unmarshal(data, &c)
+
+	return c, err
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceFilter_Matches(t *testing.T) {
+	res := &Resource{Name: "Web-Server", UID: "i-0abc", Type: "EC2", Status: "RUNNING", Region: "us-east-1",
+		CloudAccount: CloudAccount{ID: 1, Type: "AWS"}, Labels: Labels{"env": "prod"}}
+	sel, _ := ParseLabelSelector("env=prod")
+
+	testCases := []struct {
+		name     string
+		filter   ResourceFilter
+		expected bool
+	}{
+		{name: "empty filter", filter: ResourceFilter{}, expected: true},
+		{name: "any of the statuses", filter: ResourceFilter{Statuses: []string{"stopped", "running"}}, expected: true},
+		{name: "other region", filter: ResourceFilter{Regions: []string{"eu-west-1"}}},
+		{name: "provider", filter: ResourceFilter{Providers: []string{"aws"}}, expected: true},
+		{name: "name substring", filter: ResourceFilter{Name: "server"}, expected: true},
+		{name: "name mismatch", filter: ResourceFilter{Name: "db"}},
+		{name: "all criteria", filter: ResourceFilter{ResourceTypes: []string{"EC2"}, Name: "web", Labels: sel}, expected: true},
+		{name: "query on name", filter: ResourceFilter{Query: "SERVER"}, expected: true},
+		{name: "query on label value", filter: ResourceFilter{Query: "pro"}, expected: true},
+		{name: "query on UID", filter: ResourceFilter{Query: "i-0A"}, expected: true},
+		{name: "query mismatch", filter: ResourceFilter{Query: "staging"}},
+		{name: "type mismatch", filter: ResourceFilter{ResourceTypes: []string{"RDS"}, Labels: sel}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Matches(res))
+		})
+	}
+}
+
+func TestResourcePage(t *testing.T) {
+	assert.Equal(t, ResourcePage{SortBy: SortByName, Order: SortAsc, Limit: DefaultPageSize}, (&ResourcePage{}).WithDefaults())
+	assert.Equal(t, MaxPageSize, (&ResourcePage{Limit: MaxPageSize + 1}).WithDefaults().Limit)
+
+	page := (&ResourcePage{SortBy: SortByUpdatedAt, Order: SortDesc, Limit: 2}).WithDefaults()
+
+	_, _, ok, err := page.After()
+
+	require.NoError(t, err)
+	assert.False(t, ok, "the first page has no cursor")
+
+	page.Cursor = page.NextCursor("2025-01-01 10:00:00", 4)
+
+	key, id, ok, err := page.After()
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-01-01 10:00:00", key)
+	assert.Equal(t, int64(4), id)
+
+	// The cursor of a page sorted by updated_at can not be used with another sort.
+	_, _, _, err = (&ResourcePage{SortBy: SortByName, Order: SortDesc, Cursor: page.Cursor}).After()
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	_, _, _, err = (&ResourcePage{Cursor: "%%%"}).After()
+	assert.Equal(t, ErrInvalidCursor, err)
+}
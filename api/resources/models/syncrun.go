@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Status of a sync run.
+const (
+	SyncRunning   = "RUNNING"
+	SyncSucceeded = "SUCCEEDED"
+	// SyncPartial is used when the sync completed but some of the resources could not be stored.
+	SyncPartial = "PARTIALLY_SUCCEEDED"
+	SyncFailed  = "FAILED"
+)
+
+// SyncRunTimeout is the time after which a run that is still running is considered abandoned, e.g. by an instance of
+// the API stopped during the sync, it no longer keeps the other syncs of its cloud account from running.
+const SyncRunTimeout = time.Hour
+
+// ErrSyncRunning is returned when a sync run is recorded while another run of the same cloud account is running.
+var ErrSyncRunning = errors.New("a sync of the cloud account is running")
+
+// SyncScope limits a sync to some resource types and regions, an empty scope syncs everything.
+type SyncScope struct {
+	ResourceTypes StringList `json:"resource_types,omitempty"`
+	Regions       StringList `json:"regions,omitempty"`
+}
+
+// IncludesType reports whether any of the given resource types is within the scope.
+func (s *SyncScope) IncludesType(types ...string) bool {
+	if len(s.ResourceTypes) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(types, func(t string) bool {
+		return containsFold(s.ResourceTypes, t)
+	})
+}
+
+// Matches reports whether the resource is within the scope.
+func (s *SyncScope) Matches(res *Resource) bool {
+	return s.IncludesType(res.Type) && (len(s.Regions) == 0 || containsFold(s.Regions, res.Region))
+}
+
+func containsFold(list []string, val string) bool {
+	return slices.ContainsFunc(list, func(v string) bool {
+		return strings.EqualFold(v, val)
+	})
+}
+
+// SyncRun is a single sync of the resources of a cloud account.
+type SyncRun struct {
+	ID             int64 `json:"id"`
+	CloudAccountID int64 `json:"cloud_account_id"`
+	SyncScope
+	Status     string     `json:"status"`
+	Discovered int        `json:"discovered"`
+	Created    int        `json:"created"`
+	Updated    int        `json:"updated"`
+	Deleted    int        `json:"deleted"`
+	Restored   int        `json:"restored"`
+	Errors     StringList `json:"errors,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+}
+
+// IsRunning reports whether the run is running at the given time, i.e. it is not finished nor abandoned.
+func (r *SyncRun) IsRunning(now time.Time) bool {
+	return r.Status == SyncRunning && r.StartedAt.After(now.Add(-SyncRunTimeout))
+}
+
+// SyncStatus is the sync state of a cloud account along with its latest sync runs.
+type SyncStatus struct {
+	InProgress bool      `json:"in_progress"`
+	Runs       []SyncRun `json:"runs"`
+}
+
+// StringList is a list of strings stored as a JSON array.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if len(l) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(l)
+}
+
+func (l *StringList) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return driver.ErrSkip
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncScope_Matches(t *testing.T) {
+	res := &Resource{Type: "SQL", Region: "us-east-1"}
+
+	testCases := []struct {
+		name     string
+		scope    SyncScope
+		expected bool
+	}{
+		{name: "empty scope", scope: SyncScope{}, expected: true},
+		{name: "type in scope", scope: SyncScope{ResourceTypes: StringList{"sql", "EC2"}}, expected: true},
+		{name: "type out of scope", scope: SyncScope{ResourceTypes: StringList{"EC2"}}, expected: false},
+		{name: "region in scope", scope: SyncScope{Regions: StringList{"us-east-1"}}, expected: true},
+		{name: "region out of scope", scope: SyncScope{ResourceTypes: StringList{"SQL"}, Regions: StringList{"eu-west-1"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.scope.Matches(res))
+		})
+	}
+}
+
+func TestSyncRun_IsRunning(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		run      SyncRun
+		expected bool
+	}{
+		{name: "running", run: SyncRun{Status: SyncRunning, StartedAt: now.Add(-time.Minute)}, expected: true},
+		{name: "finished", run: SyncRun{Status: SyncSucceeded, StartedAt: now.Add(-time.Minute)}},
+		{name: "abandoned", run: SyncRun{Status: SyncRunning, StartedAt: now.Add(-SyncRunTimeout)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.run.IsRunning(now))
+		})
+	}
+}
+
+func TestStringList_ValueScan(t *testing.T) {
+	v, err := StringList{"SQL", "EC2"}.Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`["SQL","EC2"]`), v)
+
+	v, err = StringList(nil).Value()
+
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	var l StringList
+
+	assert.NoError(t, l.Scan(`["SQL"]`))
+	assert.Equal(t, StringList{"SQL"}, l)
+}
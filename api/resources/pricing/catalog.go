@@ -0,0 +1,126 @@
+// Package pricing is the offline catalog of the estimated hourly prices of the cloud resources supported by zopdev.
+// The prices are estimates used to report the spend and the savings of the resources, they are not billing data.
+package pricing
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed catalog.json
+var defaultCatalog []byte
+
+var errMissingCurrency = errors.New("price catalog has no currency")
+
+// Key identifies the price of a resource, an empty tier or region in the catalog matches any tier or region.
+type Key struct {
+	Provider     string `json:"provider"`
+	ResourceType string `json:"resource_type"`
+	// Tier is the machine type, instance class or SKU of the resource, e.g. t3.medium or db-custom-2-7680.
+	Tier   string `json:"tier,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// Price is the estimated hourly price of a single unit of a resource, e.g. a node of a node pool.
+type Price struct {
+	Running float64 `json:"running"`
+	// Stopped is the price of the resource while it is stopped, e.g. the storage of a stopped database.
+	Stopped float64 `json:"stopped"`
+}
+
+type entry struct {
+	Key
+	Price
+}
+
+// Catalog is a list of prices in a single currency.
+type Catalog struct {
+	currency string
+	prices   map[Key]Price
+}
+
+// Load reads a catalog in the JSON format of catalog.json.
+func Load(r io.Reader) (*Catalog, error) {
+	var file struct {
+		Currency string  `json:"currency"`
+		Prices   []entry `json:"prices"`
+	}
+
+	err := json.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Currency == "" {
+		return nil, errMissingCurrency
+	}
+
+	c := &Catalog{currency: file.Currency, prices: make(map[Key]Price, len(file.Prices))}
+
+	for _, e := range file.Prices {
+		c.prices[normalize(e.Key)] = e.Price
+	}
+
+	return c, nil
+}
+
+// LoadFile reads a catalog from a JSON file.
+func LoadFile(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Default returns the catalog shipped with zopdev, it holds the on-demand list prices of the common machine types.
+func Default() *Catalog {
+	c, err := Load(bytes.NewReader(defaultCatalog))
+	if err != nil {
+		// The embedded catalog is validated by the tests.
+		panic(err)
+	}
+
+	return c
+}
+
+// Currency returns the currency of the prices of the catalog.
+func (c *Catalog) Currency() string {
+	return c.currency
+}
+
+// Lookup returns the price of a resource. The prices of its tier in its region are preferred over the prices of its
+// tier in any region, which are preferred over the prices of its type.
+func (c *Catalog) Lookup(key Key) (Price, bool) {
+	key = normalize(key)
+
+	for _, k := range []Key{
+		key,
+		{Provider: key.Provider, ResourceType: key.ResourceType, Tier: key.Tier},
+		{Provider: key.Provider, ResourceType: key.ResourceType, Region: key.Region},
+		{Provider: key.Provider, ResourceType: key.ResourceType},
+	} {
+		if p, ok := c.prices[k]; ok {
+			return p, true
+		}
+	}
+
+	return Price{}, false
+}
+
+func normalize(k Key) Key {
+	return Key{
+		Provider:     strings.ToUpper(k.Provider),
+		ResourceType: strings.ToUpper(k.ResourceType),
+		Tier:         strings.ToLower(k.Tier),
+		Region:       strings.ToLower(k.Region),
+	}
+}
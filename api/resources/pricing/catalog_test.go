@@ -0,0 +1,63 @@
+package pricing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	c := Default()
+
+	assert.Equal(t, "USD", c.Currency())
+
+	p, ok := c.Lookup(Key{Provider: "aws", ResourceType: "EC2", Tier: "T3.Medium", Region: "us-east-1"})
+
+	require.True(t, ok)
+	assert.InDelta(t, 0.0416, p.Running, 1e-9)
+}
+
+func TestCatalog_Lookup(t *testing.T) {
+	c, err := Load(strings.NewReader(`{"currency": "EUR", "prices": [
+		{"provider": "GCP", "resource_type": "SQL", "running": 0.1, "stopped": 0.01},
+		{"provider": "GCP", "resource_type": "SQL", "region": "europe-west1", "running": 0.2},
+		{"provider": "GCP", "resource_type": "SQL", "tier": "db-f1-micro", "running": 0.3},
+		{"provider": "GCP", "resource_type": "SQL", "tier": "db-f1-micro", "region": "europe-west1", "running": 0.4}
+	]}`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		key      Key
+		expected Price
+		found    bool
+	}{
+		{name: "tier in region", key: Key{"GCP", "SQL", "db-f1-micro", "europe-west1"}, expected: Price{Running: 0.4}, found: true},
+		{name: "tier in any region", key: Key{"GCP", "SQL", "db-f1-micro", "us-east1"}, expected: Price{Running: 0.3}, found: true},
+		{name: "type in region", key: Key{"GCP", "SQL", "db-g1-small", "europe-west1"}, expected: Price{Running: 0.2}, found: true},
+		{name: "type", key: Key{"gcp", "sql", "", "us-east1"}, expected: Price{Running: 0.1, Stopped: 0.01}, found: true},
+		{name: "unknown type", key: Key{"GCP", "GCE_DISK", "", ""}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, ok := c.Lookup(tc.key)
+
+			assert.Equal(t, tc.found, ok)
+			assert.Equal(t, tc.expected, p)
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(strings.NewReader(`{"prices": []}`))
+	assert.Equal(t, errMissingCurrency, err)
+
+	_, err = Load(strings.NewReader(`not json`))
+	assert.Error(t, err)
+
+	_, err = LoadFile("does-not-exist.json")
+	assert.Error(t, err)
+}
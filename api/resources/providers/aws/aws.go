@@ -2,17 +2,22 @@ package aws
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-
-	"gofr.dev/pkg/gofr/http"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/zopdev/zopdev/api/resources/providers/aws/database"
+	awsRegions "github.com/zopdev/zopdev/api/resources/providers/aws/regions"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/scaling"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/storage"
 	"github.com/zopdev/zopdev/api/resources/providers/aws/vm"
 )
 
@@ -22,6 +27,9 @@ var (
 )
 
 type Client struct {
+	mu sync.Mutex
+	// roles are the credentials of the assumed roles, by role, external ID and base identity.
+	roles map[string]*credentials.Credentials
 }
 
 func New() *Client {
@@ -35,7 +43,7 @@ func (c *Client) NewRDSClient(_ context.Context, creds any) (*database.Client, e
 		return nil, ErrInvalidCredentials
 	}
 
-	sess, err := c.newSession(awsCreds.AccessKey, awsCreds.AccessSecret)
+	sess, err := c.newSession(awsCreds)
 	if err != nil {
 		return nil, ErrInitializingClient
 	}
@@ -43,62 +51,81 @@ func (c *Client) NewRDSClient(_ context.Context, creds any) (*database.Client, e
 	return &database.Client{RDS: rds.New(sess)}, nil
 }
 
-type awsCredentials struct {
-	AccessKey    string `json:"aws_access_key_id"`
-	AccessSecret string `json:"aws_secret_access_key"`
-}
+// NewEC2Client creates a new EC2 client with stored credentials.
+func (c *Client) NewEC2Client(_ context.Context, creds any) (*vm.Client, error) {
+	awsCreds, err := getAWSCredentials(creds)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
 
-func getAWSCredentials(creds any) (awsCredentials, error) {
-	var awsCred awsCredentials
+	sess, err := c.newSession(awsCreds)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
 
-	awsCredBody, _ := json.Marshal(creds)
+	return &vm.Client{EC2: ec2.New(sess)}, nil
+}
 
-	err := json.Unmarshal(awsCredBody, &awsCred)
+// NewScalingClient creates a client for the EKS node groups and Auto Scaling groups of all the regions
+// with stored credentials.
+func (c *Client) NewScalingClient(_ context.Context, creds any) (*scaling.Client, error) {
+	awsCreds, err := getAWSCredentials(creds)
 	if err != nil {
-		return awsCred, err
+		return nil, ErrInvalidCredentials
 	}
 
-	if awsCred.AccessKey == "" && awsCred.AccessSecret == "" {
-		return awsCred, http.ErrorMissingParam{Params: []string{"AWSAccessKeyID", "AWSecretAccessKey"}}
+	sess, err := c.newSession(awsCreds)
+	if err != nil {
+		return nil, ErrInitializingClient
 	}
 
-	if awsCred.AccessKey == "" {
-		return awsCred, http.ErrorMissingParam{Params: []string{"AWSAccessKeyID"}}
+	regions := vm.GetAWSRegions()
+	cl := &scaling.Client{
+		Regions:    make(map[string]*scaling.RegionalClient, len(regions)),
+		RegionsAPI: ec2.New(sess, aws.NewConfig().WithRegion(awsRegions.DefaultRegion)),
 	}
 
-	if awsCred.AccessSecret == "" {
-		return awsCred, http.ErrorMissingParam{Params: []string{"AWSecretAccessKey"}}
+	for _, region := range regions {
+		region = strings.TrimSpace(region)
+		cfg := aws.NewConfig().WithRegion(region)
+
+		cl.Regions[region] = &scaling.RegionalClient{
+			AutoScaling: autoscaling.New(sess, cfg),
+			EKS:         eks.New(sess, cfg),
+		}
 	}
 
-	return awsCred, nil
+	return cl, nil
 }
 
-// NewEC2Client creates a new EC2 client with stored credentials.
-func (c *Client) NewEC2Client(_ context.Context, creds any) (*vm.Client, error) {
+// NewStorageClient creates a client for the S3 buckets and EBS volumes of all the regions with stored credentials.
+func (c *Client) NewStorageClient(_ context.Context, creds any) (*storage.Client, error) {
 	awsCreds, err := getAWSCredentials(creds)
 	if err != nil {
 		return nil, ErrInvalidCredentials
 	}
 
-	sess, err := c.newSession(awsCreds.AccessKey, awsCreds.AccessSecret)
+	sess, err := c.newSession(awsCreds)
 	if err != nil {
 		return nil, ErrInitializingClient
 	}
 
-	return &vm.Client{EC2: ec2.New(sess)}, nil
-}
+	regions := vm.GetAWSRegions()
+	cl := &storage.Client{
+		Regions:    make(map[string]*storage.RegionalClient, len(regions)),
+		RegionsAPI: ec2.New(sess, aws.NewConfig().WithRegion(awsRegions.DefaultRegion)),
+	}
 
-// newSession creates a new AWS session with the stored config and credentials.
-func (*Client) newSession(accessKey, secretKey string) (*session.Session, error) {
-	creds := credentials.NewStaticCredentials(accessKey, secretKey, "")
+	for _, region := range regions {
+		region = strings.TrimSpace(region)
+		cfg := aws.NewConfig().WithRegion(region)
 
-	sess, err := session.NewSession(&aws.Config{
-		Credentials: creds,
-		Region:      aws.String("us-east-1"), // Default region, can be overridden
-	})
-	if err != nil {
-		return nil, ErrInvalidCredentials
+		cl.Regions[region] = &storage.RegionalClient{
+			S3:         s3.New(sess, cfg),
+			EC2:        ec2.New(sess, cfg),
+			CloudWatch: cloudwatch.New(sess, cfg),
+		}
 	}
 
-	return sess, nil
+	return cl, nil
 }
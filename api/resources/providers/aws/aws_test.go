@@ -5,6 +5,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,12 +63,51 @@ func Test_newSession(t *testing.T) {
 	c := &Client{}
 
 	t.Run("success", func(t *testing.T) {
-		sess, err := c.newSession("key", "secret")
+		sess, err := c.newSession(awsCredentials{AccessKey: "key", AccessSecret: "secret"})
 		require.NoError(t, err)
 		require.NotNil(t, sess)
 	})
 }
 
+func Test_getAWSCredentials_role(t *testing.T) {
+	creds, err := getAWSCredentials(map[string]string{"role_arn": "arn:aws:iam::123:role/zop", "external_id": "ext-1"})
+	require.NoError(t, err)
+	assert.Equal(t, awsCredentials{RoleARN: "arn:aws:iam::123:role/zop", ExternalID: "ext-1"}, creds)
+
+	_, err = getAWSCredentials(map[string]string{"role_arn": "arn:aws:iam::123:role/zop"})
+	require.Error(t, err)
+
+	_, err = getAWSCredentials(map[string]string{"role_arn": "arn:aws:iam::123:role/zop", "external_id": "ext-1",
+		"aws_access_key_id": "key"})
+	require.Error(t, err)
+}
+
+func TestClient_getCredentials(t *testing.T) {
+	c := &Client{}
+	role := awsCredentials{RoleARN: "arn:aws:iam::123:role/zop", ExternalID: "ext-1"}
+
+	first, err := c.getCredentials(role)
+	require.NoError(t, err)
+
+	// The credentials of a role are cached, so that its session is reused until it expires.
+	second, err := c.getCredentials(role)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	role.ExternalID = "ext-2"
+
+	other, err := c.getCredentials(role)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+
+	static, err := c.getCredentials(awsCredentials{AccessKey: "key", AccessSecret: "secret", SessionToken: "token"})
+	require.NoError(t, err)
+
+	value, err := static.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "token", value.SessionToken)
+}
+
 func TestNewRDSClient_InvalidCreds(t *testing.T) {
 	c := &Client{}
 	_, err := c.NewRDSClient(context.Background(), map[string]string{})
@@ -92,3 +135,87 @@ func TestNewEC2Client_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, client)
 }
+
+func TestNewScalingClient_InvalidCreds(t *testing.T) {
+	c := &Client{}
+	_, err := c.NewScalingClient(context.Background(), map[string]string{})
+	require.Error(t, err)
+}
+
+func TestNewScalingClient_Success(t *testing.T) {
+	c := &Client{}
+	creds := map[string]string{"aws_access_key_id": "key", "aws_secret_access_key": "secret"}
+	client, err := c.NewScalingClient(context.Background(), creds)
+	require.NoError(t, err)
+	require.NotNil(t, client.Regions["us-east-1"])
+	require.NotNil(t, client.Regions["ap-northeast-3"])
+}
+
+func TestNewStorageClient_InvalidCreds(t *testing.T) {
+	c := &Client{}
+	_, err := c.NewStorageClient(context.Background(), map[string]string{})
+	require.Error(t, err)
+}
+
+func TestNewStorageClient_Success(t *testing.T) {
+	c := &Client{}
+	creds := map[string]string{"aws_access_key_id": "key", "aws_secret_access_key": "secret"}
+	client, err := c.NewStorageClient(context.Background(), creds)
+	require.NoError(t, err)
+	require.NotNil(t, client.Regions["us-east-1"])
+	require.NotNil(t, client.Regions["eu-west-1"])
+}
+
+type mockSimulator struct {
+	results []*iam.EvaluationResult
+	err     error
+}
+
+func (m *mockSimulator) SimulatePrincipalPolicyPagesWithContext(_ aws.Context, _ *iam.SimulatePrincipalPolicyInput,
+	fn func(*iam.SimulatePolicyResponse, bool) bool, _ ...request.Option) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	fn(&iam.SimulatePolicyResponse{EvaluationResults: m.results}, true)
+
+	return nil
+}
+
+func Test_simulatePrincipalPolicy(t *testing.T) {
+	ctx := context.Background()
+	actions := []string{"ec2:DescribeInstances", "ec2:StopInstances"}
+	simulator := &mockSimulator{results: []*iam.EvaluationResult{
+		{EvalActionName: aws.String("ec2:DescribeInstances"), EvalDecision: aws.String("allowed")},
+		{EvalActionName: aws.String("ec2:StopInstances"), EvalDecision: aws.String("implicitDeny")},
+	}}
+
+	granted, err := simulatePrincipalPolicy(ctx, simulator, "arn:aws:iam::123:user/zop", actions)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ec2:DescribeInstances": true, "ec2:StopInstances": false}, granted)
+
+	// The permissions are unknown when the principal cannot simulate its policies.
+	simulator = &mockSimulator{err: awserr.New("AccessDenied", "not authorized", nil)}
+
+	granted, err = simulatePrincipalPolicy(ctx, simulator, "arn:aws:iam::123:user/zop", actions)
+	require.NoError(t, err)
+	assert.Empty(t, granted)
+
+	simulator = &mockSimulator{err: awserr.New("Throttling", "rate exceeded", nil)}
+
+	_, err = simulatePrincipalPolicy(ctx, simulator, "arn:aws:iam::123:user/zop", actions)
+	require.Error(t, err)
+}
+
+func Test_principalARN(t *testing.T) {
+	arn, isRoot := principalARN("arn:aws:sts::123:assumed-role/zop-reader/session-1")
+	assert.Equal(t, "arn:aws:iam::123:role/zop-reader", arn)
+	assert.False(t, isRoot)
+
+	arn, isRoot = principalARN("arn:aws:iam::123:user/zop")
+	assert.Equal(t, "arn:aws:iam::123:user/zop", arn)
+	assert.False(t, isRoot)
+
+	_, isRoot = principalARN("arn:aws:iam::123:root")
+	assert.True(t, isRoot)
+}
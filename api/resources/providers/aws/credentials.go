@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"gofr.dev/pkg/gofr/http"
+)
+
+const (
+	defaultRegion   = "us-east-1"
+	roleSessionName = "zopdev"
+	// roleSessionDuration is the lifetime of the credentials of an assumed role, they are refreshed roleExpiryWindow
+	// before they expire.
+	roleSessionDuration = time.Hour
+	roleExpiryWindow    = 5 * time.Minute
+)
+
+// awsCredentials are the credentials of an AWS cloud account. An account is either defined by the keys of an IAM
+// user, or by a role assumed with the external ID zopdev generated for the account. The role is assumed from the keys
+// when they are given, otherwise from the base identity of zopdev, e.g. its instance profile.
+type awsCredentials struct {
+	AccessKey    string `json:"aws_access_key_id"`
+	AccessSecret string `json:"aws_secret_access_key"`
+	// SessionToken is set along with temporary keys.
+	SessionToken string `json:"aws_session_token"`
+	RoleARN      string `json:"role_arn"`
+	ExternalID   string `json:"external_id"`
+}
+
+func getAWSCredentials(creds any) (awsCredentials, error) {
+	var awsCred awsCredentials
+
+	awsCredBody, _ := json.Marshal(creds)
+
+	err := json.Unmarshal(awsCredBody, &awsCred)
+	if err != nil {
+		return awsCred, err
+	}
+
+	if awsCred.RoleARN != "" {
+		if awsCred.ExternalID == "" {
+			return awsCred, http.ErrorMissingParam{Params: []string{"external_id"}}
+		}
+
+		// The keys are optional, but both are needed to assume the role from them.
+		if (awsCred.AccessKey == "") != (awsCred.AccessSecret == "") {
+			return awsCred, http.ErrorMissingParam{Params: []string{"AWSAccessKeyID", "AWSecretAccessKey"}}
+		}
+
+		return awsCred, nil
+	}
+
+	if awsCred.AccessKey == "" && awsCred.AccessSecret == "" {
+		return awsCred, http.ErrorMissingParam{Params: []string{"AWSAccessKeyID", "AWSecretAccessKey"}}
+	}
+
+	if awsCred.AccessKey == "" {
+		return awsCred, http.ErrorMissingParam{Params: []string{"AWSAccessKeyID"}}
+	}
+
+	if awsCred.AccessSecret == "" {
+		return awsCred, http.ErrorMissingParam{Params: []string{"AWSecretAccessKey"}}
+	}
+
+	return awsCred, nil
+}
+
+// newSession creates a new AWS session authenticated with the credentials of a cloud account.
+func (c *Client) newSession(awsCreds awsCredentials) (*session.Session, error) {
+	creds, err := c.getCredentials(awsCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: creds,
+		Region:      aws.String(defaultRegion), // Default region, can be overridden
+	})
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return sess, nil
+}
+
+// getCredentials returns the credentials of the clients of a cloud account. The credentials of an assumed role are
+// shared by all the clients of the role, so that the role is only assumed again shortly before its session expires.
+func (c *Client) getCredentials(awsCreds awsCredentials) (*credentials.Credentials, error) {
+	if awsCreds.RoleARN == "" {
+		return credentials.NewStaticCredentials(awsCreds.AccessKey, awsCreds.AccessSecret, awsCreds.SessionToken), nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := awsCreds.RoleARN + "|" + awsCreds.ExternalID + "|" + awsCreds.AccessKey
+	if creds, ok := c.roles[key]; ok {
+		return creds, nil
+	}
+
+	cfg := &aws.Config{Region: aws.String(defaultRegion)}
+	if awsCreds.AccessKey != "" {
+		cfg.Credentials = credentials.NewStaticCredentials(awsCreds.AccessKey, awsCreds.AccessSecret, awsCreds.SessionToken)
+	}
+
+	// Without keys, the base session uses the default credential chain of the SDK.
+	base, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	creds := stscreds.NewCredentials(base, awsCreds.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.ExternalID = aws.String(awsCreds.ExternalID)
+		p.RoleSessionName = roleSessionName
+		p.Duration = roleSessionDuration
+		p.ExpiryWindow = roleExpiryWindow
+	})
+
+	if c.roles == nil {
+		c.roles = make(map[string]*credentials.Credentials)
+	}
+
+	c.roles[key] = creds
+
+	return creds, nil
+}
+
+// GetAccountID validates the credentials of a cloud account and returns the ID of the AWS account they belong to.
+func (c *Client) GetAccountID(ctx context.Context, creds any) (string, error) {
+	awsCreds, err := getAWSCredentials(creds)
+	if err != nil {
+		return "", err
+	}
+
+	sess, err := c.newSession(awsCreds)
+	if err != nil {
+		return "", err
+	}
+
+	identity, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(identity.Account), nil
+}
@@ -93,6 +93,7 @@ func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
 				"engine":     engine,
 				"cluster_id": clusterID,
 			},
+			Labels:    getLabels(db.TagList),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
@@ -102,6 +103,22 @@ func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
 	return instances, nil
 }
 
+// GetInstanceStatus returns the status of the DB instance of the resource.
+func (c *Client) GetInstanceStatus(ctx *gofr.Context, resource *models.Resource) (string, error) {
+	result, err := c.RDS.DescribeDBInstancesWithContext(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(resource.Name),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(result.DBInstances) == 0 {
+		return "", gofrService.ErrorEntityNotFound{Name: "DB instance", Value: resource.Name}
+	}
+
+	return mapRDSStatus(awsStringValue(result.DBInstances[0].DBInstanceStatus)), nil
+}
+
 // StartInstance handles all RDS types: Aurora clusters and standard RDS. Aurora Serverless detection is not supported here.
 func (c *Client) StartInstance(ctx *gofr.Context, resource *models.Resource) error {
 	engine, clusterID, err := extractEngineAndClusterID(resource)
@@ -154,6 +171,21 @@ func (c *Client) StopInstance(ctx *gofr.Context, resource *models.Resource) erro
 	return err
 }
 
+// getLabels converts the tags of a DB instance to resource labels.
+func getLabels(tags []*rds.Tag) models.Labels {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	labels := make(models.Labels, len(tags))
+
+	for _, tag := range tags {
+		labels[awsStringValue(tag.Key)] = awsStringValue(tag.Value)
+	}
+
+	return labels
+}
+
 func awsStringValue(s *string) string {
 	if s == nil {
 		return ""
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zopdev/zopdev/api/resources/models"
+	gofrService "gofr.dev/pkg/gofr/http"
 )
 
 type mockRDS struct {
@@ -72,6 +73,7 @@ func Test_GetAllInstances(t *testing.T) {
 				InstanceCreateTime:   aws.Time(time.Now()),
 				DBInstanceStatus:     aws.String("available"),
 				Engine:               aws.String("mysql"),
+				TagList:              []*rds.Tag{{Key: aws.String("env"), Value: aws.String("staging")}},
 			},
 			{
 				DBInstanceIdentifier: aws.String("test-rds-2"),
@@ -92,6 +94,8 @@ func Test_GetAllInstances(t *testing.T) {
 	assert.Equal(t, "us-east-1a", instances[0].Region)
 	assert.Equal(t, RUNNING, instances[0].Status)
 	assert.Equal(t, STOPPED, instances[1].Status)
+	assert.Equal(t, models.Labels{"env": "staging"}, instances[0].Labels)
+	assert.Nil(t, instances[1].Labels)
 }
 
 func Test_GetAllInstances_Error(t *testing.T) {
@@ -102,6 +106,28 @@ func Test_GetAllInstances_Error(t *testing.T) {
 	require.Nil(t, instances)
 }
 
+func Test_GetInstanceStatus(t *testing.T) {
+	client := &Client{RDS: &mockRDS{dbInstances: []*rds.DBInstance{{DBInstanceIdentifier: aws.String("test-rds-1"),
+		DBInstanceStatus: aws.String("stopping")}}}}
+
+	status, err := client.GetInstanceStatus(nil, &models.Resource{Name: "test-rds-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, STOPPED, status)
+
+	client = &Client{RDS: &mockRDS{}}
+
+	_, err = client.GetInstanceStatus(nil, &models.Resource{Name: "test-rds-1"})
+
+	assert.Equal(t, gofrService.ErrorEntityNotFound{Name: "DB instance", Value: "test-rds-1"}, err)
+
+	client = &Client{RDS: &mockRDS{shouldErr: true}}
+
+	_, err = client.GetInstanceStatus(nil, &models.Resource{Name: "test-rds-1"})
+
+	assert.Equal(t, assert.AnError, err)
+}
+
 func Test_StartInstance(t *testing.T) {
 	cases := []struct {
 		name      string
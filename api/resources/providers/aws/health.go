@@ -0,0 +1,11 @@
+package aws
+
+import "context"
+
+// CheckHealth validates the credentials of a cloud account with a single authenticated call to STS, which needs no
+// permission. The role of the account is assumed again when its session expired.
+func (c *Client) CheckHealth(ctx context.Context, creds any) error {
+	_, err := c.GetAccountID(ctx, creds)
+
+	return err
+}
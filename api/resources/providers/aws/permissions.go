@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+const (
+	evalDecisionAllowed = "allowed"
+	errCodeAccessDenied = "AccessDenied"
+)
+
+// policySimulator simulates the policies of an IAM principal.
+type policySimulator interface {
+	SimulatePrincipalPolicyPagesWithContext(ctx aws.Context, input *iam.SimulatePrincipalPolicyInput,
+		fn func(*iam.SimulatePolicyResponse, bool) bool, opts ...request.Option) error
+}
+
+// TestPermissions returns which of the given IAM actions the identity of the credentials is allowed, as simulated by
+// IAM against its policies. Reading the identity from STS also validates the credentials. None of the actions is
+// in the result when the identity cannot simulate its own policies.
+func (c *Client) TestPermissions(ctx context.Context, creds any, actions []string) (map[string]bool, error) {
+	awsCreds, err := getAWSCredentials(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := c.newSession(awsCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	principal, isRoot := principalARN(aws.StringValue(identity.Arn))
+	if isRoot {
+		// The root user is allowed every action, its policies cannot be simulated.
+		granted := make(map[string]bool, len(actions))
+
+		for _, action := range actions {
+			granted[action] = true
+		}
+
+		return granted, nil
+	}
+
+	return simulatePrincipalPolicy(ctx, iam.New(sess), principal, actions)
+}
+
+// principalARN returns the IAM ARN whose policies apply to the caller, i.e. the role of an assumed role session,
+// and whether the caller is the root user of the account.
+func principalARN(callerARN string) (arn string, isRoot bool) {
+	if strings.HasSuffix(callerARN, ":root") {
+		return callerARN, true
+	}
+
+	// arn:aws:sts::123456789012:assumed-role/role-name/session-name
+	prefix, resource, found := strings.Cut(callerARN, ":assumed-role/")
+	if !found {
+		return callerARN, false
+	}
+
+	role, _, _ := strings.Cut(resource, "/")
+
+	return strings.Replace(prefix, ":sts:", ":iam:", 1) + ":role/" + role, false
+}
+
+func simulatePrincipalPolicy(ctx context.Context, simulator policySimulator, principal string,
+	actions []string) (map[string]bool, error) {
+	granted := make(map[string]bool, len(actions))
+
+	err := simulator.SimulatePrincipalPolicyPagesWithContext(ctx, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     aws.StringSlice(actions),
+	}, func(page *iam.SimulatePolicyResponse, _ bool) bool {
+		for _, res := range page.EvaluationResults {
+			granted[aws.StringValue(res.EvalActionName)] = aws.StringValue(res.EvalDecision) == evalDecisionAllowed
+		}
+
+		return true
+	})
+
+	var awsErr awserr.Error
+
+	// The principal cannot simulate its policies, or it is not an IAM entity, e.g. a role with a path.
+	if errors.As(err, &awsErr) && (awsErr.Code() == errCodeAccessDenied || awsErr.Code() == iam.ErrCodeNoSuchEntityException) {
+		return map[string]bool{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return granted, nil
+}
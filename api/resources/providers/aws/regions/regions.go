@@ -0,0 +1,112 @@
+// Package regions lists the resources of an AWS account in each of its regions. Only the regions the account has
+// not opted in to are skipped, any other error fails the listing, e.g. revoked credentials, so that the resources of
+// the account are not taken for deleted.
+package regions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// DefaultRegion is enabled for every account, its clients are used for the calls that are not regional.
+const DefaultRegion = "us-east-1"
+
+// optInNotOptedIn is the opt-in status of the regions the account has not opted in to.
+const optInNotOptedIn = "not-opted-in"
+
+// DescribeRegionsAPI defines the method used from the AWS EC2 client to find the regions of an account.
+type DescribeRegionsAPI interface {
+	DescribeRegionsWithContext(ctx aws.Context, input *ec2.DescribeRegionsInput,
+		opts ...request.Option) (*ec2.DescribeRegionsOutput, error)
+}
+
+// ErrUnknownRegion is returned when the client of a region is required but the client was not created for it.
+type ErrUnknownRegion struct {
+	Region string
+}
+
+func (e *ErrUnknownRegion) Error() string {
+	return fmt.Sprintf("unknown AWS region %q", e.Region)
+}
+
+// Enabled returns the regions the account can use, i.e. the regions enabled by default and the ones it opted in to.
+func Enabled(ctx context.Context, api DescribeRegionsAPI) (map[string]bool, error) {
+	out, err := api.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{AllRegions: aws.Bool(true)})
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(out.Regions))
+
+	for _, r := range out.Regions {
+		enabled[aws.StringValue(r.RegionName)] = aws.StringValue(r.OptInStatus) != optInNotOptedIn
+	}
+
+	return enabled, nil
+}
+
+// List calls list for every region of the clients enabled for the account, concurrently, and merges the results. The
+// regions are all listed when api is nil. An error of any region fails the whole listing.
+func List[C any](ctx context.Context, api DescribeRegionsAPI, clients map[string]C,
+	list func(region string, client C) ([]models.Resource, error)) ([]models.Resource, error) {
+	regions := make([]string, 0, len(clients))
+
+	var enabled map[string]bool
+
+	if api != nil {
+		var err error
+
+		enabled, err = Enabled(ctx, api)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for region := range clients {
+		if enabled == nil || enabled[region] {
+			regions = append(regions, region)
+		}
+	}
+
+	sort.Strings(regions)
+
+	type result struct {
+		resources []models.Resource
+		err       error
+	}
+
+	results := make([]result, len(regions))
+	done := make(chan struct{}, len(regions))
+
+	for i, region := range regions {
+		go func() {
+			res, err := list(region, clients[region])
+
+			results[i] = result{res, err}
+			done <- struct{}{}
+		}()
+	}
+
+	for range regions {
+		<-done
+	}
+
+	all := make([]models.Resource, 0)
+
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		all = append(all, r.resources...)
+	}
+
+	return all, nil
+}
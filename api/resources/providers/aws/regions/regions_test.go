@@ -0,0 +1,88 @@
+package regions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+type mockRegions struct {
+	regions []*ec2.Region
+	err     error
+	input   *ec2.DescribeRegionsInput
+}
+
+func (m *mockRegions) DescribeRegionsWithContext(_ aws.Context, input *ec2.DescribeRegionsInput,
+	_ ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	m.input = input
+
+	return &ec2.DescribeRegionsOutput{Regions: m.regions}, m.err
+}
+
+func TestList(t *testing.T) {
+	clients := map[string]string{"us-east-1": "a", "eu-west-1": "b", "af-south-1": "c", "me-central-1": "d"}
+	api := &mockRegions{regions: []*ec2.Region{
+		{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("eu-west-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("af-south-1"), OptInStatus: aws.String("not-opted-in")},
+		{RegionName: aws.String("me-central-1"), OptInStatus: aws.String("opted-in")},
+	}}
+	list := func(region, client string) ([]models.Resource, error) {
+		return []models.Resource{{Name: client, Region: region}}, nil
+	}
+
+	res, err := List(context.Background(), api, clients, list)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{Name: "b", Region: "eu-west-1"}, {Name: "d", Region: "me-central-1"},
+		{Name: "a", Region: "us-east-1"}}, res)
+	assert.Equal(t, &ec2.DescribeRegionsInput{AllRegions: aws.Bool(true)}, api.input)
+
+	res, err = List(context.Background(), nil, clients, list)
+
+	require.NoError(t, err)
+	assert.Len(t, res, 4)
+}
+
+func TestList_Errors(t *testing.T) {
+	clients := map[string]string{"us-east-1": "a", "eu-west-1": "b"}
+	enabled := []*ec2.Region{
+		{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("eu-west-1"), OptInStatus: aws.String("opt-in-not-required")},
+	}
+	authErr := awserr.New("AuthFailure", "AWS was not able to validate the provided credentials", nil)
+
+	testCases := []struct {
+		name string
+		api  *mockRegions
+		list func(region, client string) ([]models.Resource, error)
+	}{
+		{name: "revoked credentials", api: &mockRegions{err: authErr},
+			list: func(string, string) ([]models.Resource, error) { return nil, nil }},
+		{name: "region rejecting the credentials", api: &mockRegions{regions: enabled},
+			list: func(region, _ string) ([]models.Resource, error) {
+				if region == "eu-west-1" {
+					return nil, awserr.New("UnrecognizedClientException", "the security token included in the request is invalid", nil)
+				}
+
+				return []models.Resource{{Region: region}}, nil
+			}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := List(context.Background(), tc.api, clients, tc.list)
+
+			assert.Nil(t, res)
+			require.Error(t, err)
+		})
+	}
+}
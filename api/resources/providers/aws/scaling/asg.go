@@ -0,0 +1,161 @@
+package scaling
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// eksNodeGroupTag is set by EKS on the Auto Scaling groups backing a managed node group, such groups are
+// listed as node groups as EKS reverts any change made to them directly.
+const eksNodeGroupTag = "eks:nodegroup-name"
+
+// GetAllAutoScalingGroups returns the Auto Scaling groups of all the regions, except the ones managed by EKS.
+func (c *Client) GetAllAutoScalingGroups(ctx *gofr.Context) ([]models.Resource, error) {
+	return c.listRegions(ctx, func(region string, rc *RegionalClient) ([]models.Resource, error) {
+		groups := make([]models.Resource, 0)
+
+		err := rc.AutoScaling.DescribeAutoScalingGroupsPagesWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{},
+			func(out *autoscaling.DescribeAutoScalingGroupsOutput, _ bool) bool {
+				for _, g := range out.AutoScalingGroups {
+					labels := getASGLabels(g.Tags)
+					if _, ok := labels[eksNodeGroupTag]; ok {
+						continue
+					}
+
+					groups = append(groups, toASGResource(region, g, labels))
+				}
+
+				return true
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		return groups, nil
+	})
+}
+
+func toASGResource(region string, g *autoscaling.Group, labels models.Labels) models.Resource {
+	c := capacity{
+		DesiredCapacity: aws.Int64Value(g.DesiredCapacity),
+		MinSize:         aws.Int64Value(g.MinSize),
+		MaxSize:         aws.Int64Value(g.MaxSize),
+	}
+
+	return models.Resource{
+		Name:         awsStringValue(g.AutoScalingGroupName),
+		Type:         AutoScalingGroup,
+		UID:          awsStringValue(g.AutoScalingGroupARN),
+		Region:       region,
+		CreationTime: aws.TimeValue(g.CreatedTime).Format(time.RFC3339),
+		Status:       getState(c.DesiredCapacity),
+		Settings:     c.toSettings(),
+		Labels:       labels,
+	}
+}
+
+// getASGLabels converts the tags of an Auto Scaling group to resource labels.
+func getASGLabels(tags []*autoscaling.TagDescription) models.Labels {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	labels := make(models.Labels, len(tags))
+
+	for _, tag := range tags {
+		labels[awsStringValue(tag.Key)] = awsStringValue(tag.Value)
+	}
+
+	return labels
+}
+
+// describeAutoScalingGroup returns the Auto Scaling group of the resource.
+func describeAutoScalingGroup(ctx *gofr.Context, rc *RegionalClient, res *models.Resource) (*autoscaling.Group, error) {
+	var group *autoscaling.Group
+
+	err := rc.AutoScaling.DescribeAutoScalingGroupsPagesWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(res.Name)},
+	}, func(out *autoscaling.DescribeAutoScalingGroupsOutput, _ bool) bool {
+		if len(out.AutoScalingGroups) > 0 {
+			group = out.AutoScalingGroups[0]
+		}
+
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
+		return nil, gofrHttp.ErrorEntityNotFound{Name: "auto scaling group", Value: res.Name}
+	}
+
+	return group, nil
+}
+
+func (c *Client) autoScalingGroupStatus(ctx *gofr.Context, res *models.Resource) (string, error) {
+	rc, err := c.getRegion(res.Region)
+	if err != nil {
+		return "", err
+	}
+
+	group, err := describeAutoScalingGroup(ctx, rc, res)
+	if err != nil {
+		return "", err
+	}
+
+	return getState(aws.Int64Value(group.DesiredCapacity)), nil
+}
+
+func (c *Client) suspendAutoScalingGroup(ctx *gofr.Context, res *models.Resource) (models.Settings, error) {
+	rc, err := c.getRegion(res.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := describeAutoScalingGroup(ctx, rc, res)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := capacity{
+		DesiredCapacity: aws.Int64Value(group.DesiredCapacity),
+		MinSize:         aws.Int64Value(group.MinSize),
+		MaxSize:         aws.Int64Value(group.MaxSize),
+	}
+
+	// The maximum size is set to zero as well, so that the scaling policies can not scale the group back up.
+	_, err = rc.AutoScaling.UpdateAutoScalingGroupWithContext(ctx, &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(res.Name),
+		DesiredCapacity:      aws.Int64(0),
+		MinSize:              aws.Int64(0),
+		MaxSize:              aws.Int64(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prev.toSettings(), nil
+}
+
+func (c *Client) startAutoScalingGroup(ctx *gofr.Context, res *models.Resource, size capacity) error {
+	rc, err := c.getRegion(res.Region)
+	if err != nil {
+		return err
+	}
+
+	_, err = rc.AutoScaling.UpdateAutoScalingGroupWithContext(ctx, &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(res.Name),
+		DesiredCapacity:      aws.Int64(size.DesiredCapacity),
+		MinSize:              aws.Int64(size.MinSize),
+		MaxSize:              aws.Int64(size.MaxSize),
+	})
+
+	return err
+}
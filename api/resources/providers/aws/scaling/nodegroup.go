@@ -0,0 +1,188 @@
+package scaling
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// GetAllNodeGroups returns the managed node groups of the EKS clusters of all the regions.
+func (c *Client) GetAllNodeGroups(ctx *gofr.Context) ([]models.Resource, error) {
+	return c.listRegions(ctx, func(region string, rc *RegionalClient) ([]models.Resource, error) {
+		var clusters []*string
+
+		err := rc.EKS.ListClustersPagesWithContext(ctx, &eks.ListClustersInput{}, func(out *eks.ListClustersOutput, _ bool) bool {
+			clusters = append(clusters, out.Clusters...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		groups := make([]models.Resource, 0)
+
+		for _, cluster := range clusters {
+			var names []*string
+
+			err = rc.EKS.ListNodegroupsPagesWithContext(ctx, &eks.ListNodegroupsInput{ClusterName: cluster},
+				func(out *eks.ListNodegroupsOutput, _ bool) bool {
+					names = append(names, out.Nodegroups...)
+					return true
+				})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, name := range names {
+				out, err := rc.EKS.DescribeNodegroupWithContext(ctx, &eks.DescribeNodegroupInput{
+					ClusterName: cluster, NodegroupName: name,
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				groups = append(groups, toNodeGroupResource(region, out.Nodegroup))
+			}
+		}
+
+		return groups, nil
+	})
+}
+
+func toNodeGroupResource(region string, ng *eks.Nodegroup) models.Resource {
+	c := getNodeGroupCapacity(ng)
+
+	settings := c.toSettings()
+	settings["cluster"] = awsStringValue(ng.ClusterName)
+	settings["instance_types"] = aws.StringValueSlice(ng.InstanceTypes)
+	settings["capacity_type"] = awsStringValue(ng.CapacityType)
+
+	return models.Resource{
+		Name:         awsStringValue(ng.NodegroupName),
+		Type:         NodeGroup,
+		UID:          awsStringValue(ng.NodegroupArn),
+		Region:       region,
+		CreationTime: aws.TimeValue(ng.CreatedAt).Format(time.RFC3339),
+		Status:       getState(c.DesiredCapacity),
+		Settings:     settings,
+		Labels:       getNodeGroupLabels(ng.Tags),
+	}
+}
+
+func getNodeGroupCapacity(ng *eks.Nodegroup) capacity {
+	if ng.ScalingConfig == nil {
+		return capacity{}
+	}
+
+	return capacity{
+		DesiredCapacity: aws.Int64Value(ng.ScalingConfig.DesiredSize),
+		MinSize:         aws.Int64Value(ng.ScalingConfig.MinSize),
+		MaxSize:         aws.Int64Value(ng.ScalingConfig.MaxSize),
+	}
+}
+
+// getNodeGroupLabels converts the tags of a node group to resource labels.
+func getNodeGroupLabels(tags map[string]*string) models.Labels {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	labels := make(models.Labels, len(tags))
+
+	for k, v := range tags {
+		labels[k] = awsStringValue(v)
+	}
+
+	return labels
+}
+
+// describeNodeGroup returns the node group of the resource, along with the name of its cluster.
+func describeNodeGroup(ctx *gofr.Context, rc *RegionalClient, res *models.Resource) (string, *eks.Nodegroup, error) {
+	cluster, ok := res.Settings["cluster"].(string)
+	if !ok {
+		return "", nil, gofrHttp.ErrorInvalidParam{Params: []string{"resource.Settings.cluster"}}
+	}
+
+	out, err := rc.EKS.DescribeNodegroupWithContext(ctx, &eks.DescribeNodegroupInput{
+		ClusterName: aws.String(cluster), NodegroupName: aws.String(res.Name),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return cluster, out.Nodegroup, nil
+}
+
+func (c *Client) nodeGroupStatus(ctx *gofr.Context, res *models.Resource) (string, error) {
+	rc, err := c.getRegion(res.Region)
+	if err != nil {
+		return "", err
+	}
+
+	_, ng, err := describeNodeGroup(ctx, rc, res)
+	if err != nil {
+		return "", err
+	}
+
+	return getState(getNodeGroupCapacity(ng).DesiredCapacity), nil
+}
+
+func (c *Client) suspendNodeGroup(ctx *gofr.Context, res *models.Resource) (models.Settings, error) {
+	rc, err := c.getRegion(res.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, ng, err := describeNodeGroup(ctx, rc, res)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := getNodeGroupCapacity(ng)
+
+	// EKS requires the maximum size of a node group to be at least one, hence only the minimum and desired
+	// sizes are set to zero.
+	_, err = rc.EKS.UpdateNodegroupConfigWithContext(ctx, &eks.UpdateNodegroupConfigInput{
+		ClusterName:   aws.String(cluster),
+		NodegroupName: aws.String(res.Name),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(0),
+			MinSize:     aws.Int64(0),
+			MaxSize:     aws.Int64(max(prev.MaxSize, 1)),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prev.toSettings(), nil
+}
+
+func (c *Client) startNodeGroup(ctx *gofr.Context, res *models.Resource, size capacity) error {
+	rc, err := c.getRegion(res.Region)
+	if err != nil {
+		return err
+	}
+
+	cluster, ok := res.Settings["cluster"].(string)
+	if !ok {
+		return gofrHttp.ErrorInvalidParam{Params: []string{"resource.Settings.cluster"}}
+	}
+
+	_, err = rc.EKS.UpdateNodegroupConfigWithContext(ctx, &eks.UpdateNodegroupConfigInput{
+		ClusterName:   aws.String(cluster),
+		NodegroupName: aws.String(res.Name),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(size.DesiredCapacity),
+			MinSize:     aws.Int64(size.MinSize),
+			MaxSize:     aws.Int64(size.MaxSize),
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,178 @@
+// Package scaling discovers the EKS managed node groups and the Auto Scaling groups of an AWS account and
+// pauses them by scaling them to zero, as stopping their instances only makes the Auto Scaling group replace them.
+package scaling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/regions"
+)
+
+const (
+	// RUNNING group state for zopdev, the group has a desired capacity of at least one instance.
+	RUNNING = "RUNNING"
+	// STOPPED group state for zopdev, the group is scaled to zero.
+	STOPPED = "STOPPED"
+
+	// AutoScalingGroup is the resource type of the Auto Scaling groups.
+	AutoScalingGroup = "ASG"
+	// NodeGroup is the resource type of the EKS managed node groups.
+	NodeGroup = "EKS_NODE_GROUP"
+)
+
+// AutoScalingAPI defines the methods used from the AWS Auto Scaling client for easier testing/mocking.
+type AutoScalingAPI interface {
+	DescribeAutoScalingGroupsPagesWithContext(ctx aws.Context, input *autoscaling.DescribeAutoScalingGroupsInput,
+		fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool, opts ...request.Option) error
+	UpdateAutoScalingGroupWithContext(ctx aws.Context, input *autoscaling.UpdateAutoScalingGroupInput,
+		opts ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error)
+}
+
+// EKSAPI defines the methods used from the AWS EKS client for easier testing/mocking.
+type EKSAPI interface {
+	ListClustersPagesWithContext(ctx aws.Context, input *eks.ListClustersInput, fn func(*eks.ListClustersOutput, bool) bool,
+		opts ...request.Option) error
+	ListNodegroupsPagesWithContext(ctx aws.Context, input *eks.ListNodegroupsInput,
+		fn func(*eks.ListNodegroupsOutput, bool) bool, opts ...request.Option) error
+	DescribeNodegroupWithContext(ctx aws.Context, input *eks.DescribeNodegroupInput,
+		opts ...request.Option) (*eks.DescribeNodegroupOutput, error)
+	UpdateNodegroupConfigWithContext(ctx aws.Context, input *eks.UpdateNodegroupConfigInput,
+		opts ...request.Option) (*eks.UpdateNodegroupConfigOutput, error)
+}
+
+// RegionalClient holds the AWS clients of a single region, as Auto Scaling groups and EKS clusters are regional.
+type RegionalClient struct {
+	AutoScaling AutoScalingAPI
+	EKS         EKSAPI
+}
+
+type Client struct {
+	// Regions maps the AWS regions to their clients.
+	Regions map[string]*RegionalClient
+	// RegionsAPI finds the regions enabled for the account, all the regions are listed without it.
+	RegionsAPI regions.DescribeRegionsAPI
+}
+
+// capacity is the size of a group before it was suspended, it is stored in the settings of the resource
+// so that the group can be scaled back on start.
+type capacity struct {
+	DesiredCapacity int64 `json:"desired_capacity"`
+	MinSize         int64 `json:"min_size"`
+	MaxSize         int64 `json:"max_size"`
+}
+
+func (c *capacity) toSettings() models.Settings {
+	return models.Settings{"desired_capacity": c.DesiredCapacity, "min_size": c.MinSize, "max_size": c.MaxSize}
+}
+
+// restored returns the capacity a group is scaled back to on start, a group without a recorded capacity
+// is scaled to a single instance.
+func (c *capacity) restored() capacity {
+	r := *c
+
+	r.MaxSize = max(r.MaxSize, 1)
+	if r.DesiredCapacity == 0 {
+		r.DesiredCapacity = min(max(r.MinSize, 1), r.MaxSize)
+	}
+
+	return r
+}
+
+func getCapacity(previous models.Settings) *capacity {
+	var c capacity
+
+	// The settings read back from the store hold the numbers as float64, hence they are decoded through JSON.
+	b, err := json.Marshal(previous)
+	if err != nil {
+		return &c
+	}
+
+	_ = json.Unmarshal(b, &c)
+
+	return &c
+}
+
+func getState(desired int64) string {
+	if desired > 0 {
+		return RUNNING
+	}
+
+	return STOPPED
+}
+
+func (c *Client) getRegion(region string) (*RegionalClient, error) {
+	rc, ok := c.Regions[region]
+	if !ok {
+		return nil, &regions.ErrUnknownRegion{Region: region}
+	}
+
+	return rc, nil
+}
+
+// listRegions calls list for every region of the client enabled for the account and merges the results.
+func (c *Client) listRegions(ctx context.Context, list func(region string, rc *RegionalClient) ([]models.Resource, error)) (
+	[]models.Resource, error) {
+	return regions.List(ctx, c.RegionsAPI, c.Regions, list)
+}
+
+// Status returns the status of the group, given its desired capacity.
+func (c *Client) Status(ctx *gofr.Context, res *models.Resource) (string, error) {
+	switch res.Type {
+	case AutoScalingGroup:
+		return c.autoScalingGroupStatus(ctx, res)
+	case NodeGroup:
+		return c.nodeGroupStatus(ctx, res)
+	default:
+		return "", &ErrUnknownType{Type: res.Type}
+	}
+}
+
+// Suspend scales the group to zero and returns its capacity before it was suspended.
+func (c *Client) Suspend(ctx *gofr.Context, res *models.Resource) (models.Settings, error) {
+	switch res.Type {
+	case AutoScalingGroup:
+		return c.suspendAutoScalingGroup(ctx, res)
+	case NodeGroup:
+		return c.suspendNodeGroup(ctx, res)
+	default:
+		return nil, &ErrUnknownType{Type: res.Type}
+	}
+}
+
+// Start scales the group back to the capacity recorded when it was suspended.
+func (c *Client) Start(ctx *gofr.Context, res *models.Resource, previous models.Settings) error {
+	switch res.Type {
+	case AutoScalingGroup:
+		return c.startAutoScalingGroup(ctx, res, getCapacity(previous).restored())
+	case NodeGroup:
+		return c.startNodeGroup(ctx, res, getCapacity(previous).restored())
+	default:
+		return &ErrUnknownType{Type: res.Type}
+	}
+}
+
+// ErrUnknownType is returned when the resource is neither an Auto Scaling group nor an EKS node group.
+type ErrUnknownType struct {
+	Type string
+}
+
+func (e *ErrUnknownType) Error() string {
+	return fmt.Sprintf("resource type %q can not be scaled", e.Type)
+}
+
+func awsStringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
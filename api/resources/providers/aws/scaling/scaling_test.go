@@ -0,0 +1,309 @@
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/regions"
+)
+
+type mockAutoScaling struct {
+	groups  []*autoscaling.Group
+	err     error
+	updates []*autoscaling.UpdateAutoScalingGroupInput
+}
+
+func (m *mockAutoScaling) DescribeAutoScalingGroupsPagesWithContext(_ aws.Context, _ *autoscaling.DescribeAutoScalingGroupsInput,
+	fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool, _ ...request.Option) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	fn(&autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: m.groups}, true)
+
+	return nil
+}
+
+func (m *mockAutoScaling) UpdateAutoScalingGroupWithContext(_ aws.Context, input *autoscaling.UpdateAutoScalingGroupInput,
+	_ ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+	m.updates = append(m.updates, input)
+
+	return &autoscaling.UpdateAutoScalingGroupOutput{}, m.err
+}
+
+type mockRegions struct {
+	regions []*ec2.Region
+	err     error
+}
+
+func (m *mockRegions) DescribeRegionsWithContext(_ aws.Context, _ *ec2.DescribeRegionsInput,
+	_ ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{Regions: m.regions}, m.err
+}
+
+type mockEKS struct {
+	nodeGroups map[string][]*eks.Nodegroup
+	err        error
+	updates    []*eks.UpdateNodegroupConfigInput
+}
+
+func (m *mockEKS) ListClustersPagesWithContext(_ aws.Context, _ *eks.ListClustersInput, fn func(*eks.ListClustersOutput, bool) bool,
+	_ ...request.Option) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	out := &eks.ListClustersOutput{}
+
+	for cluster := range m.nodeGroups {
+		out.Clusters = append(out.Clusters, aws.String(cluster))
+	}
+
+	fn(out, true)
+
+	return nil
+}
+
+func (m *mockEKS) ListNodegroupsPagesWithContext(_ aws.Context, input *eks.ListNodegroupsInput,
+	fn func(*eks.ListNodegroupsOutput, bool) bool, _ ...request.Option) error {
+	out := &eks.ListNodegroupsOutput{}
+
+	for _, ng := range m.nodeGroups[aws.StringValue(input.ClusterName)] {
+		out.Nodegroups = append(out.Nodegroups, ng.NodegroupName)
+	}
+
+	fn(out, true)
+
+	return nil
+}
+
+func (m *mockEKS) DescribeNodegroupWithContext(_ aws.Context, input *eks.DescribeNodegroupInput,
+	_ ...request.Option) (*eks.DescribeNodegroupOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	for _, ng := range m.nodeGroups[aws.StringValue(input.ClusterName)] {
+		if aws.StringValue(ng.NodegroupName) == aws.StringValue(input.NodegroupName) {
+			return &eks.DescribeNodegroupOutput{Nodegroup: ng}, nil
+		}
+	}
+
+	return nil, awserr.New(eks.ErrCodeResourceNotFoundException, "node group not found", nil)
+}
+
+func (m *mockEKS) UpdateNodegroupConfigWithContext(_ aws.Context, input *eks.UpdateNodegroupConfigInput,
+	_ ...request.Option) (*eks.UpdateNodegroupConfigOutput, error) {
+	m.updates = append(m.updates, input)
+
+	return &eks.UpdateNodegroupConfigOutput{}, m.err
+}
+
+func TestClient_GetAllAutoScalingGroups(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	asg := &mockAutoScaling{groups: []*autoscaling.Group{
+		{AutoScalingGroupName: aws.String("workers"), AutoScalingGroupARN: aws.String("arn:asg/workers"),
+			CreatedTime: &created, DesiredCapacity: aws.Int64(2), MinSize: aws.Int64(1), MaxSize: aws.Int64(4),
+			Instances: []*autoscaling.Instance{{}, {}},
+			Tags:      []*autoscaling.TagDescription{{Key: aws.String("env"), Value: aws.String("dev")}}},
+		{AutoScalingGroupName: aws.String("eks-pool"), AutoScalingGroupARN: aws.String("arn:asg/eks-pool"),
+			CreatedTime: &created, DesiredCapacity: aws.Int64(1), MinSize: aws.Int64(1), MaxSize: aws.Int64(1),
+			Tags: []*autoscaling.TagDescription{{Key: aws.String(eksNodeGroupTag), Value: aws.String("pool")}}},
+		{AutoScalingGroupName: aws.String("idle"), AutoScalingGroupARN: aws.String("arn:asg/idle"),
+			CreatedTime: &created, DesiredCapacity: aws.Int64(0), MinSize: aws.Int64(0), MaxSize: aws.Int64(0)},
+	}}
+	// The account did not opt in to af-south-1, which rejects the credentials.
+	c := &Client{Regions: map[string]*RegionalClient{
+		"us-east-1": {AutoScaling: asg},
+		"af-south-1": {AutoScaling: &mockAutoScaling{
+			err: awserr.New("InvalidClientTokenId", "the security token included in the request is invalid", nil)}},
+	}, RegionsAPI: &mockRegions{regions: []*ec2.Region{
+		{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("af-south-1"), OptInStatus: aws.String("not-opted-in")},
+	}}}
+
+	expected := []models.Resource{
+		{Name: "workers", Type: AutoScalingGroup, UID: "arn:asg/workers", Region: "us-east-1",
+			CreationTime: "2025-01-01T00:00:00Z", Status: RUNNING, Labels: models.Labels{"env": "dev"},
+			Settings: models.Settings{"desired_capacity": int64(2), "min_size": int64(1), "max_size": int64(4)}},
+		{Name: "idle", Type: AutoScalingGroup, UID: "arn:asg/idle", Region: "us-east-1",
+			CreationTime: "2025-01-01T00:00:00Z", Status: STOPPED,
+			Settings: models.Settings{"desired_capacity": int64(0), "min_size": int64(0), "max_size": int64(0)}},
+	}
+
+	groups, err := c.GetAllAutoScalingGroups(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, groups)
+
+	c.Regions["us-east-1"].AutoScaling = &mockAutoScaling{err: awserr.New("Throttling", "rate exceeded", nil)}
+
+	groups, err = c.GetAllAutoScalingGroups(ctx)
+
+	assert.Nil(t, groups)
+	require.Error(t, err)
+
+	// Revoked credentials fail the listing rather than the groups being taken for deleted.
+	c.RegionsAPI = &mockRegions{err: awserr.New("AuthFailure", "AWS was not able to validate the provided credentials", nil)}
+
+	groups, err = c.GetAllAutoScalingGroups(ctx)
+
+	assert.Nil(t, groups)
+	require.Error(t, err)
+}
+
+func TestClient_GetAllNodeGroups(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mEKS := &mockEKS{nodeGroups: map[string][]*eks.Nodegroup{
+		"dev": {{NodegroupName: aws.String("pool"), NodegroupArn: aws.String("arn:ng/pool"), ClusterName: aws.String("dev"),
+			CreatedAt: &created, InstanceTypes: aws.StringSlice([]string{"t3.large"}), CapacityType: aws.String("SPOT"),
+			ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MinSize: aws.Int64(1), MaxSize: aws.Int64(5)},
+			Tags:          map[string]*string{"team": aws.String("payments")}}},
+	}}
+	c := &Client{Regions: map[string]*RegionalClient{"eu-west-1": {EKS: mEKS}}}
+
+	expected := []models.Resource{
+		{Name: "pool", Type: NodeGroup, UID: "arn:ng/pool", Region: "eu-west-1", CreationTime: "2025-01-01T00:00:00Z",
+			Status: RUNNING, Labels: models.Labels{"team": "payments"},
+			Settings: models.Settings{"desired_capacity": int64(3), "min_size": int64(1), "max_size": int64(5),
+				"cluster": "dev", "instance_types": []string{"t3.large"}, "capacity_type": "SPOT"}},
+	}
+
+	groups, err := c.GetAllNodeGroups(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, groups)
+}
+
+func TestClient_SuspendAndStart_AutoScalingGroup(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	asg := &mockAutoScaling{groups: []*autoscaling.Group{{AutoScalingGroupName: aws.String("workers"),
+		DesiredCapacity: aws.Int64(2), MinSize: aws.Int64(1), MaxSize: aws.Int64(4)}}}
+	c := &Client{Regions: map[string]*RegionalClient{"us-east-1": {AutoScaling: asg}}}
+	res := &models.Resource{Name: "workers", Type: AutoScalingGroup, Region: "us-east-1"}
+
+	prev, err := c.Suspend(ctx, res)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.Settings{"desired_capacity": int64(2), "min_size": int64(1), "max_size": int64(4)}, prev)
+	assert.Equal(t, &autoscaling.UpdateAutoScalingGroupInput{AutoScalingGroupName: aws.String("workers"),
+		DesiredCapacity: aws.Int64(0), MinSize: aws.Int64(0), MaxSize: aws.Int64(0)}, asg.updates[0])
+
+	// The previous capacity is read back from the store with the numbers decoded as float64.
+	err = c.Start(ctx, res, models.Settings{"desired_capacity": float64(2), "min_size": float64(1), "max_size": float64(4)})
+
+	require.NoError(t, err)
+	assert.Equal(t, &autoscaling.UpdateAutoScalingGroupInput{AutoScalingGroupName: aws.String("workers"),
+		DesiredCapacity: aws.Int64(2), MinSize: aws.Int64(1), MaxSize: aws.Int64(4)}, asg.updates[1])
+
+	err = c.Start(ctx, res, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, &autoscaling.UpdateAutoScalingGroupInput{AutoScalingGroupName: aws.String("workers"),
+		DesiredCapacity: aws.Int64(1), MinSize: aws.Int64(0), MaxSize: aws.Int64(1)}, asg.updates[2])
+}
+
+func TestClient_SuspendAndStart_NodeGroup(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	mEKS := &mockEKS{nodeGroups: map[string][]*eks.Nodegroup{
+		"dev": {{NodegroupName: aws.String("pool"), ClusterName: aws.String("dev"),
+			ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MinSize: aws.Int64(1), MaxSize: aws.Int64(5)}}},
+	}}
+	c := &Client{Regions: map[string]*RegionalClient{"eu-west-1": {EKS: mEKS}}}
+	res := &models.Resource{Name: "pool", Type: NodeGroup, Region: "eu-west-1", Settings: models.Settings{"cluster": "dev"}}
+
+	prev, err := c.Suspend(ctx, res)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.Settings{"desired_capacity": int64(3), "min_size": int64(1), "max_size": int64(5)}, prev)
+	assert.Equal(t, &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(0), MinSize: aws.Int64(0), MaxSize: aws.Int64(5)},
+		mEKS.updates[0].ScalingConfig)
+
+	err = c.Start(ctx, res, prev)
+
+	require.NoError(t, err)
+	assert.Equal(t, &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MinSize: aws.Int64(1), MaxSize: aws.Int64(5)},
+		mEKS.updates[1].ScalingConfig)
+}
+
+func TestClient_Suspend_Errors(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := &Client{Regions: map[string]*RegionalClient{"eu-west-1": {EKS: &mockEKS{}, AutoScaling: &mockAutoScaling{}}}}
+
+	testCases := []struct {
+		name   string
+		res    *models.Resource
+		expErr error
+	}{
+		{name: "unknown region", res: &models.Resource{Type: NodeGroup, Region: "us-east-1"},
+			expErr: &regions.ErrUnknownRegion{Region: "us-east-1"}},
+		{name: "unknown type", res: &models.Resource{Type: "EC2", Region: "eu-west-1"},
+			expErr: &ErrUnknownType{Type: "EC2"}},
+		{name: "node group without cluster", res: &models.Resource{Name: "pool", Type: NodeGroup, Region: "eu-west-1"},
+			expErr: gofrHttp.ErrorInvalidParam{Params: []string{"resource.Settings.cluster"}}},
+		{name: "auto scaling group not found", res: &models.Resource{Name: "workers", Type: AutoScalingGroup, Region: "eu-west-1"},
+			expErr: gofrHttp.ErrorEntityNotFound{Name: "auto scaling group", Value: "workers"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prev, err := c.Suspend(ctx, tc.res)
+
+			assert.Nil(t, prev)
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestClient_Status(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := &Client{Regions: map[string]*RegionalClient{"eu-west-1": {
+		AutoScaling: &mockAutoScaling{groups: []*autoscaling.Group{{AutoScalingGroupName: aws.String("workers"),
+			DesiredCapacity: aws.Int64(2)}}},
+		EKS: &mockEKS{nodeGroups: map[string][]*eks.Nodegroup{
+			"dev": {{NodegroupName: aws.String("pool"), ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(0)}}},
+		}},
+	}}}
+
+	testCases := []struct {
+		name      string
+		res       *models.Resource
+		expStatus string
+		expErr    error
+	}{
+		{name: "running auto scaling group", res: &models.Resource{Name: "workers", Type: AutoScalingGroup, Region: "eu-west-1"},
+			expStatus: RUNNING},
+		{name: "stopped node group", res: &models.Resource{Name: "pool", Type: NodeGroup, Region: "eu-west-1",
+			Settings: models.Settings{"cluster": "dev"}}, expStatus: STOPPED},
+		{name: "unknown region", res: &models.Resource{Type: AutoScalingGroup, Region: "us-east-1"},
+			expErr: &regions.ErrUnknownRegion{Region: "us-east-1"}},
+		{name: "unknown type", res: &models.Resource{Type: "EC2", Region: "eu-west-1"},
+			expErr: &ErrUnknownType{Type: "EC2"}},
+		{name: "node group without cluster", res: &models.Resource{Name: "pool", Type: NodeGroup, Region: "eu-west-1"},
+			expErr: gofrHttp.ErrorInvalidParam{Params: []string{"resource.Settings.cluster"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, err := c.Status(ctx, tc.res)
+
+			assert.Equal(t, tc.expStatus, status)
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
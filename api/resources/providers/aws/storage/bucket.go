@@ -0,0 +1,340 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/regions"
+)
+
+const (
+	// Error codes returned by S3 when a bucket has no such configuration.
+
+	noSuchPublicAccessBlock = "NoSuchPublicAccessBlockConfiguration"
+	noSuchBucketPolicy      = "NoSuchBucketPolicy"
+	noSuchLifecycle         = "NoSuchLifecycleConfiguration"
+	noSuchTagSet            = "NoSuchTagSet"
+	// accessDenied is returned when the bucket policy denies reading a configuration, it is left out of the settings.
+	accessDenied = "AccessDenied"
+
+	// bucketSizeMetric is written once a day by S3, hence the size is read from the last two days.
+	bucketSizeMetric = "BucketSizeBytes"
+	bucketSizeWindow = 48 * time.Hour
+	bucketSizePeriod = 24 * 60 * 60
+	s3Namespace      = "AWS/S3"
+)
+
+// publicAccessBlock is the public access block configuration of a bucket.
+type publicAccessBlock struct {
+	BlockPublicAcls       bool `json:"block_public_acls"`
+	IgnorePublicAcls      bool `json:"ignore_public_acls"`
+	BlockPublicPolicy     bool `json:"block_public_policy"`
+	RestrictPublicBuckets bool `json:"restrict_public_buckets"`
+}
+
+// lifecycleRule is a lifecycle rule of a bucket, i.e. the objects matching the prefix expire
+// or are moved to another storage class.
+type lifecycleRule struct {
+	ID                       string       `json:"id,omitempty"`
+	Status                   string       `json:"status"`
+	Prefix                   string       `json:"prefix,omitempty"`
+	ExpirationDays           int64        `json:"expiration_days,omitempty"`
+	NoncurrentExpirationDays int64        `json:"noncurrent_expiration_days,omitempty"`
+	Transitions              []transition `json:"transitions,omitempty"`
+}
+
+type transition struct {
+	Days         int64  `json:"days"`
+	StorageClass string `json:"storage_class"`
+}
+
+// bucketSizes maps the buckets of a region to their size in bytes by storage type, e.g. StandardStorage.
+type bucketSizes map[string]map[string]int64
+
+// GetAllBuckets returns the S3 buckets of the account along with their configuration. The configuration is read
+// from the region of the bucket, the buckets of the regions the client was not created for only hold their region.
+func (c *Client) GetAllBuckets(ctx *gofr.Context) ([]models.Resource, error) {
+	home, ok := c.Regions[DefaultRegion]
+	if !ok {
+		return nil, &regions.ErrUnknownRegion{Region: DefaultRegion}
+	}
+
+	out, err := home.S3.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]models.Resource, 0, len(out.Buckets))
+	// The sizes of the buckets are read once per region, as they are listed from the CloudWatch metrics of the region.
+	sizes := make(map[string]bucketSizes)
+
+	for _, b := range out.Buckets {
+		res, err := c.getBucket(ctx, home, b, sizes)
+		if err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, *res)
+	}
+
+	return buckets, nil
+}
+
+func (c *Client) getBucket(ctx *gofr.Context, home *RegionalClient, b *s3.Bucket,
+	sizes map[string]bucketSizes) (*models.Resource, error) {
+	name := aws.StringValue(b.Name)
+
+	loc, err := home.S3.GetBucketLocationWithContext(ctx, &s3.GetBucketLocationInput{Bucket: b.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	region := getBucketRegion(aws.StringValue(loc.LocationConstraint))
+	res := &models.Resource{
+		Name:         name,
+		Type:         Bucket,
+		UID:          "arn:aws:s3:::" + name,
+		Region:       region,
+		CreationTime: aws.TimeValue(b.CreationDate).Format(time.RFC3339),
+		Status:       AVAILABLE,
+		Settings:     models.Settings{},
+	}
+
+	rc, ok := c.Regions[region]
+	if !ok {
+		return res, nil
+	}
+
+	for _, get := range []func(*gofr.Context, S3API, *string, *models.Resource) error{
+		getPublicAccessBlock, getPolicyStatus, getLifecycleRules, getBucketTags,
+	} {
+		if err := get(ctx, rc.S3, b.Name, res); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := sizes[region]; !ok {
+		sizes[region] = getBucketSizes(ctx, rc.CloudWatch)
+	}
+
+	if bySize, ok := sizes[region][name]; ok {
+		var total int64
+
+		for _, size := range bySize {
+			total += size
+		}
+
+		res.Settings["size_bytes"] = total
+		res.Settings["storage_classes"] = bySize
+	}
+
+	return res, nil
+}
+
+// getBucketRegion returns the region of a bucket from its location constraint,
+// the buckets of us-east-1 have none and EU is the legacy name of eu-west-1.
+func getBucketRegion(constraint string) string {
+	switch constraint {
+	case "":
+		return DefaultRegion
+	case s3.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return constraint
+	}
+}
+
+func getPublicAccessBlock(ctx *gofr.Context, cl S3API, bucket *string, res *models.Resource) error {
+	out, err := cl.GetPublicAccessBlockWithContext(ctx, &s3.GetPublicAccessBlockInput{Bucket: bucket})
+
+	switch getErrorCode(err) {
+	case "":
+		if err != nil {
+			return err
+		}
+
+		cfg := out.PublicAccessBlockConfiguration
+		res.Settings["public_access_block"] = &publicAccessBlock{
+			BlockPublicAcls:       aws.BoolValue(cfg.BlockPublicAcls),
+			IgnorePublicAcls:      aws.BoolValue(cfg.IgnorePublicAcls),
+			BlockPublicPolicy:     aws.BoolValue(cfg.BlockPublicPolicy),
+			RestrictPublicBuckets: aws.BoolValue(cfg.RestrictPublicBuckets),
+		}
+	case noSuchPublicAccessBlock:
+		// Nothing is blocked when the bucket has no public access block.
+		res.Settings["public_access_block"] = &publicAccessBlock{}
+	case accessDenied:
+	default:
+		return err
+	}
+
+	return nil
+}
+
+func getPolicyStatus(ctx *gofr.Context, cl S3API, bucket *string, res *models.Resource) error {
+	out, err := cl.GetBucketPolicyStatusWithContext(ctx, &s3.GetBucketPolicyStatusInput{Bucket: bucket})
+
+	switch getErrorCode(err) {
+	case "":
+		if err != nil {
+			return err
+		}
+
+		res.Settings["public"] = out.PolicyStatus != nil && aws.BoolValue(out.PolicyStatus.IsPublic)
+	case noSuchBucketPolicy:
+		res.Settings["public"] = false
+	case accessDenied:
+	default:
+		return err
+	}
+
+	return nil
+}
+
+func getLifecycleRules(ctx *gofr.Context, cl S3API, bucket *string, res *models.Resource) error {
+	out, err := cl.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: bucket})
+
+	switch getErrorCode(err) {
+	case "":
+		if err != nil {
+			return err
+		}
+
+		rules := make([]lifecycleRule, 0, len(out.Rules))
+		for _, r := range out.Rules {
+			rules = append(rules, toLifecycleRule(r))
+		}
+
+		res.Settings["lifecycle_rules"] = rules
+	case noSuchLifecycle, accessDenied:
+	default:
+		return err
+	}
+
+	return nil
+}
+
+func toLifecycleRule(r *s3.LifecycleRule) lifecycleRule {
+	rule := lifecycleRule{ID: aws.StringValue(r.ID), Status: aws.StringValue(r.Status), Prefix: aws.StringValue(r.Prefix)}
+
+	if r.Filter != nil && r.Filter.Prefix != nil {
+		rule.Prefix = *r.Filter.Prefix
+	}
+
+	if r.Expiration != nil {
+		rule.ExpirationDays = aws.Int64Value(r.Expiration.Days)
+	}
+
+	if r.NoncurrentVersionExpiration != nil {
+		rule.NoncurrentExpirationDays = aws.Int64Value(r.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+
+	for _, t := range r.Transitions {
+		rule.Transitions = append(rule.Transitions, transition{Days: aws.Int64Value(t.Days),
+			StorageClass: aws.StringValue(t.StorageClass)})
+	}
+
+	return rule
+}
+
+func getBucketTags(ctx *gofr.Context, cl S3API, bucket *string, res *models.Resource) error {
+	out, err := cl.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{Bucket: bucket})
+
+	switch getErrorCode(err) {
+	case "":
+		if err != nil {
+			return err
+		}
+
+		if len(out.TagSet) == 0 {
+			return nil
+		}
+
+		res.Labels = make(models.Labels, len(out.TagSet))
+
+		for _, t := range out.TagSet {
+			res.Labels[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+	case noSuchTagSet, accessDenied:
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// getBucketSizes returns the size in bytes of the buckets of a region by storage type. The sizes are read from
+// CloudWatch on a best effort basis, the buckets are inventoried without their size when the metrics can not be read.
+func getBucketSizes(ctx *gofr.Context, cl CloudWatchAPI) bucketSizes {
+	var metrics []*cloudwatch.Metric
+
+	err := cl.ListMetricsPagesWithContext(ctx, &cloudwatch.ListMetricsInput{
+		Namespace:  aws.String(s3Namespace),
+		MetricName: aws.String(bucketSizeMetric),
+	}, func(out *cloudwatch.ListMetricsOutput, _ bool) bool {
+		metrics = append(metrics, out.Metrics...)
+		return true
+	})
+	if err != nil {
+		return nil
+	}
+
+	sizes := make(bucketSizes)
+	end := time.Now()
+
+	for _, m := range metrics {
+		out, err := cl.GetMetricStatisticsWithContext(ctx, &cloudwatch.GetMetricStatisticsInput{
+			Namespace:  m.Namespace,
+			MetricName: m.MetricName,
+			Dimensions: m.Dimensions,
+			StartTime:  aws.Time(end.Add(-bucketSizeWindow)),
+			EndTime:    aws.Time(end),
+			Period:     aws.Int64(bucketSizePeriod),
+			Statistics: []*string{aws.String(cloudwatch.StatisticAverage)},
+		})
+		if err != nil {
+			return nil
+		}
+
+		latest := getLatestDatapoint(out.Datapoints)
+		if latest == nil {
+			continue
+		}
+
+		bucket, storageType := getDimension(m.Dimensions, "BucketName"), getDimension(m.Dimensions, "StorageType")
+		if sizes[bucket] == nil {
+			sizes[bucket] = make(map[string]int64)
+		}
+
+		sizes[bucket][storageType] = int64(aws.Float64Value(latest.Average))
+	}
+
+	return sizes
+}
+
+func getLatestDatapoint(points []*cloudwatch.Datapoint) *cloudwatch.Datapoint {
+	var latest *cloudwatch.Datapoint
+
+	for _, p := range points {
+		if latest == nil || aws.TimeValue(p.Timestamp).After(aws.TimeValue(latest.Timestamp)) {
+			latest = p
+		}
+	}
+
+	return latest
+}
+
+func getDimension(dims []*cloudwatch.Dimension, name string) string {
+	for _, d := range dims {
+		if aws.StringValue(d.Name) == name {
+			return aws.StringValue(d.Value)
+		}
+	}
+
+	return ""
+}
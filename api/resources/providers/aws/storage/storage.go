@@ -0,0 +1,108 @@
+// Package storage discovers the S3 buckets and the EBS volumes of an AWS account.
+// They are inventoried only, their state is never changed by zopdev.
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/regions"
+)
+
+const (
+	// AVAILABLE storage state for zopdev, the bucket or volume exists and, for a volume, is not attached to any instance.
+	AVAILABLE = "AVAILABLE"
+	// INUSE storage state for zopdev, the volume is attached to at least one instance.
+	INUSE = "IN_USE"
+
+	// Bucket is the resource type of the S3 buckets.
+	Bucket = "S3_BUCKET"
+	// Volume is the resource type of the EBS volumes.
+	Volume = "EBS_VOLUME"
+
+	// DefaultRegion is the region whose client is used for the S3 calls that are not regional, e.g. listing the buckets.
+	DefaultRegion = regions.DefaultRegion
+)
+
+// S3API defines the methods used from the AWS S3 client for easier testing/mocking.
+type S3API interface {
+	ListBucketsWithContext(ctx aws.Context, input *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error)
+	GetBucketLocationWithContext(ctx aws.Context, input *s3.GetBucketLocationInput,
+		opts ...request.Option) (*s3.GetBucketLocationOutput, error)
+	GetPublicAccessBlockWithContext(ctx aws.Context, input *s3.GetPublicAccessBlockInput,
+		opts ...request.Option) (*s3.GetPublicAccessBlockOutput, error)
+	GetBucketPolicyStatusWithContext(ctx aws.Context, input *s3.GetBucketPolicyStatusInput,
+		opts ...request.Option) (*s3.GetBucketPolicyStatusOutput, error)
+	GetBucketLifecycleConfigurationWithContext(ctx aws.Context, input *s3.GetBucketLifecycleConfigurationInput,
+		opts ...request.Option) (*s3.GetBucketLifecycleConfigurationOutput, error)
+	GetBucketTaggingWithContext(ctx aws.Context, input *s3.GetBucketTaggingInput,
+		opts ...request.Option) (*s3.GetBucketTaggingOutput, error)
+}
+
+// EC2API defines the methods used from the AWS EC2 client for easier testing/mocking.
+type EC2API interface {
+	DescribeVolumesPagesWithContext(ctx aws.Context, input *ec2.DescribeVolumesInput,
+		fn func(*ec2.DescribeVolumesOutput, bool) bool, opts ...request.Option) error
+}
+
+// CloudWatchAPI defines the methods used from the AWS CloudWatch client for easier testing/mocking.
+type CloudWatchAPI interface {
+	ListMetricsPagesWithContext(ctx aws.Context, input *cloudwatch.ListMetricsInput,
+		fn func(*cloudwatch.ListMetricsOutput, bool) bool, opts ...request.Option) error
+	GetMetricStatisticsWithContext(ctx aws.Context, input *cloudwatch.GetMetricStatisticsInput,
+		opts ...request.Option) (*cloudwatch.GetMetricStatisticsOutput, error)
+}
+
+// RegionalClient holds the AWS clients of a single region. S3 buckets are global but their configuration
+// and metrics are only served by the region of the bucket.
+type RegionalClient struct {
+	S3         S3API
+	EC2        EC2API
+	CloudWatch CloudWatchAPI
+}
+
+type Client struct {
+	// Regions maps the AWS regions to their clients.
+	Regions map[string]*RegionalClient
+	// RegionsAPI finds the regions enabled for the account, all the regions are listed without it.
+	RegionsAPI regions.DescribeRegionsAPI
+}
+
+// listRegions calls list for every region of the client enabled for the account and merges the results.
+func (c *Client) listRegions(ctx context.Context, list func(region string, rc *RegionalClient) ([]models.Resource, error)) (
+	[]models.Resource, error) {
+	return regions.List(ctx, c.RegionsAPI, c.Regions, list)
+}
+
+// getErrorCode returns the code of an AWS error, or an empty string when err is not one.
+func getErrorCode(err error) string {
+	var aErr awserr.Error
+
+	if !errors.As(err, &aErr) {
+		return ""
+	}
+
+	return aErr.Code()
+}
+
+func getEC2Labels(tags []*ec2.Tag) models.Labels {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	labels := make(models.Labels, len(tags))
+
+	for _, t := range tags {
+		labels[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return labels
+}
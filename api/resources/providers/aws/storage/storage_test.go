@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/regions"
+)
+
+// mockS3 serves the configuration of the buckets by name, a missing configuration returns its AWS error code.
+type mockS3 struct {
+	buckets   []*s3.Bucket
+	locations map[string]string
+	blocks    map[string]*s3.PublicAccessBlockConfiguration
+	public    map[string]bool
+	rules     map[string][]*s3.LifecycleRule
+	tags      map[string][]*s3.Tag
+	err       error
+}
+
+func (m *mockS3) ListBucketsWithContext(aws.Context, *s3.ListBucketsInput, ...request.Option) (*s3.ListBucketsOutput, error) {
+	return &s3.ListBucketsOutput{Buckets: m.buckets}, m.err
+}
+
+func (m *mockS3) GetBucketLocationWithContext(_ aws.Context, input *s3.GetBucketLocationInput,
+	_ ...request.Option) (*s3.GetBucketLocationOutput, error) {
+	return &s3.GetBucketLocationOutput{LocationConstraint: aws.String(m.locations[aws.StringValue(input.Bucket)])}, nil
+}
+
+func (m *mockS3) GetPublicAccessBlockWithContext(_ aws.Context, input *s3.GetPublicAccessBlockInput,
+	_ ...request.Option) (*s3.GetPublicAccessBlockOutput, error) {
+	cfg, ok := m.blocks[aws.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New(noSuchPublicAccessBlock, "no public access block", nil)
+	}
+
+	return &s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: cfg}, nil
+}
+
+func (m *mockS3) GetBucketPolicyStatusWithContext(_ aws.Context, input *s3.GetBucketPolicyStatusInput,
+	_ ...request.Option) (*s3.GetBucketPolicyStatusOutput, error) {
+	public, ok := m.public[aws.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New(accessDenied, "access denied", nil)
+	}
+
+	return &s3.GetBucketPolicyStatusOutput{PolicyStatus: &s3.PolicyStatus{IsPublic: aws.Bool(public)}}, nil
+}
+
+func (m *mockS3) GetBucketLifecycleConfigurationWithContext(_ aws.Context, input *s3.GetBucketLifecycleConfigurationInput,
+	_ ...request.Option) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	rules, ok := m.rules[aws.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New(noSuchLifecycle, "no lifecycle configuration", nil)
+	}
+
+	return &s3.GetBucketLifecycleConfigurationOutput{Rules: rules}, nil
+}
+
+func (m *mockS3) GetBucketTaggingWithContext(_ aws.Context, input *s3.GetBucketTaggingInput,
+	_ ...request.Option) (*s3.GetBucketTaggingOutput, error) {
+	tags, ok := m.tags[aws.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New(noSuchTagSet, "no tag set", nil)
+	}
+
+	return &s3.GetBucketTaggingOutput{TagSet: tags}, nil
+}
+
+type mockEC2 struct {
+	volumes []*ec2.Volume
+	err     error
+}
+
+func (m *mockEC2) DescribeVolumesPagesWithContext(_ aws.Context, _ *ec2.DescribeVolumesInput,
+	fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	fn(&ec2.DescribeVolumesOutput{Volumes: m.volumes}, true)
+
+	return nil
+}
+
+type mockRegions struct {
+	regions []*ec2.Region
+	err     error
+}
+
+func (m *mockRegions) DescribeRegionsWithContext(_ aws.Context, _ *ec2.DescribeRegionsInput,
+	_ ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{Regions: m.regions}, m.err
+}
+
+// mockCloudWatch serves the bucket size metrics, sizes maps the buckets to their size by storage type.
+type mockCloudWatch struct {
+	sizes map[string]map[string]float64
+	err   error
+}
+
+func (m *mockCloudWatch) ListMetricsPagesWithContext(_ aws.Context, _ *cloudwatch.ListMetricsInput,
+	fn func(*cloudwatch.ListMetricsOutput, bool) bool, _ ...request.Option) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	out := &cloudwatch.ListMetricsOutput{}
+
+	for bucket, byType := range m.sizes {
+		for storageType := range byType {
+			out.Metrics = append(out.Metrics, &cloudwatch.Metric{Dimensions: []*cloudwatch.Dimension{
+				{Name: aws.String("BucketName"), Value: aws.String(bucket)},
+				{Name: aws.String("StorageType"), Value: aws.String(storageType)},
+			}})
+		}
+	}
+
+	fn(out, true)
+
+	return nil
+}
+
+func (m *mockCloudWatch) GetMetricStatisticsWithContext(_ aws.Context, input *cloudwatch.GetMetricStatisticsInput,
+	_ ...request.Option) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	size := m.sizes[getDimension(input.Dimensions, "BucketName")][getDimension(input.Dimensions, "StorageType")]
+	now := time.Now()
+
+	return &cloudwatch.GetMetricStatisticsOutput{Datapoints: []*cloudwatch.Datapoint{
+		{Timestamp: aws.Time(now.Add(-24 * time.Hour)), Average: aws.Float64(1)},
+		{Timestamp: aws.Time(now), Average: aws.Float64(size)},
+	}}, nil
+}
+
+func TestClient_GetAllBuckets(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bucketClient := &mockS3{
+		buckets: []*s3.Bucket{
+			{Name: aws.String("assets"), CreationDate: &created},
+			{Name: aws.String("logs"), CreationDate: &created},
+			{Name: aws.String("archive"), CreationDate: &created},
+		},
+		locations: map[string]string{"logs": "EU", "archive": "ap-south-2"},
+		blocks: map[string]*s3.PublicAccessBlockConfiguration{"assets": {BlockPublicAcls: aws.Bool(true),
+			IgnorePublicAcls: aws.Bool(true), BlockPublicPolicy: aws.Bool(true), RestrictPublicBuckets: aws.Bool(true)}},
+		public: map[string]bool{"assets": false},
+		rules: map[string][]*s3.LifecycleRule{"logs": {{ID: aws.String("expire"), Status: aws.String("Enabled"),
+			Filter: &s3.LifecycleRuleFilter{Prefix: aws.String("tmp/")}, Expiration: &s3.LifecycleExpiration{Days: aws.Int64(30)},
+			Transitions: []*s3.Transition{{Days: aws.Int64(7), StorageClass: aws.String("GLACIER")}}}}},
+		tags: map[string][]*s3.Tag{"assets": {{Key: aws.String("env"), Value: aws.String("prod")}}},
+	}
+
+	c := &Client{Regions: map[string]*RegionalClient{
+		DefaultRegion: {S3: bucketClient, CloudWatch: &mockCloudWatch{sizes: map[string]map[string]float64{
+			"assets": {"StandardStorage": 1024, "GlacierStorage": 512}}}},
+		"eu-west-1": {S3: bucketClient, CloudWatch: &mockCloudWatch{err: awserr.New(accessDenied, "access denied", nil)}},
+	}}
+
+	expected := []models.Resource{
+		{Name: "assets", Type: Bucket, UID: "arn:aws:s3:::assets", Region: DefaultRegion, CreationTime: "2025-01-01T00:00:00Z",
+			Status: AVAILABLE, Labels: models.Labels{"env": "prod"}, Settings: models.Settings{
+				"public_access_block": &publicAccessBlock{BlockPublicAcls: true, IgnorePublicAcls: true, BlockPublicPolicy: true,
+					RestrictPublicBuckets: true},
+				"public": false, "size_bytes": int64(1536),
+				"storage_classes": map[string]int64{"StandardStorage": 1024, "GlacierStorage": 512}}},
+		{Name: "logs", Type: Bucket, UID: "arn:aws:s3:::logs", Region: "eu-west-1", CreationTime: "2025-01-01T00:00:00Z",
+			Status: AVAILABLE, Settings: models.Settings{"public_access_block": &publicAccessBlock{},
+				"lifecycle_rules": []lifecycleRule{{ID: "expire", Status: "Enabled", Prefix: "tmp/", ExpirationDays: 30,
+					Transitions: []transition{{Days: 7, StorageClass: "GLACIER"}}}}}},
+		{Name: "archive", Type: Bucket, UID: "arn:aws:s3:::archive", Region: "ap-south-2", CreationTime: "2025-01-01T00:00:00Z",
+			Status: AVAILABLE, Settings: models.Settings{}},
+	}
+
+	res, err := c.GetAllBuckets(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestClient_GetAllBuckets_Errors(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	res, err := (&Client{}).GetAllBuckets(ctx)
+
+	assert.Nil(t, res)
+	assert.Equal(t, &regions.ErrUnknownRegion{Region: DefaultRegion}, err)
+
+	c := &Client{Regions: map[string]*RegionalClient{
+		DefaultRegion: {S3: &mockS3{err: awserr.New("InternalError", "internal error", nil)}},
+	}}
+
+	res, err = c.GetAllBuckets(ctx)
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func TestClient_GetAllVolumes(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := &Client{Regions: map[string]*RegionalClient{
+		"us-east-1": {EC2: &mockEC2{volumes: []*ec2.Volume{
+			{VolumeId: aws.String("vol-1"), Size: aws.Int64(8), VolumeType: aws.String("gp3"), State: aws.String("in-use"),
+				AvailabilityZone: aws.String("us-east-1a"), CreateTime: &created, Encrypted: aws.Bool(true),
+				Iops: aws.Int64(3000), Throughput: aws.Int64(125),
+				Attachments: []*ec2.VolumeAttachment{{InstanceId: aws.String("i-1")}},
+				Tags:        []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("root")}}},
+		}}},
+		"eu-west-1": {EC2: &mockEC2{volumes: []*ec2.Volume{
+			{VolumeId: aws.String("vol-2"), Size: aws.Int64(100), VolumeType: aws.String("st1"), State: aws.String("available"),
+				AvailabilityZone: aws.String("eu-west-1b"), CreateTime: &created},
+		}}},
+		// The account did not opt in to the region.
+		"me-south-1": {EC2: &mockEC2{err: awserr.New("AuthFailure", "not enabled", nil)}},
+	}, RegionsAPI: &mockRegions{regions: []*ec2.Region{
+		{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("eu-west-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("me-south-1"), OptInStatus: aws.String("not-opted-in")},
+	}}}
+
+	expected := []models.Resource{
+		{Name: "vol-2", Type: Volume, UID: "vol-2", Region: "eu-west-1", CreationTime: "2025-01-01T00:00:00Z", Status: AVAILABLE,
+			Settings: models.Settings{"size_gb": int64(100), "volume_type": "st1", "availability_zone": "eu-west-1b",
+				"encrypted": false, "attached": false}},
+		{Name: "root", Type: Volume, UID: "vol-1", Region: "us-east-1", CreationTime: "2025-01-01T00:00:00Z", Status: INUSE,
+			Labels: models.Labels{"Name": "root"}, Settings: models.Settings{"size_gb": int64(8), "volume_type": "gp3",
+				"availability_zone": "us-east-1a", "encrypted": true, "attached": true, "attached_to": []string{"i-1"},
+				"iops": int64(3000), "throughput": int64(125)}},
+	}
+
+	res, err := c.GetAllVolumes(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	c.Regions["us-east-1"].EC2 = &mockEC2{err: awserr.New("InternalError", "internal error", nil)}
+
+	res, err = c.GetAllVolumes(ctx)
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+
+	// Revoked credentials fail the listing rather than the volumes being taken for deleted.
+	c.RegionsAPI = &mockRegions{err: awserr.New("AuthFailure", "AWS was not able to validate the provided credentials", nil)}
+
+	res, err = c.GetAllVolumes(ctx)
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func Test_getVolumeState(t *testing.T) {
+	assert.Equal(t, INUSE, getVolumeState(ec2.VolumeStateInUse))
+	assert.Equal(t, AVAILABLE, getVolumeState(ec2.VolumeStateAvailable))
+	assert.Equal(t, "CREATING", getVolumeState(ec2.VolumeStateCreating))
+}
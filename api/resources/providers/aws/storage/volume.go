@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// GetAllVolumes returns the EBS volumes of all the regions.
+func (c *Client) GetAllVolumes(ctx *gofr.Context) ([]models.Resource, error) {
+	return c.listRegions(ctx, func(region string, rc *RegionalClient) ([]models.Resource, error) {
+		volumes := make([]models.Resource, 0)
+
+		err := rc.EC2.DescribeVolumesPagesWithContext(ctx, &ec2.DescribeVolumesInput{},
+			func(out *ec2.DescribeVolumesOutput, _ bool) bool {
+				for _, v := range out.Volumes {
+					volumes = append(volumes, toVolumeResource(region, v))
+				}
+
+				return true
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		return volumes, nil
+	})
+}
+
+func toVolumeResource(region string, v *ec2.Volume) models.Resource {
+	labels := getEC2Labels(v.Tags)
+
+	instances := make([]string, 0, len(v.Attachments))
+	for _, a := range v.Attachments {
+		instances = append(instances, aws.StringValue(a.InstanceId))
+	}
+
+	settings := models.Settings{
+		"size_gb":           aws.Int64Value(v.Size),
+		"volume_type":       aws.StringValue(v.VolumeType),
+		"availability_zone": aws.StringValue(v.AvailabilityZone),
+		"encrypted":         aws.BoolValue(v.Encrypted),
+		"attached":          len(instances) > 0,
+	}
+
+	if len(instances) > 0 {
+		settings["attached_to"] = instances
+	}
+
+	if v.Iops != nil {
+		settings["iops"] = *v.Iops
+	}
+
+	if v.Throughput != nil {
+		settings["throughput"] = *v.Throughput
+	}
+
+	name := labels["Name"]
+	if name == "" {
+		name = aws.StringValue(v.VolumeId)
+	}
+
+	return models.Resource{
+		Name:         name,
+		Type:         Volume,
+		UID:          aws.StringValue(v.VolumeId),
+		Region:       region,
+		CreationTime: aws.TimeValue(v.CreateTime).Format(time.RFC3339),
+		Status:       getVolumeState(aws.StringValue(v.State)),
+		Settings:     settings,
+		Labels:       labels,
+	}
+}
+
+// getVolumeState maps the state of an EBS volume to the storage states of zopdev, the transient states,
+// e.g. creating or deleting, are reported as is.
+func getVolumeState(state string) string {
+	switch state {
+	case ec2.VolumeStateInUse:
+		return INUSE
+	case ec2.VolumeStateAvailable:
+		return AVAILABLE
+	default:
+		return strings.ToUpper(state)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/zopdev/zopdev/api/resources/models"
 	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
 )
 
 // EC2API defines the methods used from the AWS EC2 client for easier testing/mocking.
@@ -48,23 +49,17 @@ func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
 
 			for _, reservation := range ec2Result.Reservations {
 				for _, inst := range reservation.Instances {
-					var instanceName string
-
-					for _, tag := range inst.Tags {
-						if *tag.Key == "Name" {
-							instanceName = awsStringValue(tag.Value)
-							break
-						}
-					}
+					labels := getLabels(inst.Tags)
 
 					instance := models.Resource{
-						Name:         instanceName,
+						Name:         labels["Name"],
 						Type:         "EC2",
 						UID:          awsStringValue(inst.InstanceId),
 						Region:       region,
 						CreationTime: inst.LaunchTime.Format(time.RFC3339),
 						Status:       awsStringValue(inst.State.Name),
 						Settings:     map[string]any{"InstanceType": awsStringValue(inst.InstanceType)},
+						Labels:       labels,
 						CreatedAt:    time.Now(),
 						UpdatedAt:    time.Now(),
 					}
@@ -94,6 +89,26 @@ func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
 	return allInstances, firstErr
 }
 
+// GetInstanceStatus returns the state of an instance.
+func (c *Client) GetInstanceStatus(ctx *gofr.Context, instanceID string) (string, error) {
+	result, err := c.EC2.DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []*string{&instanceID},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, reservation := range result.Reservations {
+		for _, inst := range reservation.Instances {
+			if inst.State != nil {
+				return awsStringValue(inst.State.Name), nil
+			}
+		}
+	}
+
+	return "", gofrHttp.ErrorEntityNotFound{Name: "instance", Value: instanceID}
+}
+
 func (c *Client) StartInstance(ctx *gofr.Context, instanceID string) error {
 	input := &ec2.StartInstancesInput{
 		InstanceIds: []*string{&instanceID},
@@ -112,6 +127,21 @@ func (c *Client) StopInstance(ctx *gofr.Context, instanceID string) error {
 	return err
 }
 
+// getLabels converts the tags of an instance to resource labels, the `Name` tag is used as the name of the instance.
+func getLabels(tags []*ec2.Tag) models.Labels {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	labels := make(models.Labels, len(tags))
+
+	for _, tag := range tags {
+		labels[awsStringValue(tag.Key)] = awsStringValue(tag.Value)
+	}
+
+	return labels
+}
+
 func awsStringValue(s *string) string {
 	if s == nil {
 		return ""
@@ -11,6 +11,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
 )
 
 var errFail = errors.New("fail")
@@ -62,7 +65,8 @@ func Test_GetAllInstances_Success(t *testing.T) {
 					InstanceType: aws.String("t2.micro"),
 					LaunchTime:   aws.Time(time.Now()),
 					State:        &ec2.InstanceState{Name: aws.String("running")},
-					Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("test-instance")}},
+					Tags: []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("test-instance")},
+						{Key: aws.String("env"), Value: aws.String("staging")}},
 				}},
 			}},
 		}
@@ -81,6 +85,7 @@ func Test_GetAllInstances_Success(t *testing.T) {
 		assert.Equal(t, "EC2", inst.Type)
 		assert.Equal(t, "i-123", inst.UID)
 		assert.Equal(t, "running", inst.Status)
+		assert.Equal(t, models.Labels{"Name": "test-instance", "env": "staging"}, inst.Labels)
 
 		regionSet[inst.Region] = struct{}{}
 	}
@@ -114,6 +119,32 @@ func Test_GetAllInstances_NoReservations(t *testing.T) {
 	require.Empty(t, instances)
 }
 
+func Test_GetInstanceStatus(t *testing.T) {
+	mock := &mockEC2{DescribeInstancesResp: map[string]*ec2.DescribeInstancesOutput{
+		"us-east-1": {Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-1"), State: &ec2.InstanceState{Name: aws.String("stopping")}},
+		}}}},
+	}, CurrentRegion: "us-east-1"}
+	client := &Client{EC2: mock}
+
+	status, err := client.GetInstanceStatus(nil, "i-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "stopping", status)
+
+	mock.CurrentRegion = "eu-west-1"
+
+	_, err = client.GetInstanceStatus(nil, "i-1")
+
+	assert.Equal(t, gofrHttp.ErrorEntityNotFound{Name: "instance", Value: "i-1"}, err)
+
+	mock.DescribeInstancesErr = errFail
+
+	_, err = client.GetInstanceStatus(nil, "i-1")
+
+	assert.Equal(t, errFail, err)
+}
+
 func Test_StartInstance(t *testing.T) {
 	cases := []struct {
 		name     string
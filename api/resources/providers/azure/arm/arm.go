@@ -0,0 +1,163 @@
+// Package arm is a minimal client of the Azure Resource Manager REST API, authenticated as a service principal.
+package arm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Client calls the Azure Resource Manager API of a single subscription.
+type Client struct {
+	// HTTP is an HTTP client that authenticates the requests, i.e. adds the bearer token of the service principal.
+	HTTP *http.Client
+	// Endpoint is the base URL of the Resource Manager, e.g. https://management.azure.com.
+	Endpoint       string
+	SubscriptionID string
+}
+
+// Error is an error response of the Resource Manager API.
+type Error struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("azure: %s: %s", e.Code, e.Message)
+}
+
+// StatusCode returns the status of the Resource Manager response, so that invalid credentials, missing permissions,
+// missing resources and conflicts are reported as such to the callers of zopdev. Any other failure is an internal
+// error of zopdev.
+func (e *Error) StatusCode() int {
+	switch e.Status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict:
+		return e.Status
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// page is a page of a Resource Manager list response.
+type page struct {
+	Value    []json.RawMessage `json:"value"`
+	NextLink string            `json:"nextLink"`
+}
+
+// SubscriptionPath returns the path of the subscription of the client, e.g. to list its resources of a provider.
+func (c *Client) SubscriptionPath() string {
+	return "/subscriptions/" + c.SubscriptionID
+}
+
+// List calls fn for every item returned by the list operation at the given path, following the next links.
+func (c *Client) List(ctx context.Context, path, apiVersion string, fn func(item json.RawMessage) error) error {
+	next := c.url(path, apiVersion)
+
+	for next != "" {
+		var p page
+
+		if err := c.do(ctx, http.MethodGet, next, &p); err != nil {
+			return err
+		}
+
+		for _, item := range p.Value {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		next = p.NextLink
+	}
+
+	return nil
+}
+
+// Get reads the resource at the given path into out.
+func (c *Client) Get(ctx context.Context, path, apiVersion string, out any) error {
+	return c.do(ctx, http.MethodGet, c.url(path, apiVersion), out)
+}
+
+// Post calls the action at the given path, e.g. `{vm}/start`. The long-running operations it starts are not awaited.
+func (c *Client) Post(ctx context.Context, path, apiVersion string) error {
+	return c.do(ctx, http.MethodPost, c.url(path, apiVersion), nil)
+}
+
+func (c *Client) url(path, apiVersion string) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	return strings.TrimSuffix(c.Endpoint, "/") + path + sep + "api-version=" + url.QueryEscape(apiVersion)
+}
+
+func (c *Client) do(ctx context.Context, method, reqURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		// The service principal could not get a token, e.g. its secret is wrong or expired.
+		var tokenErr *oauth2.RetrieveError
+		if errors.As(err, &tokenErr) {
+			return &Error{Status: http.StatusUnauthorized, Code: tokenErr.ErrorCode, Message: tokenErr.ErrorDescription}
+		}
+
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return getError(resp.StatusCode, body)
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+func getError(status int, body []byte) error {
+	var resp struct {
+		Error *Error `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error == nil {
+		return &Error{Status: status, Code: http.StatusText(status), Message: string(body)}
+	}
+
+	resp.Error.Status = status
+
+	return resp.Error
+}
+
+// ResourceGroup returns the resource group of a resource from its ID, i.e.
+// `/subscriptions/{subscription}/resourceGroups/{group}/providers/...`.
+func ResourceGroup(id string) string {
+	parts := strings.Split(id, "/")
+
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "resourceGroups") {
+			return parts[i+1]
+		}
+	}
+
+	return ""
+}
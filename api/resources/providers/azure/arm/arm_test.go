@@ -0,0 +1,111 @@
+package arm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Client{HTTP: srv.Client(), Endpoint: srv.URL + "/", SubscriptionID: "sub-1"}
+}
+
+func TestClient_List(t *testing.T) {
+	var srvURL string
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines", r.URL.Path)
+		assert.Equal(t, "2024-07-01", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "true", r.URL.Query().Get("statusOnly"))
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"value":[{"name":"vm-3"}]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"value":[{"name":"vm-1"},{"name":"vm-2"}],"nextLink":"` + srvURL +
+			`/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines?statusOnly=true&api-version=2024-07-01&page=2"}`))
+	})
+
+	srvURL = c.Endpoint[:len(c.Endpoint)-1]
+	names := make([]string, 0)
+
+	err := c.List(context.Background(), c.SubscriptionPath()+"/providers/Microsoft.Compute/virtualMachines?statusOnly=true",
+		"2024-07-01", func(item json.RawMessage) error {
+			var v struct {
+				Name string `json:"name"`
+			}
+
+			require.NoError(t, json.Unmarshal(item, &v))
+
+			names = append(names, v.Name)
+
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vm-1", "vm-2", "vm-3"}, names)
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected *Error
+		code     int
+	}{
+		{"arm error", http.StatusForbidden, `{"error":{"code":"AuthorizationFailed","message":"no access"}}`,
+			&Error{Status: http.StatusForbidden, Code: "AuthorizationFailed", Message: "no access"}, http.StatusForbidden},
+		{"plain error", http.StatusBadGateway, `bad gateway`,
+			&Error{Status: http.StatusBadGateway, Code: "Bad Gateway", Message: "bad gateway"}, http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			err := c.Post(context.Background(), "/subscriptions/sub-1/resourceGroups/rg/providers/x/vm-1/start", "v1")
+
+			var armErr *Error
+
+			require.ErrorAs(t, err, &armErr)
+			assert.Equal(t, tc.expected, armErr)
+			assert.Equal(t, tc.code, armErr.StatusCode())
+		})
+	}
+}
+
+func TestClient_Get(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"subscriptionId":"sub-1"}`))
+	})
+
+	var out struct {
+		ID string `json:"subscriptionId"`
+	}
+
+	require.NoError(t, c.Get(context.Background(), c.SubscriptionPath(), "2022-12-01", &out))
+	assert.Equal(t, "sub-1", out.ID)
+}
+
+func TestResourceGroup(t *testing.T) {
+	assert.Equal(t, "rg-1", ResourceGroup("/subscriptions/s/resourceGroups/rg-1/providers/Microsoft.Compute/virtualMachines/vm"))
+	assert.Equal(t, "RG-2", ResourceGroup("/subscriptions/s/resourcegroups/RG-2/providers/x/y/z"))
+	assert.Empty(t, ResourceGroup("/subscriptions/s"))
+}
@@ -0,0 +1,154 @@
+// Package azure creates the clients of the Azure resources supported by zopdev from the credentials
+// of a service principal.
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gofr.dev/pkg/gofr/http"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/database"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/vm"
+)
+
+const (
+	// DefaultAuthorityHost is the Microsoft Entra ID endpoint of the Azure public cloud.
+	DefaultAuthorityHost = "https://login.microsoftonline.com"
+	// DefaultResourceManager is the Resource Manager endpoint of the Azure public cloud.
+	DefaultResourceManager = "https://management.azure.com"
+
+	subscriptionAPIVersion = "2022-12-01"
+	subscriptionEnabled    = "Enabled"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid cloud credentials")
+	ErrSubscriptionState  = errors.New("azure subscription is not enabled")
+)
+
+type Client struct {
+	// AuthorityHost is the endpoint the service principals get their tokens from.
+	AuthorityHost string
+	// ResourceManager is the endpoint of the Azure Resource Manager API.
+	ResourceManager string
+}
+
+func New() *Client {
+	return &Client{AuthorityHost: DefaultAuthorityHost, ResourceManager: DefaultResourceManager}
+}
+
+type azureCredentials struct {
+	TenantID       string `json:"tenant_id"`
+	ClientID       string `json:"client_id"`
+	ClientSecret   string `json:"client_secret"`
+	SubscriptionID string `json:"subscription_id"`
+}
+
+func getAzureCredentials(creds any) (azureCredentials, error) {
+	var azureCred azureCredentials
+
+	azureCredBody, _ := json.Marshal(creds)
+
+	err := json.Unmarshal(azureCredBody, &azureCred)
+	if err != nil {
+		return azureCred, err
+	}
+
+	missing := make([]string, 0)
+
+	for _, param := range []struct{ name, value string }{
+		{"tenant_id", azureCred.TenantID},
+		{"client_id", azureCred.ClientID},
+		{"client_secret", azureCred.ClientSecret},
+		{"subscription_id", azureCred.SubscriptionID},
+	} {
+		if param.value == "" {
+			missing = append(missing, param.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return azureCred, http.ErrorMissingParam{Params: missing}
+	}
+
+	return azureCred, nil
+}
+
+// newARMClient returns a Resource Manager client of the subscription authenticated as the service principal.
+func (c *Client) newARMClient(ctx context.Context, creds any) (*arm.Client, error) {
+	azureCreds, err := getAzureCredentials(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceManager := strings.TrimSuffix(c.ResourceManager, "/")
+
+	cfg := clientcredentials.Config{
+		ClientID:     azureCreds.ClientID,
+		ClientSecret: azureCreds.ClientSecret,
+		TokenURL:     strings.TrimSuffix(c.AuthorityHost, "/") + "/" + azureCreds.TenantID + "/oauth2/v2.0/token",
+		Scopes:       []string{resourceManager + "/.default"},
+	}
+
+	return &arm.Client{
+		// The token source is tied to the background context, as the clients outlive the request creating them.
+		HTTP:           cfg.Client(context.WithoutCancel(ctx)),
+		Endpoint:       resourceManager,
+		SubscriptionID: azureCreds.SubscriptionID,
+	}, nil
+}
+
+// NewVMClient creates a client for the virtual machines of the subscription with stored credentials.
+func (c *Client) NewVMClient(ctx context.Context, creds any) (*vm.Client, error) {
+	armClient, err := c.newARMClient(ctx, creds)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &vm.Client{ARM: armClient}, nil
+}
+
+// NewDatabaseClient creates a client for the MySQL and PostgreSQL flexible servers of the subscription
+// with stored credentials.
+func (c *Client) NewDatabaseClient(ctx context.Context, creds any) (*database.Client, error) {
+	armClient, err := c.newARMClient(ctx, creds)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &database.Client{ARM: armClient}, nil
+}
+
+// Subscription is the Azure subscription the credentials of a cloud account give access to.
+type Subscription struct {
+	ID          string `json:"subscriptionId"`
+	DisplayName string `json:"displayName"`
+	State       string `json:"state"`
+}
+
+// GetSubscription validates the credentials of a service principal by reading its subscription,
+// i.e. both the token and the access to the subscription are checked.
+func (c *Client) GetSubscription(ctx context.Context, creds any) (*Subscription, error) {
+	armClient, err := c.newARMClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	var sub Subscription
+
+	err = armClient.Get(ctx, armClient.SubscriptionPath(), subscriptionAPIVersion, &sub)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.State != subscriptionEnabled {
+		return nil, ErrSubscriptionState
+	}
+
+	return &sub, nil
+}
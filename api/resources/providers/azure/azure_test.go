@@ -0,0 +1,174 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+)
+
+func validCreds() map[string]string {
+	return map[string]string{"tenant_id": "tenant-1", "client_id": "app-1", "client_secret": "secret",
+		"subscription_id": "sub-1"}
+}
+
+// newTestClient returns a client whose token and Resource Manager endpoints are served by the same test server.
+func newTestClient(t *testing.T, subscription http.HandlerFunc) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant-1/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.PostForm.Get("client_secret") != "secret" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"invalid secret"}`))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token-1","token_type":"Bearer","expires_in":3600}`))
+	})
+	authenticated := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+
+		subscription(w, r)
+	}
+	mux.HandleFunc("/subscriptions/sub-1", authenticated)
+	mux.HandleFunc("/subscriptions/sub-1/", authenticated)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return &Client{AuthorityHost: srv.URL, ResourceManager: srv.URL}
+}
+
+func TestNew(t *testing.T) {
+	c := New()
+
+	assert.Equal(t, DefaultAuthorityHost, c.AuthorityHost)
+	assert.Equal(t, DefaultResourceManager, c.ResourceManager)
+}
+
+func Test_getAzureCredentials(t *testing.T) {
+	c, err := getAzureCredentials(validCreds())
+
+	require.NoError(t, err)
+	assert.Equal(t, azureCredentials{TenantID: "tenant-1", ClientID: "app-1", ClientSecret: "secret",
+		SubscriptionID: "sub-1"}, c)
+
+	_, err = getAzureCredentials(map[string]string{"tenant_id": "tenant-1", "client_id": "app-1"})
+	assert.Equal(t, gofrHttp.ErrorMissingParam{Params: []string{"client_secret", "subscription_id"}}, err)
+
+	_, err = getAzureCredentials(make(chan int))
+	require.Error(t, err)
+}
+
+func TestClient_GetSubscription(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"subscriptionId":"sub-1","displayName":"Dev","state":"Enabled"}`))
+	})
+
+	sub, err := c.GetSubscription(context.Background(), validCreds())
+
+	require.NoError(t, err)
+	assert.Equal(t, &Subscription{ID: "sub-1", DisplayName: "Dev", State: "Enabled"}, sub)
+}
+
+func TestClient_GetSubscription_Errors(t *testing.T) {
+	invalidSecret := validCreds()
+	invalidSecret["client_secret"] = "wrong"
+
+	tests := []struct {
+		name    string
+		creds   map[string]string
+		handler http.HandlerFunc
+		status  int
+		err     error
+	}{
+		{name: "disabled subscription", creds: validCreds(), err: ErrSubscriptionState,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"subscriptionId":"sub-1","state":"Disabled"}`))
+			}},
+		{name: "no access to the subscription", creds: validCreds(), status: http.StatusForbidden,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"no access"}}`))
+			}},
+		{name: "invalid secret", creds: invalidSecret, status: http.StatusUnauthorized},
+		{name: "missing credentials", creds: map[string]string{},
+			err: gofrHttp.ErrorMissingParam{Params: []string{"tenant_id", "client_id", "client_secret", "subscription_id"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, tc.handler)
+
+			sub, err := c.GetSubscription(context.Background(), tc.creds)
+
+			assert.Nil(t, sub)
+
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+
+			var armErr *arm.Error
+
+			require.ErrorAs(t, err, &armErr)
+			assert.Equal(t, tc.status, armErr.StatusCode())
+		})
+	}
+}
+
+func TestClient_NewClients(t *testing.T) {
+	c := New()
+
+	vmClient, err := c.NewVMClient(context.Background(), validCreds())
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", vmClient.ARM.SubscriptionID)
+
+	dbClient, err := c.NewDatabaseClient(context.Background(), validCreds())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultResourceManager, dbClient.ARM.Endpoint)
+
+	_, err = c.NewVMClient(context.Background(), map[string]string{})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = c.NewDatabaseClient(context.Background(), map[string]string{})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestClient_TestPermissions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subscriptions/sub-1/providers/Microsoft.Authorization/permissions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"value":[
+			{"actions":["*/read"],"notActions":["Microsoft.Insights/*"]},
+			{"actions":["Microsoft.Compute/virtualMachines/start/action"],"notActions":[]}
+		]}`))
+	})
+
+	granted, err := c.TestPermissions(context.Background(), validCreds(), []string{
+		"Microsoft.Compute/virtualMachines/read",
+		"microsoft.compute/virtualMachines/start/action",
+		"Microsoft.Compute/virtualMachines/deallocate/action",
+		"Microsoft.Insights/metrics/read",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"Microsoft.Compute/virtualMachines/read":              true,
+		"microsoft.compute/virtualMachines/start/action":      true,
+		"Microsoft.Compute/virtualMachines/deallocate/action": false,
+		"Microsoft.Insights/metrics/read":                     false,
+	}, granted)
+}
@@ -0,0 +1,195 @@
+// Package database discovers the Azure Database for MySQL and PostgreSQL flexible servers of a subscription
+// and starts or stops them.
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+)
+
+const (
+	// RUNNING instance state for zopdev.
+	RUNNING = "RUNNING"
+	// STOPPED instance state for zopdev.
+	STOPPED = "STOPPED"
+
+	// FlexibleServer is the resource type of the Azure Database flexible servers.
+	FlexibleServer = "AZURE_FLEXIBLE_SERVER"
+
+	stateReady   = "Ready"
+	stateStopped = "Stopped"
+)
+
+// engine is a database engine offered as an Azure Database flexible server.
+type engine struct {
+	name       string
+	namespace  string
+	apiVersion string
+}
+
+func engines() []engine {
+	return []engine{
+		{name: "MySQL", namespace: "Microsoft.DBforMySQL", apiVersion: "2023-12-30"},
+		{name: "PostgreSQL", namespace: "Microsoft.DBforPostgreSQL", apiVersion: "2022-12-01"},
+	}
+}
+
+type Client struct {
+	ARM *arm.Client
+}
+
+// ErrUnknownServer is returned when the resource ID is not the one of a MySQL or PostgreSQL flexible server.
+type ErrUnknownServer struct {
+	ID string
+}
+
+func (e *ErrUnknownServer) Error() string {
+	return fmt.Sprintf("unknown flexible server %q", e.ID)
+}
+
+func (*ErrUnknownServer) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// flexibleServer is the part of an Azure Database flexible server read by zopdev.
+type flexibleServer struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Location string            `json:"location"`
+	Tags     map[string]string `json:"tags"`
+	SKU      struct {
+		Name string `json:"name"`
+		Tier string `json:"tier"`
+	} `json:"sku"`
+	Properties struct {
+		State   string `json:"state"`
+		Version string `json:"version"`
+		Storage struct {
+			StorageSizeGB int64 `json:"storageSizeGB"`
+		} `json:"storage"`
+		HighAvailability struct {
+			Mode string `json:"mode"`
+		} `json:"highAvailability"`
+	} `json:"properties"`
+	SystemData struct {
+		CreatedAt string `json:"createdAt"`
+	} `json:"systemData"`
+}
+
+// GetAllInstances returns the MySQL and PostgreSQL flexible servers of the subscription.
+func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
+	instances := make([]models.Resource, 0)
+
+	for _, e := range engines() {
+		err := c.ARM.List(ctx, c.ARM.SubscriptionPath()+"/providers/"+e.namespace+"/flexibleServers", e.apiVersion,
+			func(item json.RawMessage) error {
+				var fs flexibleServer
+
+				if err := json.Unmarshal(item, &fs); err != nil {
+					return err
+				}
+
+				instances = append(instances, toResource(e.name, &fs))
+
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return instances, nil
+}
+
+func toResource(engineName string, fs *flexibleServer) models.Resource {
+	var labels models.Labels
+	if len(fs.Tags) > 0 {
+		labels = fs.Tags
+	}
+
+	return models.Resource{
+		Name:         fs.Name,
+		Type:         FlexibleServer,
+		UID:          fs.ID,
+		Region:       fs.Location,
+		CreationTime: fs.SystemData.CreatedAt,
+		Status:       getState(fs.Properties.State),
+		Settings: models.Settings{
+			"resource_group":    arm.ResourceGroup(fs.ID),
+			"engine":            engineName,
+			"version":           fs.Properties.Version,
+			"sku":               fs.SKU.Name,
+			"tier":              fs.SKU.Tier,
+			"storage_gb":        fs.Properties.Storage.StorageSizeGB,
+			"high_availability": fs.Properties.HighAvailability.Mode,
+		},
+		Labels: labels,
+	}
+}
+
+// getState maps the state of a flexible server to the states of zopdev,
+// the transient states, e.g. starting or updating, are reported as is.
+func getState(state string) string {
+	switch state {
+	case stateReady:
+		return RUNNING
+	case stateStopped:
+		return STOPPED
+	default:
+		return strings.ToUpper(state)
+	}
+}
+
+// GetInstanceStatus returns the state of the flexible server with the given resource ID.
+func (c *Client) GetInstanceStatus(ctx *gofr.Context, id string) (string, error) {
+	e, err := getEngine(id)
+	if err != nil {
+		return "", err
+	}
+
+	var fs flexibleServer
+
+	if err = c.ARM.Get(ctx, id, e.apiVersion, &fs); err != nil {
+		return "", err
+	}
+
+	return getState(fs.Properties.State), nil
+}
+
+// StartInstance starts the flexible server with the given resource ID.
+func (c *Client) StartInstance(ctx *gofr.Context, id string) error {
+	e, err := getEngine(id)
+	if err != nil {
+		return err
+	}
+
+	return c.ARM.Post(ctx, id+"/start", e.apiVersion)
+}
+
+// StopInstance stops the flexible server with the given resource ID, note that Azure automatically starts
+// a stopped server again after seven days.
+func (c *Client) StopInstance(ctx *gofr.Context, id string) error {
+	e, err := getEngine(id)
+	if err != nil {
+		return err
+	}
+
+	return c.ARM.Post(ctx, id+"/stop", e.apiVersion)
+}
+
+func getEngine(id string) (engine, error) {
+	for _, e := range engines() {
+		if strings.Contains(strings.ToLower(id), "/providers/"+strings.ToLower(e.namespace)+"/flexibleservers/") {
+			return e, nil
+		}
+	}
+
+	return engine{}, &ErrUnknownServer{ID: id}
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+)
+
+const (
+	mysqlID    = "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.DBforMySQL/flexibleServers/orders"
+	postgresID = "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.DBforPostgreSQL/flexibleServers/users"
+)
+
+func newClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Client{ARM: &arm.Client{HTTP: srv.Client(), Endpoint: srv.URL, SubscriptionID: "sub-1"}}
+}
+
+func TestClient_GetAllInstances(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subscriptions/sub-1/providers/Microsoft.DBforMySQL/flexibleServers":
+			_, _ = w.Write([]byte(`{"value":[{"id":"` + mysqlID + `","name":"orders","location":"eastus",
+				"tags":{"team":"shop"},"sku":{"name":"Standard_B1ms","tier":"Burstable"},
+				"properties":{"state":"Ready","version":"8.0.21","storage":{"storageSizeGB":20},
+					"highAvailability":{"mode":"Disabled"}},
+				"systemData":{"createdAt":"2025-01-01T00:00:00Z"}}]}`))
+		case "/subscriptions/sub-1/providers/Microsoft.DBforPostgreSQL/flexibleServers":
+			_, _ = w.Write([]byte(`{"value":[{"id":"` + postgresID + `","name":"users","location":"westeurope",
+				"sku":{"name":"Standard_D2s_v3","tier":"GeneralPurpose"},
+				"properties":{"state":"Stopped","version":"16","storage":{"storageSizeGB":128},
+					"highAvailability":{"mode":"ZoneRedundant"}}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	res, err := c.GetAllInstances(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{
+		{Name: "orders", Type: FlexibleServer, UID: mysqlID, Region: "eastus", CreationTime: "2025-01-01T00:00:00Z",
+			Status: RUNNING, Labels: models.Labels{"team": "shop"}, Settings: models.Settings{"resource_group": "rg-1",
+				"engine": "MySQL", "version": "8.0.21", "sku": "Standard_B1ms", "tier": "Burstable",
+				"storage_gb": int64(20), "high_availability": "Disabled"}},
+		{Name: "users", Type: FlexibleServer, UID: postgresID, Region: "westeurope", Status: STOPPED,
+			Settings: models.Settings{"resource_group": "rg-1", "engine": "PostgreSQL", "version": "16",
+				"sku": "Standard_D2s_v3", "tier": "GeneralPurpose", "storage_gb": int64(128),
+				"high_availability": "ZoneRedundant"}},
+	}, res)
+}
+
+func TestClient_GetAllInstances_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	res, err := c.GetAllInstances(ctx)
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func TestClient_GetInstanceStatus(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, postgresID, r.URL.Path)
+		assert.Equal(t, "2022-12-01", r.URL.Query().Get("api-version"))
+
+		_, _ = w.Write([]byte(`{"id":"` + postgresID + `","properties":{"state":"Stopped"}}`))
+	})
+
+	status, err := c.GetInstanceStatus(ctx, postgresID)
+
+	require.NoError(t, err)
+	assert.Equal(t, STOPPED, status)
+
+	_, err = c.GetInstanceStatus(ctx, "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Sql/servers/db")
+
+	assert.Equal(t, &ErrUnknownServer{ID: "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Sql/servers/db"}, err)
+}
+
+func TestClient_StartStopInstance(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	calls := make([]string, 0)
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		calls = append(calls, r.URL.Path+"@"+r.URL.Query().Get("api-version"))
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	require.NoError(t, c.StartInstance(ctx, mysqlID))
+	require.NoError(t, c.StopInstance(ctx, postgresID))
+	assert.Equal(t, []string{mysqlID + "/start@2023-12-30", postgresID + "/stop@2022-12-01"}, calls)
+}
+
+func TestClient_StartInstance_UnknownServer(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := &Client{}
+
+	err := c.StartInstance(ctx, "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Sql/servers/legacy")
+
+	var unknownErr *ErrUnknownServer
+
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, http.StatusBadRequest, unknownErr.StatusCode())
+}
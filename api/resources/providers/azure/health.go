@@ -0,0 +1,11 @@
+package azure
+
+import "context"
+
+// CheckHealth validates the credentials of a cloud account by reading its subscription, which also fails when the
+// subscription is not enabled anymore.
+func (c *Client) CheckHealth(ctx context.Context, creds any) error {
+	_, err := c.GetSubscription(ctx, creds)
+
+	return err
+}
@@ -0,0 +1,76 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const permissionsAPIVersion = "2022-04-01"
+
+// permission is a set of actions granted to the service principal by one of its role assignments. The actions may
+// contain wildcards, e.g. `Microsoft.Compute/*` or `*/read`.
+type permission struct {
+	Actions    []string `json:"actions"`
+	NotActions []string `json:"notActions"`
+}
+
+// TestPermissions returns which of the given actions the service principal of the credentials is granted on its
+// subscription, as listed by the permissions of its role assignments. Getting a token also validates the credentials.
+func (c *Client) TestPermissions(ctx context.Context, creds any, actions []string) (map[string]bool, error) {
+	armClient, err := c.newARMClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]permission, 0)
+
+	err = armClient.List(ctx, armClient.SubscriptionPath()+"/providers/Microsoft.Authorization/permissions",
+		permissionsAPIVersion, func(item json.RawMessage) error {
+			var p permission
+
+			if err := json.Unmarshal(item, &p); err != nil {
+				return err
+			}
+
+			permissions = append(permissions, p)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string]bool, len(actions))
+
+	for _, action := range actions {
+		granted[action] = isGranted(permissions, action)
+	}
+
+	return granted, nil
+}
+
+// isGranted returns whether one of the permissions grants the action without excluding it.
+func isGranted(permissions []permission, action string) bool {
+	for _, p := range permissions {
+		if matchesAny(p.Actions, action) && !matchesAny(p.NotActions, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAny returns whether the action matches one of the patterns, the actions are case-insensitive.
+func matchesAny(patterns []string, action string) bool {
+	for _, pattern := range patterns {
+		expr := "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+
+		if regexp.MustCompile(expr).MatchString(action) {
+			return true
+		}
+	}
+
+	return false
+}
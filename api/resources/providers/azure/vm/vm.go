@@ -0,0 +1,149 @@
+// Package vm discovers the virtual machines of an Azure subscription and starts or deallocates them.
+package vm
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+)
+
+const (
+	// RUNNING instance state for zopdev.
+	RUNNING = "RUNNING"
+	// STOPPED instance state for zopdev, the virtual machine is stopped or deallocated.
+	STOPPED = "STOPPED"
+
+	// VirtualMachine is the resource type of the Azure virtual machines.
+	VirtualMachine = "AZURE_VM"
+
+	apiVersion       = "2024-07-01"
+	powerStatePrefix = "PowerState/"
+)
+
+type Client struct {
+	ARM *arm.Client
+}
+
+// virtualMachine is the part of an Azure virtual machine read by zopdev.
+type virtualMachine struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Location   string            `json:"location"`
+	Tags       map[string]string `json:"tags"`
+	Properties struct {
+		TimeCreated     string `json:"timeCreated"`
+		Priority        string `json:"priority"`
+		HardwareProfile struct {
+			VMSize string `json:"vmSize"`
+		} `json:"hardwareProfile"`
+		StorageProfile struct {
+			OSDisk struct {
+				OSType string `json:"osType"`
+			} `json:"osDisk"`
+		} `json:"storageProfile"`
+		InstanceView struct {
+			Statuses []struct {
+				Code string `json:"code"`
+			} `json:"statuses"`
+		} `json:"instanceView"`
+	} `json:"properties"`
+}
+
+// GetAllInstances returns the virtual machines of the subscription along with their power state.
+func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
+	instances := make([]models.Resource, 0)
+
+	// statusOnly returns the instance view, i.e. the power state, of every virtual machine.
+	err := c.ARM.List(ctx, c.ARM.SubscriptionPath()+"/providers/Microsoft.Compute/virtualMachines?statusOnly=true", apiVersion,
+		func(item json.RawMessage) error {
+			var vm virtualMachine
+
+			if err := json.Unmarshal(item, &vm); err != nil {
+				return err
+			}
+
+			instances = append(instances, toResource(&vm))
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+func toResource(vm *virtualMachine) models.Resource {
+	powerState := getPowerState(vm)
+
+	var labels models.Labels
+	if len(vm.Tags) > 0 {
+		labels = vm.Tags
+	}
+
+	return models.Resource{
+		Name:         vm.Name,
+		Type:         VirtualMachine,
+		UID:          vm.ID,
+		Region:       vm.Location,
+		CreationTime: vm.Properties.TimeCreated,
+		Status:       getState(powerState),
+		Settings: models.Settings{
+			"resource_group": arm.ResourceGroup(vm.ID),
+			"vm_size":        vm.Properties.HardwareProfile.VMSize,
+			"os_type":        vm.Properties.StorageProfile.OSDisk.OSType,
+			"priority":       vm.Properties.Priority,
+			"power_state":    powerState,
+		},
+		Labels: labels,
+	}
+}
+
+func getPowerState(vm *virtualMachine) string {
+	for _, s := range vm.Properties.InstanceView.Statuses {
+		if state, ok := strings.CutPrefix(s.Code, powerStatePrefix); ok {
+			return state
+		}
+	}
+
+	return ""
+}
+
+// getState maps the power state of a virtual machine to the states of zopdev,
+// the transient states, e.g. starting or deallocating, are reported as is.
+func getState(powerState string) string {
+	switch powerState {
+	case "running":
+		return RUNNING
+	case "stopped", "deallocated":
+		return STOPPED
+	default:
+		return strings.ToUpper(powerState)
+	}
+}
+
+// GetInstanceStatus returns the state of the virtual machine with the given resource ID, read from its instance view.
+func (c *Client) GetInstanceStatus(ctx *gofr.Context, id string) (string, error) {
+	var vm virtualMachine
+
+	if err := c.ARM.Get(ctx, id+"/instanceView", apiVersion, &vm.Properties.InstanceView); err != nil {
+		return "", err
+	}
+
+	return getState(getPowerState(&vm)), nil
+}
+
+// StartInstance starts the virtual machine with the given resource ID.
+func (c *Client) StartInstance(ctx *gofr.Context, id string) error {
+	return c.ARM.Post(ctx, id+"/start", apiVersion)
+}
+
+// StopInstance deallocates the virtual machine with the given resource ID. A virtual machine that is only
+// stopped keeps its compute resources and is still billed for them, a deallocated one is not.
+func (c *Client) StopInstance(ctx *gofr.Context, id string) error {
+	return c.ARM.Post(ctx, id+"/deallocate", apiVersion)
+}
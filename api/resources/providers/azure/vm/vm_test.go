@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+)
+
+const vmID = "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Compute/virtualMachines/web"
+
+func newClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Client{ARM: &arm.Client{HTTP: srv.Client(), Endpoint: srv.URL, SubscriptionID: "sub-1"}}
+}
+
+func TestClient_GetAllInstances(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("statusOnly"))
+
+		_, _ = w.Write([]byte(`{"value":[
+			{"id":"` + vmID + `","name":"web","location":"eastus","tags":{"env":"dev"},
+				"properties":{"timeCreated":"2025-01-01T00:00:00Z","priority":"Regular",
+					"hardwareProfile":{"vmSize":"Standard_B2s"},"storageProfile":{"osDisk":{"osType":"Linux"}},
+					"instanceView":{"statuses":[{"code":"ProvisioningState/succeeded"},{"code":"PowerState/running"}]}}},
+			{"id":"/subscriptions/sub-1/resourceGroups/rg-2/providers/Microsoft.Compute/virtualMachines/batch",
+				"name":"batch","location":"westeurope",
+				"properties":{"instanceView":{"statuses":[{"code":"PowerState/deallocated"}]}}}
+		]}`))
+	})
+
+	res, err := c.GetAllInstances(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{
+		{Name: "web", Type: VirtualMachine, UID: vmID, Region: "eastus", CreationTime: "2025-01-01T00:00:00Z",
+			Status: RUNNING, Labels: models.Labels{"env": "dev"}, Settings: models.Settings{"resource_group": "rg-1",
+				"vm_size": "Standard_B2s", "os_type": "Linux", "priority": "Regular", "power_state": "running"}},
+		{Name: "batch", Type: VirtualMachine, Region: "westeurope", Status: STOPPED,
+			UID: "/subscriptions/sub-1/resourceGroups/rg-2/providers/Microsoft.Compute/virtualMachines/batch",
+			Settings: models.Settings{"resource_group": "rg-2", "vm_size": "", "os_type": "", "priority": "",
+				"power_state": "deallocated"}},
+	}, res)
+}
+
+func TestClient_GetAllInstances_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"no access"}}`))
+	})
+
+	res, err := c.GetAllInstances(ctx)
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func TestClient_GetInstanceStatus(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, vmID+"/instanceView", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"statuses":[{"code":"ProvisioningState/updating"},{"code":"PowerState/deallocating"}]}`))
+	})
+
+	status, err := c.GetInstanceStatus(ctx, vmID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "DEALLOCATING", status)
+
+	c = newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err = c.GetInstanceStatus(ctx, vmID)
+
+	require.Error(t, err)
+}
+
+func TestClient_StartStopInstance(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	paths := make([]string, 0)
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+
+		paths = append(paths, r.URL.Path)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	require.NoError(t, c.StartInstance(ctx, vmID))
+	require.NoError(t, c.StopInstance(ctx, vmID))
+	assert.Equal(t, []string{vmID + "/start", vmID + "/deallocate"}, paths)
+}
+
+func Test_getState(t *testing.T) {
+	assert.Equal(t, RUNNING, getState("running"))
+	assert.Equal(t, STOPPED, getState("stopped"))
+	assert.Equal(t, STOPPED, getState("deallocated"))
+	assert.Equal(t, "DEALLOCATING", getState("deallocating"))
+}
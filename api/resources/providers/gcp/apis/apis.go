@@ -0,0 +1,32 @@
+// Package apis tells apart the errors returned by the Google Cloud APIs that are not enabled in a project. A project
+// holds no resource of an API it did not enable, so such errors are not failures of the listings.
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// reasonNotConfigured is the reason of the errors returned by the APIs not enabled in the project.
+const reasonNotConfigured = "accessNotConfigured"
+
+// IsDisabled reports whether the error is returned because the API is not enabled in the project. Any other
+// permission error, e.g. of revoked credentials, is not.
+func IsDisabled(err error) bool {
+	var gErr *googleapi.Error
+
+	if !errors.As(err, &gErr) || gErr.Code != http.StatusForbidden {
+		return false
+	}
+
+	for _, item := range gErr.Errors {
+		if item.Reason == reasonNotConfigured {
+			return true
+		}
+	}
+
+	return strings.Contains(gErr.Message, "SERVICE_DISABLED") || strings.Contains(gErr.Message, "has not been used")
+}
@@ -0,0 +1,33 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsDisabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "not configured", expected: true, err: &googleapi.Error{Code: http.StatusForbidden,
+			Errors: []googleapi.ErrorItem{{Reason: "accessNotConfigured"}}}},
+		{name: "service disabled", expected: true, err: fmt.Errorf("listing clusters: %w", &googleapi.Error{
+			Code: http.StatusForbidden, Message: "Kubernetes Engine API has not been used in project 42 before or it is disabled."})},
+		{name: "permission denied", err: &googleapi.Error{Code: http.StatusForbidden,
+			Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}},
+		{name: "not found", err: &googleapi.Error{Code: http.StatusNotFound}},
+		{name: "other error", err: assert.AnError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsDisabled(tc.err))
+		})
+	}
+}
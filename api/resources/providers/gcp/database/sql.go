@@ -45,12 +45,21 @@ func (c *Client) GetAllInstances(_ *gofr.Context, projectID string) ([]models.Re
 			CreationTime: item.CreateTime,
 			UID:          projectID + "/" + item.Name,
 			Status:       getState(item.Settings.ActivationPolicy),
+			Labels:       getLabels(item.Settings.UserLabels),
 		})
 	}
 
 	return instances, nil
 }
 
+func getLabels(userLabels map[string]string) models.Labels {
+	if len(userLabels) == 0 {
+		return nil
+	}
+
+	return models.Labels(userLabels)
+}
+
 func getState(state string) string {
 	switch state {
 	case ALWAYS:
@@ -62,6 +71,20 @@ func getState(state string) string {
 	}
 }
 
+// GetInstanceStatus returns the status of a Cloud SQL instance, given its activation policy.
+func (c *Client) GetInstanceStatus(_ *gofr.Context, projectID, instanceName string) (string, error) {
+	inst, err := c.SQL.Get(projectID, instanceName).Do()
+	if err != nil {
+		return "", err
+	}
+
+	if inst.Settings == nil {
+		return STOPPED, nil
+	}
+
+	return getState(inst.Settings.ActivationPolicy), nil
+}
+
 func (c *Client) StartInstance(_ *gofr.Context, projectID, instanceName string) error {
 	patchReq := &sqladmin.DatabaseInstance{
 		Settings: &sqladmin.Settings{
@@ -40,12 +40,14 @@ func getServer(t *testing.T, resp any, isError bool) *httptest.Server {
 func Test_GetAllInstances(t *testing.T) {
 	resp := &sqladmin.InstancesListResponse{
 		Items: []*sqladmin.DatabaseInstance{
-			{Name: "test-instance1", Project: "test-project", Settings: &sqladmin.Settings{ActivationPolicy: ALWAYS}},
+			{Name: "test-instance1", Project: "test-project", Settings: &sqladmin.Settings{ActivationPolicy: ALWAYS,
+				UserLabels: map[string]string{"env": "staging"}}},
 			{Name: "test-instance2", Project: "test-project", Settings: &sqladmin.Settings{ActivationPolicy: NEVER}},
 			{Name: "test-instance3", Project: "test-project", Settings: &sqladmin.Settings{ActivationPolicy: "ON_DEMAND"}},
 		}}
 	result := []models.Resource{
-		{Name: "test-instance1", UID: "test-project/test-instance1", Type: "SQL", Status: RUNNING},
+		{Name: "test-instance1", UID: "test-project/test-instance1", Type: "SQL", Status: RUNNING,
+			Labels: models.Labels{"env": "staging"}},
 		{Name: "test-instance2", UID: "test-project/test-instance2", Type: "SQL", Status: STOPPED},
 		{Name: "test-instance3", UID: "test-project/test-instance3", Type: "SQL", Status: STOPPED},
 	}
@@ -86,6 +88,35 @@ func Test_GetAllInstances_Error(t *testing.T) {
 	assert.Equal(t, expected.Error(), err.Error())
 }
 
+func TestClient_GetInstanceStatus(t *testing.T) {
+	srv := getServer(t, &sqladmin.DatabaseInstance{Name: "test-instance", Settings: &sqladmin.Settings{ActivationPolicy: NEVER}},
+		false)
+	defer srv.Close()
+
+	instSvc, err := sqladmin.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	c := Client{SQL: instSvc.Instances}
+
+	status, err := c.GetInstanceStatus(nil, "test-project", "test-instance")
+
+	require.NoError(t, err)
+	assert.Equal(t, STOPPED, status)
+
+	srv = getServer(t, nil, true)
+	defer srv.Close()
+
+	instSvc, err = sqladmin.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	c = Client{SQL: instSvc.Instances}
+
+	status, err = c.GetInstanceStatus(nil, "test-project", "test-instance")
+
+	require.Error(t, err)
+	assert.Empty(t, status)
+}
+
 func TestClient_StartInstance(t *testing.T) {
 	// Success case
 	srv1 := getServer(t, nil, false)
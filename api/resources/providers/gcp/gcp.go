@@ -6,37 +6,78 @@ import (
 	"errors"
 
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/appengine/v1"
+	"google.golang.org/api/cloudfunctions/v2"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/monitoring/v3"
 	"google.golang.org/api/option"
+	run "google.golang.org/api/run/v2"
 	"google.golang.org/api/sqladmin/v1"
+	gcs "google.golang.org/api/storage/v1"
 
 	gmonitoring "cloud.google.com/go/monitoring/apiv3/v2"
 	sql "github.com/zopdev/zopdev/api/resources/providers/gcp/database"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/gke"
 	metric "github.com/zopdev/zopdev/api/resources/providers/gcp/monitoring"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/serverless"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/storage"
 )
 
 var (
 	ErrInvalidCredentials = errors.New("invalid cloud credentials")
 	ErrInitializingClient = errors.New("error initializing client")
+	ErrNoProjects         = errors.New("no project in the scope of the cloud account")
 )
 
 type Client struct{}
 
 func New() *Client { return &Client{} }
 
+// NewGoogleCredentials returns the credentials of a cloud account. When the cloud account impersonates a service
+// account, the tokens are created for that service account by the service account of the key.
 func (*Client) NewGoogleCredentials(ctx context.Context, cred any, scopes ...string) (*google.Credentials, error) {
-	var gcpCreds credentials
+	gcpCreds, err := getCredentials(cred)
+	if err != nil {
+		return nil, err
+	}
 
 	b, _ := json.Marshal(cred)
-	if err := json.Unmarshal(b, &gcpCreds); err != nil {
+
+	creds, err := google.CredentialsFromJSON(ctx, b, scopes...)
+	if err != nil {
 		return nil, ErrInvalidCredentials
 	}
 
-	creds, err := google.CredentialsFromJSON(ctx, b, scopes...)
+	if gcpCreds.ImpersonateServiceAccount == "" {
+		return creds, nil
+	}
+
+	if len(scopes) == 0 {
+		scopes = []string{cloudPlatformScope}
+	}
+
+	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: gcpCreds.ImpersonateServiceAccount,
+		Scopes:          scopes,
+	}, option.WithCredentials(creds))
 	if err != nil {
 		return nil, ErrInvalidCredentials
 	}
 
-	return creds, nil
+	return &google.Credentials{ProjectID: creds.ProjectID, TokenSource: ts}, nil
+}
+
+func getCredentials(cred any) (*credentials, error) {
+	var gcpCreds credentials
+
+	b, _ := json.Marshal(cred)
+	if err := json.Unmarshal(b, &gcpCreds); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &gcpCreds, nil
 }
 
 func (*Client) NewSQLClient(ctx context.Context, opts ...option.ClientOption) (SQLClient, error) {
@@ -48,6 +89,60 @@ func (*Client) NewSQLClient(ctx context.Context, opts ...option.ClientOption) (S
 	return &sql.Client{SQL: admin.Instances}, nil
 }
 
+func (*Client) NewGKEClient(ctx context.Context, opts ...option.ClientOption) (GKEClient, error) {
+	cs, err := container.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	// The size of the node pools is read from the managed instance groups backing them.
+	cmp, err := compute.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	return &gke.Client{Container: cs, Compute: cmp}, nil
+}
+
+func (*Client) NewServerlessClient(ctx context.Context, opts ...option.ClientOption) (ServerlessClient, error) {
+	runSvc, err := run.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	appSvc, err := appengine.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	fnSvc, err := cloudfunctions.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	return &serverless.Client{Run: runSvc, AppEngine: appSvc, Functions: fnSvc}, nil
+}
+
+func (*Client) NewStorageClient(ctx context.Context, opts ...option.ClientOption) (StorageClient, error) {
+	storageSvc, err := gcs.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	cmp, err := compute.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	// The size of the buckets is only exposed as a Cloud Monitoring metric.
+	mon, err := monitoring.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	return &storage.Client{Storage: storageSvc, Compute: cmp, Monitoring: mon}, nil
+}
+
 func (*Client) NewMetricsClient(ctx context.Context, opts ...option.ClientOption) (MetricsClient, error) {
 	mCl, err := gmonitoring.NewMetricClient(ctx, opts...)
 	if err != nil {
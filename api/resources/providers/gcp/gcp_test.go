@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
@@ -34,6 +35,100 @@ func TestClient_NewGoogleCredentials(t *testing.T) {
 	assert.Equal(t, ErrInvalidCredentials, err)
 }
 
+func TestClient_NewGoogleCredentials_impersonation(t *testing.T) {
+	cred := map[string]string{
+		"type":                        "service_account",
+		"project_id":                  "test-project",
+		"impersonate_service_account": "target@test-project.iam.gserviceaccount.com",
+	}
+
+	creds, err := New().NewGoogleCredentials(context.Background(), cred, "https://www.googleapis.com/auth/cloud-platform")
+
+	require.NoError(t, err)
+	assert.Equal(t, "test-project", creds.ProjectID)
+	assert.NotNil(t, creds.TokenSource)
+}
+
+func TestClient_GetProjects(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cred        map[string]string
+		expProjects []string
+	}{
+		{
+			name:        "project of the key",
+			cred:        map[string]string{"type": "service_account", "project_id": "test-project"},
+			expProjects: []string{"test-project"},
+		},
+		{
+			name:        "listed projects",
+			cred:        map[string]string{"type": "service_account", "project_id": "test-project", "projects": "prod, staging,"},
+			expProjects: []string{"prod", "staging"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projects, err := New().GetProjects(context.Background(), tc.cred)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expProjects, projects)
+		})
+	}
+}
+
+func Test_listProjects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path + "?" + r.URL.Query().Get("parent") {
+		case "/v3/projects?organizations/1":
+			_, _ = w.Write([]byte(`{"projects":[{"projectId":"prod","state":"ACTIVE"},{"projectId":"old","state":"DELETE_REQUESTED"}]}`))
+		case "/v3/folders?organizations/1":
+			_, _ = w.Write([]byte(`{"folders":[{"name":"folders/2","state":"ACTIVE"}]}`))
+		case "/v3/projects?folders/2":
+			_, _ = w.Write([]byte(`{"projects":[{"projectId":"staging","state":"ACTIVE"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	projects, err := listProjects(context.Background(), "organizations/1", option.WithEndpoint(srv.URL),
+		option.WithoutAuthentication())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "staging"}, projects)
+}
+
+func Test_getResource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v3/projects/prod", "/v3/folders/2", "/v3/organizations/1":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"code":403,"message":"denied"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	for _, name := range []string{"projects/prod", "folders/2", "organizations/1"} {
+		err := getResource(context.Background(), name, option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+		require.NoError(t, err, name)
+	}
+
+	err := getResource(context.Background(), "projects/other", option.WithEndpoint(srv.URL),
+		option.WithoutAuthentication())
+
+	var apiErr *googleapi.Error
+
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.Code)
+}
+
 func TestClient_NewSQLInstanceLister(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -53,6 +148,54 @@ func TestClient_NewSQLInstanceLister(t *testing.T) {
 	assert.Equal(t, ErrInitializingClient, err)
 }
 
+func TestClient_NewGKEClient(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	gke, err := c.NewGKEClient(ctx, option.WithoutAuthentication())
+
+	require.NoError(t, err)
+	assert.NotNil(t, gke)
+
+	gke, err = c.NewGKEClient(ctx, option.WithoutAuthentication(), option.WithCredentialsFile("test.json"))
+
+	assert.Nil(t, gke)
+	require.Error(t, err)
+	assert.Equal(t, ErrInitializingClient, err)
+}
+
+func TestClient_NewServerlessClient(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	cl, err := c.NewServerlessClient(ctx, option.WithoutAuthentication())
+
+	require.NoError(t, err)
+	assert.NotNil(t, cl)
+
+	cl, err = c.NewServerlessClient(ctx, option.WithoutAuthentication(), option.WithCredentialsFile("test.json"))
+
+	assert.Nil(t, cl)
+	require.Error(t, err)
+	assert.Equal(t, ErrInitializingClient, err)
+}
+
+func TestClient_NewStorageClient(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	cl, err := c.NewStorageClient(ctx, option.WithoutAuthentication())
+
+	require.NoError(t, err)
+	assert.NotNil(t, cl)
+
+	cl, err = c.NewStorageClient(ctx, option.WithoutAuthentication(), option.WithCredentialsFile("test.json"))
+
+	assert.Nil(t, cl)
+	require.Error(t, err)
+	assert.Equal(t, ErrInitializingClient, err)
+}
+
 func TestClient_NewMetricsClient(t *testing.T) {
 	ctx := context.Background()
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -70,3 +213,19 @@ func TestClient_NewMetricsClient(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, ErrInitializingClient, err)
 }
+
+func Test_testProjectPermissions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/test-project:testIamPermissions", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"permissions":["compute.instances.list"]}`))
+	}))
+	defer srv.Close()
+
+	granted, err := testProjectPermissions(context.Background(), "test-project",
+		[]string{"compute.instances.list", "compute.instances.stop"}, option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"compute.instances.list": true, "compute.instances.stop": false}, granted)
+}
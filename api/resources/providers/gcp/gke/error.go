@@ -0,0 +1,47 @@
+package gke
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type ErrConflict struct {
+	Message string `json:"message"`
+}
+
+func (e *ErrConflict) Error() string {
+	return e.Message
+}
+
+func (*ErrConflict) StatusCode() int {
+	return http.StatusConflict
+}
+
+// ErrInvalidNodePool is returned when the UID of a node pool is not of the form `project/location/cluster/pool`.
+type ErrInvalidNodePool struct {
+	UID string
+}
+
+func (e *ErrInvalidNodePool) Error() string {
+	return fmt.Sprintf("invalid node pool %q", e.UID)
+}
+
+func (*ErrInvalidNodePool) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ErrOperationFailed is returned when a GKE operation on a node pool completes with an error.
+type ErrOperationFailed struct {
+	Operation string
+	Message   string
+}
+
+func (e *ErrOperationFailed) Error() string {
+	return fmt.Sprintf("operation %s failed: %s", e.Operation, e.Message)
+}
+
+type InternalServerError struct{}
+
+func (*InternalServerError) Error() string {
+	return "Internal server error!"
+}
@@ -0,0 +1,375 @@
+package gke
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/apis"
+)
+
+const (
+	// RUNNING node pool state for zopdev, the node pool has at least one node.
+	RUNNING = "RUNNING"
+	// STOPPED node pool state for zopdev, the node pool is scaled to zero.
+	STOPPED = "STOPPED"
+
+	// NodePool is the resource type of the GKE node pools.
+	NodePool = "GKE_NODE_POOL"
+
+	operationDone = "DONE"
+
+	defaultPollInterval = 5 * time.Second
+	// maxOperationWait bounds the time spent waiting for a GKE operation to complete.
+	maxOperationWait = 5 * time.Minute
+	// uidParts is the number of parts in the UID of a node pool, i.e. `project/location/cluster/pool`.
+	uidParts = 4
+)
+
+type Client struct {
+	Container *container.Service
+	Compute   *compute.Service
+	// PollInterval is the interval at which the pending GKE operations are polled, it defaults to 5 seconds.
+	PollInterval time.Duration
+}
+
+// previousState is the size and autoscaling config of a node pool before it was suspended,
+// it is stored in the settings of the resource so that the node pool can be restored on start.
+type previousState struct {
+	// NodeCount is the number of nodes per zone of the node pool.
+	NodeCount   int64        `json:"node_count"`
+	Autoscaling *autoscaling `json:"autoscaling,omitempty"`
+}
+
+type autoscaling struct {
+	MinNodeCount      int64  `json:"min_node_count,omitempty"`
+	MaxNodeCount      int64  `json:"max_node_count,omitempty"`
+	TotalMinNodeCount int64  `json:"total_min_node_count,omitempty"`
+	TotalMaxNodeCount int64  `json:"total_max_node_count,omitempty"`
+	LocationPolicy    string `json:"location_policy,omitempty"`
+}
+
+// GetAllNodePools returns the node pools of all the GKE clusters in the project, none when the project did not enable
+// the GKE API.
+func (c *Client) GetAllNodePools(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	list, err := c.Container.Projects.Locations.Clusters.List(fmt.Sprintf("projects/%s/locations/-", projectID)).
+		Context(ctx).Do()
+	if apis.IsDisabled(err) {
+		return make([]models.Resource, 0), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]models.Resource, 0)
+
+	for _, cluster := range list.Clusters {
+		for _, np := range cluster.NodePools {
+			nodes, _, err := c.getNodeCount(ctx, np)
+			if err != nil {
+				return nil, err
+			}
+
+			pools = append(pools, models.Resource{
+				Name:         np.Name,
+				Type:         NodePool,
+				Region:       cluster.Location,
+				CreationTime: cluster.CreateTime,
+				UID:          strings.Join([]string{projectID, cluster.Location, cluster.Name, np.Name}, "/"),
+				Status:       getState(nodes),
+				Settings:     getSettings(cluster, np, nodes),
+				Labels:       getLabels(cluster.ResourceLabels),
+			})
+		}
+	}
+
+	return pools, nil
+}
+
+func getSettings(cluster *container.Cluster, np *container.NodePool, nodes int64) models.Settings {
+	settings := models.Settings{
+		"cluster":    cluster.Name,
+		"node_count": nodes,
+	}
+
+	if np.Config != nil {
+		settings["machine_type"] = np.Config.MachineType
+	}
+
+	if as := getAutoscaling(np); as != nil {
+		settings["autoscaling"] = as
+	}
+
+	return settings
+}
+
+func getAutoscaling(np *container.NodePool) *autoscaling {
+	if np.Autoscaling == nil || !np.Autoscaling.Enabled {
+		return nil
+	}
+
+	return &autoscaling{
+		MinNodeCount:      np.Autoscaling.MinNodeCount,
+		MaxNodeCount:      np.Autoscaling.MaxNodeCount,
+		TotalMinNodeCount: np.Autoscaling.TotalMinNodeCount,
+		TotalMaxNodeCount: np.Autoscaling.TotalMaxNodeCount,
+		LocationPolicy:    np.Autoscaling.LocationPolicy,
+	}
+}
+
+func getLabels(resourceLabels map[string]string) models.Labels {
+	if len(resourceLabels) == 0 {
+		return nil
+	}
+
+	return models.Labels(resourceLabels)
+}
+
+func getState(nodes int64) string {
+	if nodes > 0 {
+		return RUNNING
+	}
+
+	return STOPPED
+}
+
+// getNodeCount returns the total number of nodes of the node pool and the largest number of nodes in a single zone,
+// read from the target size of the managed instance groups backing the node pool.
+func (c *Client) getNodeCount(ctx *gofr.Context, np *container.NodePool) (total, perZone int64, err error) {
+	for _, url := range np.InstanceGroupUrls {
+		project, zone, name, ok := parseInstanceGroupURL(url)
+		if !ok {
+			continue
+		}
+
+		mig, err := c.Compute.InstanceGroupManagers.Get(project, zone, name).Context(ctx).Do()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		total += mig.TargetSize
+		perZone = max(perZone, mig.TargetSize)
+	}
+
+	return total, perZone, nil
+}
+
+// parseInstanceGroupURL extracts the project, zone and name from the URL of a managed instance group, i.e.
+// `https://www.googleapis.com/compute/v1/projects/{project}/zones/{zone}/instanceGroupManagers/{name}`.
+func parseInstanceGroupURL(url string) (project, zone, name string, ok bool) {
+	parts := strings.Split(url, "/")
+
+	for i := 0; i+5 < len(parts); i++ {
+		if parts[i] == "projects" && parts[i+2] == "zones" && parts[i+4] == "instanceGroupManagers" {
+			return parts[i+1], parts[i+3], parts[i+5], true
+		}
+	}
+
+	return "", "", "", false
+}
+
+// GetNodePoolStatus returns the status of a node pool, read from the target size of its managed instance groups.
+func (c *Client) GetNodePoolStatus(ctx *gofr.Context, uid string) (string, error) {
+	name, err := getNodePoolName(uid)
+	if err != nil {
+		return "", err
+	}
+
+	np, err := c.Container.Projects.Locations.Clusters.NodePools.Get(name).Context(ctx).Do()
+	if err != nil {
+		return "", getError(err)
+	}
+
+	nodes, _, err := c.getNodeCount(ctx, np)
+	if err != nil {
+		return "", getError(err)
+	}
+
+	return getState(nodes), nil
+}
+
+// SuspendNodePool scales the node pool to zero. The autoscaler is disabled while the node pool is suspended, as it
+// would otherwise scale the node pool back to its minimum. The size and autoscaling config of the node pool before
+// it was suspended are returned so that they can be restored by StartNodePool.
+func (c *Client) SuspendNodePool(ctx *gofr.Context, uid string) (models.Settings, error) {
+	name, err := getNodePoolName(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	np, err := c.Container.Projects.Locations.Clusters.NodePools.Get(name).Context(ctx).Do()
+	if err != nil {
+		return nil, getError(err)
+	}
+
+	_, perZone, err := c.getNodeCount(ctx, np)
+	if err != nil {
+		return nil, getError(err)
+	}
+
+	prev := previousState{NodeCount: perZone, Autoscaling: getAutoscaling(np)}
+
+	if prev.Autoscaling != nil {
+		err = c.setAutoscaling(ctx, name, &container.NodePoolAutoscaling{Enabled: false, ForceSendFields: []string{"Enabled"}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = c.Container.Projects.Locations.Clusters.NodePools.SetSize(name, &container.SetNodePoolSizeRequest{
+		NodeCount: 0, ForceSendFields: []string{"NodeCount"},
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, getError(err)
+	}
+
+	return prev.toSettings(), nil
+}
+
+// StartNodePool restores the size and autoscaling config of a node pool recorded when it was suspended.
+// A node pool without a recorded size is scaled to the minimum of its autoscaler, or to a single node.
+func (c *Client) StartNodePool(ctx *gofr.Context, uid string, previous models.Settings) error {
+	name, err := getNodePoolName(uid)
+	if err != nil {
+		return err
+	}
+
+	prev := getPreviousState(previous)
+
+	if as := prev.Autoscaling; as != nil {
+		err = c.setAutoscaling(ctx, name, &container.NodePoolAutoscaling{
+			Enabled:           true,
+			MinNodeCount:      as.MinNodeCount,
+			MaxNodeCount:      as.MaxNodeCount,
+			TotalMinNodeCount: as.TotalMinNodeCount,
+			TotalMaxNodeCount: as.TotalMaxNodeCount,
+			LocationPolicy:    as.LocationPolicy,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = c.Container.Projects.Locations.Clusters.NodePools.SetSize(name, &container.SetNodePoolSizeRequest{
+		NodeCount: prev.startNodeCount(),
+	}).Context(ctx).Do()
+	if err != nil {
+		return getError(err)
+	}
+
+	return nil
+}
+
+// setAutoscaling updates the autoscaler of the node pool and waits for the update to complete,
+// as GKE does not accept another operation on the node pool until then.
+func (c *Client) setAutoscaling(ctx *gofr.Context, name string, as *container.NodePoolAutoscaling) error {
+	op, err := c.Container.Projects.Locations.Clusters.NodePools.SetAutoscaling(name,
+		&container.SetNodePoolAutoscalingRequest{Autoscaling: as}).Context(ctx).Do()
+	if err != nil {
+		return getError(err)
+	}
+
+	return c.waitForOperation(ctx, strings.Split(name, "/clusters/")[0], op)
+}
+
+func (c *Client) waitForOperation(ctx *gofr.Context, location string, op *container.Operation) error {
+	interval := c.PollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	deadline := time.Now().Add(maxOperationWait)
+
+	for op.Status != operationDone {
+		if time.Now().After(deadline) {
+			return &ErrOperationFailed{Operation: op.Name, Message: "timed out waiting for the operation to complete"}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var err error
+
+		op, err = c.Container.Projects.Locations.Operations.Get(location + "/operations/" + op.Name).Context(ctx).Do()
+		if err != nil {
+			return getError(err)
+		}
+	}
+
+	if op.Error != nil {
+		return &ErrOperationFailed{Operation: op.Name, Message: op.Error.Message}
+	}
+
+	return nil
+}
+
+func (p *previousState) toSettings() models.Settings {
+	settings := models.Settings{"node_count": p.NodeCount}
+
+	if p.Autoscaling != nil {
+		settings["autoscaling"] = p.Autoscaling
+	}
+
+	return settings
+}
+
+// startNodeCount returns the number of nodes per zone the node pool is scaled to on start.
+func (p *previousState) startNodeCount() int64 {
+	if p.NodeCount > 0 {
+		return p.NodeCount
+	}
+
+	if p.Autoscaling != nil && p.Autoscaling.MinNodeCount > 0 {
+		return p.Autoscaling.MinNodeCount
+	}
+
+	return 1
+}
+
+func getPreviousState(settings models.Settings) *previousState {
+	var prev previousState
+
+	// The settings read back from the store hold the numbers as float64, hence they are decoded through JSON.
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return &prev
+	}
+
+	_ = json.Unmarshal(b, &prev)
+
+	return &prev
+}
+
+func getNodePoolName(uid string) (string, error) {
+	parts := strings.Split(uid, "/")
+	if len(parts) != uidParts {
+		return "", &ErrInvalidNodePool{UID: uid}
+	}
+
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s/nodePools/%s", parts[0], parts[1], parts[2], parts[3]), nil
+}
+
+func getError(err error) error {
+	var gErr *googleapi.Error
+
+	if errors.As(err, &gErr) {
+		if gErr.Code == http.StatusConflict {
+			return &ErrConflict{Message: gErr.Message}
+		}
+	}
+
+	return &InternalServerError{}
+}
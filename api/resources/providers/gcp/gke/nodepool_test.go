@@ -0,0 +1,315 @@
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+const poolPath = "/v1/projects/test-project/locations/us-central1/clusters/dev/nodePools/default"
+
+// recorder serves the given responses by request path and records the body of the requests it receives.
+type recorder struct {
+	mu        sync.Mutex
+	responses map[string]any
+	requests  map[string]string
+}
+
+func newServer(t *testing.T, responses map[string]any) (*httptest.Server, *recorder) {
+	t.Helper()
+
+	rec := &recorder{responses: responses, requests: make(map[string]string)}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		body, _ := io.ReadAll(r.Body)
+
+		rec.mu.Lock()
+		rec.requests[r.URL.Path] = string(body)
+		rec.mu.Unlock()
+
+		resp, ok := rec.responses[r.URL.Path]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		if code, ok := resp.(int); ok {
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		if gErr, ok := resp.(*googleapi.Error); ok {
+			w.WriteHeader(gErr.Code)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": gErr})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, rec
+}
+
+func newClient(t *testing.T, containerResp, computeResp map[string]any) (*Client, *recorder) {
+	t.Helper()
+
+	containerSrv, rec := newServer(t, containerResp)
+	computeSrv, _ := newServer(t, computeResp)
+
+	cs, err := container.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(containerSrv.URL))
+	require.NoError(t, err)
+
+	cmp, err := compute.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(computeSrv.URL))
+	require.NoError(t, err)
+
+	return &Client{Container: cs, Compute: cmp}, rec
+}
+
+func migURL(zone, name string) string {
+	return "https://www.googleapis.com/compute/v1/projects/test-project/zones/" + zone + "/instanceGroupManagers/" + name
+}
+
+func TestClient_GetAllNodePools(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	clusters := &container.ListClustersResponse{Clusters: []*container.Cluster{
+		{Name: "dev", Location: "us-central1", CreateTime: "2025-01-01T00:00:00Z",
+			ResourceLabels: map[string]string{"env": "dev"},
+			NodePools: []*container.NodePool{
+				{Name: "default", Config: &container.NodeConfig{MachineType: "e2-medium"},
+					InstanceGroupUrls: []string{migURL("us-central1-a", "pool-a"), migURL("us-central1-b", "pool-b")}},
+				{Name: "batch", Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MaxNodeCount: 3},
+					InstanceGroupUrls: []string{migURL("us-central1-a", "batch-a")}},
+			}},
+	}}
+
+	c, _ := newClient(t, map[string]any{"/v1/projects/test-project/locations/-/clusters": clusters}, map[string]any{
+		"/projects/test-project/zones/us-central1-a/instanceGroupManagers/pool-a":  &compute.InstanceGroupManager{TargetSize: 2},
+		"/projects/test-project/zones/us-central1-b/instanceGroupManagers/pool-b":  &compute.InstanceGroupManager{TargetSize: 1},
+		"/projects/test-project/zones/us-central1-a/instanceGroupManagers/batch-a": &compute.InstanceGroupManager{},
+	})
+
+	expected := []models.Resource{
+		{Name: "default", Type: NodePool, Region: "us-central1", CreationTime: "2025-01-01T00:00:00Z",
+			UID: "test-project/us-central1/dev/default", Status: RUNNING, Labels: models.Labels{"env": "dev"},
+			Settings: models.Settings{"cluster": "dev", "node_count": int64(3), "machine_type": "e2-medium"}},
+		{Name: "batch", Type: NodePool, Region: "us-central1", CreationTime: "2025-01-01T00:00:00Z",
+			UID: "test-project/us-central1/dev/batch", Status: STOPPED, Labels: models.Labels{"env": "dev"},
+			Settings: models.Settings{"cluster": "dev", "node_count": int64(0), "autoscaling": &autoscaling{MaxNodeCount: 3}}},
+	}
+
+	pools, err := c.GetAllNodePools(ctx, "test-project")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, pools)
+}
+
+func TestClient_GetAllNodePools_Disabled(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c, _ := newClient(t, map[string]any{"/v1/projects/test-project/locations/-/clusters": &googleapi.Error{
+		Code: http.StatusForbidden, Message: "Kubernetes Engine API has not been used in project test-project before or it is disabled.",
+		Errors: []googleapi.ErrorItem{{Reason: "accessNotConfigured"}}}}, nil)
+
+	pools, err := c.GetAllNodePools(ctx, "test-project")
+
+	require.NoError(t, err)
+	assert.Empty(t, pools)
+}
+
+func TestClient_GetAllNodePools_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	for _, resp := range []any{http.StatusInternalServerError, &googleapi.Error{Code: http.StatusForbidden,
+		Message: "Permission denied on resource project test-project.", Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}} {
+		c, _ := newClient(t, map[string]any{"/v1/projects/test-project/locations/-/clusters": resp}, nil)
+
+		pools, err := c.GetAllNodePools(ctx, "test-project")
+
+		assert.Nil(t, pools)
+		require.Error(t, err)
+	}
+}
+
+func TestClient_GetNodePoolStatus(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	migPath := "/projects/test-project/zones/us-central1-a/instanceGroupManagers/pool-a"
+	pool := &container.NodePool{Name: "default", InstanceGroupUrls: []string{migURL("us-central1-a", "pool-a")}}
+
+	c, _ := newClient(t, map[string]any{poolPath: pool},
+		map[string]any{migPath: &compute.InstanceGroupManager{TargetSize: 2}})
+
+	status, err := c.GetNodePoolStatus(ctx, "test-project/us-central1/dev/default")
+
+	require.NoError(t, err)
+	assert.Equal(t, RUNNING, status)
+
+	c, _ = newClient(t, map[string]any{poolPath: pool},
+		map[string]any{migPath: &compute.InstanceGroupManager{TargetSize: 0, ForceSendFields: []string{"TargetSize"}}})
+
+	status, err = c.GetNodePoolStatus(ctx, "test-project/us-central1/dev/default")
+
+	require.NoError(t, err)
+	assert.Equal(t, STOPPED, status)
+
+	_, err = c.GetNodePoolStatus(ctx, "test-project/dev/default")
+
+	assert.Equal(t, &ErrInvalidNodePool{UID: "test-project/dev/default"}, err)
+
+	c, _ = newClient(t, map[string]any{poolPath: http.StatusConflict}, nil)
+
+	_, err = c.GetNodePoolStatus(ctx, "test-project/us-central1/dev/default")
+
+	assert.IsType(t, &ErrConflict{}, err)
+}
+
+func TestClient_SuspendNodePool(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	done := &container.Operation{Name: "op-1", Status: operationDone}
+
+	testCases := []struct {
+		name        string
+		pool        *container.NodePool
+		expSettings models.Settings
+		expRequests []string
+	}{
+		{
+			name:        "fixed size node pool",
+			pool:        &container.NodePool{Name: "default", InstanceGroupUrls: []string{migURL("us-central1-a", "pool-a")}},
+			expSettings: models.Settings{"node_count": int64(2)},
+			expRequests: []string{poolPath + ":setSize"},
+		},
+		{
+			name: "autoscaled node pool",
+			pool: &container.NodePool{Name: "default", InstanceGroupUrls: []string{migURL("us-central1-a", "pool-a")},
+				Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 5}},
+			expSettings: models.Settings{"node_count": int64(2),
+				"autoscaling": &autoscaling{MinNodeCount: 1, MaxNodeCount: 5}},
+			expRequests: []string{poolPath + ":setSize", poolPath + ":setAutoscaling"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rec := newClient(t, map[string]any{
+				poolPath:                     tc.pool,
+				poolPath + ":setSize":        done,
+				poolPath + ":setAutoscaling": done,
+			}, map[string]any{
+				"/projects/test-project/zones/us-central1-a/instanceGroupManagers/pool-a": &compute.InstanceGroupManager{TargetSize: 2},
+			})
+
+			settings, err := c.SuspendNodePool(ctx, "test-project/us-central1/dev/default")
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expSettings, settings)
+			assert.JSONEq(t, `{"nodeCount":0}`, rec.requests[poolPath+":setSize"])
+
+			for _, path := range tc.expRequests {
+				assert.Contains(t, rec.requests, path)
+			}
+
+			if tc.pool.Autoscaling != nil {
+				assert.JSONEq(t, `{"autoscaling":{"enabled":false}}`, rec.requests[poolPath+":setAutoscaling"])
+			}
+		})
+	}
+}
+
+func TestClient_SuspendNodePool_Errors(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c, _ := newClient(t, map[string]any{
+		poolPath: &container.NodePool{Name: "default", Autoscaling: &container.NodePoolAutoscaling{Enabled: true}},
+		poolPath + ":setAutoscaling": &container.Operation{Name: "op-1", Status: operationDone,
+			Error: &container.Status{Message: "quota exceeded"}},
+	}, nil)
+
+	settings, err := c.SuspendNodePool(ctx, "test-project/us-central1/dev/default")
+
+	assert.Nil(t, settings)
+	assert.Equal(t, &ErrOperationFailed{Operation: "op-1", Message: "quota exceeded"}, err)
+
+	settings, err = c.SuspendNodePool(ctx, "test-project/dev/default")
+
+	assert.Nil(t, settings)
+	assert.Equal(t, &ErrInvalidNodePool{UID: "test-project/dev/default"}, err)
+
+	c, _ = newClient(t, map[string]any{poolPath: http.StatusConflict}, nil)
+
+	_, err = c.SuspendNodePool(ctx, "test-project/us-central1/dev/default")
+
+	assert.IsType(t, &ErrConflict{}, err)
+}
+
+func TestClient_StartNodePool(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	testCases := []struct {
+		name       string
+		previous   models.Settings
+		expSize    string
+		autoscaled bool
+	}{
+		{name: "restore size", previous: models.Settings{"node_count": float64(3)}, expSize: `{"nodeCount":3}`},
+		{name: "restore autoscaling", autoscaled: true, expSize: `{"nodeCount":2}`,
+			previous: models.Settings{"node_count": float64(2),
+				"autoscaling": map[string]any{"min_node_count": float64(1), "max_node_count": float64(5)}}},
+		{name: "autoscaler minimum", autoscaled: true, expSize: `{"nodeCount":1}`,
+			previous: models.Settings{"autoscaling": map[string]any{"min_node_count": float64(1), "max_node_count": float64(5)}}},
+		{name: "no previous state", previous: nil, expSize: `{"nodeCount":1}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rec := newClient(t, map[string]any{
+				poolPath + ":setSize":        &container.Operation{Name: "op-2"},
+				poolPath + ":setAutoscaling": &container.Operation{Name: "op-1", Status: "RUNNING"},
+				"/v1/projects/test-project/locations/us-central1/operations/op-1": &container.Operation{
+					Name: "op-1", Status: operationDone},
+			}, nil)
+			c.PollInterval = 1
+
+			err := c.StartNodePool(ctx, "test-project/us-central1/dev/default", tc.previous)
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expSize, rec.requests[poolPath+":setSize"])
+
+			if tc.autoscaled {
+				assert.JSONEq(t, `{"autoscaling":{"enabled":true,"minNodeCount":1,"maxNodeCount":5}}`,
+					rec.requests[poolPath+":setAutoscaling"])
+			} else {
+				assert.NotContains(t, rec.requests, poolPath+":setAutoscaling")
+			}
+		})
+	}
+}
+
+func Test_parseInstanceGroupURL(t *testing.T) {
+	project, zone, name, ok := parseInstanceGroupURL(migURL("us-central1-a", "pool-a"))
+
+	assert.True(t, ok)
+	assert.Equal(t, []string{"test-project", "us-central1-a", "pool-a"}, []string{project, zone, name})
+
+	_, _, _, ok = parseInstanceGroupURL("https://www.googleapis.com/compute/v1/projects/test-project")
+
+	assert.False(t, ok)
+}
@@ -0,0 +1,54 @@
+package gcp
+
+import (
+	"context"
+	"strings"
+
+	crm "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/option"
+)
+
+// CheckHealth validates the credentials of a cloud account with a single authenticated call, by reading its folder,
+// its organization or its first project from Resource Manager.
+func (c *Client) CheckHealth(ctx context.Context, cred any) error {
+	gcpCreds, err := getCredentials(cred)
+	if err != nil {
+		return err
+	}
+
+	name := gcpCreds.parent()
+	if name == "" {
+		projects := gcpCreds.projects()
+		if len(projects) == 0 {
+			projects = []string{gcpCreds.ProjectID}
+		}
+
+		name = "projects/" + projects[0]
+	}
+
+	googleCreds, err := c.NewGoogleCredentials(ctx, cred, cloudPlatformScope)
+	if err != nil {
+		return err
+	}
+
+	return getResource(ctx, name, option.WithCredentials(googleCreds))
+}
+
+// getResource reads a project, a folder or an organization by its resource name.
+func getResource(ctx context.Context, name string, opts ...option.ClientOption) error {
+	svc, err := crm.NewService(ctx, opts...)
+	if err != nil {
+		return ErrInitializingClient
+	}
+
+	switch {
+	case strings.HasPrefix(name, "folders/"):
+		_, err = svc.Folders.Get(name).Context(ctx).Do()
+	case strings.HasPrefix(name, "organizations/"):
+		_, err = svc.Organizations.Get(name).Context(ctx).Do()
+	default:
+		_, err = svc.Projects.Get(name).Context(ctx).Do()
+	}
+
+	return err
+}
@@ -11,6 +11,28 @@ import (
 type SQLClient interface {
 	InstanceLister
 	Idler
+	GetInstanceStatus(ctx *gofr.Context, projectID, instanceName string) (string, error)
+}
+
+// GKEClient lists the node pools of the GKE clusters and scales them to zero and back.
+type GKEClient interface {
+	GetAllNodePools(ctx *gofr.Context, projectID string) ([]models.Resource, error)
+	GetNodePoolStatus(ctx *gofr.Context, uid string) (string, error)
+	SuspendNodePool(ctx *gofr.Context, uid string) (models.Settings, error)
+	StartNodePool(ctx *gofr.Context, uid string, previous models.Settings) error
+}
+
+// ServerlessClient lists the serverless workloads of a project and suspends the Cloud Run services.
+type ServerlessClient interface {
+	GetAllServerless(ctx *gofr.Context, projectID string) ([]models.Resource, error)
+	GetServiceStatus(ctx *gofr.Context, name string) (string, error)
+	SuspendService(ctx *gofr.Context, name string) (models.Settings, error)
+	StartService(ctx *gofr.Context, name string, previous models.Settings) error
+}
+
+// StorageClient lists the Cloud Storage buckets and the persistent disks of a project, they are inventoried only.
+type StorageClient interface {
+	GetAllStorage(ctx *gofr.Context, projectID string) ([]models.Resource, error)
 }
 
 type MetricsClient interface {
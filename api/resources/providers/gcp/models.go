@@ -1,5 +1,10 @@
 package gcp
 
+import "strings"
+
+// credentials are the key of a service account along with the scope of the cloud account. A cloud account covers the
+// project of its key unless it lists its projects or names a folder or an organization, and it acts as the service
+// account of its key unless it impersonates another service account.
 type credentials struct {
 	Type                    string `json:"type"`
 	ProjectID               string `json:"project_id"`
@@ -12,4 +17,36 @@ type credentials struct {
 	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
 	ClientX509CertURL       string `json:"client_x509_cert_url"`
 	UniverseDomain          string `json:"universe_domain"`
+
+	// Projects is the comma separated list of the projects of the cloud account.
+	Projects       string `json:"projects"`
+	FolderID       string `json:"folder_id"`
+	OrganizationID string `json:"organization_id"`
+	// ImpersonateServiceAccount is the email of the service account the key creates its tokens for.
+	ImpersonateServiceAccount string `json:"impersonate_service_account"`
+}
+
+// parent returns the resource name of the folder or the organization of the cloud account, if any.
+func (c *credentials) parent() string {
+	switch {
+	case c.FolderID != "":
+		return "folders/" + c.FolderID
+	case c.OrganizationID != "":
+		return "organizations/" + c.OrganizationID
+	default:
+		return ""
+	}
+}
+
+// projects returns the projects listed by the cloud account.
+func (c *credentials) projects() []string {
+	projects := make([]string, 0)
+
+	for _, p := range strings.Split(c.Projects, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			projects = append(projects, p)
+		}
+	}
+
+	return projects
 }
@@ -0,0 +1,56 @@
+package gcp
+
+import (
+	"context"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/option"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// TestPermissions returns which of the given IAM permissions the service account of the credentials is granted on
+// the first project of the cloud account. Getting a token for the service account also validates the credentials.
+func (c *Client) TestPermissions(ctx context.Context, creds any, permissions []string) (map[string]bool, error) {
+	projects, err := c.GetProjects(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projects) == 0 {
+		return nil, ErrNoProjects
+	}
+
+	googleCreds, err := c.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return testProjectPermissions(ctx, projects[0], permissions, option.WithCredentials(googleCreds))
+}
+
+func testProjectPermissions(ctx context.Context, projectID string, permissions []string,
+	opts ...option.ClientOption) (map[string]bool, error) {
+	crm, err := cloudresourcemanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	resp, err := crm.Projects.TestIamPermissions(projectID,
+		&cloudresourcemanager.TestIamPermissionsRequest{Permissions: permissions}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string]bool, len(permissions))
+
+	for _, p := range permissions {
+		granted[p] = false
+	}
+
+	for _, p := range resp.Permissions {
+		granted[p] = true
+	}
+
+	return granted, nil
+}
@@ -0,0 +1,79 @@
+package gcp
+
+import (
+	"context"
+
+	crm "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/option"
+)
+
+const stateActive = "ACTIVE"
+
+// GetProjects returns the IDs of the projects covered by a cloud account: the projects it lists, the active projects
+// of its folder or organization and of their sub-folders, or the project of its key otherwise.
+func (c *Client) GetProjects(ctx context.Context, cred any) ([]string, error) {
+	gcpCreds, err := getCredentials(cred)
+	if err != nil {
+		return nil, err
+	}
+
+	if gcpCreds.Projects != "" {
+		return gcpCreds.projects(), nil
+	}
+
+	parent := gcpCreds.parent()
+	if parent == "" {
+		return []string{gcpCreds.ProjectID}, nil
+	}
+
+	googleCreds, err := c.NewGoogleCredentials(ctx, cred, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjects(ctx, parent, option.WithCredentials(googleCreds))
+}
+
+// listProjects walks the folders under a folder or an organization and returns the active projects found in them.
+func listProjects(ctx context.Context, parent string, opts ...option.ClientOption) ([]string, error) {
+	svc, err := crm.NewService(ctx, opts...)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	projects := make([]string, 0)
+	parents := []string{parent}
+
+	for len(parents) > 0 {
+		p := parents[0]
+		parents = parents[1:]
+
+		err = svc.Projects.List().Parent(p).Pages(ctx, func(resp *crm.ListProjectsResponse) error {
+			for _, project := range resp.Projects {
+				if project.State == stateActive {
+					projects = append(projects, project.ProjectId)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = svc.Folders.List().Parent(p).Pages(ctx, func(resp *crm.ListFoldersResponse) error {
+			for _, folder := range resp.Folders {
+				if folder.State == stateActive {
+					parents = append(parents, folder.Name)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return projects, nil
+}
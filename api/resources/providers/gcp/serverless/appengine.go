@@ -0,0 +1,87 @@
+package serverless
+
+import (
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/appengine/v1"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// servingStatusStopped is the serving status of an App Engine version that does not serve any request.
+const servingStatusStopped = "STOPPED"
+
+// getAllAppEngineVersions returns the versions of all the services of the App Engine application of the project,
+// the traffic split of a service is recorded on each of its versions.
+func (c *Client) getAllAppEngineVersions(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	app, err := c.AppEngine.Apps.Get(projectID).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]models.Resource, 0)
+
+	err = c.AppEngine.Apps.Services.List(projectID).Pages(ctx, func(resp *appengine.ListServicesResponse) error {
+		for _, svc := range resp.Services {
+			err := c.AppEngine.Apps.Services.Versions.List(projectID, svc.Id).Pages(ctx,
+				func(vResp *appengine.ListVersionsResponse) error {
+					for _, v := range vResp.Versions {
+						versions = append(versions, toAppEngineResource(app.LocationId, svc, v))
+					}
+
+					return nil
+				})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func toAppEngineResource(location string, svc *appengine.Service, v *appengine.Version) models.Resource {
+	status := RUNNING
+	if v.ServingStatus == servingStatusStopped {
+		status = STOPPED
+	}
+
+	var split float64
+
+	if svc.Split != nil {
+		split = svc.Split.Allocations[v.Id]
+	}
+
+	settings := models.Settings{
+		"service":        svc.Id,
+		"runtime":        v.Runtime,
+		"env":            v.Env,
+		"instance_class": v.InstanceClass,
+		"traffic_split":  split,
+	}
+
+	switch {
+	case v.AutomaticScaling != nil && v.AutomaticScaling.StandardSchedulerSettings != nil:
+		settings["min_instances"] = v.AutomaticScaling.StandardSchedulerSettings.MinInstances
+		settings["max_instances"] = v.AutomaticScaling.StandardSchedulerSettings.MaxInstances
+	case v.AutomaticScaling != nil:
+		settings["min_instances"] = v.AutomaticScaling.MinTotalInstances
+	case v.ManualScaling != nil:
+		settings["min_instances"] = v.ManualScaling.Instances
+	}
+
+	return models.Resource{
+		Name:         svc.Id + "/" + v.Id,
+		Type:         AppEngineVersion,
+		UID:          v.Name,
+		Region:       location,
+		CreationTime: v.CreateTime,
+		Status:       status,
+		Settings:     settings,
+		Labels:       getLabels(svc.Labels),
+	}
+}
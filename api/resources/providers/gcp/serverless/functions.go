@@ -0,0 +1,61 @@
+package serverless
+
+import (
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/cloudfunctions/v2"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// functionActive is the state of a Cloud Function that is deployed and can be invoked.
+const functionActive = "ACTIVE"
+
+func (c *Client) getAllFunctions(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	functions := make([]models.Resource, 0)
+
+	err := c.Functions.Projects.Locations.Functions.List(fmt.Sprintf("projects/%s/locations/%s", projectID, allLocations)).
+		Pages(ctx, func(resp *cloudfunctions.ListFunctionsResponse) error {
+			for _, fn := range resp.Functions {
+				functions = append(functions, toFunctionResource(fn))
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return functions, nil
+}
+
+func toFunctionResource(fn *cloudfunctions.Function) models.Resource {
+	status := fn.State
+	if status == functionActive {
+		status = RUNNING
+	}
+
+	settings := models.Settings{"environment": fn.Environment}
+
+	if fn.BuildConfig != nil {
+		settings["runtime"] = fn.BuildConfig.Runtime
+	}
+
+	if fn.ServiceConfig != nil {
+		settings["min_instance_count"] = fn.ServiceConfig.MinInstanceCount
+		settings["max_instance_count"] = fn.ServiceConfig.MaxInstanceCount
+		settings["available_memory"] = fn.ServiceConfig.AvailableMemory
+	}
+
+	return models.Resource{
+		Name:         getShortName(fn.Name),
+		Type:         CloudFunction,
+		UID:          fn.Name,
+		Region:       getLocation(fn.Name),
+		CreationTime: fn.CreateTime,
+		Status:       status,
+		Settings:     settings,
+		Labels:       getLabels(fn.Labels),
+	}
+}
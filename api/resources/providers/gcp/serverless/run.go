@@ -0,0 +1,277 @@
+package serverless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/googleapi"
+	run "google.golang.org/api/run/v2"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+const (
+	// ingressNone disables the ingress of a Cloud Run service, no request reaches the service.
+	ingressNone = "INGRESS_TRAFFIC_NONE"
+	// ingressAll is the default ingress of a Cloud Run service.
+	ingressAll = "INGRESS_TRAFFIC_ALL"
+)
+
+// ErrConflict is returned when the Cloud Run service is being changed by another operation.
+type ErrConflict struct {
+	Message string `json:"message"`
+}
+
+func (e *ErrConflict) Error() string {
+	return e.Message
+}
+
+func (*ErrConflict) StatusCode() int {
+	return http.StatusConflict
+}
+
+// runState is the configuration of a Cloud Run service before it was suspended, it is stored in the settings
+// of the resource so that the service can be restored on start.
+type runState struct {
+	Ingress string `json:"ingress"`
+	// MinInstanceCount is the minimum number of instances of the revisions of the service.
+	MinInstanceCount int64 `json:"min_instance_count"`
+	// ServiceMinInstanceCount is the minimum number of instances of the service across all its revisions.
+	ServiceMinInstanceCount int64 `json:"service_min_instance_count"`
+}
+
+type traffic struct {
+	Type     string `json:"type"`
+	Revision string `json:"revision,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Percent  int64  `json:"percent"`
+}
+
+func (c *Client) getAllRunServices(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	services := make([]models.Resource, 0)
+
+	err := c.Run.Projects.Locations.Services.List(fmt.Sprintf("projects/%s/locations/%s", projectID, allLocations)).
+		Pages(ctx, func(resp *run.GoogleCloudRunV2ListServicesResponse) error {
+			for _, svc := range resp.Services {
+				services = append(services, toRunServiceResource(svc))
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+func toRunServiceResource(svc *run.GoogleCloudRunV2Service) models.Resource {
+	state := getRunState(svc)
+
+	settings := models.Settings{
+		"ingress":                    state.Ingress,
+		"min_instance_count":         state.MinInstanceCount,
+		"service_min_instance_count": state.ServiceMinInstanceCount,
+		"traffic":                    getTraffic(svc.Traffic),
+		"uri":                        svc.Uri,
+	}
+
+	if svc.Template != nil && svc.Template.Scaling != nil {
+		settings["max_instance_count"] = svc.Template.Scaling.MaxInstanceCount
+	}
+
+	status := RUNNING
+	if svc.Ingress == ingressNone {
+		status = STOPPED
+	}
+
+	return models.Resource{
+		Name:         getShortName(svc.Name),
+		Type:         CloudRunService,
+		UID:          svc.Name,
+		Region:       getLocation(svc.Name),
+		CreationTime: svc.CreateTime,
+		Status:       status,
+		Settings:     settings,
+		Labels:       getLabels(svc.Labels),
+	}
+}
+
+func getRunState(svc *run.GoogleCloudRunV2Service) runState {
+	state := runState{Ingress: svc.Ingress}
+
+	if svc.Template != nil && svc.Template.Scaling != nil {
+		state.MinInstanceCount = svc.Template.Scaling.MinInstanceCount
+	}
+
+	if svc.Scaling != nil {
+		state.ServiceMinInstanceCount = svc.Scaling.MinInstanceCount
+	}
+
+	return state
+}
+
+func getTraffic(targets []*run.GoogleCloudRunV2TrafficTarget) []traffic {
+	split := make([]traffic, 0, len(targets))
+
+	for _, t := range targets {
+		split = append(split, traffic{Type: t.Type, Revision: t.Revision, Tag: t.Tag, Percent: t.Percent})
+	}
+
+	return split
+}
+
+func (c *Client) getAllRunJobs(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	jobs := make([]models.Resource, 0)
+
+	err := c.Run.Projects.Locations.Jobs.List(fmt.Sprintf("projects/%s/locations/%s", projectID, allLocations)).
+		Pages(ctx, func(resp *run.GoogleCloudRunV2ListJobsResponse) error {
+			for _, job := range resp.Jobs {
+				jobs = append(jobs, toRunJobResource(job))
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func toRunJobResource(job *run.GoogleCloudRunV2Job) models.Resource {
+	// A job only runs while one of its executions is in progress.
+	status := STOPPED
+	if job.LatestCreatedExecution != nil && job.LatestCreatedExecution.CompletionTime == "" {
+		status = RUNNING
+	}
+
+	settings := models.Settings{"execution_count": job.ExecutionCount}
+
+	if job.Template != nil {
+		settings["task_count"] = job.Template.TaskCount
+		settings["parallelism"] = job.Template.Parallelism
+	}
+
+	return models.Resource{
+		Name:         getShortName(job.Name),
+		Type:         CloudRunJob,
+		UID:          job.Name,
+		Region:       getLocation(job.Name),
+		CreationTime: job.CreateTime,
+		Status:       status,
+		Settings:     settings,
+		Labels:       getLabels(job.Labels),
+	}
+}
+
+// GetServiceStatus returns the status of a Cloud Run service, it is stopped while its ingress is disabled.
+func (c *Client) GetServiceStatus(ctx *gofr.Context, name string) (string, error) {
+	svc, err := c.Run.Projects.Locations.Services.Get(name).Context(ctx).Do()
+	if err != nil {
+		return "", getError(err)
+	}
+
+	return toRunServiceResource(svc).Status, nil
+}
+
+// SuspendService sets the minimum number of instances of a Cloud Run service to zero and disables its ingress,
+// so that no instance is kept or started while it is suspended. The configuration of the service before it was
+// suspended is returned so that it can be restored by StartService.
+func (c *Client) SuspendService(ctx *gofr.Context, name string) (models.Settings, error) {
+	svc, err := c.Run.Projects.Locations.Services.Get(name).Context(ctx).Do()
+	if err != nil {
+		return nil, getError(err)
+	}
+
+	prev := getRunState(svc)
+
+	setRunState(svc, runState{Ingress: ingressNone})
+
+	_, err = c.Run.Projects.Locations.Services.Patch(name, svc).Context(ctx).Do()
+	if err != nil {
+		return nil, getError(err)
+	}
+
+	return prev.toSettings(), nil
+}
+
+// StartService restores the ingress and minimum number of instances of a Cloud Run service recorded when it was
+// suspended. A service without a recorded configuration gets the default ingress.
+func (c *Client) StartService(ctx *gofr.Context, name string, previous models.Settings) error {
+	svc, err := c.Run.Projects.Locations.Services.Get(name).Context(ctx).Do()
+	if err != nil {
+		return getError(err)
+	}
+
+	prev := getPreviousRunState(previous)
+	if prev.Ingress == "" || prev.Ingress == ingressNone {
+		prev.Ingress = ingressAll
+	}
+
+	setRunState(svc, prev)
+
+	_, err = c.Run.Projects.Locations.Services.Patch(name, svc).Context(ctx).Do()
+	if err != nil {
+		return getError(err)
+	}
+
+	return nil
+}
+
+func setRunState(svc *run.GoogleCloudRunV2Service, state runState) {
+	svc.Ingress = state.Ingress
+
+	if svc.Template == nil {
+		svc.Template = &run.GoogleCloudRunV2RevisionTemplate{}
+	}
+
+	if svc.Template.Scaling == nil {
+		svc.Template.Scaling = &run.GoogleCloudRunV2RevisionScaling{}
+	}
+
+	svc.Template.Scaling.MinInstanceCount = state.MinInstanceCount
+
+	if svc.Scaling != nil || state.ServiceMinInstanceCount > 0 {
+		if svc.Scaling == nil {
+			svc.Scaling = &run.GoogleCloudRunV2ServiceScaling{}
+		}
+
+		svc.Scaling.MinInstanceCount = state.ServiceMinInstanceCount
+	}
+}
+
+func (s *runState) toSettings() models.Settings {
+	return models.Settings{
+		"ingress":                    s.Ingress,
+		"min_instance_count":         s.MinInstanceCount,
+		"service_min_instance_count": s.ServiceMinInstanceCount,
+	}
+}
+
+func getPreviousRunState(settings models.Settings) runState {
+	var state runState
+
+	// The settings read back from the store hold the numbers as float64, hence they are decoded through JSON.
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return state
+	}
+
+	_ = json.Unmarshal(b, &state)
+
+	return state
+}
+
+func getError(err error) error {
+	var gErr *googleapi.Error
+
+	if errors.As(err, &gErr) && gErr.Code == http.StatusConflict {
+		return &ErrConflict{Message: gErr.Message}
+	}
+
+	return err
+}
@@ -0,0 +1,115 @@
+// Package serverless discovers the serverless workloads of a GCP project, i.e. Cloud Run services and jobs,
+// App Engine versions and Cloud Functions, and suspends the Cloud Run services.
+package serverless
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/appengine/v1"
+	"google.golang.org/api/cloudfunctions/v2"
+	"google.golang.org/api/googleapi"
+	run "google.golang.org/api/run/v2"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/apis"
+)
+
+const (
+	// RUNNING serverless state for zopdev.
+	RUNNING = "RUNNING"
+	// STOPPED serverless state for zopdev.
+	STOPPED = "STOPPED"
+
+	// Resource types of the serverless workloads.
+
+	CloudRunService  = "CLOUD_RUN_SERVICE"
+	CloudRunJob      = "CLOUD_RUN_JOB"
+	AppEngineVersion = "APP_ENGINE_VERSION"
+	CloudFunction    = "CLOUD_FUNCTION"
+
+	// allLocations lists the resources of all the locations of a project.
+	allLocations = "-"
+	// locationNameIndex is the index of the location in a resource name split by "/".
+	locationNameIndex = 3
+)
+
+type Client struct {
+	Run       *run.Service
+	AppEngine *appengine.APIService
+	Functions *cloudfunctions.Service
+}
+
+// GetAllServerless returns the Cloud Run services and jobs, App Engine versions and Cloud Functions of the project.
+// The products whose API is not enabled in the project are skipped.
+func (c *Client) GetAllServerless(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	listers := []func(*gofr.Context, string) ([]models.Resource, error){
+		c.getAllRunServices, c.getAllRunJobs, c.getAllAppEngineVersions, c.getAllFunctions,
+	}
+	results := make([][]models.Resource, len(listers))
+
+	var g errgroup.Group
+
+	for i, list := range listers {
+		g.Go(func() error {
+			res, err := list(ctx, projectID)
+			if err != nil && !isNotEnabled(err) {
+				return err
+			}
+
+			results[i] = res
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	all := make([]models.Resource, 0)
+
+	for _, r := range results {
+		all = append(all, r...)
+	}
+
+	return all, nil
+}
+
+// isNotEnabled reports whether the error is returned because the API, or the App Engine application,
+// is not enabled in the project.
+func isNotEnabled(err error) bool {
+	var gErr *googleapi.Error
+
+	if errors.As(err, &gErr) && gErr.Code == http.StatusNotFound {
+		return true
+	}
+
+	return apis.IsDisabled(err)
+}
+
+// getLocation returns the location of a resource named `projects/{project}/locations/{location}/...`.
+func getLocation(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) <= locationNameIndex {
+		return ""
+	}
+
+	return parts[locationNameIndex]
+}
+
+// getShortName returns the last part of a resource name.
+func getShortName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+func getLabels(labels map[string]string) models.Labels {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	return models.Labels(labels)
+}
@@ -0,0 +1,242 @@
+package serverless
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/appengine/v1"
+	"google.golang.org/api/cloudfunctions/v2"
+	"google.golang.org/api/option"
+	run "google.golang.org/api/run/v2"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+const servicePath = "/v2/projects/test-project/locations/us-central1/services/api"
+
+// apiError is served as a Google API error response.
+type apiError struct {
+	code int
+	body string
+}
+
+// newClient returns a client whose APIs are served from the given responses by request path,
+// the body of the last PATCH request is written to patched.
+func newClient(t *testing.T, responses map[string]any, patched *string) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			*patched = string(body)
+		}
+
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+			return
+		}
+
+		if code, ok := resp.(int); ok {
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		if e, ok := resp.(apiError); ok {
+			w.WriteHeader(e.code)
+			_, _ = w.Write([]byte(e.body))
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	opts := []option.ClientOption{option.WithoutAuthentication(), option.WithEndpoint(srv.URL)}
+
+	runSvc, err := run.NewService(context.Background(), opts...)
+	require.NoError(t, err)
+
+	appSvc, err := appengine.NewService(context.Background(), opts...)
+	require.NoError(t, err)
+
+	fnSvc, err := cloudfunctions.NewService(context.Background(), opts...)
+	require.NoError(t, err)
+
+	return &Client{Run: runSvc, AppEngine: appSvc, Functions: fnSvc}
+}
+
+func TestClient_GetAllServerless(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := newClient(t, map[string]any{
+		"/v2/projects/test-project/locations/-/services": &run.GoogleCloudRunV2ListServicesResponse{
+			Services: []*run.GoogleCloudRunV2Service{{
+				Name: "projects/test-project/locations/us-central1/services/api", Ingress: ingressAll,
+				CreateTime: "2025-01-01T00:00:00Z", Uri: "https://api.run.app", Labels: map[string]string{"env": "staging"},
+				Template: &run.GoogleCloudRunV2RevisionTemplate{
+					Scaling: &run.GoogleCloudRunV2RevisionScaling{MinInstanceCount: 2, MaxInstanceCount: 10}},
+				Traffic: []*run.GoogleCloudRunV2TrafficTarget{
+					{Type: "TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST", Percent: 90},
+					{Type: "TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION", Revision: "api-001", Percent: 10}},
+			}}},
+		"/v2/projects/test-project/locations/-/jobs": &run.GoogleCloudRunV2ListJobsResponse{
+			Jobs: []*run.GoogleCloudRunV2Job{{Name: "projects/test-project/locations/europe-west1/jobs/migrate",
+				ExecutionCount: 3, Template: &run.GoogleCloudRunV2ExecutionTemplate{TaskCount: 1, Parallelism: 1}}}},
+		"/v1/apps/test-project": &appengine.Application{LocationId: "us-central"},
+		"/v1/apps/test-project/services": &appengine.ListServicesResponse{Services: []*appengine.Service{
+			{Id: "default", Split: &appengine.TrafficSplit{Allocations: map[string]float64{"v2": 1}}}}},
+		"/v1/apps/test-project/services/default/versions": &appengine.ListVersionsResponse{Versions: []*appengine.Version{
+			{Id: "v1", Name: "apps/test-project/services/default/versions/v1", ServingStatus: servingStatusStopped,
+				Runtime: "go122", Env: "standard", ManualScaling: &appengine.ManualScaling{Instances: 1}},
+			{Id: "v2", Name: "apps/test-project/services/default/versions/v2", ServingStatus: "SERVING",
+				Runtime: "go122", Env: "standard", AutomaticScaling: &appengine.AutomaticScaling{
+					StandardSchedulerSettings: &appengine.StandardSchedulerSettings{MinInstances: 1, MaxInstances: 5}}},
+		}},
+		// The Cloud Functions API is not enabled in the project.
+		"/v2/projects/test-project/locations/-/functions": apiError{code: http.StatusForbidden, body: `{"error":{"code":403,
+			"message":"Cloud Functions API has not been used in project test-project before or it is disabled.",
+			"errors":[{"reason":"accessNotConfigured"}]}}`},
+	}, nil)
+
+	expected := []models.Resource{
+		{Name: "api", Type: CloudRunService, UID: "projects/test-project/locations/us-central1/services/api",
+			Region: "us-central1", CreationTime: "2025-01-01T00:00:00Z", Status: RUNNING, Labels: models.Labels{"env": "staging"},
+			Settings: models.Settings{"ingress": ingressAll, "min_instance_count": int64(2), "service_min_instance_count": int64(0),
+				"max_instance_count": int64(10), "uri": "https://api.run.app", "traffic": []traffic{
+					{Type: "TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST", Percent: 90},
+					{Type: "TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION", Revision: "api-001", Percent: 10}}}},
+		{Name: "migrate", Type: CloudRunJob, UID: "projects/test-project/locations/europe-west1/jobs/migrate",
+			Region: "europe-west1", Status: STOPPED,
+			Settings: models.Settings{"execution_count": int64(3), "task_count": int64(1), "parallelism": int64(1)}},
+		{Name: "default/v1", Type: AppEngineVersion, UID: "apps/test-project/services/default/versions/v1",
+			Region: "us-central", Status: STOPPED, Settings: models.Settings{"service": "default", "runtime": "go122",
+				"env": "standard", "instance_class": "", "traffic_split": float64(0), "min_instances": int64(1)}},
+		{Name: "default/v2", Type: AppEngineVersion, UID: "apps/test-project/services/default/versions/v2",
+			Region: "us-central", Status: RUNNING, Settings: models.Settings{"service": "default", "runtime": "go122",
+				"env": "standard", "instance_class": "", "traffic_split": float64(1), "min_instances": int64(1),
+				"max_instances": int64(5)}},
+	}
+
+	res, err := c.GetAllServerless(ctx, "test-project")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestClient_GetAllServerless_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := newClient(t, map[string]any{
+		"/v2/projects/test-project/locations/-/services": http.StatusInternalServerError,
+	}, nil)
+
+	res, err := c.GetAllServerless(ctx, "test-project")
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func Test_toFunctionResource(t *testing.T) {
+	fn := &cloudfunctions.Function{Name: "projects/test-project/locations/us-east1/functions/resize", State: functionActive,
+		Environment: "GEN_2", BuildConfig: &cloudfunctions.BuildConfig{Runtime: "go122"},
+		ServiceConfig: &cloudfunctions.ServiceConfig{MinInstanceCount: 1, MaxInstanceCount: 3, AvailableMemory: "256M"}}
+
+	expected := models.Resource{Name: "resize", Type: CloudFunction, UID: fn.Name, Region: "us-east1", Status: RUNNING,
+		Settings: models.Settings{"environment": "GEN_2", "runtime": "go122", "min_instance_count": int64(1),
+			"max_instance_count": int64(3), "available_memory": "256M"}}
+
+	assert.Equal(t, expected, toFunctionResource(fn))
+}
+
+func TestClient_GetServiceStatus(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := newClient(t, map[string]any{servicePath: &run.GoogleCloudRunV2Service{
+		Name: "projects/test-project/locations/us-central1/services/api", Ingress: ingressNone}}, nil)
+
+	status, err := c.GetServiceStatus(ctx, "projects/test-project/locations/us-central1/services/api")
+
+	require.NoError(t, err)
+	assert.Equal(t, STOPPED, status)
+
+	c = newClient(t, map[string]any{servicePath: http.StatusConflict}, nil)
+
+	_, err = c.GetServiceStatus(ctx, "projects/test-project/locations/us-central1/services/api")
+
+	assert.IsType(t, &ErrConflict{}, err)
+}
+
+func TestClient_SuspendService(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	var patched string
+
+	c := newClient(t, map[string]any{
+		servicePath: &run.GoogleCloudRunV2Service{Name: "projects/test-project/locations/us-central1/services/api",
+			Ingress: "INGRESS_TRAFFIC_INTERNAL_ONLY", Scaling: &run.GoogleCloudRunV2ServiceScaling{MinInstanceCount: 1},
+			Template: &run.GoogleCloudRunV2RevisionTemplate{
+				Scaling: &run.GoogleCloudRunV2RevisionScaling{MinInstanceCount: 2, MaxInstanceCount: 10}}},
+	}, &patched)
+
+	prev, err := c.SuspendService(ctx, "projects/test-project/locations/us-central1/services/api")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.Settings{"ingress": "INGRESS_TRAFFIC_INTERNAL_ONLY", "min_instance_count": int64(2),
+		"service_min_instance_count": int64(1)}, prev)
+	assert.JSONEq(t, `{"name":"projects/test-project/locations/us-central1/services/api","ingress":"INGRESS_TRAFFIC_NONE",
+		"scaling":{},"template":{"scaling":{"maxInstanceCount":10}}}`, patched)
+}
+
+func TestClient_StartService(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	testCases := []struct {
+		name     string
+		previous models.Settings
+		expPatch string
+	}{
+		{
+			name: "restore previous configuration",
+			previous: models.Settings{"ingress": "INGRESS_TRAFFIC_INTERNAL_ONLY", "min_instance_count": float64(2),
+				"service_min_instance_count": float64(1)},
+			expPatch: `{"ingress":"INGRESS_TRAFFIC_INTERNAL_ONLY","scaling":{"minInstanceCount":1},
+				"template":{"scaling":{"minInstanceCount":2}}}`,
+		},
+		{
+			name:     "no previous configuration",
+			expPatch: `{"ingress":"INGRESS_TRAFFIC_ALL","template":{"scaling":{}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var patched string
+
+			c := newClient(t, map[string]any{servicePath: &run.GoogleCloudRunV2Service{Ingress: ingressNone}}, &patched)
+
+			err := c.StartService(ctx, "projects/test-project/locations/us-central1/services/api", tc.previous)
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expPatch, patched)
+		})
+	}
+}
+
+func TestClient_SuspendService_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := newClient(t, map[string]any{servicePath: http.StatusConflict}, nil)
+
+	prev, err := c.SuspendService(ctx, "projects/test-project/locations/us-central1/services/api")
+
+	assert.Nil(t, prev)
+	assert.IsType(t, &ErrConflict{}, err)
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/monitoring/v3"
+	gcs "google.golang.org/api/storage/v1"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/apis"
+)
+
+const (
+	// publicAccessEnforced is the public access prevention of the buckets that can never be made public.
+	publicAccessEnforced = "enforced"
+
+	// bucketSizeMetric is written once a day by Cloud Storage, hence the size is read from the last two days.
+	bucketSizeMetric = `metric.type="storage.googleapis.com/storage/total_bytes"`
+	bucketSizeWindow = 48 * time.Hour
+)
+
+// lifecycleRule is a lifecycle rule of a bucket, i.e. the objects matching the conditions are deleted
+// or moved to another storage class.
+type lifecycleRule struct {
+	Action              string   `json:"action"`
+	StorageClass        string   `json:"storage_class,omitempty"`
+	AgeDays             int64    `json:"age_days,omitempty"`
+	CreatedBefore       string   `json:"created_before,omitempty"`
+	NumNewerVersions    int64    `json:"num_newer_versions,omitempty"`
+	MatchesStorageClass []string `json:"matches_storage_class,omitempty"`
+	MatchesPrefix       []string `json:"matches_prefix,omitempty"`
+}
+
+func (c *Client) getAllBuckets(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	buckets := make([]models.Resource, 0)
+	sizes := c.getBucketSizes(ctx, projectID)
+
+	err := c.Storage.Buckets.List(projectID).Pages(ctx, func(list *gcs.Buckets) error {
+		for _, b := range list.Items {
+			settings := getBucketSettings(b)
+
+			if size, ok := sizes[b.Name]; ok {
+				settings["size_bytes"] = size
+			}
+
+			if public, ok := c.isPublic(ctx, b); ok {
+				settings["public"] = public
+			}
+
+			buckets = append(buckets, models.Resource{
+				Name:         b.Name,
+				Type:         Bucket,
+				UID:          b.Name,
+				Region:       b.Location,
+				CreationTime: b.TimeCreated,
+				Status:       AVAILABLE,
+				Settings:     settings,
+				Labels:       getLabels(b.Labels),
+			})
+		}
+
+		return nil
+	})
+	if apis.IsDisabled(err) {
+		return make([]models.Resource, 0), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func getBucketSettings(b *gcs.Bucket) models.Settings {
+	settings := models.Settings{
+		"location":      b.Location,
+		"location_type": b.LocationType,
+		"storage_class": b.StorageClass,
+	}
+
+	if b.IamConfiguration != nil {
+		settings["public_access_prevention"] = b.IamConfiguration.PublicAccessPrevention
+
+		if ubla := b.IamConfiguration.UniformBucketLevelAccess; ubla != nil {
+			settings["uniform_bucket_level_access"] = ubla.Enabled
+		}
+	}
+
+	if b.Versioning != nil {
+		settings["versioning"] = b.Versioning.Enabled
+	}
+
+	if rules := getLifecycleRules(b.Lifecycle); len(rules) > 0 {
+		settings["lifecycle_rules"] = rules
+	}
+
+	return settings
+}
+
+func getLifecycleRules(lc *gcs.BucketLifecycle) []lifecycleRule {
+	if lc == nil {
+		return nil
+	}
+
+	rules := make([]lifecycleRule, 0, len(lc.Rule))
+
+	for _, r := range lc.Rule {
+		if r.Action == nil {
+			continue
+		}
+
+		rule := lifecycleRule{Action: r.Action.Type, StorageClass: r.Action.StorageClass}
+
+		if cond := r.Condition; cond != nil {
+			if cond.Age != nil {
+				rule.AgeDays = *cond.Age
+			}
+
+			rule.CreatedBefore = cond.CreatedBefore
+			rule.NumNewerVersions = cond.NumNewerVersions
+			rule.MatchesStorageClass = cond.MatchesStorageClass
+			rule.MatchesPrefix = cond.MatchesPrefix
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// isPublic returns whether the IAM policy of the bucket grants access to anyone. The second value is false when
+// this is not known, i.e. the policy could not be read, in which case the bucket is still inventoried.
+func (c *Client) isPublic(ctx *gofr.Context, b *gcs.Bucket) (public, ok bool) {
+	if b.IamConfiguration != nil && b.IamConfiguration.PublicAccessPrevention == publicAccessEnforced {
+		return false, true
+	}
+
+	policy, err := c.Storage.Buckets.GetIamPolicy(b.Name).Context(ctx).Do()
+	if err != nil {
+		return false, false
+	}
+
+	for _, binding := range policy.Bindings {
+		for _, m := range binding.Members {
+			// allUsers and allAuthenticatedUsers grant access to anyone, with or without a Google account.
+			if m == "allUsers" || m == "allAuthenticatedUsers" {
+				return true, true
+			}
+		}
+	}
+
+	return false, true
+}
+
+// getBucketSizes returns the size in bytes of the buckets of the project, summed over their storage classes.
+// The sizes are read from Cloud Monitoring on a best effort basis, the buckets are inventoried without
+// their size when the metrics can not be read, e.g. the Monitoring API is not enabled.
+func (c *Client) getBucketSizes(ctx *gofr.Context, projectID string) map[string]int64 {
+	if c.Monitoring == nil {
+		return nil
+	}
+
+	sizes := make(map[string]int64)
+	end := time.Now()
+
+	err := c.Monitoring.Projects.TimeSeries.List("projects/"+projectID).
+		Filter(bucketSizeMetric).
+		IntervalStartTime(end.Add(-bucketSizeWindow).Format(time.RFC3339)).
+		IntervalEndTime(end.Format(time.RFC3339)).
+		Pages(ctx, func(resp *monitoring.ListTimeSeriesResponse) error {
+			for _, ts := range resp.TimeSeries {
+				// The points are returned in reverse time order, the first one is the latest size.
+				if ts.Resource == nil || len(ts.Points) == 0 || ts.Points[0].Value == nil || ts.Points[0].Value.DoubleValue == nil {
+					continue
+				}
+
+				sizes[ts.Resource.Labels["bucket_name"]] += int64(*ts.Points[0].Value.DoubleValue)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil
+	}
+
+	return sizes
+}
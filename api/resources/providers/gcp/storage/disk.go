@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/apis"
+)
+
+// diskReady is the status of the disks that can be used, the disks in any other status are reported as is.
+const diskReady = "READY"
+
+func (c *Client) getAllDisks(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	disks := make([]models.Resource, 0)
+
+	err := c.Compute.Disks.AggregatedList(projectID).Pages(ctx, func(list *compute.DiskAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, d := range scoped.Disks {
+				disks = append(disks, toDiskResource(projectID, d))
+			}
+		}
+
+		return nil
+	})
+	if apis.IsDisabled(err) {
+		return make([]models.Resource, 0), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return disks, nil
+}
+
+func toDiskResource(projectID string, d *compute.Disk) models.Resource {
+	// A disk is either zonal or regional, i.e. replicated across two zones of the region.
+	scope, location := "zones", getShortName(d.Zone)
+	if d.Zone == "" {
+		scope, location = "regions", getShortName(d.Region)
+	}
+
+	users := make([]string, 0, len(d.Users))
+	for _, u := range d.Users {
+		users = append(users, getShortName(u))
+	}
+
+	settings := models.Settings{
+		"size_gb":   d.SizeGb,
+		"disk_type": getShortName(d.Type),
+		"attached":  len(users) > 0,
+	}
+
+	if len(users) > 0 {
+		settings["attached_to"] = users
+	}
+
+	if d.LastAttachTimestamp != "" {
+		settings["last_attach_timestamp"] = d.LastAttachTimestamp
+	}
+
+	if d.LastDetachTimestamp != "" {
+		settings["last_detach_timestamp"] = d.LastDetachTimestamp
+	}
+
+	return models.Resource{
+		Name:         d.Name,
+		Type:         Disk,
+		UID:          fmt.Sprintf("projects/%s/%s/%s/disks/%s", projectID, scope, location, d.Name),
+		Region:       location,
+		CreationTime: d.CreationTimestamp,
+		Status:       getDiskState(d.Status, len(users) > 0),
+		Settings:     settings,
+		Labels:       getLabels(d.Labels),
+	}
+}
+
+func getDiskState(status string, attached bool) string {
+	switch {
+	case status != diskReady:
+		return status
+	case attached:
+		return INUSE
+	default:
+		return AVAILABLE
+	}
+}
@@ -0,0 +1,73 @@
+// Package storage discovers the Cloud Storage buckets and the Compute Engine persistent disks of a GCP project.
+// They are inventoried only, their state is never changed by zopdev.
+package storage
+
+import (
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/monitoring/v3"
+	gcs "google.golang.org/api/storage/v1"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+const (
+	// AVAILABLE storage state for zopdev, the bucket or disk exists and, for a disk, is not attached to any instance.
+	AVAILABLE = "AVAILABLE"
+	// INUSE storage state for zopdev, the disk is attached to at least one instance.
+	INUSE = "IN_USE"
+
+	// Bucket is the resource type of the Cloud Storage buckets.
+	Bucket = "GCS_BUCKET"
+	// Disk is the resource type of the Compute Engine persistent disks.
+	Disk = "GCE_DISK"
+)
+
+type Client struct {
+	Storage *gcs.Service
+	Compute *compute.Service
+	// Monitoring is used to read the size of the buckets, which is not exposed by the Cloud Storage API.
+	Monitoring *monitoring.Service
+}
+
+// GetAllStorage returns the Cloud Storage buckets and the persistent disks of the project. The products whose API
+// is not enabled in the project are skipped.
+func (c *Client) GetAllStorage(ctx *gofr.Context, projectID string) ([]models.Resource, error) {
+	var (
+		buckets, disks []models.Resource
+		g              errgroup.Group
+	)
+
+	g.Go(func() (err error) {
+		buckets, err = c.getAllBuckets(ctx, projectID)
+		return err
+	})
+
+	g.Go(func() (err error) {
+		disks, err = c.getAllDisks(ctx, projectID)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return append(buckets, disks...), nil
+}
+
+func getLabels(labels map[string]string) models.Labels {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	return models.Labels(labels)
+}
+
+// getShortName returns the last part of a resource URL, e.g. the zone of
+// `https://www.googleapis.com/compute/v1/projects/{project}/zones/{zone}`.
+func getShortName(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
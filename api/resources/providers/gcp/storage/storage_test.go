@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+	gcs "google.golang.org/api/storage/v1"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// newClient returns a client whose APIs are served from the given responses by request path.
+func newClient(t *testing.T, responses map[string]any) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+			return
+		}
+
+		if code, ok := resp.(int); ok {
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		if gErr, ok := resp.(*googleapi.Error); ok {
+			w.WriteHeader(gErr.Code)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": gErr})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	opts := []option.ClientOption{option.WithoutAuthentication(), option.WithEndpoint(srv.URL)}
+
+	storageSvc, err := gcs.NewService(context.Background(), opts...)
+	require.NoError(t, err)
+
+	computeSvc, err := compute.NewService(context.Background(), opts...)
+	require.NoError(t, err)
+
+	monitoringSvc, err := monitoring.NewService(context.Background(), opts...)
+	require.NoError(t, err)
+
+	return &Client{Storage: storageSvc, Compute: computeSvc, Monitoring: monitoringSvc}
+}
+
+func sizePoint(bytes float64) []*monitoring.Point {
+	return []*monitoring.Point{{Value: &monitoring.TypedValue{DoubleValue: &bytes}}}
+}
+
+func TestClient_GetAllStorage(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	age := int64(30)
+
+	c := newClient(t, map[string]any{
+		"/b": &gcs.Buckets{Items: []*gcs.Bucket{
+			{Name: "assets", Location: "US", LocationType: "multi-region", StorageClass: "STANDARD",
+				TimeCreated: "2025-01-01T00:00:00Z", Labels: map[string]string{"env": "prod"},
+				IamConfiguration: &gcs.BucketIamConfiguration{PublicAccessPrevention: "inherited",
+					UniformBucketLevelAccess: &gcs.BucketIamConfigurationUniformBucketLevelAccess{Enabled: true}},
+				Lifecycle: &gcs.BucketLifecycle{Rule: []*gcs.BucketLifecycleRule{
+					{Action: &gcs.BucketLifecycleRuleAction{Type: "SetStorageClass", StorageClass: "COLDLINE"},
+						Condition: &gcs.BucketLifecycleRuleCondition{Age: &age}},
+				}}},
+			{Name: "backups", Location: "EUROPE-WEST1", LocationType: "region", StorageClass: "NEARLINE",
+				IamConfiguration: &gcs.BucketIamConfiguration{PublicAccessPrevention: publicAccessEnforced},
+				Versioning:       &gcs.BucketVersioning{Enabled: true}},
+		}},
+		"/b/assets/iam": &gcs.Policy{Bindings: []*gcs.PolicyBindings{
+			{Role: "roles/storage.objectViewer", Members: []string{"allUsers"}}}},
+		"/v3/projects/test-project/timeSeries": &monitoring.ListTimeSeriesResponse{TimeSeries: []*monitoring.TimeSeries{
+			{Resource: &monitoring.MonitoredResource{Labels: map[string]string{"bucket_name": "assets"}}, Points: sizePoint(1024)},
+			{Resource: &monitoring.MonitoredResource{Labels: map[string]string{"bucket_name": "assets"}}, Points: sizePoint(512)},
+		}},
+		"/projects/test-project/aggregated/disks": &compute.DiskAggregatedList{Items: map[string]compute.DisksScopedList{
+			"zones/us-central1-a": {Disks: []*compute.Disk{
+				{Name: "boot", SizeGb: 10, Status: diskReady, CreationTimestamp: "2025-01-01T00:00:00Z",
+					Zone:  "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a",
+					Type:  "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/diskTypes/pd-balanced",
+					Users: []string{"https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instances/vm-1"}},
+			}},
+			"regions/us-central1": {Disks: []*compute.Disk{
+				{Name: "data", SizeGb: 200, Status: diskReady, LastDetachTimestamp: "2025-02-01T00:00:00Z",
+					Region: "https://www.googleapis.com/compute/v1/projects/test-project/regions/us-central1",
+					Type:   "https://www.googleapis.com/compute/v1/projects/test-project/regions/us-central1/diskTypes/pd-ssd"},
+			}},
+		}},
+	})
+
+	expected := []models.Resource{
+		{Name: "assets", Type: Bucket, UID: "assets", Region: "US", CreationTime: "2025-01-01T00:00:00Z", Status: AVAILABLE,
+			Labels: models.Labels{"env": "prod"}, Settings: models.Settings{"location": "US", "location_type": "multi-region",
+				"storage_class": "STANDARD", "public_access_prevention": "inherited", "uniform_bucket_level_access": true,
+				"size_bytes": int64(1536), "public": true,
+				"lifecycle_rules": []lifecycleRule{{Action: "SetStorageClass", StorageClass: "COLDLINE", AgeDays: 30}}}},
+		{Name: "backups", Type: Bucket, UID: "backups", Region: "EUROPE-WEST1", Status: AVAILABLE,
+			Settings: models.Settings{"location": "EUROPE-WEST1", "location_type": "region", "storage_class": "NEARLINE",
+				"public_access_prevention": publicAccessEnforced, "versioning": true, "public": false}},
+		{Name: "boot", Type: Disk, UID: "projects/test-project/zones/us-central1-a/disks/boot", Region: "us-central1-a",
+			CreationTime: "2025-01-01T00:00:00Z", Status: INUSE, Settings: models.Settings{"size_gb": int64(10),
+				"disk_type": "pd-balanced", "attached": true, "attached_to": []string{"vm-1"}}},
+		{Name: "data", Type: Disk, UID: "projects/test-project/regions/us-central1/disks/data", Region: "us-central1",
+			Status: AVAILABLE, Settings: models.Settings{"size_gb": int64(200), "disk_type": "pd-ssd", "attached": false,
+				"last_detach_timestamp": "2025-02-01T00:00:00Z"}},
+	}
+
+	res, err := c.GetAllStorage(ctx, "test-project")
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expected, res)
+}
+
+func TestClient_GetAllStorage_UnknownSizeAndPolicy(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	// The monitoring metrics and the IAM policy of the bucket can not be read.
+	c := newClient(t, map[string]any{
+		"/b":                                   &gcs.Buckets{Items: []*gcs.Bucket{{Name: "logs", Location: "US"}}},
+		"/b/logs/iam":                          http.StatusForbidden,
+		"/v3/projects/test-project/timeSeries": http.StatusForbidden,
+		"/projects/test-project/aggregated/disks": &compute.DiskAggregatedList{},
+	})
+
+	res, err := c.GetAllStorage(ctx, "test-project")
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{Name: "logs", Type: Bucket, UID: "logs", Region: "US", Status: AVAILABLE,
+		Settings: models.Settings{"location": "US", "location_type": "", "storage_class": ""}}}, res)
+}
+
+func TestClient_GetAllStorage_ComputeDisabled(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, map[string]any{
+		"/b": &gcs.Buckets{Items: []*gcs.Bucket{{Name: "logs", Location: "US"}}},
+		"/projects/test-project/aggregated/disks": &googleapi.Error{Code: http.StatusForbidden,
+			Message: "Compute Engine API has not been used in project test-project before or it is disabled.",
+			Errors:  []googleapi.ErrorItem{{Reason: "accessNotConfigured"}}},
+	})
+
+	res, err := c.GetAllStorage(ctx, "test-project")
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{Name: "logs", Type: Bucket, UID: "logs", Region: "US", Status: AVAILABLE,
+		Settings: models.Settings{"location": "US", "location_type": "", "storage_class": ""}}}, res)
+}
+
+func TestClient_GetAllStorage_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	c := newClient(t, map[string]any{
+		"/b": &gcs.Buckets{},
+		"/projects/test-project/aggregated/disks": http.StatusInternalServerError,
+	})
+
+	res, err := c.GetAllStorage(ctx, "test-project")
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func Test_getDiskState(t *testing.T) {
+	assert.Equal(t, INUSE, getDiskState(diskReady, true))
+	assert.Equal(t, AVAILABLE, getDiskState(diskReady, false))
+	assert.Equal(t, "CREATING", getDiskState("CREATING", false))
+}
@@ -0,0 +1,67 @@
+// Package compartment walks the compartment tree of an OCI tenancy, the resources of OCI are listed per compartment.
+package compartment
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+// IdentityAPI is the part of the OCI Identity client used to list the compartments.
+type IdentityAPI interface {
+	ListCompartments(ctx context.Context, request identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error)
+}
+
+// Tree is the compartment tree below a root compartment, e.g. the tenancy.
+type Tree struct {
+	Identity IdentityAPI
+	// Root is the OCID of the compartment the tree starts at, it is part of the tree.
+	Root string
+}
+
+// Compartments returns the OCIDs of the root and of all the active compartments below it.
+// The tree is walked level by level, since the whole subtree can only be listed at once from the tenancy.
+func (t *Tree) Compartments(ctx context.Context) ([]string, error) {
+	ids := []string{t.Root}
+
+	for i := 0; i < len(ids); i++ {
+		children, err := t.children(ctx, ids[i])
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, children...)
+	}
+
+	return ids, nil
+}
+
+func (t *Tree) children(ctx context.Context, parent string) ([]string, error) {
+	var (
+		ids  []string
+		page *string
+	)
+
+	for {
+		resp, err := t.Identity.ListCompartments(ctx, identity.ListCompartmentsRequest{
+			CompartmentId:  &parent,
+			Page:           page,
+			LifecycleState: identity.CompartmentLifecycleStateActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range resp.Items {
+			if c.Id != nil {
+				ids = append(ids, *c.Id)
+			}
+		}
+
+		if resp.OpcNextPage == nil {
+			return ids, nil
+		}
+
+		page = resp.OpcNextPage
+	}
+}
@@ -0,0 +1,69 @@
+package compartment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errMock = errors.New("mock error")
+
+// mockIdentity returns the pages of the children of every compartment.
+type mockIdentity struct {
+	pages map[string][][]string
+	err   error
+}
+
+func (m *mockIdentity) ListCompartments(_ context.Context,
+	req identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
+	if m.err != nil {
+		return identity.ListCompartmentsResponse{}, m.err
+	}
+
+	pages := m.pages[*req.CompartmentId]
+	if len(pages) == 0 {
+		return identity.ListCompartmentsResponse{}, nil
+	}
+
+	page := 0
+	if req.Page != nil {
+		page = 1
+	}
+
+	resp := identity.ListCompartmentsResponse{}
+	for _, id := range pages[page] {
+		resp.Items = append(resp.Items, identity.Compartment{Id: common.String(id)})
+	}
+
+	if page+1 < len(pages) {
+		resp.OpcNextPage = common.String("next")
+	}
+
+	return resp, nil
+}
+
+func TestTree_Compartments(t *testing.T) {
+	tree := &Tree{Root: "tenancy", Identity: &mockIdentity{pages: map[string][][]string{
+		"tenancy": {{"dev"}, {"prod"}},
+		"dev":     {{"dev-team"}},
+	}}}
+
+	ids, err := tree.Compartments(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tenancy", "dev", "prod", "dev-team"}, ids)
+}
+
+func TestTree_Compartments_Error(t *testing.T) {
+	tree := &Tree{Root: "tenancy", Identity: &mockIdentity{err: errMock}}
+
+	ids, err := tree.Compartments(context.Background())
+
+	assert.Nil(t, ids)
+	require.ErrorIs(t, err, errMock)
+}
@@ -0,0 +1,158 @@
+// Package compute discovers the compute instances of an OCI tenancy and starts or stops them.
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/oci/compartment"
+)
+
+const (
+	// RUNNING instance state for zopdev.
+	RUNNING = "RUNNING"
+	// STOPPED instance state for zopdev.
+	STOPPED = "STOPPED"
+
+	// Instance is the resource type of the OCI compute instances.
+	Instance = "OCI_INSTANCE"
+)
+
+// ComputeAPI is the part of the OCI Compute client used by zopdev.
+type ComputeAPI interface {
+	ListInstances(ctx context.Context, request core.ListInstancesRequest) (core.ListInstancesResponse, error)
+	GetInstance(ctx context.Context, request core.GetInstanceRequest) (core.GetInstanceResponse, error)
+	InstanceAction(ctx context.Context, request core.InstanceActionRequest) (core.InstanceActionResponse, error)
+}
+
+type Client struct {
+	Compute ComputeAPI
+	Tree    *compartment.Tree
+	// Region is the region of the credentials, the instances only return the short key of some regions, e.g. iad.
+	Region string
+}
+
+// GetAllInstances returns the compute instances of all the compartments of the tree, terminated instances are skipped.
+func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
+	compartments, err := c.Tree.Compartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]models.Resource, 0)
+
+	for _, id := range compartments {
+		res, err := c.listInstances(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, res...)
+	}
+
+	return instances, nil
+}
+
+func (c *Client) listInstances(ctx context.Context, compartmentID string) ([]models.Resource, error) {
+	var (
+		instances []models.Resource
+		page      *string
+	)
+
+	for {
+		resp, err := c.Compute.ListInstances(ctx, core.ListInstancesRequest{CompartmentId: &compartmentID, Page: page})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Items {
+			if resp.Items[i].LifecycleState == core.InstanceLifecycleStateTerminated {
+				continue
+			}
+
+			instances = append(instances, c.toResource(&resp.Items[i]))
+		}
+
+		if resp.OpcNextPage == nil {
+			return instances, nil
+		}
+
+		page = resp.OpcNextPage
+	}
+}
+
+func (c *Client) toResource(in *core.Instance) models.Resource {
+	settings := models.Settings{
+		"compartment_id":      deref(in.CompartmentId),
+		"shape":               deref(in.Shape),
+		"availability_domain": deref(in.AvailabilityDomain),
+	}
+
+	if in.ShapeConfig != nil {
+		if in.ShapeConfig.Ocpus != nil {
+			settings["ocpus"] = *in.ShapeConfig.Ocpus
+		}
+
+		if in.ShapeConfig.MemoryInGBs != nil {
+			settings["memory_gb"] = *in.ShapeConfig.MemoryInGBs
+		}
+	}
+
+	var labels models.Labels
+	if len(in.FreeformTags) > 0 {
+		labels = in.FreeformTags
+	}
+
+	var creationTime string
+	if in.TimeCreated != nil {
+		creationTime = in.TimeCreated.Format(time.RFC3339)
+	}
+
+	return models.Resource{
+		Name:         deref(in.DisplayName),
+		Type:         Instance,
+		UID:          deref(in.Id),
+		Region:       c.Region,
+		CreationTime: creationTime,
+		Status:       string(in.LifecycleState),
+		Settings:     settings,
+		Labels:       labels,
+	}
+}
+
+// GetInstanceStatus returns the lifecycle state of the compute instance with the given OCID.
+func (c *Client) GetInstanceStatus(ctx *gofr.Context, id string) (string, error) {
+	resp, err := c.Compute.GetInstance(ctx, core.GetInstanceRequest{InstanceId: &id})
+	if err != nil {
+		return "", err
+	}
+
+	return string(resp.LifecycleState), nil
+}
+
+// StartInstance starts the compute instance with the given OCID.
+func (c *Client) StartInstance(ctx *gofr.Context, id string) error {
+	_, err := c.Compute.InstanceAction(ctx, core.InstanceActionRequest{InstanceId: &id, Action: core.InstanceActionActionStart})
+
+	return err
+}
+
+// StopInstance stops the compute instance with the given OCID gracefully, OCI forces the instance off if its
+// operating system does not shut down in time.
+func (c *Client) StopInstance(ctx *gofr.Context, id string) error {
+	_, err := c.Compute.InstanceAction(ctx, core.InstanceActionRequest{InstanceId: &id, Action: core.InstanceActionActionSoftstop})
+
+	return err
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
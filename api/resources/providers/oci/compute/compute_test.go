@@ -0,0 +1,132 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/oci/compartment"
+)
+
+var errMock = errors.New("mock error")
+
+// flatIdentity has no child compartments.
+type flatIdentity struct{}
+
+func (flatIdentity) ListCompartments(context.Context, identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
+	return identity.ListCompartmentsResponse{}, nil
+}
+
+type mockCompute struct {
+	instances map[string][]core.Instance
+	actions   []core.InstanceActionRequest
+	err       error
+}
+
+func (m *mockCompute) ListInstances(_ context.Context, req core.ListInstancesRequest) (core.ListInstancesResponse, error) {
+	if m.err != nil {
+		return core.ListInstancesResponse{}, m.err
+	}
+
+	return core.ListInstancesResponse{Items: m.instances[*req.CompartmentId]}, nil
+}
+
+func (m *mockCompute) GetInstance(_ context.Context, req core.GetInstanceRequest) (core.GetInstanceResponse, error) {
+	if m.err != nil {
+		return core.GetInstanceResponse{}, m.err
+	}
+
+	for _, instances := range m.instances {
+		for i := range instances {
+			if *instances[i].Id == *req.InstanceId {
+				return core.GetInstanceResponse{Instance: instances[i]}, nil
+			}
+		}
+	}
+
+	return core.GetInstanceResponse{}, errMock
+}
+
+func (m *mockCompute) InstanceAction(_ context.Context, req core.InstanceActionRequest) (core.InstanceActionResponse, error) {
+	m.actions = append(m.actions, req)
+
+	return core.InstanceActionResponse{}, m.err
+}
+
+func newClient(api *mockCompute) *Client {
+	return &Client{Compute: api, Tree: &compartment.Tree{Identity: flatIdentity{}, Root: "tenancy"}, Region: "us-ashburn-1"}
+}
+
+func TestClient_GetAllInstances(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ocpus, memory := float32(2), float32(16)
+
+	c := newClient(&mockCompute{instances: map[string][]core.Instance{"tenancy": {
+		{Id: common.String("ocid1.instance.oc1..web"), DisplayName: common.String("web"),
+			CompartmentId: common.String("tenancy"), Shape: common.String("VM.Standard.E4.Flex"),
+			AvailabilityDomain: common.String("AD-1"), LifecycleState: core.InstanceLifecycleStateRunning,
+			TimeCreated: &common.SDKTime{Time: created}, FreeformTags: map[string]string{"env": "dev"},
+			ShapeConfig: &core.InstanceShapeConfig{Ocpus: &ocpus, MemoryInGBs: &memory}},
+		{Id: common.String("ocid1.instance.oc1..old"), LifecycleState: core.InstanceLifecycleStateTerminated},
+	}}})
+
+	res, err := c.GetAllInstances(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{Name: "web", Type: Instance, UID: "ocid1.instance.oc1..web", Region: "us-ashburn-1",
+		CreationTime: "2025-01-01T00:00:00Z", Status: RUNNING, Labels: models.Labels{"env": "dev"},
+		Settings: models.Settings{"compartment_id": "tenancy", "shape": "VM.Standard.E4.Flex", "availability_domain": "AD-1",
+			"ocpus": float32(2), "memory_gb": float32(16)}}}, res)
+}
+
+func TestClient_GetAllInstances_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	res, err := newClient(&mockCompute{err: errMock}).GetAllInstances(ctx)
+
+	assert.Nil(t, res)
+	require.ErrorIs(t, err, errMock)
+}
+
+func TestClient_GetInstanceStatus(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	api := &mockCompute{instances: map[string][]core.Instance{"tenancy": {
+		{Id: common.String("ocid1.instance.oc1..web"), LifecycleState: core.InstanceLifecycleStateStopping},
+	}}}
+	c := newClient(api)
+
+	status, err := c.GetInstanceStatus(ctx, "ocid1.instance.oc1..web")
+
+	require.NoError(t, err)
+	assert.Equal(t, "STOPPING", status)
+
+	api.err = errMock
+
+	_, err = c.GetInstanceStatus(ctx, "ocid1.instance.oc1..web")
+
+	assert.Equal(t, errMock, err)
+}
+
+func TestClient_StartStopInstance(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	api := &mockCompute{}
+	c := newClient(api)
+
+	require.NoError(t, c.StartInstance(ctx, "ocid1.instance.oc1..web"))
+	require.NoError(t, c.StopInstance(ctx, "ocid1.instance.oc1..web"))
+
+	assert.Equal(t, []core.InstanceActionRequest{
+		{InstanceId: common.String("ocid1.instance.oc1..web"), Action: core.InstanceActionActionStart},
+		{InstanceId: common.String("ocid1.instance.oc1..web"), Action: core.InstanceActionActionSoftstop},
+	}, api.actions)
+}
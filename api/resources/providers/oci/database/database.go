@@ -0,0 +1,385 @@
+// Package database discovers the DB systems and Autonomous Databases of an OCI tenancy and starts or stops them.
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/oci/compartment"
+)
+
+const (
+	// RUNNING instance state for zopdev.
+	RUNNING = "RUNNING"
+	// STOPPED instance state for zopdev.
+	STOPPED = "STOPPED"
+
+	// DBSystem is the resource type of the OCI Base Database Service DB systems.
+	DBSystem = "OCI_DB_SYSTEM"
+	// AutonomousDatabase is the resource type of the OCI Autonomous Databases.
+	AutonomousDatabase = "OCI_AUTONOMOUS_DB"
+
+	dbSystemOCIDPrefix           = "ocid1.dbsystem."
+	autonomousDatabaseOCIDPrefix = "ocid1.autonomousdatabase."
+
+	stateAvailable  = "AVAILABLE"
+	stateTerminated = "TERMINATED"
+)
+
+// DatabaseAPI is the part of the OCI Database client used by zopdev.
+type DatabaseAPI interface {
+	ListDbSystems(ctx context.Context, request database.ListDbSystemsRequest) (database.ListDbSystemsResponse, error)
+	GetDbSystem(ctx context.Context, request database.GetDbSystemRequest) (database.GetDbSystemResponse, error)
+	ListDbNodes(ctx context.Context, request database.ListDbNodesRequest) (database.ListDbNodesResponse, error)
+	DbNodeAction(ctx context.Context, request database.DbNodeActionRequest) (database.DbNodeActionResponse, error)
+	GetAutonomousDatabase(ctx context.Context,
+		request database.GetAutonomousDatabaseRequest) (database.GetAutonomousDatabaseResponse, error)
+	ListAutonomousDatabases(ctx context.Context,
+		request database.ListAutonomousDatabasesRequest) (database.ListAutonomousDatabasesResponse, error)
+	StartAutonomousDatabase(ctx context.Context,
+		request database.StartAutonomousDatabaseRequest) (database.StartAutonomousDatabaseResponse, error)
+	StopAutonomousDatabase(ctx context.Context,
+		request database.StopAutonomousDatabaseRequest) (database.StopAutonomousDatabaseResponse, error)
+}
+
+type Client struct {
+	Database DatabaseAPI
+	Tree     *compartment.Tree
+	// Region is the region of the credentials, the Database API does not return it with the resources.
+	Region string
+}
+
+// ErrUnknownDatabase is returned when the OCID is not the one of a DB system or an Autonomous Database.
+type ErrUnknownDatabase struct {
+	ID string
+}
+
+func (e *ErrUnknownDatabase) Error() string {
+	return fmt.Sprintf("unknown OCI database %q", e.ID)
+}
+
+func (*ErrUnknownDatabase) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// GetAllInstances returns the DB systems and the Autonomous Databases of all the compartments of the tree,
+// terminated databases are skipped.
+func (c *Client) GetAllInstances(ctx *gofr.Context) ([]models.Resource, error) {
+	compartments, err := c.Tree.Compartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]models.Resource, 0)
+
+	for _, id := range compartments {
+		systems, err := c.listDBSystems(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		autonomous, err := c.listAutonomousDatabases(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, systems...)
+		instances = append(instances, autonomous...)
+	}
+
+	return instances, nil
+}
+
+func (c *Client) listDBSystems(ctx context.Context, compartmentID string) ([]models.Resource, error) {
+	var (
+		systems []models.Resource
+		page    *string
+	)
+
+	for {
+		resp, err := c.Database.ListDbSystems(ctx, database.ListDbSystemsRequest{CompartmentId: &compartmentID, Page: page})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Items {
+			sys := &resp.Items[i]
+			if sys.LifecycleState == database.DbSystemSummaryLifecycleStateTerminated {
+				continue
+			}
+
+			nodes, err := c.listDBNodes(ctx, compartmentID, deref(sys.Id))
+			if err != nil {
+				return nil, err
+			}
+
+			systems = append(systems, c.dbSystemResource(sys, nodes))
+		}
+
+		if resp.OpcNextPage == nil {
+			return systems, nil
+		}
+
+		page = resp.OpcNextPage
+	}
+}
+
+func (c *Client) listDBNodes(ctx context.Context, compartmentID, dbSystemID string) ([]database.DbNodeSummary, error) {
+	var (
+		nodes []database.DbNodeSummary
+		page  *string
+	)
+
+	for {
+		resp, err := c.Database.ListDbNodes(ctx, database.ListDbNodesRequest{CompartmentId: &compartmentID,
+			DbSystemId: &dbSystemID, Page: page})
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			return nodes, nil
+		}
+
+		page = resp.OpcNextPage
+	}
+}
+
+func (c *Client) dbSystemResource(sys *database.DbSystemSummary, nodes []database.DbNodeSummary) models.Resource {
+	settings := models.Settings{
+		"compartment_id":      deref(sys.CompartmentId),
+		"shape":               deref(sys.Shape),
+		"availability_domain": deref(sys.AvailabilityDomain),
+		"database_edition":    string(sys.DatabaseEdition),
+		"version":             deref(sys.Version),
+		"node_count":          len(nodes),
+	}
+
+	if sys.CpuCoreCount != nil {
+		settings["cpu_core_count"] = *sys.CpuCoreCount
+	}
+
+	if sys.DataStorageSizeInGBs != nil {
+		settings["storage_gb"] = *sys.DataStorageSizeInGBs
+	}
+
+	return models.Resource{
+		Name:         deref(sys.DisplayName),
+		Type:         DBSystem,
+		UID:          deref(sys.Id),
+		Region:       c.Region,
+		CreationTime: formatTime(sys.TimeCreated),
+		Status:       getDBSystemState(string(sys.LifecycleState), nodes),
+		Settings:     settings,
+		Labels:       getLabels(sys.FreeformTags),
+	}
+}
+
+// getDBSystemState returns the state of a DB system from the state of its nodes, the DB system stays available
+// while its nodes are stopped.
+func getDBSystemState(state string, nodes []database.DbNodeSummary) string {
+	if state != stateAvailable || len(nodes) == 0 {
+		return state
+	}
+
+	stopped := 0
+
+	for i := range nodes {
+		switch nodes[i].LifecycleState {
+		case database.DbNodeSummaryLifecycleStateAvailable:
+			return RUNNING
+		case database.DbNodeSummaryLifecycleStateStopped:
+			stopped++
+		}
+	}
+
+	if stopped == len(nodes) {
+		return STOPPED
+	}
+
+	// The nodes are starting or stopping.
+	return string(nodes[0].LifecycleState)
+}
+
+func (c *Client) listAutonomousDatabases(ctx context.Context, compartmentID string) ([]models.Resource, error) {
+	var (
+		dbs  []models.Resource
+		page *string
+	)
+
+	for {
+		resp, err := c.Database.ListAutonomousDatabases(ctx, database.ListAutonomousDatabasesRequest{
+			CompartmentId: &compartmentID, Page: page})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Items {
+			if string(resp.Items[i].LifecycleState) == stateTerminated {
+				continue
+			}
+
+			dbs = append(dbs, c.autonomousDatabaseResource(&resp.Items[i]))
+		}
+
+		if resp.OpcNextPage == nil {
+			return dbs, nil
+		}
+
+		page = resp.OpcNextPage
+	}
+}
+
+func (c *Client) autonomousDatabaseResource(db *database.AutonomousDatabaseSummary) models.Resource {
+	settings := models.Settings{
+		"compartment_id": deref(db.CompartmentId),
+		"db_name":        deref(db.DbName),
+		"db_workload":    string(db.DbWorkload),
+		"version":        deref(db.DbVersion),
+		"compute_model":  string(db.ComputeModel),
+	}
+
+	if db.ComputeCount != nil {
+		settings["compute_count"] = *db.ComputeCount
+	}
+
+	if db.DataStorageSizeInGBs != nil {
+		settings["storage_gb"] = *db.DataStorageSizeInGBs
+	}
+
+	if db.IsFreeTier != nil {
+		settings["free_tier"] = *db.IsFreeTier
+	}
+
+	return models.Resource{
+		Name:         deref(db.DisplayName),
+		Type:         AutonomousDatabase,
+		UID:          deref(db.Id),
+		Region:       c.Region,
+		CreationTime: formatTime(db.TimeCreated),
+		Status:       getAutonomousDatabaseState(string(db.LifecycleState)),
+		Settings:     settings,
+		Labels:       getLabels(db.FreeformTags),
+	}
+}
+
+// getAutonomousDatabaseState maps the state of an Autonomous Database to the states of zopdev,
+// the transient states, e.g. starting or scaling, are reported as is.
+func getAutonomousDatabaseState(state string) string {
+	if state == stateAvailable {
+		return RUNNING
+	}
+
+	return state
+}
+
+// GetInstanceStatus returns the state of the DB system or the Autonomous Database with the given OCID.
+func (c *Client) GetInstanceStatus(ctx *gofr.Context, id string) (string, error) {
+	switch {
+	case strings.HasPrefix(id, dbSystemOCIDPrefix):
+		sys, err := c.Database.GetDbSystem(ctx, database.GetDbSystemRequest{DbSystemId: &id})
+		if err != nil {
+			return "", err
+		}
+
+		nodes, err := c.listDBNodes(ctx, deref(sys.CompartmentId), id)
+		if err != nil {
+			return "", err
+		}
+
+		return getDBSystemState(string(sys.LifecycleState), nodes), nil
+	case strings.HasPrefix(id, autonomousDatabaseOCIDPrefix):
+		resp, err := c.Database.GetAutonomousDatabase(ctx, database.GetAutonomousDatabaseRequest{AutonomousDatabaseId: &id})
+		if err != nil {
+			return "", err
+		}
+
+		return getAutonomousDatabaseState(string(resp.LifecycleState)), nil
+	default:
+		return "", &ErrUnknownDatabase{ID: id}
+	}
+}
+
+// StartInstance starts the DB system or the Autonomous Database with the given OCID.
+func (c *Client) StartInstance(ctx *gofr.Context, id string) error {
+	switch {
+	case strings.HasPrefix(id, dbSystemOCIDPrefix):
+		return c.dbNodesAction(ctx, id, database.DbNodeActionActionStart)
+	case strings.HasPrefix(id, autonomousDatabaseOCIDPrefix):
+		_, err := c.Database.StartAutonomousDatabase(ctx, database.StartAutonomousDatabaseRequest{AutonomousDatabaseId: &id})
+
+		return err
+	default:
+		return &ErrUnknownDatabase{ID: id}
+	}
+}
+
+// StopInstance stops the DB system or the Autonomous Database with the given OCID.
+func (c *Client) StopInstance(ctx *gofr.Context, id string) error {
+	switch {
+	case strings.HasPrefix(id, dbSystemOCIDPrefix):
+		return c.dbNodesAction(ctx, id, database.DbNodeActionActionStop)
+	case strings.HasPrefix(id, autonomousDatabaseOCIDPrefix):
+		_, err := c.Database.StopAutonomousDatabase(ctx, database.StopAutonomousDatabaseRequest{AutonomousDatabaseId: &id})
+
+		return err
+	default:
+		return &ErrUnknownDatabase{ID: id}
+	}
+}
+
+// dbNodesAction starts or stops a DB system, i.e. all of its nodes.
+func (c *Client) dbNodesAction(ctx context.Context, dbSystemID string, action database.DbNodeActionActionEnum) error {
+	sys, err := c.Database.GetDbSystem(ctx, database.GetDbSystemRequest{DbSystemId: &dbSystemID})
+	if err != nil {
+		return err
+	}
+
+	nodes, err := c.listDBNodes(ctx, deref(sys.CompartmentId), dbSystemID)
+	if err != nil {
+		return err
+	}
+
+	for i := range nodes {
+		_, err = c.Database.DbNodeAction(ctx, database.DbNodeActionRequest{DbNodeId: nodes[i].Id, Action: action})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getLabels(tags map[string]string) models.Labels {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return tags
+}
+
+func formatTime(t *common.SDKTime) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/oci/compartment"
+)
+
+const (
+	dbSystemID   = "ocid1.dbsystem.oc1..orders"
+	autonomousID = "ocid1.autonomousdatabase.oc1..reports"
+)
+
+var errMock = errors.New("mock error")
+
+// flatIdentity has no child compartments.
+type flatIdentity struct{}
+
+func (flatIdentity) ListCompartments(context.Context, identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
+	return identity.ListCompartmentsResponse{}, nil
+}
+
+type mockDatabase struct {
+	systems     []database.DbSystemSummary
+	nodes       []database.DbNodeSummary
+	autonomous  []database.AutonomousDatabaseSummary
+	nodeActions []database.DbNodeActionRequest
+	started     []string
+	stopped     []string
+	err         error
+}
+
+func (m *mockDatabase) ListDbSystems(context.Context, database.ListDbSystemsRequest) (database.ListDbSystemsResponse, error) {
+	return database.ListDbSystemsResponse{Items: m.systems}, m.err
+}
+
+func (*mockDatabase) GetDbSystem(_ context.Context, req database.GetDbSystemRequest) (database.GetDbSystemResponse, error) {
+	return database.GetDbSystemResponse{DbSystem: database.DbSystem{Id: req.DbSystemId,
+		CompartmentId: common.String("tenancy"), LifecycleState: database.DbSystemLifecycleStateAvailable}}, nil
+}
+
+func (m *mockDatabase) ListDbNodes(context.Context, database.ListDbNodesRequest) (database.ListDbNodesResponse, error) {
+	return database.ListDbNodesResponse{Items: m.nodes}, nil
+}
+
+func (m *mockDatabase) DbNodeAction(_ context.Context, req database.DbNodeActionRequest) (database.DbNodeActionResponse, error) {
+	m.nodeActions = append(m.nodeActions, req)
+
+	return database.DbNodeActionResponse{}, nil
+}
+
+func (m *mockDatabase) GetAutonomousDatabase(_ context.Context,
+	req database.GetAutonomousDatabaseRequest) (database.GetAutonomousDatabaseResponse, error) {
+	for i := range m.autonomous {
+		if *m.autonomous[i].Id == *req.AutonomousDatabaseId {
+			return database.GetAutonomousDatabaseResponse{AutonomousDatabase: database.AutonomousDatabase{
+				Id: m.autonomous[i].Id, LifecycleState: database.AutonomousDatabaseLifecycleStateEnum(m.autonomous[i].LifecycleState)}}, nil
+		}
+	}
+
+	return database.GetAutonomousDatabaseResponse{}, errMock
+}
+
+func (m *mockDatabase) ListAutonomousDatabases(context.Context,
+	database.ListAutonomousDatabasesRequest) (database.ListAutonomousDatabasesResponse, error) {
+	return database.ListAutonomousDatabasesResponse{Items: m.autonomous}, nil
+}
+
+func (m *mockDatabase) StartAutonomousDatabase(_ context.Context,
+	req database.StartAutonomousDatabaseRequest) (database.StartAutonomousDatabaseResponse, error) {
+	m.started = append(m.started, *req.AutonomousDatabaseId)
+
+	return database.StartAutonomousDatabaseResponse{}, nil
+}
+
+func (m *mockDatabase) StopAutonomousDatabase(_ context.Context,
+	req database.StopAutonomousDatabaseRequest) (database.StopAutonomousDatabaseResponse, error) {
+	m.stopped = append(m.stopped, *req.AutonomousDatabaseId)
+
+	return database.StopAutonomousDatabaseResponse{}, nil
+}
+
+func newClient(api *mockDatabase) *Client {
+	return &Client{Database: api, Tree: &compartment.Tree{Identity: flatIdentity{}, Root: "tenancy"}, Region: "eu-frankfurt-1"}
+}
+
+func TestClient_GetAllInstances(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	cores, storage, compute := 4, 256, float32(2)
+
+	c := newClient(&mockDatabase{
+		systems: []database.DbSystemSummary{
+			{Id: common.String(dbSystemID), DisplayName: common.String("orders"), CompartmentId: common.String("tenancy"),
+				Shape: common.String("VM.Standard2.4"), AvailabilityDomain: common.String("AD-1"),
+				DatabaseEdition: database.DbSystemSummaryDatabaseEditionEnterpriseEdition, Version: common.String("19c"),
+				CpuCoreCount: &cores, DataStorageSizeInGBs: &storage,
+				LifecycleState: database.DbSystemSummaryLifecycleStateAvailable},
+			{Id: common.String("ocid1.dbsystem.oc1..old"), LifecycleState: database.DbSystemSummaryLifecycleStateTerminated},
+		},
+		nodes: []database.DbNodeSummary{{Id: common.String("node-1"),
+			LifecycleState: database.DbNodeSummaryLifecycleStateStopped}},
+		autonomous: []database.AutonomousDatabaseSummary{
+			{Id: common.String(autonomousID), DisplayName: common.String("reports"), CompartmentId: common.String("tenancy"),
+				DbName: common.String("REPORTS"), DbWorkload: database.AutonomousDatabaseSummaryDbWorkloadDw,
+				DbVersion: common.String("23ai"), ComputeModel: database.AutonomousDatabaseSummaryComputeModelEcpu,
+				ComputeCount: &compute, FreeformTags: map[string]string{"team": "bi"},
+				LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable},
+		},
+	})
+
+	res, err := c.GetAllInstances(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{
+		{Name: "orders", Type: DBSystem, UID: dbSystemID, Region: "eu-frankfurt-1", Status: STOPPED,
+			Settings: models.Settings{"compartment_id": "tenancy", "shape": "VM.Standard2.4", "availability_domain": "AD-1",
+				"database_edition": "ENTERPRISE_EDITION", "version": "19c", "node_count": 1, "cpu_core_count": 4,
+				"storage_gb": 256}},
+		{Name: "reports", Type: AutonomousDatabase, UID: autonomousID, Region: "eu-frankfurt-1", Status: RUNNING,
+			Labels: models.Labels{"team": "bi"}, Settings: models.Settings{"compartment_id": "tenancy", "db_name": "REPORTS",
+				"db_workload": "DW", "version": "23ai", "compute_model": "ECPU", "compute_count": float32(2)}},
+	}, res)
+}
+
+func TestClient_GetAllInstances_Error(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	res, err := newClient(&mockDatabase{err: errMock}).GetAllInstances(ctx)
+
+	assert.Nil(t, res)
+	require.ErrorIs(t, err, errMock)
+}
+
+func Test_getDBSystemState(t *testing.T) {
+	node := func(state database.DbNodeSummaryLifecycleStateEnum) database.DbNodeSummary {
+		return database.DbNodeSummary{LifecycleState: state}
+	}
+
+	assert.Equal(t, RUNNING, getDBSystemState(stateAvailable, []database.DbNodeSummary{
+		node(database.DbNodeSummaryLifecycleStateStopped), node(database.DbNodeSummaryLifecycleStateAvailable)}))
+	assert.Equal(t, STOPPED, getDBSystemState(stateAvailable, []database.DbNodeSummary{
+		node(database.DbNodeSummaryLifecycleStateStopped)}))
+	assert.Equal(t, "STARTING", getDBSystemState(stateAvailable, []database.DbNodeSummary{
+		node(database.DbNodeSummaryLifecycleStateStarting)}))
+	assert.Equal(t, "UPDATING", getDBSystemState("UPDATING", nil))
+}
+
+func TestClient_GetInstanceStatus(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	c := newClient(&mockDatabase{
+		nodes: []database.DbNodeSummary{{LifecycleState: database.DbNodeSummaryLifecycleStateStopped}},
+		autonomous: []database.AutonomousDatabaseSummary{{Id: common.String(autonomousID),
+			LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable}},
+	})
+
+	status, err := c.GetInstanceStatus(ctx, dbSystemID)
+
+	require.NoError(t, err)
+	assert.Equal(t, STOPPED, status)
+
+	status, err = c.GetInstanceStatus(ctx, autonomousID)
+
+	require.NoError(t, err)
+	assert.Equal(t, RUNNING, status)
+
+	_, err = c.GetInstanceStatus(ctx, "ocid1.autonomousdatabase.oc1..unknown")
+
+	assert.Equal(t, errMock, err)
+
+	_, err = c.GetInstanceStatus(ctx, "ocid1.instance.oc1..web")
+
+	assert.Equal(t, &ErrUnknownDatabase{ID: "ocid1.instance.oc1..web"}, err)
+}
+
+func TestClient_StartStopInstance(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+	api := &mockDatabase{nodes: []database.DbNodeSummary{{Id: common.String("node-1")}, {Id: common.String("node-2")}}}
+	c := newClient(api)
+
+	require.NoError(t, c.StopInstance(ctx, dbSystemID))
+	require.NoError(t, c.StartInstance(ctx, autonomousID))
+	require.NoError(t, c.StopInstance(ctx, autonomousID))
+
+	assert.Equal(t, []database.DbNodeActionRequest{
+		{DbNodeId: common.String("node-1"), Action: database.DbNodeActionActionStop},
+		{DbNodeId: common.String("node-2"), Action: database.DbNodeActionActionStop},
+	}, api.nodeActions)
+	assert.Equal(t, []string{autonomousID}, api.started)
+	assert.Equal(t, []string{autonomousID}, api.stopped)
+}
+
+func TestClient_StartInstance_UnknownDatabase(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
+
+	err := newClient(&mockDatabase{}).StartInstance(ctx, "ocid1.instance.oc1..web")
+
+	var unknownErr *ErrUnknownDatabase
+
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, http.StatusBadRequest, unknownErr.StatusCode())
+}
@@ -0,0 +1,25 @@
+package oci
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+// CheckHealth validates the API signing key of a cloud account with a single authenticated call, by reading the root
+// of its compartments.
+func (*Client) CheckHealth(ctx context.Context, creds any) error {
+	provider, ociCreds, err := newConfigurationProvider(creds)
+	if err != nil {
+		return err
+	}
+
+	identityClient, err := identity.NewIdentityClientWithConfigurationProvider(provider)
+	if err != nil {
+		return ErrInitializingClient
+	}
+
+	_, err = identityClient.GetCompartment(ctx, identity.GetCompartmentRequest{CompartmentId: &ociCreds.Compartment})
+
+	return err
+}
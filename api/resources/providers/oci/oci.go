@@ -0,0 +1,144 @@
+// Package oci creates the clients of the OCI resources supported by zopdev from the API signing key of a user.
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	ociDatabase "github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/providers/oci/compartment"
+	"github.com/zopdev/zopdev/api/resources/providers/oci/compute"
+	"github.com/zopdev/zopdev/api/resources/providers/oci/database"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid cloud credentials")
+	ErrInitializingClient = errors.New("error initializing OCI client")
+)
+
+// Credentials are the credentials of an OCI cloud account, they are shared with the OCI audit rules.
+type Credentials struct {
+	TenancyOCID string `json:"tenancy_ocid"`
+	UserOCID    string `json:"user_ocid"`
+	Region      string `json:"region"`
+	Fingerprint string `json:"fingerprint"`
+	PrivateKey  string `json:"private_key"`
+	// Compartment is the root of the compartments whose resources are read, the whole tenancy when empty.
+	Compartment string `json:"compartment"`
+}
+
+type Client struct {
+}
+
+func New() *Client {
+	return &Client{}
+}
+
+func getOCICredentials(creds any) (Credentials, error) {
+	var ociCred Credentials
+
+	ociCredBody, _ := json.Marshal(creds)
+
+	err := json.Unmarshal(ociCredBody, &ociCred)
+	if err != nil {
+		return ociCred, err
+	}
+
+	missing := make([]string, 0)
+
+	for _, param := range []struct{ name, value string }{
+		{"tenancy_ocid", ociCred.TenancyOCID},
+		{"user_ocid", ociCred.UserOCID},
+		{"region", ociCred.Region},
+		{"fingerprint", ociCred.Fingerprint},
+		{"private_key", ociCred.PrivateKey},
+	} {
+		if param.value == "" {
+			missing = append(missing, param.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return ociCred, http.ErrorMissingParam{Params: missing}
+	}
+
+	return ociCred, nil
+}
+
+// newConfigurationProvider returns the configuration of the OCI clients from the stored credentials,
+// along with the root of the compartment tree.
+func newConfigurationProvider(creds any) (common.ConfigurationProvider, Credentials, error) {
+	ociCreds, err := getOCICredentials(creds)
+	if err != nil {
+		return nil, ociCreds, ErrInvalidCredentials
+	}
+
+	// The private key is often stored with escaped new lines.
+	privateKey := strings.ReplaceAll(ociCreds.PrivateKey, "\\n", "\n")
+
+	provider := common.NewRawConfigurationProvider(ociCreds.TenancyOCID, ociCreds.UserOCID, ociCreds.Region,
+		ociCreds.Fingerprint, privateKey, nil)
+
+	if ociCreds.Compartment == "" {
+		ociCreds.Compartment = ociCreds.TenancyOCID
+	}
+
+	return provider, ociCreds, nil
+}
+
+func newTree(provider common.ConfigurationProvider, root string) (*compartment.Tree, error) {
+	identityClient, err := identity.NewIdentityClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	return &compartment.Tree{Identity: &identityClient, Root: root}, nil
+}
+
+// NewComputeClient creates a client for the compute instances of the compartment tree with stored credentials.
+func (*Client) NewComputeClient(_ context.Context, creds any) (*compute.Client, error) {
+	provider, ociCreds, err := newConfigurationProvider(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := newTree(provider, ociCreds.Compartment)
+	if err != nil {
+		return nil, err
+	}
+
+	computeClient, err := core.NewComputeClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	return &compute.Client{Compute: &computeClient, Tree: tree, Region: ociCreds.Region}, nil
+}
+
+// NewDatabaseClient creates a client for the DB systems and Autonomous Databases of the compartment tree
+// with stored credentials.
+func (*Client) NewDatabaseClient(_ context.Context, creds any) (*database.Client, error) {
+	provider, ociCreds, err := newConfigurationProvider(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := newTree(provider, ociCreds.Compartment)
+	if err != nil {
+		return nil, err
+	}
+
+	dbClient, err := ociDatabase.NewDatabaseClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	return &database.Client{Database: &dbClient, Tree: tree, Region: ociCreds.Region}, nil
+}
@@ -0,0 +1,200 @@
+package oci
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	ociDatabase "github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr/http"
+)
+
+func validCreds(t *testing.T) map[string]string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return map[string]string{"tenancy_ocid": "ocid1.tenancy.oc1..test", "user_ocid": "ocid1.user.oc1..test",
+		"region": "us-ashburn-1", "fingerprint": "aa:bb", "private_key": string(privateKey)}
+}
+
+func TestNew(t *testing.T) {
+	assert.NotNil(t, New())
+}
+
+func Test_getOCICredentials(t *testing.T) {
+	_, err := getOCICredentials(map[string]string{"tenancy_ocid": "ocid1.tenancy.oc1..test", "region": "us-ashburn-1"})
+	assert.Equal(t, http.ErrorMissingParam{Params: []string{"user_ocid", "fingerprint", "private_key"}}, err)
+
+	_, err = getOCICredentials(make(chan int))
+	require.Error(t, err)
+}
+
+func TestClient_NewComputeClient(t *testing.T) {
+	c := New()
+
+	cl, err := c.NewComputeClient(context.Background(), validCreds(t))
+
+	require.NoError(t, err)
+	assert.Equal(t, "us-ashburn-1", cl.Region)
+	// The whole tenancy is read when no compartment is given.
+	assert.Equal(t, "ocid1.tenancy.oc1..test", cl.Tree.Root)
+
+	_, err = c.NewComputeClient(context.Background(), map[string]string{})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestClient_NewDatabaseClient(t *testing.T) {
+	c := New()
+	creds := validCreds(t)
+	creds["compartment"] = "ocid1.compartment.oc1..dev"
+
+	cl, err := c.NewDatabaseClient(context.Background(), creds)
+
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.compartment.oc1..dev", cl.Tree.Root)
+
+	_, err = c.NewDatabaseClient(context.Background(), map[string]string{})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+type serviceError struct {
+	status int
+}
+
+func (serviceError) Error() string            { return "service error" }
+func (e serviceError) GetHTTPStatusCode() int { return e.status }
+func (serviceError) GetMessage() string       { return "service error" }
+func (serviceError) GetCode() string          { return "NotAuthorizedOrNotFound" }
+func (serviceError) GetOpcRequestID() string  { return "request-1" }
+
+func Test_testPermissions(t *testing.T) {
+	ctx := context.Background()
+	probes := map[string]probe{
+		PermissionInstanceInspect: func(context.Context) error { return nil },
+		PermissionDBSystemInspect: func(context.Context) error { return serviceError{status: 404} },
+		PermissionMetricInspect:   func(context.Context) error { return serviceError{status: 500} },
+		PermissionInstancePowerActions: func(context.Context) error {
+			return errors.Join(errNoProbeTarget, serviceError{status: 404})
+		},
+	}
+
+	granted, err := testPermissions(ctx, probes, []string{PermissionInstanceInspect, PermissionDBSystemInspect,
+		PermissionMetricInspect, PermissionInstancePowerActions, "BUCKET_INSPECT"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{PermissionInstanceInspect: true, PermissionDBSystemInspect: false}, granted)
+
+	probes[PermissionInstanceInspect] = func(context.Context) error { return serviceError{status: 401} }
+
+	_, err = testPermissions(ctx, probes, []string{PermissionInstanceInspect})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+// stubActions lists a compute instance and a DB node of each of the given states and records the power actions run on
+// them.
+type stubActions struct {
+	states  []string
+	listErr error
+	err     error
+	actions []string
+}
+
+func (s *stubActions) ListInstances(context.Context, core.ListInstancesRequest) (core.ListInstancesResponse, error) {
+	var resp core.ListInstancesResponse
+
+	for _, state := range s.states {
+		resp.Items = append(resp.Items, core.Instance{Id: common.String(state), LifecycleState: core.InstanceLifecycleStateEnum(state)})
+	}
+
+	return resp, s.listErr
+}
+
+func (s *stubActions) InstanceAction(_ context.Context, req core.InstanceActionRequest) (core.InstanceActionResponse, error) {
+	s.actions = append(s.actions, *req.InstanceId+":"+string(req.Action)+":"+*req.IfMatch)
+
+	return core.InstanceActionResponse{}, s.err
+}
+
+func (s *stubActions) ListDbNodes(context.Context, ociDatabase.ListDbNodesRequest) (ociDatabase.ListDbNodesResponse, error) {
+	var resp ociDatabase.ListDbNodesResponse
+
+	for _, state := range s.states {
+		resp.Items = append(resp.Items, ociDatabase.DbNodeSummary{Id: common.String(state),
+			LifecycleState: ociDatabase.DbNodeSummaryLifecycleStateEnum(state)})
+	}
+
+	return resp, s.listErr
+}
+
+func (s *stubActions) DbNodeAction(_ context.Context, req ociDatabase.DbNodeActionRequest) (ociDatabase.DbNodeActionResponse, error) {
+	s.actions = append(s.actions, *req.DbNodeId+":"+string(req.Action)+":"+*req.IfMatch)
+
+	return ociDatabase.DbNodeActionResponse{}, s.err
+}
+
+func Test_powerActionsProbes(t *testing.T) {
+	ctx := context.Background()
+	compartmentID := common.String("ocid1.compartment.oc1..dev")
+
+	testCases := []struct {
+		name       string
+		api        *stubActions
+		expErr     error
+		expActions []string
+	}{
+		{
+			// The action is authorized once OCI checks its entity tag, the resource is left untouched.
+			name: "authorized", api: &stubActions{states: []string{"TERMINATED", "RUNNING"}, err: serviceError{status: 412}},
+			expActions: []string{"RUNNING:%s:" + probeEtag},
+		},
+		{
+			name: "not authorized", api: &stubActions{states: []string{"STOPPED"}, err: serviceError{status: 404}},
+			expErr: serviceError{status: 404}, expActions: []string{"STOPPED:%s:" + probeEtag},
+		},
+		{
+			name: "no resource", api: &stubActions{states: []string{"TERMINATED"}}, expErr: errNoProbeTarget,
+		},
+		{
+			name: "resources not listed", api: &stubActions{listErr: serviceError{status: 404}}, expErr: errNoProbeTarget,
+		},
+	}
+
+	for _, tc := range testCases {
+		for action, p := range map[string]func(*stubActions) probe{
+			string(core.InstanceActionActionSoftstop): func(api *stubActions) probe {
+				return instancePowerActionsProbe(api, compartmentID)
+			},
+			string(ociDatabase.DbNodeActionActionStop): func(api *stubActions) probe {
+				return dbNodePowerActionsProbe(api, compartmentID)
+			},
+		} {
+			t.Run(tc.name+" "+action, func(t *testing.T) {
+				api := *tc.api
+
+				err := p(&api)(ctx)
+
+				require.ErrorIs(t, err, tc.expErr)
+
+				var expActions []string
+				for _, a := range tc.expActions {
+					expActions = append(expActions, fmt.Sprintf(a, action))
+				}
+
+				assert.Equal(t, expActions, api.actions)
+			})
+		}
+	}
+}
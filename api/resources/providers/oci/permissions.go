@@ -0,0 +1,201 @@
+package oci
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	ociDatabase "github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+)
+
+const (
+	// PermissionInstanceInspect lists the compute instances.
+	PermissionInstanceInspect = "INSTANCE_INSPECT"
+	// PermissionDBSystemInspect lists the DB systems.
+	PermissionDBSystemInspect = "DB_SYSTEM_INSPECT"
+	// PermissionMetricInspect lists the Monitoring metrics.
+	PermissionMetricInspect = "METRIC_INSPECT"
+	// PermissionInstancePowerActions starts and stops the compute instances.
+	PermissionInstancePowerActions = "INSTANCE_POWER_ACTIONS"
+	// PermissionDBNodePowerActions starts and stops the nodes of the DB systems.
+	PermissionDBNodePowerActions = "DB_NODE_POWER_ACTIONS"
+)
+
+const (
+	// probeEtag is the entity tag of the power actions run as probes. It never matches the one of a resource, so OCI
+	// rejects an authorized action as a failed precondition and the resource is left untouched.
+	probeEtag = "zopdev-permission-probe"
+	// probeTargets is the number of resources listed to find one to run a power action on.
+	probeTargets = 50
+)
+
+// errNoProbeTarget is returned by a probe of a power action when there is no resource to run the action on.
+var errNoProbeTarget = errors.New("no resource to probe the permission on")
+
+// probe calls an API that requires a permission.
+type probe func(ctx context.Context) error
+
+// instanceActionAPI lists the compute instances and runs their power actions.
+type instanceActionAPI interface {
+	ListInstances(ctx context.Context, request core.ListInstancesRequest) (core.ListInstancesResponse, error)
+	InstanceAction(ctx context.Context, request core.InstanceActionRequest) (core.InstanceActionResponse, error)
+}
+
+// dbNodeActionAPI lists the nodes of the DB systems and runs their power actions.
+type dbNodeActionAPI interface {
+	ListDbNodes(ctx context.Context, request ociDatabase.ListDbNodesRequest) (ociDatabase.ListDbNodesResponse, error)
+	DbNodeAction(ctx context.Context, request ociDatabase.DbNodeActionRequest) (ociDatabase.DbNodeActionResponse, error)
+}
+
+// TestPermissions returns which of the given OCI permissions the user of the credentials is granted on its
+// compartment. OCI has no API to test permissions, so the permissions to inspect resources are tested by listing them,
+// and the power actions by stopping a resource of the compartment with an entity tag that never matches. A power
+// action is not in the result when the compartment has no resource to run it on, nor are the permissions without a
+// probe. The calls also validate the API signing key.
+func (*Client) TestPermissions(ctx context.Context, creds any, permissions []string) (map[string]bool, error) {
+	provider, ociCreds, err := newConfigurationProvider(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	computeClient, err := core.NewComputeClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	dbClient, err := ociDatabase.NewDatabaseClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	monitoringClient, err := monitoring.NewMonitoringClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, ErrInitializingClient
+	}
+
+	compartmentID := &ociCreds.Compartment
+	limit := common.Int(1)
+
+	probes := map[string]probe{
+		PermissionInstanceInspect: func(ctx context.Context) error {
+			_, err := computeClient.ListInstances(ctx, core.ListInstancesRequest{CompartmentId: compartmentID, Limit: limit})
+			return err
+		},
+		PermissionDBSystemInspect: func(ctx context.Context) error {
+			_, err := dbClient.ListDbSystems(ctx, ociDatabase.ListDbSystemsRequest{CompartmentId: compartmentID, Limit: limit})
+			return err
+		},
+		PermissionMetricInspect: func(ctx context.Context) error {
+			_, err := monitoringClient.ListMetrics(ctx, monitoring.ListMetricsRequest{CompartmentId: compartmentID, Limit: limit})
+			return err
+		},
+		PermissionInstancePowerActions: instancePowerActionsProbe(&computeClient, compartmentID),
+		PermissionDBNodePowerActions:   dbNodePowerActionsProbe(&dbClient, compartmentID),
+	}
+
+	return testPermissions(ctx, probes, permissions)
+}
+
+// instancePowerActionsProbe returns the probe of the power actions of the compute instances, it stops the first
+// instance of the compartment that is not terminated.
+func instancePowerActionsProbe(api instanceActionAPI, compartmentID *string) probe {
+	return func(ctx context.Context) error {
+		resp, err := api.ListInstances(ctx, core.ListInstancesRequest{CompartmentId: compartmentID,
+			Limit: common.Int(probeTargets)})
+		if err != nil {
+			return errors.Join(errNoProbeTarget, err)
+		}
+
+		for _, instance := range resp.Items {
+			if instance.LifecycleState == core.InstanceLifecycleStateTerminating ||
+				instance.LifecycleState == core.InstanceLifecycleStateTerminated {
+				continue
+			}
+
+			_, err = api.InstanceAction(ctx, core.InstanceActionRequest{InstanceId: instance.Id,
+				Action: core.InstanceActionActionSoftstop, IfMatch: common.String(probeEtag)})
+
+			return authorizedAction(err)
+		}
+
+		return errNoProbeTarget
+	}
+}
+
+// dbNodePowerActionsProbe returns the probe of the power actions of the DB nodes, it stops the first node of the
+// compartment that is not terminated.
+func dbNodePowerActionsProbe(api dbNodeActionAPI, compartmentID *string) probe {
+	return func(ctx context.Context) error {
+		resp, err := api.ListDbNodes(ctx, ociDatabase.ListDbNodesRequest{CompartmentId: compartmentID,
+			Limit: common.Int(probeTargets)})
+		if err != nil {
+			return errors.Join(errNoProbeTarget, err)
+		}
+
+		for _, node := range resp.Items {
+			if node.LifecycleState == ociDatabase.DbNodeSummaryLifecycleStateTerminating ||
+				node.LifecycleState == ociDatabase.DbNodeSummaryLifecycleStateTerminated {
+				continue
+			}
+
+			_, err = api.DbNodeAction(ctx, ociDatabase.DbNodeActionRequest{DbNodeId: node.Id,
+				Action: ociDatabase.DbNodeActionActionStop, IfMatch: common.String(probeEtag)})
+
+			return authorizedAction(err)
+		}
+
+		return errNoProbeTarget
+	}
+}
+
+// authorizedAction returns nil when a power action run as a probe failed on its entity tag, i.e. it was authorized.
+func authorizedAction(err error) error {
+	if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == http.StatusPreconditionFailed {
+		return nil
+	}
+
+	return err
+}
+
+// testPermissions runs the probes of the given permissions. A permission is denied when its probe is not authorized,
+// and it is not in the result when it has no probe or its probe fails for another reason.
+func testPermissions(ctx context.Context, probes map[string]probe, permissions []string) (map[string]bool, error) {
+	granted := make(map[string]bool, len(permissions))
+
+	for _, permission := range permissions {
+		p, ok := probes[permission]
+		if !ok {
+			continue
+		}
+
+		err := p(ctx)
+		if err == nil {
+			granted[permission] = true
+
+			continue
+		}
+
+		if errors.Is(err, errNoProbeTarget) {
+			continue
+		}
+
+		serviceErr, ok := common.IsServiceError(err)
+		if !ok {
+			return nil, err
+		}
+
+		switch serviceErr.GetHTTPStatusCode() {
+		case http.StatusUnauthorized:
+			// The signature of the request is rejected, i.e. the credentials are invalid.
+			return nil, errors.Join(ErrInvalidCredentials, err)
+		case http.StatusForbidden, http.StatusNotFound:
+			// OCI reports a missing permission as NotAuthorizedOrNotFound.
+			granted[permission] = false
+		}
+	}
+
+	return granted, nil
+}
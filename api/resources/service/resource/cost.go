@@ -0,0 +1,260 @@
+package resource
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/pricing"
+)
+
+// costPrecision rounds the reported hours and amounts to four decimals.
+const costPrecision = 1e4
+
+// SetPriceCatalog replaces the catalog used to estimate the spend and the savings of the resources.
+func (s *Service) SetPriceCatalog(c PriceCatalog) {
+	s.prices = c
+}
+
+// GetAccountCost returns the time the resources of a cloud account spent running and stopped over a period along
+// with their estimated spend and savings. Resources that did not exist during the period are left out.
+func (s *Service) GetAccountCost(ctx *gofr.Context, cloudAccID int64, period models.CostPeriod) (*models.CostReport, error) {
+	return s.getCost(ctx, cloudAccID, period, func(*models.Resource) bool { return true })
+}
+
+// GetResourcesCost returns the time the given resources of a cloud account spent running and stopped over a period
+// along with their estimated spend and savings.
+func (s *Service) GetResourcesCost(ctx *gofr.Context, cloudAccID int64, ids []int64,
+	period models.CostPeriod) (*models.CostReport, error) {
+	return s.getCost(ctx, cloudAccID, period, func(res *models.Resource) bool { return slices.Contains(ids, res.ID) })
+}
+
+func (s *Service) getCost(ctx *gofr.Context, cloudAccID int64, period models.CostPeriod,
+	include func(*models.Resource) bool) (*models.CostReport, error) {
+	// The time to come is not accounted for, so a period ending in the future is reported up to now.
+	until := period.To
+	if now := time.Now(); now.Before(until) {
+		until = now
+	}
+
+	res, err := s.store.GetResourcesIncludingDeleted(ctx, cloudAccID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.store.GetStatusHistory(ctx, cloudAccID, until)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[int64][]models.ResourceEvent)
+
+	for i := range events {
+		history[events[i].ResourceID] = append(history[events[i].ResourceID], events[i])
+	}
+
+	report := &models.CostReport{CostPeriod: period, Currency: s.prices.Currency(), Resources: make([]models.ResourceCost, 0)}
+
+	for i := range res {
+		if !include(&res[i]) {
+			continue
+		}
+
+		running, stopped := uptime(&res[i], history[res[i].ID], period.From, until)
+		if running+stopped == 0 {
+			continue
+		}
+
+		c := s.resourceCost(&res[i], running, stopped)
+		report.Add(&c)
+	}
+
+	report.RunningHours = round(report.RunningHours)
+	report.StoppedHours = round(report.StoppedHours)
+	report.EstimatedSpend = round(report.EstimatedSpend)
+	report.EstimatedSavings = round(report.EstimatedSavings)
+
+	return report, nil
+}
+
+// resourceCost prices the time a resource spent running and stopped, the savings are the difference between the
+// running and the stopped price over the time it was stopped.
+func (s *Service) resourceCost(res *models.Resource, running, stopped time.Duration) models.ResourceCost {
+	c := models.ResourceCost{
+		ResourceID:   res.ID,
+		Name:         res.Name,
+		Type:         res.Type,
+		Region:       res.Region,
+		Tier:         tier(res.Settings),
+		Units:        units(res.Settings),
+		RunningHours: running.Hours(),
+		StoppedHours: stopped.Hours(),
+	}
+
+	price, ok := s.prices.Lookup(pricing.Key{
+		Provider: res.CloudAccount.Type, ResourceType: res.Type, Tier: c.Tier, Region: res.Region,
+	})
+	if ok {
+		n := float64(c.Units)
+
+		c.Priced = true
+		c.EstimatedSpend = round(n * (c.RunningHours*price.Running + c.StoppedHours*price.Stopped))
+		c.EstimatedSavings = round(n * c.StoppedHours * (price.Running - price.Stopped))
+	}
+
+	c.RunningHours = round(c.RunningHours)
+	c.StoppedHours = round(c.StoppedHours)
+
+	return c
+}
+
+// uptime replays the status history of a resource to find the time it spent running and stopped between from and
+// to. The time the resource was removed from the cloud is not accounted for.
+func uptime(res *models.Resource, events []models.ResourceEvent, from, to time.Time) (running, stopped time.Duration) {
+	status, exists, since := initialStatus(res, events), true, res.CreatedAt
+
+	accrue := func(until time.Time) {
+		start, end := latest(since, from), earliest(until, to)
+		if exists && end.After(start) {
+			if isStopped(status) {
+				stopped += end.Sub(start)
+			} else {
+				running += end.Sub(start)
+			}
+		}
+
+		since = latest(since, until)
+	}
+
+	for i := range events {
+		accrue(events[i].CreatedAt)
+
+		switch events[i].EventType {
+		case models.EventCreated, models.EventStatusChanged:
+			status = eventStatus(events[i].NewValue, status)
+		case models.EventDeleted:
+			exists = false
+		case models.EventRestored:
+			exists = true
+		}
+	}
+
+	if res.DeletedAt != nil && exists {
+		accrue(*res.DeletedAt)
+
+		exists = false
+	}
+
+	accrue(to)
+
+	return running, stopped
+}
+
+// initialStatus returns the status of a resource when it was first seen, which is the previous value of its first
+// status change when the creation of the resource predates its history.
+func initialStatus(res *models.Resource, events []models.ResourceEvent) string {
+	for i := range events {
+		switch events[i].EventType {
+		case models.EventCreated:
+			return eventStatus(events[i].NewValue, res.Status)
+		case models.EventStatusChanged:
+			return eventStatus(events[i].OldValue, res.Status)
+		}
+	}
+
+	return res.Status
+}
+
+// eventStatus decodes the status recorded in an event, the fallback is returned when the event holds no status.
+func eventStatus(value json.RawMessage, fallback string) string {
+	var status string
+
+	if err := json.Unmarshal(value, &status); err != nil || status == "" {
+		return fallback
+	}
+
+	return status
+}
+
+// stoppedStatuses are the statuses of the resources not billed for their compute, the statuses of the providers are
+// kept as is for some resources, e.g. the EC2 instances are "stopped" or "terminated" and the OCI instances
+// "TERMINATED".
+var stoppedStatuses = map[string]bool{
+	STOPPED: true, "STOPPING": true, "TERMINATED": true, "TERMINATING": true, "SHUTTING_DOWN": true,
+	"DEALLOCATED": true, "DEALLOCATING": true, "SUSPENDED": true, "SUSPENDING": true,
+}
+
+// isStopped returns whether a resource is stopped, whatever the case and separators of its status.
+func isStopped(status string) bool {
+	return stoppedStatuses[strings.ReplaceAll(strings.ToUpper(status), "-", "_")]
+}
+
+// tier returns the machine type, instance class or SKU of a resource from its settings.
+func tier(settings models.Settings) string {
+	keys := []string{
+		"machine_type", "InstanceType", "instance_class", "vm_size", "shape", "sku", "tier",
+		"disk_type", "volume_type", "storage_class",
+	}
+
+	for _, key := range keys {
+		if t, ok := settings[key].(string); ok && t != "" {
+			return t
+		}
+	}
+
+	return ""
+}
+
+// units returns the number of priced units of a resource. Suspended node pools and scaling groups are sized to zero,
+// so their size before they were suspended is used instead.
+func units(settings models.Settings) int {
+	for _, s := range []models.Settings{settings, getPreviousState(settings)} {
+		for _, key := range []string{"node_count", "desired_capacity"} {
+			if n := toInt(s[key]); n > 0 {
+				return n
+			}
+		}
+	}
+
+	return 1
+}
+
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+func round(v float64) float64 {
+	return math.Round(v*costPrecision) / costPrecision
+}
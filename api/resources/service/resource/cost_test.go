@@ -0,0 +1,235 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/pricing"
+)
+
+func statusEvent(resID int64, at time.Time, eventType, oldStatus, newStatus string) models.ResourceEvent {
+	e := models.ResourceEvent{ResourceID: resID, EventType: eventType, CreatedAt: at}
+
+	if oldStatus != "" {
+		e.OldValue, _ = json.Marshal(oldStatus)
+	}
+
+	if newStatus != "" {
+		e.NewValue, _ = json.Marshal(newStatus)
+	}
+
+	return e
+}
+
+func TestUptime(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	deletedAt := at(20)
+
+	testCases := []struct {
+		name       string
+		res        models.Resource
+		events     []models.ResourceEvent
+		expRunning time.Duration
+		expStopped time.Duration
+	}{
+		{
+			name: "no history", res: models.Resource{Status: RUNNING, CreatedAt: at(-5)},
+			expRunning: 24 * time.Hour,
+		},
+		{
+			name: "created during the period", res: models.Resource{Status: RUNNING, CreatedAt: at(6)},
+			events:     []models.ResourceEvent{statusEvent(1, at(6), models.EventCreated, "", RUNNING)},
+			expRunning: 18 * time.Hour,
+		},
+		{
+			name: "stopped and started", res: models.Resource{Status: RUNNING, CreatedAt: at(-5)},
+			events: []models.ResourceEvent{
+				statusEvent(1, at(8), models.EventStatusChanged, RUNNING, STOPPED),
+				statusEvent(1, at(18), models.EventStatusChanged, STOPPED, RUNNING),
+			},
+			expRunning: 14 * time.Hour, expStopped: 10 * time.Hour,
+		},
+		{
+			name: "provider statuses", res: models.Resource{Status: "running", CreatedAt: at(-5)},
+			events: []models.ResourceEvent{
+				statusEvent(1, at(8), models.EventStatusChanged, "running", "stopping"),
+				statusEvent(1, at(9), models.EventStatusChanged, "stopping", "stopped"),
+				statusEvent(1, at(18), models.EventStatusChanged, "stopped", "pending"),
+			},
+			expRunning: 14 * time.Hour, expStopped: 10 * time.Hour,
+		},
+		{
+			name: "status predates the history", res: models.Resource{Status: RUNNING, CreatedAt: at(-5)},
+			events:     []models.ResourceEvent{statusEvent(1, at(12), models.EventStatusChanged, STOPPED, RUNNING)},
+			expRunning: 12 * time.Hour, expStopped: 12 * time.Hour,
+		},
+		{
+			name: "removed and restored", res: models.Resource{Status: RUNNING, CreatedAt: at(-5)},
+			events: []models.ResourceEvent{
+				statusEvent(1, at(4), models.EventDeleted, "", ""),
+				statusEvent(1, at(10), models.EventRestored, "", ""),
+			},
+			expRunning: 18 * time.Hour,
+		},
+		{
+			name: "removed without history", res: models.Resource{Status: STOPPED, CreatedAt: at(-5), DeletedAt: &deletedAt},
+			expStopped: 20 * time.Hour,
+		},
+		{
+			name: "created after the period", res: models.Resource{Status: RUNNING, CreatedAt: at(30)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			running, stopped := uptime(&tc.res, tc.events, at(0), at(24))
+
+			assert.Equal(t, tc.expRunning, running)
+			assert.Equal(t, tc.expStopped, stopped)
+		})
+	}
+}
+
+func TestIsStopped(t *testing.T) {
+	for _, status := range []string{STOPPED, "stopped", "stopping", "terminated", "shutting-down", "TERMINATED", "deallocated"} {
+		assert.True(t, isStopped(status), status)
+	}
+
+	for _, status := range []string{RUNNING, "running", "pending", "AVAILABLE", "IN_USE", "PROVISIONING", ""} {
+		assert.False(t, isStopped(status), status)
+	}
+}
+
+func TestUnitsAndTier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings models.Settings
+		expUnits int
+		expTier  string
+	}{
+		{name: "no settings", expUnits: 1},
+		{name: "ec2 instance", settings: models.Settings{"InstanceType": "t3.micro"}, expUnits: 1, expTier: "t3.micro"},
+		{name: "node pool", settings: models.Settings{"machine_type": "e2-medium", "node_count": float64(3)},
+			expUnits: 3, expTier: "e2-medium"},
+		{name: "suspended node pool", settings: models.Settings{"node_count": 0,
+			previousStateKey: map[string]any{"node_count": float64(4)}}, expUnits: 4},
+		{name: "scaling group", settings: models.Settings{"desired_capacity": int64(2)}, expUnits: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expUnits, units(tc.settings))
+			assert.Equal(t, tc.expTier, tier(tc.settings))
+		})
+	}
+}
+
+func TestService_GetAccountCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	catalog, err := pricing.Load(strings.NewReader(`{"currency": "USD", "prices": [
+		{"provider": "AWS", "resource_type": "EC2", "tier": "t3.micro", "running": 0.01, "stopped": 0.002}]}`))
+	require.NoError(t, err)
+
+	s.SetPriceCatalog(catalog)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	period := models.CostPeriod{From: from, To: from.Add(24 * time.Hour)}
+	resources := []models.Resource{
+		{ID: 1, Name: "web", Type: "EC2", Status: RUNNING, CloudAccount: models.CloudAccount{ID: 1, Type: "AWS"},
+			Settings: models.Settings{"InstanceType": "t3.micro"}, CreatedAt: from.Add(-time.Hour)},
+		{ID: 2, Name: "db", Type: "RDS", Status: RUNNING, CloudAccount: models.CloudAccount{ID: 1, Type: "AWS"},
+			CreatedAt: from.Add(-time.Hour)},
+		{ID: 3, Name: "new", Type: "EC2", Status: RUNNING, CloudAccount: models.CloudAccount{ID: 1, Type: "AWS"},
+			CreatedAt: from.Add(48 * time.Hour)},
+	}
+	events := []models.ResourceEvent{
+		statusEvent(1, from.Add(6*time.Hour), models.EventStatusChanged, RUNNING, STOPPED),
+	}
+
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(1)).Return(resources, nil)
+	mStore.EXPECT().GetStatusHistory(ctx, int64(1), period.To).Return(events, nil)
+
+	report, err := s.GetAccountCost(ctx, 1, period)
+
+	require.NoError(t, err)
+	assert.Equal(t, &models.CostReport{
+		CostPeriod:       period,
+		Currency:         "USD",
+		RunningHours:     30,
+		StoppedHours:     18,
+		EstimatedSpend:   0.096,
+		EstimatedSavings: 0.144,
+		Resources: []models.ResourceCost{
+			{ResourceID: 1, Name: "web", Type: "EC2", Tier: "t3.micro", Units: 1, RunningHours: 6, StoppedHours: 18,
+				EstimatedSpend: 0.096, EstimatedSavings: 0.144, Priced: true},
+			{ResourceID: 2, Name: "db", Type: "RDS", Units: 1, RunningHours: 24},
+		},
+	}, report)
+}
+
+func TestService_GetResourcesCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	period := models.CostPeriod{From: from, To: from.Add(time.Hour)}
+	resources := []models.Resource{
+		{ID: 1, Name: "web", Type: "SQL", Status: RUNNING, CreatedAt: from},
+		{ID: 2, Name: "db", Type: "SQL", Status: RUNNING, CreatedAt: from},
+	}
+
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(1)).Return(resources, nil)
+	mStore.EXPECT().GetStatusHistory(ctx, int64(1), period.To).Return(nil, nil)
+
+	report, err := s.GetResourcesCost(ctx, 1, []int64{2}, period)
+
+	require.NoError(t, err)
+	require.Len(t, report.Resources, 1)
+	assert.Equal(t, int64(2), report.Resources[0].ResourceID)
+	assert.InDelta(t, 1, report.RunningHours, 0)
+}
+
+func TestService_GetAccountCost_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+	period := models.CostPeriod{From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Hour)}
+
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(1)).Return(nil, errMock)
+
+	report, err := s.GetAccountCost(ctx, 1, period)
+
+	assert.Nil(t, report)
+	assert.Equal(t, errMock, err)
+
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(1)).Return(nil, nil)
+	mStore.EXPECT().GetStatusHistory(ctx, int64(1), gomock.Any()).Return(nil, errMock)
+
+	report, err = s.GetAccountCost(ctx, 1, period)
+
+	assert.Nil(t, report)
+	assert.Equal(t, errMock, err)
+}
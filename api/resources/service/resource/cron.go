@@ -1,14 +1,20 @@
 package resource
 
 import (
+	"errors"
 	"sync"
 
 	"gofr.dev/pkg/gofr"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/zopdev/zopdev/api/resources/client"
 )
 
-// SyncCron is a cron job that syncs resources for all cloud accounts.
+// maxParallelSyncs is the number of cloud accounts synced at the same time by the cron.
+const maxParallelSyncs = 5
+
+// SyncCron is a cron job that syncs resources for all cloud accounts. The accounts paused by their health checks are
+// skipped until their credentials work again.
 func (s *Service) SyncCron(ctx *gofr.Context) {
 	cl, err := s.http.GetAllCloudAccounts(ctx)
 	if err != nil {
@@ -18,34 +24,59 @@ func (s *Service) SyncCron(ctx *gofr.Context) {
 		return
 	}
 
-	errs := s.SyncAll(ctx, cl)
+	active := make([]client.CloudAccount, 0, len(cl))
+
+	for i := range cl {
+		if cl[i].IsPaused() {
+			ctx.Infof("skipping sync of account %d: paused after failed health checks (%s)", cl[i].ID,
+				cl[i].Health.ErrorClass)
+
+			continue
+		}
+
+		active = append(active, cl[i])
+	}
+
+	errs := s.SyncAll(ctx, active)
 	if len(errs) > 0 {
 		ctx.Metrics().IncrementCounter(ctx, "sync_error_count")
 	}
 }
 
-// SyncAll synchronizes resources for all provided cloud accounts concurrently.
+// SyncAll synchronizes resources for all provided cloud accounts with bounded parallelism.
+// The accounts that are already being synced are skipped.
 func (s *Service) SyncAll(ctx *gofr.Context, accounts []client.CloudAccount) []error {
 	var (
-		wg  sync.WaitGroup
-		err []error
+		mu   sync.Mutex
+		errs []error
+		grp  errgroup.Group
 	)
 
+	grp.SetLimit(maxParallelSyncs)
+
 	for _, account := range accounts {
-		wg.Add(1)
+		grp.Go(func() error {
+			_, er := s.SyncResources(ctx, account.ID, nil)
 
-		go func(accountID int64) {
-			defer wg.Done()
+			var inProgress *errSyncInProgress
+			if errors.As(er, &inProgress) {
+				ctx.Infof("skipping sync of account %d: %v", account.ID, er)
+				return nil
+			}
 
-			_, er := s.SyncResources(ctx, accountID)
 			if er != nil {
-				ctx.Errorf("failed to sync resources for account %d: %v", accountID, er)
-				err = append(err, er)
+				ctx.Errorf("failed to sync resources for account %d: %v", account.ID, er)
+
+				mu.Lock()
+				errs = append(errs, er)
+				mu.Unlock()
 			}
-		}(account.ID)
+
+			return nil
+		})
 	}
 
-	wg.Wait()
+	_ = grp.Wait()
 
-	return err
+	return errs
 }
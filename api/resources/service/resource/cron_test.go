@@ -78,27 +78,45 @@ func TestService_SyncCron(t *testing.T) {
 		{ID: 1, Name: "sql-instance-1", UID: "zop/sql1", Status: RUNNING},
 		{ID: 2, Name: "sql-instance-2", UID: "zop/sql2", Status: STOPPED},
 	}
+	storedResp := []models.Resource{
+		{ID: 1, Name: "sql-instance-1", UID: "zop/sql1", Status: STOPPED},
+		{ID: 2, Name: "sql-instance-2", UID: "zop/sql2", Status: RUNNING},
+	}
 	mockLister := &mockSQLClient{
 		isError:   false,
 		instances: mockResp,
 	}
 	mockCreds := &google.Credentials{ProjectID: "test-project"}
 
-	service := New(mGCP, mAWS, mHTTP, mStore)
+	service := New(mGCP, mAWS, nil, nil, mHTTP, mStore)
 
 	// mock expectations
 	mHTTP.EXPECT().GetAllCloudAccounts(ctx).
-		Return([]client.CloudAccount{{ID: 1, Provider: "GCP"}, {ID: 2, Provider: "Unknown"}}, nil)
+		Return([]client.CloudAccount{{ID: 1, Provider: "GCP"}, {ID: 2, Provider: "Unknown"},
+			{ID: 3, Provider: "GCP", Health: &client.Health{Status: "UNHEALTHY", ErrorClass: "AUTH", Paused: true}}}, nil)
 	mHTTP.EXPECT().GetCloudCredentials(ctx, int64(1)).
 		Return(&client.CloudAccount{ID: 1, Provider: "GCP"}, nil)
 	mHTTP.EXPECT().GetCloudCredentials(ctx, int64(2)).
 		Return(&client.CloudAccount{ID: 2, Provider: "Unknown"}, nil)
 
-	mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
-		Return(mockCreds, nil)
-	mGCP.EXPECT().NewSQLClient(ctx, gomock.Any()).
+	// The drivers are called with the context of the sync, the projects are resolved once for all of them.
+	mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+		Return(mockCreds, nil).Times(4)
+	mGCP.EXPECT().GetProjects(gomock.Any(), gomock.Any()).
+		Return([]string{"test-project"}, nil)
+	mGCP.EXPECT().NewSQLClient(gomock.Any(), gomock.Any()).
 		Return(mockLister, nil)
-
+	mGCP.EXPECT().NewGKEClient(gomock.Any(), gomock.Any()).
+		Return(&mockGKEClient{}, nil)
+	mGCP.EXPECT().NewServerlessClient(gomock.Any(), gomock.Any()).
+		Return(&mockServerlessClient{}, nil)
+	mGCP.EXPECT().NewStorageClient(gomock.Any(), gomock.Any()).
+		Return(&mockStorageClient{}, nil)
+
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(1)).
+		Return(storedResp, nil)
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(2)).
+		Return(nil, nil)
 	mStore.EXPECT().GetResources(ctx, int64(1), nil).
 		Return(mockResp, nil).AnyTimes()
 	mStore.EXPECT().GetResources(ctx, int64(2), nil).
@@ -107,6 +125,14 @@ func TestService_SyncCron(t *testing.T) {
 		Return(nil)
 	mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(2)).
 		Return(nil)
+	mStore.EXPECT().InsertEvent(ctx, gomock.Any()).
+		Return(nil).Times(2)
+	mStore.EXPECT().UpdateProject(ctx, "test-project", gomock.Any()).
+		Return(nil).Times(2)
+	mStore.EXPECT().InsertSyncRun(ctx, gomock.Any()).
+		Return(nil).Times(2)
+	mStore.EXPECT().UpdateSyncRun(ctx, gomock.Any()).
+		Return(nil).Times(2)
 
 	// Add correct mocks for AWS EC2 and RDS clients
 	mAWS.EXPECT().NewEC2Client(gomock.Any(), gomock.Any()).Return(&vm.Client{EC2: &stubEC2{}}, nil).AnyTimes()
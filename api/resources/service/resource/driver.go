@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"slices"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// ResourceDriver lists the resources of one or more types of a cloud provider and changes their state.
+// The credentials passed to the driver are the ones of the cloud account the resources belong to.
+type ResourceDriver interface {
+	// Types returns the resource types the driver lists and manages.
+	Types() []ResourceType
+	// List returns the resources of all the types of the driver.
+	List(ctx *gofr.Context, creds any) ([]models.Resource, error)
+	// Start starts a resource, the state recorded when it was stopped, if any, is in its settings.
+	Start(ctx *gofr.Context, creds any, res *models.Resource) error
+	// Stop stops or suspends a resource, it returns the state to restore on start, if any.
+	Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error)
+	// Status returns the current status of a resource on the cloud, read with a single lookup of the resource.
+	Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error)
+}
+
+// driverKey identifies the driver of a resource type of a cloud provider.
+type driverKey struct {
+	provider     CloudProvider
+	resourceType ResourceType
+}
+
+// registry holds the drivers of the resource types supported by zopdev.
+type registry struct {
+	drivers map[driverKey]ResourceDriver
+	// ordered keeps the drivers of every provider in the order they are registered, the resources of a cloud account
+	// are listed in this order.
+	ordered map[CloudProvider][]ResourceDriver
+}
+
+func newRegistry() *registry {
+	return &registry{drivers: make(map[driverKey]ResourceDriver), ordered: make(map[CloudProvider][]ResourceDriver)}
+}
+
+// register adds the driver for all of its types, replacing the drivers previously registered for them.
+func (r *registry) register(provider CloudProvider, d ResourceDriver) {
+	for _, t := range d.Types() {
+		r.drivers[driverKey{provider: provider, resourceType: t}] = d
+	}
+
+	r.ordered[provider] = append(r.ordered[provider], d)
+}
+
+// get returns the driver of a resource type of a provider.
+func (r *registry) get(provider CloudProvider, resourceType ResourceType) (ResourceDriver, bool) {
+	d, ok := r.drivers[driverKey{provider: provider, resourceType: resourceType}]
+
+	return d, ok
+}
+
+// list returns the drivers of a provider that are registered for at least one type, i.e. not replaced entirely.
+func (r *registry) list(provider CloudProvider) []ResourceDriver {
+	drivers := make([]ResourceDriver, 0, len(r.ordered[provider]))
+
+	for _, d := range r.ordered[provider] {
+		if slices.ContainsFunc(d.Types(), func(t ResourceType) bool {
+			registered, ok := r.get(provider, t)
+
+			return ok && registered == d
+		}) {
+			drivers = append(drivers, d)
+		}
+	}
+
+	return drivers
+}
+
+// RegisterDriver adds a driver for the resource types of a cloud provider, replacing the drivers previously
+// registered for them. The resources of the types are then synced and their state changed through the driver.
+func (s *Service) RegisterDriver(provider CloudProvider, d ResourceDriver) {
+	s.drivers.register(provider, d)
+}
+
+// registerDrivers registers the drivers of all the resource types supported by zopdev.
+func (s *Service) registerDrivers() {
+	s.RegisterDriver(GCP, &gcpSQLDriver{gcp: s.gcp})
+	s.RegisterDriver(AWS, &awsRDSDriver{aws: s.aws})
+	s.RegisterDriver(AZURE, &azureDatabaseDriver{azure: s.azure})
+	s.RegisterDriver(OCI, &ociDatabaseDriver{oci: s.oci})
+	s.RegisterDriver(AWS, &awsEC2Driver{aws: s.aws})
+	s.RegisterDriver(AZURE, &azureVMDriver{azure: s.azure})
+	s.RegisterDriver(OCI, &ociComputeDriver{oci: s.oci})
+	s.RegisterDriver(GCP, &gkeNodePoolDriver{gcp: s.gcp})
+	s.RegisterDriver(AWS, &awsScalingDriver{aws: s.aws})
+	s.RegisterDriver(GCP, &gcpServerlessDriver{gcp: s.gcp})
+	s.RegisterDriver(GCP, &gcpStorageDriver{gcp: s.gcp})
+	s.RegisterDriver(AWS, &awsStorageDriver{aws: s.aws})
+}
+
+// readOnly is embedded by the drivers whose resources are inventoried only.
+type readOnly struct{}
+
+func (readOnly) Start(_ *gofr.Context, _ any, res *models.Resource) error {
+	return &errReadOnlyResource{resourceType: ResourceType(res.Type)}
+}
+
+func (readOnly) Stop(_ *gofr.Context, _ any, res *models.Resource) (models.Settings, error) {
+	return nil, &errReadOnlyResource{resourceType: ResourceType(res.Type)}
+}
+
+// Status returns the status of the last sync, the state of the resources inventoried only is not changed by zopdev.
+func (readOnly) Status(_ *gofr.Context, _ any, res *models.Resource) (string, error) {
+	return res.Status, nil
+}
@@ -0,0 +1,214 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/resources/client"
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// fakeDriver is a driver of the tests, it records the resources it started and stopped.
+type fakeDriver struct {
+	types     []ResourceType
+	resources []models.Resource
+	previous  models.Settings
+	status    string
+	err       error
+	statusErr error
+
+	started []string
+	stopped []string
+}
+
+func (f *fakeDriver) Types() []ResourceType {
+	return f.types
+}
+
+func (f *fakeDriver) List(_ *gofr.Context, _ any) ([]models.Resource, error) {
+	return f.resources, f.err
+}
+
+func (f *fakeDriver) Start(_ *gofr.Context, _ any, res *models.Resource) error {
+	f.started = append(f.started, res.UID)
+
+	return f.err
+}
+
+func (f *fakeDriver) Stop(_ *gofr.Context, _ any, res *models.Resource) (models.Settings, error) {
+	f.stopped = append(f.stopped, res.UID)
+
+	return f.previous, f.err
+}
+
+func (f *fakeDriver) Status(_ *gofr.Context, _ any, _ *models.Resource) (string, error) {
+	return f.status, f.statusErr
+}
+
+func TestRegistry(t *testing.T) {
+	r := newRegistry()
+	databases := &fakeDriver{types: []ResourceType{SQL}}
+	storage := &fakeDriver{types: []ResourceType{GCSBUCKET, GCEDISK}}
+	disks := &fakeDriver{types: []ResourceType{GCEDISK}}
+
+	r.register(GCP, databases)
+	r.register(GCP, storage)
+
+	d, ok := r.get(GCP, GCEDISK)
+	require.True(t, ok)
+	assert.Same(t, storage, d)
+
+	_, ok = r.get(AWS, SQL)
+	assert.False(t, ok, "drivers are registered per provider")
+
+	// A driver registered for a type replaces the previous one for that type only.
+	r.register(GCP, disks)
+
+	d, _ = r.get(GCP, GCEDISK)
+	assert.Same(t, disks, d)
+
+	d, _ = r.get(GCP, GCSBUCKET)
+	assert.Same(t, storage, d)
+
+	assert.Equal(t, []ResourceDriver{databases, storage, disks}, r.list(GCP))
+
+	// A driver replaced for all of its types is no longer listed.
+	replacement := &fakeDriver{types: []ResourceType{SQL}}
+	r.register(GCP, replacement)
+
+	drivers := r.list(GCP)
+	require.Len(t, drivers, 3)
+	assert.Same(t, storage, drivers[0])
+	assert.Same(t, disks, drivers[1])
+	assert.Same(t, replacement, drivers[2])
+	assert.Empty(t, r.list(AWS))
+}
+
+func TestService_RegisterDriver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := &gofr.Context{Context: context.Background()}
+	mStore := NewMockStore(ctrl)
+	mHTTP := NewMockHTTPClient(ctrl)
+	s := New(nil, nil, nil, nil, mHTTP, mStore)
+
+	d := &fakeDriver{types: []ResourceType{SQL}, status: STOPPED,
+		resources: []models.Resource{{Name: "users", UID: "users", Type: "SQL"}}}
+	s.RegisterDriver("ACME", d)
+
+	ca := &client.CloudAccount{ID: 1, Provider: "acme"}
+	res := &models.Resource{ID: 2, UID: "users", Type: string(SQL), CloudAccount: models.CloudAccount{ID: 1}, Status: RUNNING}
+
+	mHTTP.EXPECT().GetCloudCredentials(ctx, int64(1)).Return(ca, nil)
+	mStore.EXPECT().GetResourceByID(ctx, int64(2)).Return(res, nil)
+	mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(2)).Return(nil)
+	mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+	err := s.ChangeState(ctx, ResourceDetails{ID: 2, CloudAccID: 1, Type: SQL, State: SUSPEND})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users"}, d.stopped)
+
+	instances, err := s.getAllInstances(ctx, ca, &models.SyncScope{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{Name: "users", UID: "users", Type: "SQL", CloudAccount: models.CloudAccount{ID: 1, Type: "acme"}}},
+		instances)
+}
+
+func TestService_changeState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	mStore := NewMockStore(ctrl)
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	t.Run("suspend records the previous state", func(t *testing.T) {
+		d := &fakeDriver{previous: models.Settings{"size": 3}, status: STOPPED}
+		res := &models.Resource{ID: 1, UID: "pool", Status: RUNNING, Settings: models.Settings{"zone": "a"}}
+
+		mStore.EXPECT().UpdateSettings(ctx, models.Settings{"zone": "a", previousStateKey: models.Settings{"size": 3}}, int64(1)).
+			Return(nil)
+		mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(1)).Return(nil)
+		mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+		require.NoError(t, s.changeState(ctx, d, nil, SUSPEND, res))
+		assert.Equal(t, models.Settings{"zone": "a"}, res.Settings, "the settings of the resource are not mutated")
+	})
+
+	t.Run("start clears the previous state", func(t *testing.T) {
+		d := &fakeDriver{status: RUNNING}
+		res := &models.Resource{ID: 1, UID: "pool", Status: STOPPED,
+			Settings: models.Settings{"zone": "a", previousStateKey: models.Settings{"size": 3}}}
+
+		mStore.EXPECT().UpdateSettings(ctx, models.Settings{"zone": "a"}, int64(1)).Return(nil)
+		mStore.EXPECT().UpdateStatus(ctx, RUNNING, int64(1)).Return(nil)
+		mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+		require.NoError(t, s.changeState(ctx, d, nil, START, res))
+		assert.Equal(t, []string{"pool"}, d.started)
+	})
+
+	t.Run("the status is refreshed from the cloud", func(t *testing.T) {
+		d := &fakeDriver{status: "PENDING"}
+		res := &models.Resource{ID: 1, UID: "pool", Status: STOPPED}
+
+		mStore.EXPECT().UpdateStatus(ctx, "PENDING", int64(1)).Return(nil)
+		mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+		require.NoError(t, s.changeState(ctx, d, nil, START, res))
+	})
+
+	t.Run("the expected status is recorded when it can not be refreshed", func(t *testing.T) {
+		d := &fakeDriver{status: "PENDING", statusErr: errMock}
+		res := &models.Resource{ID: 1, UID: "pool", Status: RUNNING}
+
+		mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(1)).Return(nil)
+		mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+		require.NoError(t, s.changeState(ctx, d, nil, SUSPEND, res))
+	})
+
+	t.Run("the actor is recorded", func(t *testing.T) {
+		actorCtx := &gofr.Context{Context: auth.NewContext(context.Background(), &auth.Principal{Subject: "alice@example.com"}),
+			Container: mockContainer}
+		d := &fakeDriver{status: RUNNING}
+		res := &models.Resource{ID: 1, UID: "pool", Status: STOPPED}
+
+		mStore.EXPECT().UpdateStatus(actorCtx, RUNNING, int64(1)).Return(nil)
+		mStore.EXPECT().InsertEvent(actorCtx, gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, event *models.ResourceEvent) error {
+				assert.Equal(t, models.EventSourceAPI, event.Source)
+				assert.Equal(t, "alice@example.com", event.Actor)
+
+				return nil
+			})
+
+		require.NoError(t, s.changeState(actorCtx, d, nil, START, res))
+	})
+
+	t.Run("driver error", func(t *testing.T) {
+		d := &fakeDriver{err: errMock}
+
+		err := s.changeState(ctx, d, nil, SUSPEND, &models.Resource{ID: 1, UID: "pool"})
+
+		assert.Equal(t, errMock, err)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		err := s.changeState(ctx, &fakeDriver{}, nil, "invalid", &models.Resource{ID: 1, UID: "pool"})
+
+		assert.Equal(t, gofrHttp.ErrorInvalidParam{Params: []string{"req.State"}}, err)
+	})
+}
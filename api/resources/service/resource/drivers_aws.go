@@ -0,0 +1,186 @@
+package resource
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// awsRDSDriver is the driver of the RDS instances.
+type awsRDSDriver struct {
+	aws AWSClient
+}
+
+func (*awsRDSDriver) Types() []ResourceType {
+	return []ResourceType{RDS}
+}
+
+func (d *awsRDSDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.aws.NewRDSClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetAllInstances(ctx)
+}
+
+func (d *awsRDSDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.aws.NewRDSClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, res)
+}
+
+func (d *awsRDSDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.aws.NewRDSClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, res)
+}
+
+func (d *awsRDSDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.aws.NewRDSClient(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, res)
+}
+
+// awsEC2Driver is the driver of the EC2 instances.
+type awsEC2Driver struct {
+	aws AWSClient
+}
+
+func (*awsEC2Driver) Types() []ResourceType {
+	return []ResourceType{AWSCOMPUTE}
+}
+
+func (d *awsEC2Driver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.aws.NewEC2Client(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetAllInstances(ctx)
+}
+
+func (d *awsEC2Driver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.aws.NewEC2Client(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, res.UID)
+}
+
+func (d *awsEC2Driver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.aws.NewEC2Client(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, res.UID)
+}
+
+func (d *awsEC2Driver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.aws.NewEC2Client(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, res.UID)
+}
+
+// awsScalingDriver is the driver of the Auto Scaling groups and the EKS managed node groups, they are suspended
+// by scaling them to zero.
+type awsScalingDriver struct {
+	aws AWSClient
+}
+
+func (*awsScalingDriver) Types() []ResourceType {
+	return []ResourceType{ASG, EKSNODEGROUP}
+}
+
+func (d *awsScalingDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.aws.NewScalingClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := cl.GetAllAutoScalingGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeGroups, err := cl.GetAllNodeGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(groups, nodeGroups...), nil
+}
+
+// Start scales a group back to its previous capacity.
+func (d *awsScalingDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.aws.NewScalingClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.Start(ctx, res, getPreviousState(res.Settings))
+}
+
+// Stop scales a group to zero.
+func (d *awsScalingDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.aws.NewScalingClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.Suspend(ctx, res)
+}
+
+// Status reads the desired capacity of a group.
+func (d *awsScalingDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.aws.NewScalingClient(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.Status(ctx, res)
+}
+
+// awsStorageDriver is the driver of the S3 buckets and the EBS volumes, they are inventoried only.
+type awsStorageDriver struct {
+	readOnly
+
+	aws AWSClient
+}
+
+func (*awsStorageDriver) Types() []ResourceType {
+	return []ResourceType{S3BUCKET, EBSVOLUME}
+}
+
+func (d *awsStorageDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.aws.NewStorageClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := cl.GetAllBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := cl.GetAllVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buckets, volumes...), nil
+}
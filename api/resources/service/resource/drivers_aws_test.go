@@ -0,0 +1,52 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestAWSDrivers_ClientError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"aws_access_key_id": "key"}
+	mockAWS := NewMockAWSClient(ctrl)
+	res := &models.Resource{ID: 1, UID: "i-123", Name: "web"}
+
+	mockAWS.EXPECT().NewRDSClient(ctx, creds).Return(nil, errMock).Times(2)
+	mockAWS.EXPECT().NewEC2Client(ctx, creds).Return(nil, errMock).Times(2)
+	mockAWS.EXPECT().NewScalingClient(ctx, creds).Return(nil, errMock).Times(2)
+	mockAWS.EXPECT().NewStorageClient(ctx, creds).Return(nil, errMock)
+
+	for _, d := range []ResourceDriver{&awsRDSDriver{aws: mockAWS}, &awsEC2Driver{aws: mockAWS},
+		&awsScalingDriver{aws: mockAWS}} {
+		instances, err := d.List(ctx, creds)
+
+		assert.Nil(t, instances)
+		assert.Equal(t, errMock, err)
+
+		assert.Equal(t, errMock, d.Start(ctx, creds, res))
+	}
+
+	instances, err := (&awsStorageDriver{aws: mockAWS}).List(ctx, creds)
+
+	assert.Nil(t, instances)
+	assert.Equal(t, errMock, err)
+}
+
+func TestAWSStorageDriver_ReadOnly(t *testing.T) {
+	d := &awsStorageDriver{}
+	volume := &models.Resource{Name: "data", Type: string(EBSVOLUME)}
+
+	prev, err := d.Stop(&gofr.Context{}, nil, volume)
+
+	assert.Nil(t, prev)
+	assert.Equal(t, &errReadOnlyResource{resourceType: EBSVOLUME}, err)
+}
@@ -0,0 +1,99 @@
+package resource
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// azureVMDriver is the driver of the Azure virtual machines, they are deallocated on stop so that their compute
+// is no longer billed.
+type azureVMDriver struct {
+	azure AzureClient
+}
+
+func (*azureVMDriver) Types() []ResourceType {
+	return []ResourceType{AZUREVM}
+}
+
+func (d *azureVMDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.azure.NewVMClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetAllInstances(ctx)
+}
+
+func (d *azureVMDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.azure.NewVMClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, res.UID)
+}
+
+func (d *azureVMDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.azure.NewVMClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, res.UID)
+}
+
+// Status reads the power state of a virtual machine from its instance view.
+func (d *azureVMDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.azure.NewVMClient(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, res.UID)
+}
+
+// azureDatabaseDriver is the driver of the Azure Database for PostgreSQL and MySQL flexible servers.
+type azureDatabaseDriver struct {
+	azure AzureClient
+}
+
+func (*azureDatabaseDriver) Types() []ResourceType {
+	return []ResourceType{AZUREFLEXIBLESERVER}
+}
+
+func (d *azureDatabaseDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.azure.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetAllInstances(ctx)
+}
+
+func (d *azureDatabaseDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.azure.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, res.UID)
+}
+
+func (d *azureDatabaseDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.azure.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, res.UID)
+}
+
+func (d *azureDatabaseDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.azure.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, res.UID)
+}
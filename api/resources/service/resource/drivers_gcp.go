@@ -0,0 +1,338 @@
+package resource
+
+import (
+	"context"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/gcp/apis"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+type projectsKey struct{}
+
+// projects are the projects of the cloud account being synced, they are resolved once for all its drivers.
+type projects struct {
+	once sync.Once
+	ids  []string
+	err  error
+}
+
+// withProjects returns a copy of the context of a sync, so that the projects of its cloud account are resolved once.
+// The context is copied rather than changed as the cloud accounts are synced concurrently.
+func withProjects(ctx *gofr.Context) *gofr.Context {
+	syncCtx := *ctx
+	syncCtx.Context = context.WithValue(ctx.Context, projectsKey{}, &projects{})
+
+	return &syncCtx
+}
+
+// getProjects returns the projects of a cloud account, the ones resolved earlier in the same sync if any.
+func getProjects(ctx *gofr.Context, gcp GCPClient, creds any) ([]string, error) {
+	p, ok := ctx.Value(projectsKey{}).(*projects)
+	if !ok {
+		return gcp.GetProjects(ctx, creds)
+	}
+
+	p.once.Do(func() {
+		p.ids, p.err = gcp.GetProjects(ctx, creds)
+	})
+
+	return p.ids, p.err
+}
+
+// listProjects lists the resources of every project of a cloud account and records the project of each of them.
+// The projects that did not enable the API of the resources hold none of them and are skipped. Any other error fails
+// the listing, so that the resources of the project are not removed by the sync. The projects of cloud accounts may
+// overlap, the resources of a project covered by several of them are stored once per cloud account.
+func listProjects(ctx *gofr.Context, gcp GCPClient, creds any,
+	list func(projectID string) ([]models.Resource, error)) ([]models.Resource, error) {
+	projectIDs, err := getProjects(ctx, gcp, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]models.Resource, 0)
+
+	for _, projectID := range projectIDs {
+		res, er := list(projectID)
+		if apis.IsDisabled(er) {
+			continue
+		}
+
+		if er != nil {
+			return nil, er
+		}
+
+		for i := range res {
+			res[i].Project = projectID
+		}
+
+		resources = append(resources, res...)
+	}
+
+	return resources, nil
+}
+
+// resourceProject returns the project of a resource, the resources synced before their project was recorded belong
+// to the project of the key.
+func resourceProject(res *models.Resource, gCreds *google.Credentials) string {
+	if res.Project != "" {
+		return res.Project
+	}
+
+	return gCreds.ProjectID
+}
+
+// gcpSQLDriver is the driver of the Cloud SQL instances.
+type gcpSQLDriver struct {
+	gcp GCPClient
+}
+
+func (*gcpSQLDriver) Types() []ResourceType {
+	return []ResourceType{SQL}
+}
+
+func (d *gcpSQLDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewSQLClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjects(ctx, d.gcp, creds, func(projectID string) ([]models.Resource, error) {
+		return cl.GetAllInstances(ctx, projectID)
+	})
+}
+
+func (d *gcpSQLDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return err
+	}
+
+	cl, err := d.gcp.NewSQLClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, resourceProject(res, gCreds), res.Name)
+}
+
+func (d *gcpSQLDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewSQLClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, resourceProject(res, gCreds), res.Name)
+}
+
+func (d *gcpSQLDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return "", err
+	}
+
+	cl, err := d.gcp.NewSQLClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, resourceProject(res, gCreds), res.Name)
+}
+
+// gkeNodePoolDriver is the driver of the GKE node pools, they are suspended by scaling them to zero.
+type gkeNodePoolDriver struct {
+	gcp GCPClient
+}
+
+func (*gkeNodePoolDriver) Types() []ResourceType {
+	return []ResourceType{GKENODEPOOL}
+}
+
+func (d *gkeNodePoolDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewGKEClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjects(ctx, d.gcp, creds, func(projectID string) ([]models.Resource, error) {
+		return cl.GetAllNodePools(ctx, projectID)
+	})
+}
+
+// Start scales a node pool back to its previous size and autoscaling config.
+func (d *gkeNodePoolDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return err
+	}
+
+	cl, err := d.gcp.NewGKEClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return err
+	}
+
+	return cl.StartNodePool(ctx, res.UID, getPreviousState(res.Settings))
+}
+
+// Stop scales a node pool to zero.
+func (d *gkeNodePoolDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewGKEClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.SuspendNodePool(ctx, res.UID)
+}
+
+// Status reads the size of a node pool.
+func (d *gkeNodePoolDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return "", err
+	}
+
+	cl, err := d.gcp.NewGKEClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetNodePoolStatus(ctx, res.UID)
+}
+
+// gcpServerlessDriver is the driver of the Cloud Run services and jobs, App Engine versions and Cloud Functions.
+// Only the Cloud Run services can be suspended, the other workloads are inventoried only.
+type gcpServerlessDriver struct {
+	gcp GCPClient
+}
+
+func (*gcpServerlessDriver) Types() []ResourceType {
+	return []ResourceType{CLOUDRUNSERVICE, CLOUDRUNJOB, APPENGINEVERSION, CLOUDFUNCTION}
+}
+
+func (d *gcpServerlessDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewServerlessClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjects(ctx, d.gcp, creds, func(projectID string) ([]models.Resource, error) {
+		return cl.GetAllServerless(ctx, projectID)
+	})
+}
+
+// Start restores the minimum instances and the ingress of a Cloud Run service.
+func (d *gcpServerlessDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	if ResourceType(res.Type) != CLOUDRUNSERVICE {
+		return &errReadOnlyResource{resourceType: ResourceType(res.Type)}
+	}
+
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return err
+	}
+
+	cl, err := d.gcp.NewServerlessClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return err
+	}
+
+	return cl.StartService(ctx, res.UID, getPreviousState(res.Settings))
+}
+
+// Stop sets the minimum instances of a Cloud Run service to zero and disables its ingress.
+func (d *gcpServerlessDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	if ResourceType(res.Type) != CLOUDRUNSERVICE {
+		return nil, &errReadOnlyResource{resourceType: ResourceType(res.Type)}
+	}
+
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewServerlessClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.SuspendService(ctx, res.UID)
+}
+
+// Status reads the ingress of a Cloud Run service, the other workloads are not changed by zopdev and keep the status
+// of their last sync.
+func (d *gcpServerlessDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	if ResourceType(res.Type) != CLOUDRUNSERVICE {
+		return res.Status, nil
+	}
+
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return "", err
+	}
+
+	cl, err := d.gcp.NewServerlessClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetServiceStatus(ctx, res.UID)
+}
+
+// gcpStorageDriver is the driver of the Cloud Storage buckets and the persistent disks, they are inventoried only.
+type gcpStorageDriver struct {
+	readOnly
+
+	gcp GCPClient
+}
+
+func (*gcpStorageDriver) Types() []ResourceType {
+	return []ResourceType{GCSBUCKET, GCEDISK}
+}
+
+func (d *gcpStorageDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	gCreds, err := d.gcp.NewGoogleCredentials(ctx, creds, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := d.gcp.NewStorageClient(ctx, option.WithCredentials(gCreds))
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjects(ctx, d.gcp, creds, func(projectID string) ([]models.Resource, error) {
+		return cl.GetAllStorage(ctx, projectID)
+	})
+}
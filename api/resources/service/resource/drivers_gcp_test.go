@@ -0,0 +1,362 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestGCPSQLDriver_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	creds := map[string]any{
+		"project_id": "test-project",
+		"region":     "us-central1",
+	}
+	ctx := &gofr.Context{
+		Context: context.Background(),
+	}
+
+	mockGCP := NewMockGCPClient(ctrl)
+	mockCreds := &google.Credentials{ProjectID: "test-project"}
+	mockResp := []models.Resource{
+		{Name: "sql-instance-1"}, {Name: "sql-instance-2"},
+	}
+	mockLister := &mockSQLClient{
+		isError:   false,
+		instances: mockResp,
+	}
+
+	testCases := []struct {
+		name      string
+		expResp   []models.Resource
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name:    "Success",
+			expResp: mockResp,
+			expErr:  nil,
+			mockCalls: func() {
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(mockLister, nil)
+				mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"test-project"}, nil)
+			},
+		},
+		{
+			name:   "Error getting projects",
+			expErr: errMock,
+			mockCalls: func() {
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(mockLister, nil)
+				mockGCP.EXPECT().GetProjects(ctx, creds).Return(nil, errMock)
+			},
+		},
+		{
+			name:   "Error creating credentials",
+			expErr: errMock,
+			mockCalls: func() {
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(nil, errMock)
+			},
+		},
+		{
+			name:   "Error creating SQL instance lister",
+			expErr: errMock,
+			mockCalls: func() {
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name:   "Error getting SQL instances",
+			expErr: errMock,
+			mockCalls: func() {
+				mockLister.isError = true
+
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(mockLister, nil)
+				mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"test-project"}, nil)
+			},
+		},
+	}
+
+	d := &gcpSQLDriver{gcp: mockGCP}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			instances, err := d.List(ctx, creds)
+
+			assert.Equal(t, tc.expResp, instances)
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func Test_listProjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"project_id": "zop", "organization_id": "1"}
+	mockGCP := NewMockGCPClient(ctrl)
+
+	mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"prod", "staging"}, nil)
+
+	res, err := listProjects(ctx, mockGCP, creds, func(projectID string) ([]models.Resource, error) {
+		return []models.Resource{{Name: "sql-1", UID: projectID + "/sql-1"}}, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{
+		{Name: "sql-1", UID: "prod/sql-1", Project: "prod"},
+		{Name: "sql-1", UID: "staging/sql-1", Project: "staging"},
+	}, res)
+}
+
+func Test_listProjects_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"project_id": "zop", "organization_id": "1"}
+	mockGCP := NewMockGCPClient(ctrl)
+	disabled := &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "accessNotConfigured"}}}
+
+	mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"prod", "sandbox"}, nil).Times(2)
+
+	// The sandbox project did not enable the API, it holds no resource of it.
+	res, err := listProjects(ctx, mockGCP, creds, func(projectID string) ([]models.Resource, error) {
+		if projectID == "sandbox" {
+			return nil, disabled
+		}
+
+		return []models.Resource{{Name: "sql-1"}}, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{Name: "sql-1", Project: "prod"}}, res)
+
+	res, err = listProjects(ctx, mockGCP, creds, func(string) ([]models.Resource, error) {
+		return nil, &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}
+	})
+
+	assert.Nil(t, res)
+	require.Error(t, err)
+}
+
+func Test_getProjects_OncePerSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := withProjects(&gofr.Context{Context: context.Background()})
+	creds := map[string]any{"project_id": "zop", "organization_id": "1"}
+	mockGCP := NewMockGCPClient(ctrl)
+
+	mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"prod"}, nil)
+
+	for range 3 {
+		projects, err := getProjects(ctx, mockGCP, creds)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod"}, projects)
+	}
+}
+
+func Test_resourceProject(t *testing.T) {
+	gCreds := &google.Credentials{ProjectID: "zop"}
+
+	assert.Equal(t, "prod", resourceProject(&models.Resource{Project: "prod"}, gCreds))
+	assert.Equal(t, "zop", resourceProject(&models.Resource{}, gCreds))
+}
+
+func TestGCPSQLDriver_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mGCP := NewMockGCPClient(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"project_id": "test-project", "region": "us-central1"}
+	mockCreds := &google.Credentials{ProjectID: "test-project"}
+	res := &models.Resource{ID: 1, Name: "test-instance", Type: string(SQL)}
+	d := &gcpSQLDriver{gcp: mGCP}
+
+	testCases := []struct {
+		name      string
+		state     ResourceState
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name:  "Successfully start SQL instance",
+			state: START,
+			mockCalls: func() {
+				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(&mockSQLClient{}, nil)
+			},
+		},
+		{
+			name:  "Successfully stop SQL instance",
+			state: SUSPEND,
+			mockCalls: func() {
+				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(&mockSQLClient{}, nil)
+			},
+		},
+		{
+			name:   "Error getting google credentials",
+			state:  START,
+			expErr: errMock,
+			mockCalls: func() {
+				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+					Return(nil, errMock)
+			},
+		},
+		{
+			name:   "Error getting SQL Client",
+			state:  SUSPEND,
+			expErr: errMock,
+			mockCalls: func() {
+				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name:   "Error starting SQL instance",
+			state:  START,
+			expErr: errMock,
+			mockCalls: func() {
+				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(&mockSQLClient{isError: true}, nil)
+			},
+		},
+		{
+			name:   "Error stopping SQL instance",
+			state:  SUSPEND,
+			expErr: errMock,
+			mockCalls: func() {
+				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+					Return(&mockSQLClient{isError: true}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			var err error
+
+			if tc.state == START {
+				err = d.Start(ctx, creds, res)
+			} else {
+				var prev models.Settings
+
+				prev, err = d.Stop(ctx, creds, res)
+
+				assert.Nil(t, prev)
+			}
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestGCPServerlessDriver_ReadOnlyTypes(t *testing.T) {
+	d := &gcpServerlessDriver{}
+	job := &models.Resource{Name: "nightly", Type: string(CLOUDRUNJOB)}
+
+	err := d.Start(&gofr.Context{}, nil, job)
+	assert.Equal(t, &errReadOnlyResource{resourceType: CLOUDRUNJOB}, err)
+
+	prev, err := d.Stop(&gofr.Context{}, nil, job)
+	assert.Nil(t, prev)
+	assert.Equal(t, &errReadOnlyResource{resourceType: CLOUDRUNJOB}, err)
+}
+
+func TestGCPStorageDriver_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"project_id": "test-project"}
+	mockGCP := NewMockGCPClient(ctrl)
+	mockCreds := &google.Credentials{ProjectID: "test-project"}
+	mockResp := []models.Resource{{Name: "assets", Type: string(GCSBUCKET)}, {Name: "boot", Type: string(GCEDISK)}}
+	d := &gcpStorageDriver{gcp: mockGCP}
+
+	testCases := []struct {
+		name      string
+		expResp   []models.Resource
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name:    "buckets and disks",
+			expResp: mockResp,
+			mockCalls: func() {
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mockGCP.EXPECT().NewStorageClient(ctx, option.WithCredentials(mockCreds)).
+					Return(&mockStorageClient{resources: mockResp}, nil)
+				mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"test-project"}, nil)
+			},
+		},
+		{
+			name:   "listing error",
+			expErr: errMock,
+			mockCalls: func() {
+				mockGCP.EXPECT().NewGoogleCredentials(ctx, creds, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil)
+				mockGCP.EXPECT().NewStorageClient(ctx, option.WithCredentials(mockCreds)).
+					Return(&mockStorageClient{isError: true}, nil)
+				mockGCP.EXPECT().GetProjects(ctx, creds).Return([]string{"test-project"}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			res, err := d.List(ctx, creds)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expResp, res)
+		})
+	}
+
+	err := d.Start(ctx, creds, &mockResp[0])
+	assert.Equal(t, &errReadOnlyResource{resourceType: GCSBUCKET}, err)
+}
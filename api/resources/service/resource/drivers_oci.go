@@ -0,0 +1,97 @@
+package resource
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// ociComputeDriver is the driver of the OCI compute instances.
+type ociComputeDriver struct {
+	oci OCIClient
+}
+
+func (*ociComputeDriver) Types() []ResourceType {
+	return []ResourceType{OCIINSTANCE}
+}
+
+func (d *ociComputeDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.oci.NewComputeClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetAllInstances(ctx)
+}
+
+func (d *ociComputeDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.oci.NewComputeClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, res.UID)
+}
+
+func (d *ociComputeDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.oci.NewComputeClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, res.UID)
+}
+
+func (d *ociComputeDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.oci.NewComputeClient(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, res.UID)
+}
+
+// ociDatabaseDriver is the driver of the OCI DB systems and Autonomous Databases.
+type ociDatabaseDriver struct {
+	oci OCIClient
+}
+
+func (*ociDatabaseDriver) Types() []ResourceType {
+	return []ResourceType{OCIDBSYSTEM, OCIAUTONOMOUSDB}
+}
+
+func (d *ociDatabaseDriver) List(ctx *gofr.Context, creds any) ([]models.Resource, error) {
+	cl, err := d.oci.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetAllInstances(ctx)
+}
+
+func (d *ociDatabaseDriver) Start(ctx *gofr.Context, creds any, res *models.Resource) error {
+	cl, err := d.oci.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	return cl.StartInstance(ctx, res.UID)
+}
+
+func (d *ociDatabaseDriver) Stop(ctx *gofr.Context, creds any, res *models.Resource) (models.Settings, error) {
+	cl, err := d.oci.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cl.StopInstance(ctx, res.UID)
+}
+
+func (d *ociDatabaseDriver) Status(ctx *gofr.Context, creds any, res *models.Resource) (string, error) {
+	cl, err := d.oci.NewDatabaseClient(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+
+	return cl.GetInstanceStatus(ctx, res.UID)
+}
@@ -0,0 +1,32 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// errSyncInProgress is returned when the resources of a cloud account are already being synced.
+type errSyncInProgress struct {
+	cloudAccID int64
+}
+
+func (e *errSyncInProgress) Error() string {
+	return fmt.Sprintf("resources of cloud account %d are already being synced", e.cloudAccID)
+}
+
+func (*errSyncInProgress) StatusCode() int {
+	return http.StatusConflict
+}
+
+// errReadOnlyResource is returned when the state of a resource that is inventoried only is changed.
+type errReadOnlyResource struct {
+	resourceType ResourceType
+}
+
+func (e *errReadOnlyResource) Error() string {
+	return fmt.Sprintf("state of %s resources can not be changed", e.resourceType)
+}
+
+func (*errReadOnlyResource) StatusCode() int {
+	return http.StatusBadRequest
+}
@@ -0,0 +1,142 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/auth"
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+const (
+	// defaultEventLimit is the number of events returned when no limit is requested.
+	defaultEventLimit = 100
+	// maxEventLimit caps the number of events returned in a single request.
+	maxEventLimit = 1000
+)
+
+// GetEvents returns the inventory history of a cloud account, the latest events come first.
+func (s *Service) GetEvents(ctx *gofr.Context, cloudAccID int64, filter *models.EventFilter) ([]models.ResourceEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventLimit
+	}
+
+	filter.Limit = min(filter.Limit, maxEventLimit)
+
+	return s.store.GetEvents(ctx, cloudAccID, filter)
+}
+
+// syncResource updates the stored resource with the latest state fetched from the cloud
+// and records an event for every change.
+func (s *Service) syncResource(ctx *gofr.Context, run *models.SyncRun, stored, latest *models.Resource) {
+	var updated bool
+
+	// The previous state is recorded by zopdev when a resource is suspended, the cloud provider does not report it.
+	if prev, ok := stored.Settings[previousStateKey]; ok {
+		if latest.Settings == nil {
+			latest.Settings = models.Settings{}
+		}
+
+		latest.Settings[previousStateKey] = prev
+	}
+
+	if stored.Status != latest.Status {
+		err := s.store.UpdateStatus(ctx, latest.Status, stored.ID)
+		if err != nil {
+			ctx.Errorf("failed to update resource: %v", err)
+			addSyncError(run, "failed to update status of resource %s: %v", stored.UID, err)
+		} else {
+			updated = true
+
+			s.recordEvent(ctx, stored, models.EventStatusChanged, models.EventSourceSync, stored.Status, latest.Status)
+		}
+	}
+
+	if !settingsEqual(stored.Settings, latest.Settings) {
+		err := s.store.UpdateSettings(ctx, latest.Settings, stored.ID)
+		if err != nil {
+			ctx.Errorf("failed to update resource settings: %v", err)
+			addSyncError(run, "failed to update settings of resource %s: %v", stored.UID, err)
+		} else {
+			updated = true
+
+			s.recordEvent(ctx, stored, models.EventSettingsChanged, models.EventSourceSync, stored.Settings, latest.Settings)
+		}
+	}
+
+	if !maps.Equal(stored.Labels, latest.Labels) {
+		err := s.store.UpdateLabels(ctx, latest.Labels, stored.ID)
+		if err != nil {
+			ctx.Errorf("failed to update resource labels: %v", err)
+			addSyncError(run, "failed to update labels of resource %s: %v", stored.UID, err)
+		} else {
+			updated = true
+
+			s.recordEvent(ctx, stored, models.EventLabelsChanged, models.EventSourceSync, stored.Labels, latest.Labels)
+		}
+	}
+
+	// The project is not a change of the resource, it is only missing for the resources synced before it was recorded.
+	if stored.Project != latest.Project {
+		err := s.store.UpdateProject(ctx, latest.Project, stored.ID)
+		if err != nil {
+			ctx.Errorf("failed to update resource project: %v", err)
+			addSyncError(run, "failed to update project of resource %s: %v", stored.UID, err)
+		}
+	}
+
+	if updated {
+		run.Updated++
+	}
+}
+
+// recordEvent persists a change of the given resource in the inventory history. A nil value is not recorded,
+// e.g. there is no old value for a created resource. Failing to record an event does not fail the operation.
+func (s *Service) recordEvent(ctx *gofr.Context, res *models.Resource, eventType, source string, oldVal, newVal any) {
+	event := &models.ResourceEvent{
+		CloudAccountID: res.CloudAccount.ID,
+		ResourceID:     res.ID,
+		ResourceUID:    res.UID,
+		ResourceName:   res.Name,
+		ResourceType:   res.Type,
+		EventType:      eventType,
+		Source:         source,
+		OldValue:       marshalEventValue(oldVal),
+		NewValue:       marshalEventValue(newVal),
+	}
+
+	// The changes made through zopdev record who made them.
+	if principal, ok := auth.FromContext(ctx); ok && source == models.EventSourceAPI {
+		event.Actor = principal.Subject
+	}
+
+	err := s.store.InsertEvent(ctx, event)
+	if err != nil {
+		ctx.Errorf("failed to record %s event for resource %d: %v", eventType, res.ID, err)
+	}
+}
+
+func marshalEventValue(val any) json.RawMessage {
+	if val == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+// settingsEqual compares the JSON representation of the settings, as the numbers read back from the store
+// are decoded as float64 whereas the providers may use other numeric types.
+func settingsEqual(a, b models.Settings) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+
+	return errX == nil && errY == nil && bytes.Equal(x, y)
+}
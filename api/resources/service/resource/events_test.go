@@ -0,0 +1,133 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestService_GetEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+	mockEvents := []models.ResourceEvent{{ID: 1, EventType: models.EventCreated}}
+
+	testCases := []struct {
+		name     string
+		filter   *models.EventFilter
+		expLimit int
+		expErr   error
+	}{
+		{name: "default limit", filter: &models.EventFilter{}, expLimit: defaultEventLimit},
+		{name: "requested limit", filter: &models.EventFilter{ResourceID: 2, Limit: 10}, expLimit: 10},
+		{name: "limit capped", filter: &models.EventFilter{Limit: 5000}, expLimit: maxEventLimit},
+		{name: "store error", filter: &models.EventFilter{}, expLimit: defaultEventLimit, expErr: errMock},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expFilter := *tc.filter
+			expFilter.Limit = tc.expLimit
+
+			if tc.expErr != nil {
+				mStore.EXPECT().GetEvents(ctx, int64(1), &expFilter).Return(nil, tc.expErr)
+			} else {
+				mStore.EXPECT().GetEvents(ctx, int64(1), &expFilter).Return(mockEvents, nil)
+			}
+
+			events, err := s.GetEvents(ctx, 1, tc.filter)
+
+			assert.Equal(t, tc.expErr, err)
+
+			if tc.expErr == nil {
+				assert.Equal(t, mockEvents, events)
+			}
+		})
+	}
+}
+
+func TestService_syncResource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	stored := &models.Resource{ID: 1, UID: "i-123", Name: "vm-1", Type: "EC2", Status: RUNNING,
+		CloudAccount: models.CloudAccount{ID: 2}, Settings: models.Settings{"InstanceType": "t2.micro", "size": float64(10)}}
+
+	testCases := []struct {
+		name      string
+		latest    *models.Resource
+		mockCalls func()
+	}{
+		{
+			name: "no changes",
+			latest: &models.Resource{ID: 1, Status: RUNNING,
+				Settings: models.Settings{"InstanceType": "t2.micro", "size": 10}},
+			mockCalls: func() {},
+		},
+		{
+			name: "settings changed",
+			latest: &models.Resource{ID: 1, Status: RUNNING,
+				Settings: models.Settings{"InstanceType": "t3.micro", "size": 10}},
+			mockCalls: func() {
+				mStore.EXPECT().UpdateSettings(ctx, models.Settings{"InstanceType": "t3.micro", "size": 10}, int64(1)).
+					Return(nil)
+				mStore.EXPECT().InsertEvent(ctx, &models.ResourceEvent{CloudAccountID: 2, ResourceID: 1, ResourceUID: "i-123",
+					ResourceName: "vm-1", ResourceType: "EC2", EventType: models.EventSettingsChanged,
+					Source: models.EventSourceSync, OldValue: json.RawMessage(`{"InstanceType":"t2.micro","size":10}`),
+					NewValue: json.RawMessage(`{"InstanceType":"t3.micro","size":10}`)}).
+					Return(nil)
+			},
+		},
+		{
+			name: "failed update is not recorded",
+			latest: &models.Resource{ID: 1, Status: STOPPED,
+				Settings: models.Settings{"InstanceType": "t2.micro", "size": 10}},
+			mockCalls: func() {
+				mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(1)).Return(errMock)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			s.syncResource(ctx, &models.SyncRun{}, stored, tc.latest)
+		})
+	}
+}
+
+func TestService_syncResource_KeepsPreviousState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	previous := map[string]any{"node_count": float64(3)}
+	stored := &models.Resource{ID: 1, UID: "p/us-central1/dev/default", Type: string(GKENODEPOOL), Status: STOPPED,
+		Settings: models.Settings{"node_count": float64(0), previousStateKey: previous}}
+	latest := &models.Resource{ID: 1, Status: STOPPED, Settings: models.Settings{"node_count": int64(0)}}
+
+	// The previous state is not reported by the cloud provider, hence the settings are unchanged.
+	s.syncResource(ctx, &models.SyncRun{}, stored, latest)
+
+	assert.Equal(t, models.Settings{"node_count": int64(0), previousStateKey: previous}, latest.Settings)
+}
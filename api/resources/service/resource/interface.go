@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"time"
 
 	"gofr.dev/pkg/gofr"
 	"golang.org/x/oauth2/google"
@@ -9,19 +10,42 @@ import (
 
 	"github.com/zopdev/zopdev/api/resources/client"
 	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/pricing"
 	"github.com/zopdev/zopdev/api/resources/providers/aws/database"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/scaling"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/storage"
 	"github.com/zopdev/zopdev/api/resources/providers/aws/vm"
+	azureDatabase "github.com/zopdev/zopdev/api/resources/providers/azure/database"
+	azureVM "github.com/zopdev/zopdev/api/resources/providers/azure/vm"
 	"github.com/zopdev/zopdev/api/resources/providers/gcp"
+	ociCompute "github.com/zopdev/zopdev/api/resources/providers/oci/compute"
+	ociDatabase "github.com/zopdev/zopdev/api/resources/providers/oci/database"
 )
 
 type GCPClient interface {
 	NewGoogleCredentials(ctx context.Context, cred any, scopes ...string) (*google.Credentials, error)
+	GetProjects(ctx context.Context, cred any) ([]string, error)
 	NewSQLClient(ctx context.Context, opts ...option.ClientOption) (gcp.SQLClient, error)
+	NewGKEClient(ctx context.Context, opts ...option.ClientOption) (gcp.GKEClient, error)
+	NewServerlessClient(ctx context.Context, opts ...option.ClientOption) (gcp.ServerlessClient, error)
+	NewStorageClient(ctx context.Context, opts ...option.ClientOption) (gcp.StorageClient, error)
 }
 
 type AWSClient interface {
 	NewRDSClient(_ context.Context, creds any) (*database.Client, error)
 	NewEC2Client(_ context.Context, creds any) (*vm.Client, error)
+	NewScalingClient(_ context.Context, creds any) (*scaling.Client, error)
+	NewStorageClient(_ context.Context, creds any) (*storage.Client, error)
+}
+
+type AzureClient interface {
+	NewVMClient(ctx context.Context, creds any) (*azureVM.Client, error)
+	NewDatabaseClient(ctx context.Context, creds any) (*azureDatabase.Client, error)
+}
+
+type OCIClient interface {
+	NewComputeClient(ctx context.Context, creds any) (*ociCompute.Client, error)
+	NewDatabaseClient(ctx context.Context, creds any) (*ociDatabase.Client, error)
 }
 
 type HTTPClient interface {
@@ -32,7 +56,28 @@ type HTTPClient interface {
 type Store interface {
 	InsertResource(ctx *gofr.Context, resources *models.Resource) error
 	GetResources(ctx *gofr.Context, cloudAccountID int64, resourceType []string) ([]models.Resource, error)
+	ListResources(ctx *gofr.Context, cloudAccountIDs []int64, filter *models.ResourceFilter,
+		page *models.ResourcePage) (*models.ResourceList, error)
+	GetResourcesIncludingDeleted(ctx *gofr.Context, cloudAccountID int64) ([]models.Resource, error)
 	UpdateStatus(ctx *gofr.Context, status string, id int64) error
+	UpdateLabels(ctx *gofr.Context, labels models.Labels, id int64) error
+	UpdateSettings(ctx *gofr.Context, settings models.Settings, id int64) error
+	UpdateProject(ctx *gofr.Context, project string, id int64) error
 	RemoveResource(ctx *gofr.Context, id int64) error
+	RestoreResource(ctx *gofr.Context, id int64) error
 	GetResourceByID(ctx *gofr.Context, id int64) (*models.Resource, error)
+
+	InsertEvent(ctx *gofr.Context, event *models.ResourceEvent) error
+	GetEvents(ctx *gofr.Context, cloudAccountID int64, filter *models.EventFilter) ([]models.ResourceEvent, error)
+	GetStatusHistory(ctx *gofr.Context, cloudAccountID int64, until time.Time) ([]models.ResourceEvent, error)
+
+	InsertSyncRun(ctx *gofr.Context, run *models.SyncRun) error
+	UpdateSyncRun(ctx *gofr.Context, run *models.SyncRun) error
+	GetSyncRuns(ctx *gofr.Context, cloudAccountID int64, limit int) ([]models.SyncRun, error)
+}
+
+// PriceCatalog provides the estimated hourly prices used to report the spend and the savings of the resources.
+type PriceCatalog interface {
+	Currency() string
+	Lookup(key pricing.Key) (pricing.Price, bool)
 }
@@ -4,113 +4,60 @@ import (
 	"strings"
 
 	"gofr.dev/pkg/gofr"
-	"google.golang.org/api/option"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/zopdev/zopdev/api/resources/client"
 	"github.com/zopdev/zopdev/api/resources/models"
 )
 
-func (s *Service) getAllInstances(ctx *gofr.Context, ca *client.CloudAccount) ([]models.Resource, error) {
-	var instances []models.Resource
-
-	type result struct {
-		instances []models.Resource
-		err       error
-	}
+// getAllInstances lists the resources of the cloud account within the given scope through the drivers of its provider.
+// The drivers whose resource types are all out of the scope are not called.
+func (s *Service) getAllInstances(ctx *gofr.Context, ca *client.CloudAccount, scope *models.SyncScope) ([]models.Resource, error) {
+	ctx = withProjects(ctx)
 
-	sqlCh := make(chan result, 1)
-	computeCh := make(chan result, 1)
+	// An unknown provider has no drivers, the sync process is completely internal so no error is returned.
+	drivers := s.drivers.list(CloudProvider(strings.ToUpper(ca.Provider)))
+	results := make([][]models.Resource, len(drivers))
 
-	// Fetch SQL instances concurrently
-	go func() {
-		sql, err := s.getAllSQLInstances(ctx, CloudDetails{
-			CloudType: CloudProvider(strings.ToUpper(ca.Provider)),
-			Creds:     ca.Credentials,
-		})
+	var g errgroup.Group
 
-		for i := range sql {
-			sql[i].CloudAccount.ID = ca.ID
-			sql[i].CloudAccount.Type = ca.Provider
+	// The drivers are called concurrently, the results are kept in the order the drivers are registered.
+	for i, d := range drivers {
+		types := make([]string, 0, len(d.Types()))
+		for _, t := range d.Types() {
+			types = append(types, string(t))
 		}
 
-		sqlCh <- result{sql, err}
-	}()
-
-	// Fetch compute instances concurrently
-	go func() {
-		computeInstances, err := s.getALLComputeInstances(ctx, CloudDetails{
-			CloudType: CloudProvider(strings.ToUpper(ca.Provider)),
-			Creds:     ca.Credentials,
-		})
-
-		for i := range computeInstances {
-			computeInstances[i].CloudAccount.ID = ca.ID
-			computeInstances[i].CloudAccount.Type = ca.Provider
+		if !scope.IncludesType(types...) {
+			continue
 		}
 
-		computeCh <- result{computeInstances, err}
-	}()
+		g.Go(func() error {
+			instances, err := d.List(ctx, ca.Credentials)
+			if err != nil {
+				return err
+			}
 
-	var sqlRes, computeRes result
+			for j := range instances {
+				instances[j].CloudAccount.ID = ca.ID
+				instances[j].CloudAccount.Type = ca.Provider
+			}
 
-	for i := 0; i < 2; i++ {
-		select {
-		case r := <-sqlCh:
-			sqlRes = r
-		case r := <-computeCh:
-			computeRes = r
-		}
-	}
-
-	if sqlRes.err != nil {
-		return nil, sqlRes.err
-	}
-
-	if computeRes.err != nil {
-		return nil, computeRes.err
-	}
+			results[i] = instances
 
-	instances = append(instances, sqlRes.instances...)
-	instances = append(instances, computeRes.instances...)
-
-	// Get all other instances (e.g., Compute Engine, Kubernetes, etc.)
-	// TODO: Implement other instance types
-
-	return instances, nil
-}
-
-func (s *Service) getAllSQLInstances(ctx *gofr.Context, req CloudDetails) ([]models.Resource, error) {
-	switch req.CloudType {
-	case GCP:
-		return s.getGCPSQLInstances(ctx, req.Creds)
-	case AWS:
-		return s.getAWSRDSInstances(ctx, req.Creds)
-	default:
-		// We are not returning any error because the sync process is completely internal, works on the cloud Account ID,
-		// if we are getting an unknown cloud type, then this feature is not implemented and we simply return nil.
-		return nil, nil
-	}
-}
-
-func (s *Service) getGCPSQLInstances(ctx *gofr.Context, cred any) ([]models.Resource, error) {
-	creds, err := s.gcp.NewGoogleCredentials(ctx, cred, "https://www.googleapis.com/auth/cloud-platform")
-	if err != nil {
-		return nil, err
+			return nil
+		})
 	}
 
-	sqlClient, err := s.gcp.NewSQLClient(ctx, option.WithCredentials(creds))
-	if err != nil {
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 
-	return sqlClient.GetAllInstances(ctx, creds.ProjectID)
-}
+	var instances []models.Resource
 
-func (s *Service) getAWSRDSInstances(ctx *gofr.Context, cred any) ([]models.Resource, error) {
-	awsRDSClient, err := s.aws.NewRDSClient(ctx, cred)
-	if err != nil {
-		return nil, err
+	for _, r := range results {
+		instances = append(instances, r...)
 	}
 
-	return awsRDSClient.GetAllInstances(ctx)
+	return filterByScope(instances, scope), nil
 }
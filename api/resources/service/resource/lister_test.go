@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,115 +12,86 @@ import (
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	"github.com/zopdev/zopdev/api/resources/client"
 	"github.com/zopdev/zopdev/api/resources/models"
+	azureDatabase "github.com/zopdev/zopdev/api/resources/providers/azure/database"
+	azureVM "github.com/zopdev/zopdev/api/resources/providers/azure/vm"
 )
 
-func TestService_getAllSQLInstances_UnsupportedCloud(t *testing.T) {
-	ctx := &gofr.Context{}
-	req := CloudDetails{CloudType: "Unknown"}
+func TestService_getAllInstances_UnsupportedCloud(t *testing.T) {
+	ctx := &gofr.Context{Context: context.Background()}
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mAWS := NewMockAWSClient(ctrl)
-	s := New(nil, mAWS, nil, nil)
-	instances, err := s.getAllSQLInstances(ctx, req)
+	s := New(nil, mAWS, nil, nil, nil, nil)
+	instances, err := s.getAllInstances(ctx, &client.CloudAccount{ID: 1, Provider: "Unknown"}, &models.SyncScope{})
 
 	assert.Nil(t, instances)
 	require.NoError(t, err)
 }
 
-func TestService_getAllSQLInstances_GCP(t *testing.T) {
+func TestService_getAllInstances_Scope(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	req := CloudDetails{
-		CloudType: GCP,
-		Creds: map[string]any{
-			"project_id": "test-project",
-			"region":     "us-central1",
-		},
-	}
-	ctx := &gofr.Context{
-		Context: context.Background(),
-	}
-
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"project_id": "test-project"}
 	mockGCP := NewMockGCPClient(ctrl)
 	mockCreds := &google.Credentials{ProjectID: "test-project"}
-	mockResp := []models.Resource{
-		{Name: "sql-instance-1"}, {Name: "sql-instance-2"},
-	}
-	mockLister := &mockSQLClient{
-		isError:   false,
-		instances: mockResp,
-	}
+	mockResp := []models.Resource{{Name: "assets", UID: "assets", Type: string(GCSBUCKET)},
+		{Name: "boot", UID: "boot", Type: string(GCEDISK)}}
+	s := New(mockGCP, nil, nil, nil, nil, nil)
 
-	testCases := []struct {
-		name      string
-		req       CloudDetails
-		expResp   []models.Resource
-		expErr    error
-		mockCalls func()
-	}{
-		{
-			name:    "Success",
-			req:     req,
-			expResp: mockResp,
-			expErr:  nil,
-			mockCalls: func() {
-				mockGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(mockCreds, nil)
-				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
-					Return(mockLister, nil)
-			},
-		},
-		{
-			name:   "Error creating credentials",
-			req:    req,
-			expErr: errMock,
-			mockCalls: func() {
-				mockGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(nil, errMock)
-			},
-		},
-		{
-			name:   "Error creating SQL instance lister",
-			req:    req,
-			expErr: errMock,
-			mockCalls: func() {
-				mockGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(mockCreds, nil)
-				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
-					Return(nil, errMock)
-			},
-		},
-		{
-			name:   "Error getting SQL instances",
-			req:    req,
-			expErr: errMock,
-			mockCalls: func() {
-				mockLister.isError = true
-
-				mockGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(mockCreds, nil)
-				mockGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
-					Return(mockLister, nil)
-			},
-		},
-	}
+	// Only the storage driver is called, the other GCP drivers have no type within the scope.
+	mockGCP.EXPECT().NewGoogleCredentials(gomock.Any(), creds, "https://www.googleapis.com/auth/cloud-platform").
+		Return(mockCreds, nil)
+	mockGCP.EXPECT().NewStorageClient(gomock.Any(), option.WithCredentials(mockCreds)).
+		Return(&mockStorageClient{resources: mockResp}, nil)
+	mockGCP.EXPECT().GetProjects(gomock.Any(), creds).Return([]string{"test-project"}, nil)
 
-	mAWS := NewMockAWSClient(ctrl)
-	s := New(mockGCP, mAWS, nil, nil)
+	res, err := s.getAllInstances(ctx, &client.CloudAccount{ID: 7, Provider: "gcp", Credentials: creds},
+		&models.SyncScope{ResourceTypes: []string{string(GCEDISK)}})
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			tc.mockCalls()
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "boot", res[0].Name)
+	assert.Equal(t, models.CloudAccount{ID: 7, Type: "gcp"}, res[0].CloudAccount)
+}
 
-			instances, err := s.getAllSQLInstances(ctx, req)
+func TestService_getAllInstances_Azure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-			assert.Equal(t, tc.expResp, instances)
-			assert.Equal(t, tc.expErr, err)
-		})
-	}
+	ctx := &gofr.Context{Context: context.Background()}
+	creds := map[string]any{"subscription_id": "sub-1"}
+	mockAzure := NewMockAzureClient(ctrl)
+	s := New(nil, nil, mockAzure, nil, nil, nil)
+
+	armClient := newAzureARM(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines":
+			_, _ = w.Write([]byte(`{"value":[{"id":"vm-id","name":"web","location":"eastus"}]}`))
+		case "/subscriptions/sub-1/providers/Microsoft.DBforPostgreSQL/flexibleServers":
+			_, _ = w.Write([]byte(`{"value":[{"id":"db-id","name":"users","location":"eastus"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"value":[]}`))
+		}
+	})
+
+	mockAzure.EXPECT().NewVMClient(gomock.Any(), creds).Return(&azureVM.Client{ARM: armClient}, nil)
+	mockAzure.EXPECT().NewDatabaseClient(gomock.Any(), creds).Return(&azureDatabase.Client{ARM: armClient}, nil)
+
+	res, err := s.getAllInstances(ctx, &client.CloudAccount{ID: 7, Provider: "azure", Credentials: creds},
+		&models.SyncScope{})
+
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "users", res[0].Name)
+	assert.Equal(t, string(AZUREFLEXIBLESERVER), res[0].Type)
+	assert.Equal(t, "web", res[1].Name)
+	assert.Equal(t, string(AZUREVM), res[1].Type)
+	assert.Equal(t, int64(7), res[1].CloudAccount.ID)
 }
 
 func TestService_bSearch(t *testing.T) {
@@ -3,21 +3,29 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interface.go -destination=mock_interface.go -package=service
+//	mockgen -destination=mock_interface.go -package=resource -source=interface.go
 //
 
-// Package service is a generated GoMock package.
+// Package resource is a generated GoMock package.
 package resource
 
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	client "github.com/zopdev/zopdev/api/resources/client"
 	models "github.com/zopdev/zopdev/api/resources/models"
+	pricing "github.com/zopdev/zopdev/api/resources/pricing"
 	database "github.com/zopdev/zopdev/api/resources/providers/aws/database"
+	scaling "github.com/zopdev/zopdev/api/resources/providers/aws/scaling"
+	storage "github.com/zopdev/zopdev/api/resources/providers/aws/storage"
 	vm "github.com/zopdev/zopdev/api/resources/providers/aws/vm"
+	database0 "github.com/zopdev/zopdev/api/resources/providers/azure/database"
+	vm0 "github.com/zopdev/zopdev/api/resources/providers/azure/vm"
 	gcp "github.com/zopdev/zopdev/api/resources/providers/gcp"
+	compute "github.com/zopdev/zopdev/api/resources/providers/oci/compute"
+	database1 "github.com/zopdev/zopdev/api/resources/providers/oci/database"
 	gomock "go.uber.org/mock/gomock"
 	gofr "gofr.dev/pkg/gofr"
 	google "golang.org/x/oauth2/google"
@@ -48,6 +56,41 @@ func (m *MockGCPClient) EXPECT() *MockGCPClientMockRecorder {
 	return m.recorder
 }
 
+// GetProjects mocks base method.
+func (m *MockGCPClient) GetProjects(ctx context.Context, cred any) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx, cred)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockGCPClientMockRecorder) GetProjects(ctx, cred any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockGCPClient)(nil).GetProjects), ctx, cred)
+}
+
+// NewGKEClient mocks base method.
+func (m *MockGCPClient) NewGKEClient(ctx context.Context, opts ...option.ClientOption) (gcp.GKEClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NewGKEClient", varargs...)
+	ret0, _ := ret[0].(gcp.GKEClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewGKEClient indicates an expected call of NewGKEClient.
+func (mr *MockGCPClientMockRecorder) NewGKEClient(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewGKEClient", reflect.TypeOf((*MockGCPClient)(nil).NewGKEClient), varargs...)
+}
+
 // NewGoogleCredentials mocks base method.
 func (m *MockGCPClient) NewGoogleCredentials(ctx context.Context, cred any, scopes ...string) (*google.Credentials, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +131,46 @@ func (mr *MockGCPClientMockRecorder) NewSQLClient(ctx any, opts ...any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSQLClient", reflect.TypeOf((*MockGCPClient)(nil).NewSQLClient), varargs...)
 }
 
+// NewServerlessClient mocks base method.
+func (m *MockGCPClient) NewServerlessClient(ctx context.Context, opts ...option.ClientOption) (gcp.ServerlessClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NewServerlessClient", varargs...)
+	ret0, _ := ret[0].(gcp.ServerlessClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewServerlessClient indicates an expected call of NewServerlessClient.
+func (mr *MockGCPClientMockRecorder) NewServerlessClient(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewServerlessClient", reflect.TypeOf((*MockGCPClient)(nil).NewServerlessClient), varargs...)
+}
+
+// NewStorageClient mocks base method.
+func (m *MockGCPClient) NewStorageClient(ctx context.Context, opts ...option.ClientOption) (gcp.StorageClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NewStorageClient", varargs...)
+	ret0, _ := ret[0].(gcp.StorageClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewStorageClient indicates an expected call of NewStorageClient.
+func (mr *MockGCPClientMockRecorder) NewStorageClient(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewStorageClient", reflect.TypeOf((*MockGCPClient)(nil).NewStorageClient), varargs...)
+}
+
 // MockAWSClient is a mock of AWSClient interface.
 type MockAWSClient struct {
 	ctrl     *gomock.Controller
@@ -142,6 +225,144 @@ func (mr *MockAWSClientMockRecorder) NewRDSClient(arg0, creds any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRDSClient", reflect.TypeOf((*MockAWSClient)(nil).NewRDSClient), arg0, creds)
 }
 
+// NewScalingClient mocks base method.
+func (m *MockAWSClient) NewScalingClient(arg0 context.Context, creds any) (*scaling.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewScalingClient", arg0, creds)
+	ret0, _ := ret[0].(*scaling.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewScalingClient indicates an expected call of NewScalingClient.
+func (mr *MockAWSClientMockRecorder) NewScalingClient(arg0, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewScalingClient", reflect.TypeOf((*MockAWSClient)(nil).NewScalingClient), arg0, creds)
+}
+
+// NewStorageClient mocks base method.
+func (m *MockAWSClient) NewStorageClient(arg0 context.Context, creds any) (*storage.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewStorageClient", arg0, creds)
+	ret0, _ := ret[0].(*storage.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewStorageClient indicates an expected call of NewStorageClient.
+func (mr *MockAWSClientMockRecorder) NewStorageClient(arg0, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewStorageClient", reflect.TypeOf((*MockAWSClient)(nil).NewStorageClient), arg0, creds)
+}
+
+// MockAzureClient is a mock of AzureClient interface.
+type MockAzureClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAzureClientMockRecorder
+	isgomock struct{}
+}
+
+// MockAzureClientMockRecorder is the mock recorder for MockAzureClient.
+type MockAzureClientMockRecorder struct {
+	mock *MockAzureClient
+}
+
+// NewMockAzureClient creates a new mock instance.
+func NewMockAzureClient(ctrl *gomock.Controller) *MockAzureClient {
+	mock := &MockAzureClient{ctrl: ctrl}
+	mock.recorder = &MockAzureClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAzureClient) EXPECT() *MockAzureClientMockRecorder {
+	return m.recorder
+}
+
+// NewDatabaseClient mocks base method.
+func (m *MockAzureClient) NewDatabaseClient(ctx context.Context, creds any) (*database0.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewDatabaseClient", ctx, creds)
+	ret0, _ := ret[0].(*database0.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewDatabaseClient indicates an expected call of NewDatabaseClient.
+func (mr *MockAzureClientMockRecorder) NewDatabaseClient(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDatabaseClient", reflect.TypeOf((*MockAzureClient)(nil).NewDatabaseClient), ctx, creds)
+}
+
+// NewVMClient mocks base method.
+func (m *MockAzureClient) NewVMClient(ctx context.Context, creds any) (*vm0.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewVMClient", ctx, creds)
+	ret0, _ := ret[0].(*vm0.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewVMClient indicates an expected call of NewVMClient.
+func (mr *MockAzureClientMockRecorder) NewVMClient(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewVMClient", reflect.TypeOf((*MockAzureClient)(nil).NewVMClient), ctx, creds)
+}
+
+// MockOCIClient is a mock of OCIClient interface.
+type MockOCIClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOCIClientMockRecorder
+	isgomock struct{}
+}
+
+// MockOCIClientMockRecorder is the mock recorder for MockOCIClient.
+type MockOCIClientMockRecorder struct {
+	mock *MockOCIClient
+}
+
+// NewMockOCIClient creates a new mock instance.
+func NewMockOCIClient(ctrl *gomock.Controller) *MockOCIClient {
+	mock := &MockOCIClient{ctrl: ctrl}
+	mock.recorder = &MockOCIClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOCIClient) EXPECT() *MockOCIClientMockRecorder {
+	return m.recorder
+}
+
+// NewComputeClient mocks base method.
+func (m *MockOCIClient) NewComputeClient(ctx context.Context, creds any) (*compute.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewComputeClient", ctx, creds)
+	ret0, _ := ret[0].(*compute.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewComputeClient indicates an expected call of NewComputeClient.
+func (mr *MockOCIClientMockRecorder) NewComputeClient(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewComputeClient", reflect.TypeOf((*MockOCIClient)(nil).NewComputeClient), ctx, creds)
+}
+
+// NewDatabaseClient mocks base method.
+func (m *MockOCIClient) NewDatabaseClient(ctx context.Context, creds any) (*database1.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewDatabaseClient", ctx, creds)
+	ret0, _ := ret[0].(*database1.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewDatabaseClient indicates an expected call of NewDatabaseClient.
+func (mr *MockOCIClientMockRecorder) NewDatabaseClient(ctx, creds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDatabaseClient", reflect.TypeOf((*MockOCIClient)(nil).NewDatabaseClient), ctx, creds)
+}
+
 // MockHTTPClient is a mock of HTTPClient interface.
 type MockHTTPClient struct {
 	ctrl     *gomock.Controller
@@ -220,6 +441,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// GetEvents mocks base method.
+func (m *MockStore) GetEvents(ctx *gofr.Context, cloudAccountID int64, filter *models.EventFilter) ([]models.ResourceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, cloudAccountID, filter)
+	ret0, _ := ret[0].([]models.ResourceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockStoreMockRecorder) GetEvents(ctx, cloudAccountID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockStore)(nil).GetEvents), ctx, cloudAccountID, filter)
+}
+
 // GetResourceByID mocks base method.
 func (m *MockStore) GetResourceByID(ctx *gofr.Context, id int64) (*models.Resource, error) {
 	m.ctrl.T.Helper()
@@ -250,6 +486,65 @@ func (mr *MockStoreMockRecorder) GetResources(ctx, cloudAccountID, resourceType
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResources", reflect.TypeOf((*MockStore)(nil).GetResources), ctx, cloudAccountID, resourceType)
 }
 
+// GetResourcesIncludingDeleted mocks base method.
+func (m *MockStore) GetResourcesIncludingDeleted(ctx *gofr.Context, cloudAccountID int64) ([]models.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcesIncludingDeleted", ctx, cloudAccountID)
+	ret0, _ := ret[0].([]models.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcesIncludingDeleted indicates an expected call of GetResourcesIncludingDeleted.
+func (mr *MockStoreMockRecorder) GetResourcesIncludingDeleted(ctx, cloudAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesIncludingDeleted", reflect.TypeOf((*MockStore)(nil).GetResourcesIncludingDeleted), ctx, cloudAccountID)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockStore) GetStatusHistory(ctx *gofr.Context, cloudAccountID int64, until time.Time) ([]models.ResourceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, cloudAccountID, until)
+	ret0, _ := ret[0].([]models.ResourceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockStoreMockRecorder) GetStatusHistory(ctx, cloudAccountID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockStore)(nil).GetStatusHistory), ctx, cloudAccountID, until)
+}
+
+// GetSyncRuns mocks base method.
+func (m *MockStore) GetSyncRuns(ctx *gofr.Context, cloudAccountID int64, limit int) ([]models.SyncRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncRuns", ctx, cloudAccountID, limit)
+	ret0, _ := ret[0].([]models.SyncRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncRuns indicates an expected call of GetSyncRuns.
+func (mr *MockStoreMockRecorder) GetSyncRuns(ctx, cloudAccountID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncRuns", reflect.TypeOf((*MockStore)(nil).GetSyncRuns), ctx, cloudAccountID, limit)
+}
+
+// InsertEvent mocks base method.
+func (m *MockStore) InsertEvent(ctx *gofr.Context, event *models.ResourceEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEvent indicates an expected call of InsertEvent.
+func (mr *MockStoreMockRecorder) InsertEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEvent", reflect.TypeOf((*MockStore)(nil).InsertEvent), ctx, event)
+}
+
 // InsertResource mocks base method.
 func (m *MockStore) InsertResource(ctx *gofr.Context, resources *models.Resource) error {
 	m.ctrl.T.Helper()
@@ -264,6 +559,35 @@ func (mr *MockStoreMockRecorder) InsertResource(ctx, resources any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertResource", reflect.TypeOf((*MockStore)(nil).InsertResource), ctx, resources)
 }
 
+// InsertSyncRun mocks base method.
+func (m *MockStore) InsertSyncRun(ctx *gofr.Context, run *models.SyncRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSyncRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSyncRun indicates an expected call of InsertSyncRun.
+func (mr *MockStoreMockRecorder) InsertSyncRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSyncRun", reflect.TypeOf((*MockStore)(nil).InsertSyncRun), ctx, run)
+}
+
+// ListResources mocks base method.
+func (m *MockStore) ListResources(ctx *gofr.Context, cloudAccountIDs []int64, filter *models.ResourceFilter, page *models.ResourcePage) (*models.ResourceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResources", ctx, cloudAccountIDs, filter, page)
+	ret0, _ := ret[0].(*models.ResourceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResources indicates an expected call of ListResources.
+func (mr *MockStoreMockRecorder) ListResources(ctx, cloudAccountIDs, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResources", reflect.TypeOf((*MockStore)(nil).ListResources), ctx, cloudAccountIDs, filter, page)
+}
+
 // RemoveResource mocks base method.
 func (m *MockStore) RemoveResource(ctx *gofr.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -278,6 +602,62 @@ func (mr *MockStoreMockRecorder) RemoveResource(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveResource", reflect.TypeOf((*MockStore)(nil).RemoveResource), ctx, id)
 }
 
+// RestoreResource mocks base method.
+func (m *MockStore) RestoreResource(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreResource", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreResource indicates an expected call of RestoreResource.
+func (mr *MockStoreMockRecorder) RestoreResource(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreResource", reflect.TypeOf((*MockStore)(nil).RestoreResource), ctx, id)
+}
+
+// UpdateLabels mocks base method.
+func (m *MockStore) UpdateLabels(ctx *gofr.Context, labels models.Labels, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabels", ctx, labels, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabels indicates an expected call of UpdateLabels.
+func (mr *MockStoreMockRecorder) UpdateLabels(ctx, labels, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabels", reflect.TypeOf((*MockStore)(nil).UpdateLabels), ctx, labels, id)
+}
+
+// UpdateProject mocks base method.
+func (m *MockStore) UpdateProject(ctx *gofr.Context, project string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, project, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockStoreMockRecorder) UpdateProject(ctx, project, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockStore)(nil).UpdateProject), ctx, project, id)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockStore) UpdateSettings(ctx *gofr.Context, settings models.Settings, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, settings, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockStoreMockRecorder) UpdateSettings(ctx, settings, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockStore)(nil).UpdateSettings), ctx, settings, id)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockStore) UpdateStatus(ctx *gofr.Context, status string, id int64) error {
 	m.ctrl.T.Helper()
@@ -291,3 +671,70 @@ func (mr *MockStoreMockRecorder) UpdateStatus(ctx, status, id any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockStore)(nil).UpdateStatus), ctx, status, id)
 }
+
+// UpdateSyncRun mocks base method.
+func (m *MockStore) UpdateSyncRun(ctx *gofr.Context, run *models.SyncRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncRun indicates an expected call of UpdateSyncRun.
+func (mr *MockStoreMockRecorder) UpdateSyncRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncRun", reflect.TypeOf((*MockStore)(nil).UpdateSyncRun), ctx, run)
+}
+
+// MockPriceCatalog is a mock of PriceCatalog interface.
+type MockPriceCatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceCatalogMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceCatalogMockRecorder is the mock recorder for MockPriceCatalog.
+type MockPriceCatalogMockRecorder struct {
+	mock *MockPriceCatalog
+}
+
+// NewMockPriceCatalog creates a new mock instance.
+func NewMockPriceCatalog(ctrl *gomock.Controller) *MockPriceCatalog {
+	mock := &MockPriceCatalog{ctrl: ctrl}
+	mock.recorder = &MockPriceCatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceCatalog) EXPECT() *MockPriceCatalogMockRecorder {
+	return m.recorder
+}
+
+// Currency mocks base method.
+func (m *MockPriceCatalog) Currency() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Currency")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Currency indicates an expected call of Currency.
+func (mr *MockPriceCatalogMockRecorder) Currency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Currency", reflect.TypeOf((*MockPriceCatalog)(nil).Currency))
+}
+
+// Lookup mocks base method.
+func (m *MockPriceCatalog) Lookup(key pricing.Key) (pricing.Price, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", key)
+	ret0, _ := ret[0].(pricing.Price)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockPriceCatalogMockRecorder) Lookup(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockPriceCatalog)(nil).Lookup), key)
+}
@@ -13,6 +13,7 @@ var errMock = errors.New("mock error")
 type mockSQLClient struct {
 	isError   bool
 	instances []models.Resource
+	status    string
 }
 
 func (m *mockSQLClient) GetAllInstances(_ *gofr.Context, _ string) ([]models.Resource, error) {
@@ -38,3 +39,106 @@ func (m *mockSQLClient) StopInstance(_ *gofr.Context, _, _ string) error {
 
 	return nil
 }
+
+func (m *mockSQLClient) GetInstanceStatus(_ *gofr.Context, _, _ string) (string, error) {
+	if m.isError {
+		return "", errMock
+	}
+
+	return m.status, nil
+}
+
+type mockGKEClient struct {
+	isError   bool
+	nodePools []models.Resource
+	previous  models.Settings
+	status    string
+}
+
+func (m *mockGKEClient) GetAllNodePools(_ *gofr.Context, _ string) ([]models.Resource, error) {
+	if m.isError {
+		return nil, errMock
+	}
+
+	return m.nodePools, nil
+}
+
+func (m *mockGKEClient) SuspendNodePool(_ *gofr.Context, _ string) (models.Settings, error) {
+	if m.isError {
+		return nil, errMock
+	}
+
+	return m.previous, nil
+}
+
+func (m *mockGKEClient) StartNodePool(_ *gofr.Context, _ string, previous models.Settings) error {
+	if m.isError {
+		return errMock
+	}
+
+	m.previous = previous
+
+	return nil
+}
+
+func (m *mockGKEClient) GetNodePoolStatus(_ *gofr.Context, _ string) (string, error) {
+	if m.isError {
+		return "", errMock
+	}
+
+	return m.status, nil
+}
+
+type mockServerlessClient struct {
+	isError   bool
+	resources []models.Resource
+	previous  models.Settings
+	status    string
+}
+
+func (m *mockServerlessClient) GetAllServerless(_ *gofr.Context, _ string) ([]models.Resource, error) {
+	if m.isError {
+		return nil, errMock
+	}
+
+	return m.resources, nil
+}
+
+func (m *mockServerlessClient) SuspendService(_ *gofr.Context, _ string) (models.Settings, error) {
+	if m.isError {
+		return nil, errMock
+	}
+
+	return m.previous, nil
+}
+
+func (m *mockServerlessClient) StartService(_ *gofr.Context, _ string, previous models.Settings) error {
+	if m.isError {
+		return errMock
+	}
+
+	m.previous = previous
+
+	return nil
+}
+
+func (m *mockServerlessClient) GetServiceStatus(_ *gofr.Context, _ string) (string, error) {
+	if m.isError {
+		return "", errMock
+	}
+
+	return m.status, nil
+}
+
+type mockStorageClient struct {
+	isError   bool
+	resources []models.Resource
+}
+
+func (m *mockStorageClient) GetAllStorage(_ *gofr.Context, _ string) ([]models.Resource, error) {
+	if m.isError {
+		return nil, errMock
+	}
+
+	return m.resources, nil
+}
@@ -10,16 +10,41 @@ type (
 const (
 	// Cloud Providers that are currently supported in zopdev.
 
-	GCP CloudProvider = "GCP"
-	AWS CloudProvider = "AWS"
+	GCP   CloudProvider = "GCP"
+	AWS   CloudProvider = "AWS"
+	AZURE CloudProvider = "AZURE"
+	OCI   CloudProvider = "OCI"
 
 	// Resource Types that are currently supported in zopdev.
 	// TODO: add more resource types.
 
 	SQL ResourceType = "SQL"
+	RDS ResourceType = "RDS"
 
 	AWSCOMPUTE ResourceType = "EC2"
 
+	GKENODEPOOL ResourceType = "GKE_NODE_POOL"
+
+	ASG          ResourceType = "ASG"
+	EKSNODEGROUP ResourceType = "EKS_NODE_GROUP"
+
+	CLOUDRUNSERVICE  ResourceType = "CLOUD_RUN_SERVICE"
+	CLOUDRUNJOB      ResourceType = "CLOUD_RUN_JOB"
+	APPENGINEVERSION ResourceType = "APP_ENGINE_VERSION"
+	CLOUDFUNCTION    ResourceType = "CLOUD_FUNCTION"
+
+	GCSBUCKET ResourceType = "GCS_BUCKET"
+	GCEDISK   ResourceType = "GCE_DISK"
+	S3BUCKET  ResourceType = "S3_BUCKET"
+	EBSVOLUME ResourceType = "EBS_VOLUME"
+
+	AZUREVM             ResourceType = "AZURE_VM"
+	AZUREFLEXIBLESERVER ResourceType = "AZURE_FLEXIBLE_SERVER"
+
+	OCIINSTANCE     ResourceType = "OCI_INSTANCE"
+	OCIDBSYSTEM     ResourceType = "OCI_DB_SYSTEM"
+	OCIAUTONOMOUSDB ResourceType = "OCI_AUTONOMOUS_DB"
+
 	// Resource State constants.
 
 	START   ResourceState = "START"
@@ -27,11 +52,20 @@ const (
 
 	RUNNING = "RUNNING"
 	STOPPED = "STOPPED"
+
+	// previousStateKey is the settings key holding the state of a resource before it was suspended, e.g. the size
+	// of a node pool, so that it can be restored on start. It is owned by zopdev and kept across syncs.
+	previousStateKey = "previous_state"
 )
 
-type CloudDetails struct {
-	CloudType CloudProvider `json:"cloudType,omitempty"`
-	Creds     any           `json:"creds"`
+// IsReadOnly returns whether the resources of the type are inventoried only, i.e. their state can not be changed.
+func (t ResourceType) IsReadOnly() bool {
+	switch t {
+	case CLOUDRUNJOB, APPENGINEVERSION, CLOUDFUNCTION, GCSBUCKET, GCEDISK, S3BUCKET, EBSVOLUME:
+		return true
+	default:
+		return false
+	}
 }
 
 type ResourceDetails struct {
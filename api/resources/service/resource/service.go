@@ -1,31 +1,130 @@
 package resource
 
 import (
+	"database/sql"
+	"errors"
+	"maps"
+	"strconv"
+	"strings"
+
 	"gofr.dev/pkg/gofr"
 	gofrHttp "gofr.dev/pkg/gofr/http"
 
-	"github.com/zopdev/zopdev/api/resources/client"
+	"github.com/zopdev/zopdev/api/auth"
 	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/pricing"
 )
 
 type Service struct {
-	gcp   GCPClient
-	aws   AWSClient
-	http  HTTPClient
-	store Store
+	gcp     GCPClient
+	aws     AWSClient
+	azure   AzureClient
+	oci     OCIClient
+	http    HTTPClient
+	store   Store
+	drivers *registry
+	prices  PriceCatalog
 }
 
-func New(gcp GCPClient, aws AWSClient, http HTTPClient, store Store) *Service {
-	return &Service{gcp: gcp, aws: aws, http: http, store: store}
+func New(gcp GCPClient, aws AWSClient, azure AzureClient, oci OCIClient, http HTTPClient, store Store) *Service {
+	s := &Service{gcp: gcp, aws: aws, azure: azure, oci: oci, http: http, store: store,
+		drivers: newRegistry(), prices: pricing.Default()}
+
+	s.registerDrivers()
+
+	return s
 }
 
-func (s *Service) GetAll(ctx *gofr.Context, id int64, resourceType []string) ([]models.Resource, error) {
-	res, err := s.store.GetResources(ctx, id, resourceType)
+// GetAll returns the resources of a cloud account that match the given filter, a nil filter returns all the resources.
+func (s *Service) GetAll(ctx *gofr.Context, id int64, filter *models.ResourceFilter) ([]models.Resource, error) {
+	if filter == nil {
+		filter = &models.ResourceFilter{}
+	}
+
+	res, err := s.store.GetResources(ctx, id, filter.ResourceTypes)
 	if err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	// Labels are stored as JSON, so the filter is applied here rather than in the query.
+	filtered := make([]models.Resource, 0, len(res))
+
+	for i := range res {
+		if filter.Matches(&res[i]) {
+			filtered = append(filtered, res[i])
+		}
+	}
+
+	return filtered, nil
+}
+
+// List returns a page of the sorted resources of a cloud account that match the given filter, along with the number
+// of matching resources per type, status and region.
+func (s *Service) List(ctx *gofr.Context, id int64, filter *models.ResourceFilter,
+	page *models.ResourcePage) (*models.ResourceList, error) {
+	if filter == nil {
+		filter = &models.ResourceFilter{}
+	}
+
+	if page == nil {
+		page = &models.ResourcePage{}
+	}
+
+	// The resources are filtered, sorted and paginated by the store, only the page is loaded.
+	list, err := s.store.ListResources(ctx, []int64{id}, filter, page)
+	if errors.Is(err, models.ErrInvalidCursor) {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"cursor"}}
+	}
+
+	return list, err
+}
+
+// Search returns a page of the sorted resources of all the cloud accounts that match the given filter. The resources
+// are annotated with the name of their cloud account.
+func (s *Service) Search(ctx *gofr.Context, filter *models.ResourceFilter, page *models.ResourcePage) (*models.ResourceList, error) {
+	if filter == nil {
+		filter = &models.ResourceFilter{}
+	}
+
+	if page == nil {
+		page = &models.ResourcePage{}
+	}
+
+	accounts, err := s.http.GetAllCloudAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ids   = make([]int64, 0, len(accounts))
+		names = make(map[int64]string, len(accounts))
+	)
+
+	for i := range accounts {
+		// Only the resources of the cloud accounts of the organization of the request that its principal may view are
+		// searched, the cloud accounts of every organization are listed by the internal route. The resources of the
+		// cloud accounts that no longer exist are not searched either.
+		if auth.InOrganization(ctx, accounts[i].OrganizationID) &&
+			auth.Can(ctx, auth.RoleViewer, auth.CloudAccount(accounts[i].ID)) {
+			ids = append(ids, accounts[i].ID)
+			names[accounts[i].ID] = accounts[i].Name
+		}
+	}
+
+	list, err := s.store.ListResources(ctx, ids, filter, page)
+	if errors.Is(err, models.ErrInvalidCursor) {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"cursor"}}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list.Resources {
+		list.Resources[i].CloudAccount.Name = names[list.Resources[i].CloudAccount.ID]
+	}
+
+	return list, nil
 }
 
 func (s *Service) GetByID(ctx *gofr.Context, id int64) (*models.Resource, error) {
@@ -41,15 +140,32 @@ func (s *Service) GetByID(ctx *gofr.Context, id int64) (*models.Resource, error)
 	return res, nil
 }
 
-// TODO : This function should be generic such that we dont need to call anything specific to a particular cloud provider type.
-// and the error returned in case when the resource is starting or stopping or other operation, error should be returned carefully.
-// or state should be managed.
+// ChangeState starts or suspends a resource through the driver of its type. The resource must belong to the cloud
+// account of the request, the driver is picked by the stored type of the resource rather than the requested one.
+// TODO: the error returned when the resource is already starting or stopping should be handled carefully,
+// or the transient states should be managed.
 func (s *Service) ChangeState(ctx *gofr.Context, resDetails ResourceDetails) error {
+	notFound := gofrHttp.ErrorEntityNotFound{Name: "resource", Value: strconv.FormatInt(resDetails.ID, 10)}
+
 	res, err := s.store.GetResourceByID(ctx, resDetails.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+
 	if err != nil {
 		return err
 	}
 
+	// The role of the request is checked on its cloud account only, the resources of the other accounts are not found.
+	if res.DeletedAt != nil || res.CloudAccount.ID != resDetails.CloudAccID {
+		return notFound
+	}
+
+	resType := ResourceType(res.Type)
+	if resType.IsReadOnly() {
+		return &errReadOnlyResource{resourceType: resType}
+	}
+
 	if res.Status == getStatus(resDetails.State) {
 		return nil
 	}
@@ -59,59 +175,95 @@ func (s *Service) ChangeState(ctx *gofr.Context, resDetails ResourceDetails) err
 		return err
 	}
 
-	switch resDetails.Type {
-	case SQL, "RDS":
-		return s.handleSQLChangeState(ctx, ca, resDetails)
-	case AWSCOMPUTE:
-		return s.handleAWSComputeChangeState(ctx, ca, resDetails, res)
-	default:
+	driver, ok := s.drivers.get(CloudProvider(strings.ToUpper(ca.Provider)), resType)
+	if !ok {
 		return gofrHttp.ErrorInvalidParam{Params: []string{"req.Type"}}
 	}
+
+	return s.changeState(ctx, driver, ca.Credentials, resDetails.State, res)
 }
 
-func (s *Service) handleSQLChangeState(ctx *gofr.Context, ca *client.CloudAccount, resDetails ResourceDetails) error {
-	err := s.changeSQLState(ctx, ca, resDetails)
-	if err != nil {
-		ctx.Errorf("failed to change SQL state: %v", err)
-		return err
+// changeState starts or stops a resource through its driver. The state returned by the driver on stop, e.g. the
+// size of a node pool, is recorded in the settings of the resource and passed back to the driver on start.
+func (s *Service) changeState(ctx *gofr.Context, driver ResourceDriver, creds any, state ResourceState, res *models.Resource) error {
+	settings := maps.Clone(res.Settings)
+	if settings == nil {
+		settings = models.Settings{}
 	}
 
-	err = s.store.UpdateStatus(ctx, getStatus(resDetails.State), resDetails.ID)
-	if err != nil {
-		ctx.Errorf("failed to update resource status: %v", err)
+	var changed bool
+
+	switch state {
+	case START:
+		err := driver.Start(ctx, creds, res)
+		if err != nil {
+			ctx.Errorf("failed to start %s %s: %v", res.Type, res.Name, err)
+			return err
+		}
+
+		_, changed = settings[previousStateKey]
+		delete(settings, previousStateKey)
+	case SUSPEND:
+		prev, err := driver.Stop(ctx, creds, res)
+		if err != nil {
+			ctx.Errorf("failed to suspend %s %s: %v", res.Type, res.Name, err)
+			return err
+		}
+
+		if prev != nil {
+			settings[previousStateKey] = prev
+			changed = true
+		}
+	default:
+		return gofrHttp.ErrorInvalidParam{Params: []string{"req.State"}}
+	}
+
+	if changed {
+		err := s.store.UpdateSettings(ctx, settings, res.ID)
+		if err != nil {
+			ctx.Errorf("failed to update settings of %s %s: %v", res.Type, res.Name, err)
+		}
 	}
 
+	s.updateStatus(ctx, res, refreshStatus(ctx, driver, creds, state, res))
+
 	return nil
 }
 
-func (s *Service) handleAWSComputeChangeState(ctx *gofr.Context, ca *client.CloudAccount, resDetails ResourceDetails,
-	res *models.Resource) error {
-	cl, err := s.aws.NewEC2Client(ctx, ca.Credentials)
+// refreshStatus returns the status of a resource on the cloud after its state was changed. The status expected after
+// the change is returned when it can not be read, the next sync corrects it if needed.
+func refreshStatus(ctx *gofr.Context, driver ResourceDriver, creds any, state ResourceState, res *models.Resource) string {
+	status, err := driver.Status(ctx, creds, res)
 	if err != nil {
-		ctx.Errorf("failed to create AWS EC2 client: %v", err)
-		return err
+		ctx.Warnf("failed to refresh the status of %s %s: %v", res.Type, res.Name, err)
+
+		return getStatus(state)
 	}
 
-	if resDetails.State == START {
-		err = cl.StartInstance(ctx, res.UID)
-		if err != nil {
-			ctx.Errorf("failed to start EC2 instance: %v", err)
-			return err
-		}
-	} else {
-		err = cl.StopInstance(ctx, res.UID)
-		if err != nil {
-			ctx.Errorf("failed to start EC2 instance: %v", err)
-			return err
-		}
+	return status
+}
+
+// getPreviousState returns the state of a resource recorded when it was suspended, if any.
+func getPreviousState(settings models.Settings) models.Settings {
+	switch prev := settings[previousStateKey].(type) {
+	case models.Settings:
+		return prev
+	case map[string]any:
+		return prev
+	default:
+		return nil
 	}
+}
 
-	err = s.store.UpdateStatus(ctx, getStatus(resDetails.State), resDetails.ID)
+// updateStatus stores the status of a resource changed through zopdev and records it in the inventory history.
+func (s *Service) updateStatus(ctx *gofr.Context, res *models.Resource, status string) {
+	err := s.store.UpdateStatus(ctx, status, res.ID)
 	if err != nil {
 		ctx.Errorf("failed to update resource status: %v", err)
+		return
 	}
 
-	return nil
+	s.recordEvent(ctx, res, models.EventStatusChanged, models.EventSourceAPI, res.Status, status)
 }
 
 func getStatus(action ResourceState) string {
@@ -125,24 +277,57 @@ func getStatus(action ResourceState) string {
 	}
 }
 
-func (s *Service) SyncResources(ctx *gofr.Context, id int64) ([]models.Resource, error) {
-	ca, err := s.http.GetCloudCredentials(ctx, id)
+// SyncResources syncs the resources of a cloud account within the given scope with the cloud provider, a nil scope
+// syncs all the resources. Only one sync of a cloud account runs at a time, the concurrent ones are rejected.
+func (s *Service) SyncResources(ctx *gofr.Context, id int64, scope *models.SyncScope) ([]models.Resource, error) {
+	if scope == nil {
+		scope = &models.SyncScope{}
+	}
+
+	run, err := s.startSyncRun(ctx, id, scope)
 	if err != nil {
 		return nil, err
 	}
 
-	ins, err := s.getAllInstances(ctx, ca)
+	err = s.syncResources(ctx, id, scope, run)
+
+	s.finishSyncRun(ctx, run, err)
+
 	if err != nil {
-		ctx.Errorf("failed to get all instances: %v", err)
 		return nil, err
 	}
 
-	res, err := s.store.GetResources(ctx, id, nil)
+	res, err := s.GetAll(ctx, id, &models.ResourceFilter{ResourceTypes: scope.ResourceTypes})
 	if err != nil {
-		ctx.Errorf("failed to get existing resources: %v", err)
 		return nil, err
 	}
 
+	return filterByScope(res, scope), nil
+}
+
+func (s *Service) syncResources(ctx *gofr.Context, id int64, scope *models.SyncScope, run *models.SyncRun) error {
+	ca, err := s.http.GetCloudCredentials(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ins, err := s.getAllInstances(ctx, ca, scope)
+	if err != nil {
+		ctx.Errorf("failed to get all instances: %v", err)
+		return err
+	}
+
+	run.Discovered = len(ins)
+
+	// The deleted resources are fetched as well so that they are restored if they reappear on the cloud.
+	res, err := s.store.GetResourcesIncludingDeleted(ctx, id)
+	if err != nil {
+		ctx.Errorf("failed to get existing resources: %v", err)
+		return err
+	}
+
+	// Only the resources within the scope are compared, the others were not listed and must not be removed.
+	res = filterByScope(res, scope)
 	visited := make([]bool, len(res))
 
 	for i := range ins {
@@ -154,27 +339,35 @@ func (s *Service) SyncResources(ctx *gofr.Context, id int64) ([]models.Resource,
 			err = s.store.InsertResource(ctx, &ins[i])
 			if err != nil {
 				ctx.Errorf("failed to insert resource: %v", err)
+				addSyncError(run, "failed to insert resource %s: %v", ins[i].UID, err)
+
+				continue
 			}
+
+			run.Created++
+
+			s.recordEvent(ctx, &ins[i], models.EventCreated, models.EventSourceSync, nil, ins[i].Status)
 		} else {
 			// else update the existing resource and mark the resource as visited.
 			visited[idx] = true
 			ins[i].ID = res[idx].ID
-			err = s.store.UpdateStatus(ctx, ins[i].Status, ins[i].ID)
 
-			if err != nil {
-				ctx.Errorf("failed to update resource: %v", err)
+			if res[idx].DeletedAt != nil {
+				s.restoreResource(ctx, run, &res[idx])
 			}
+
+			s.syncResource(ctx, run, &res[idx], &ins[i])
 		}
 	}
 
-	s.removeStale(ctx, visited, res)
+	s.removeStale(ctx, run, visited, res)
 
-	return s.GetAll(ctx, id, nil)
+	return nil
 }
 
-func (s *Service) removeStale(ctx *gofr.Context, visited []bool, res []models.Resource) {
+func (s *Service) removeStale(ctx *gofr.Context, run *models.SyncRun, visited []bool, res []models.Resource) {
 	for i, v := range visited {
-		if v {
+		if v || res[i].DeletedAt != nil {
 			continue
 		}
 
@@ -182,26 +375,30 @@ func (s *Service) removeStale(ctx *gofr.Context, visited []bool, res []models.Re
 		err := s.store.RemoveResource(ctx, res[i].ID)
 		if err != nil {
 			ctx.Errorf("failed to remove resource: %v", err)
+			addSyncError(run, "failed to remove resource %s: %v", res[i].UID, err)
+
+			continue
 		}
+
+		run.Deleted++
+
+		s.recordEvent(ctx, &res[i], models.EventDeleted, models.EventSourceSync, res[i].Status, nil)
 	}
 }
 
-func (s *Service) getALLComputeInstances(ctx *gofr.Context, details CloudDetails) ([]models.Resource, error) {
-	switch details.CloudType {
-	case AWS:
-		ec2Client, err := s.aws.NewEC2Client(ctx, details.Creds)
-		if err != nil {
-			return nil, err
-		}
+// restoreResource clears the deleted mark of a resource that reappeared on the cloud.
+func (s *Service) restoreResource(ctx *gofr.Context, run *models.SyncRun, res *models.Resource) {
+	err := s.store.RestoreResource(ctx, res.ID)
+	if err != nil {
+		ctx.Errorf("failed to restore resource: %v", err)
+		addSyncError(run, "failed to restore resource %s: %v", res.UID, err)
 
-		return ec2Client.GetAllInstances(ctx)
-	case GCP:
-		return nil, nil
-	default:
-		// We are not returning any error because the sync process is completely internal, works on the cloud Account ID,
-		// if we are getting an unknown cloud type, then this feature is not implemented and we simply return nil.
-		return nil, nil
+		return
 	}
+
+	run.Restored++
+
+	s.recordEvent(ctx, res, models.EventRestored, models.EventSourceSync, nil, nil)
 }
 
 // bSearch performs a binary search on the sorted slice of models.Resource.
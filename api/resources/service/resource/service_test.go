@@ -2,9 +2,21 @@ package resource
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/oracle/oci-go-sdk/v65/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
@@ -12,8 +24,14 @@ import (
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	"github.com/zopdev/zopdev/api/auth"
 	"github.com/zopdev/zopdev/api/resources/client"
 	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/providers/aws/scaling"
+	"github.com/zopdev/zopdev/api/resources/providers/azure/arm"
+	azureDatabase "github.com/zopdev/zopdev/api/resources/providers/azure/database"
+	azureVM "github.com/zopdev/zopdev/api/resources/providers/azure/vm"
+	ociCompute "github.com/zopdev/zopdev/api/resources/providers/oci/compute"
 )
 
 func TestService_SyncResources(t *testing.T) {
@@ -32,17 +50,11 @@ func TestService_SyncResources(t *testing.T) {
 		Credentials: map[string]any{"project_id": "test-project", "region": "us-central1"}}
 	mockCreds := &google.Credentials{ProjectID: "test-project"}
 
-	s := New(mGCP, mAWS, mClient, mStore)
+	s := New(mGCP, mAWS, nil, nil, mClient, mStore)
 
-	req := CloudDetails{
-		CloudType: GCP,
-		Creds: map[string]any{
-			"project_id": "test-project",
-			"region":     "us-central1",
-		},
-	}
 	mockInst := []models.Resource{
-		{Name: "sql-instance-1", UID: "zopdev/sql-instance-1", Type: "SQL", Status: "RUNNING"},
+		{Name: "sql-instance-1", UID: "zopdev/sql-instance-1", Type: "SQL", Status: "RUNNING",
+			Labels: models.Labels{"env": "prod"}},
 		{Name: "sql-instance-2", UID: "zopdev/sql-instance-2", Type: "SQL", Status: "SUSPENDED"},
 	}
 	mStrResp := []models.Resource{
@@ -57,6 +69,7 @@ func TestService_SyncResources(t *testing.T) {
 		isError:   false,
 		instances: mockInst,
 	}
+	deletedAt := time.Now()
 
 	testCases := []struct {
 		name      string
@@ -74,21 +87,63 @@ func TestService_SyncResources(t *testing.T) {
 			expResp:   mStrResp,
 			mockCalls: func() {
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
-				mGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(mockCreds, nil)
-				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
+				// The drivers are called with the context of the sync, the projects are resolved once for all of them.
+				mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), ca.Credentials, "https://www.googleapis.com/auth/cloud-platform").
+					Return(mockCreds, nil).Times(4)
+				mGCP.EXPECT().GetProjects(gomock.Any(), ca.Credentials).Return([]string{"test-project"}, nil)
+				mGCP.EXPECT().NewSQLClient(gomock.Any(), option.WithCredentials(mockCreds)).
 					Return(mockLister, nil)
+				mGCP.EXPECT().NewGKEClient(gomock.Any(), option.WithCredentials(mockCreds)).
+					Return(&mockGKEClient{}, nil)
+				mGCP.EXPECT().NewServerlessClient(gomock.Any(), option.WithCredentials(mockCreds)).
+					Return(&mockServerlessClient{}, nil)
+				mGCP.EXPECT().NewStorageClient(gomock.Any(), option.WithCredentials(mockCreds)).
+					Return(&mockStorageClient{}, nil)
+				mStore.EXPECT().InsertSyncRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+						run.ID = 1
+						return nil
+					})
+				mStore.EXPECT().UpdateSyncRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+						assert.Equal(t, models.SyncSucceeded, run.Status)
+						assert.Equal(t, 2, run.Discovered)
+						assert.Equal(t, 1, run.Created)
+						assert.Equal(t, 1, run.Updated)
+						assert.Equal(t, 1, run.Deleted)
+						assert.Equal(t, 1, run.Restored)
+
+						return nil
+					})
 				gomock.InOrder(
-					mStore.EXPECT().GetResources(gomock.Any(), int64(123), nil).
+					mStore.EXPECT().GetResourcesIncludingDeleted(gomock.Any(), int64(123)).
 						Return([]models.Resource{
 							{ID: 1, CloudAccount: models.CloudAccount{ID: 123, Type: string(GCP)},
-								Name: "sql-instance-1", Type: string(SQL), UID: "zopdev/sql-instance-1"},
+								Name: "sql-instance-1", Type: string(SQL), UID: "zopdev/sql-instance-1", DeletedAt: &deletedAt},
 							{ID: 2, CloudAccount: models.CloudAccount{ID: 123, Type: string(GCP)},
 								Name: "sql-instance-3", Type: string(SQL), UID: "zopdev/sql-instance-3"},
+							{ID: 4, CloudAccount: models.CloudAccount{ID: 123, Type: string(GCP)},
+								Name: "sql-instance-4", Type: string(SQL), UID: "zopdev/sql-instance-4", DeletedAt: &deletedAt},
 						}, nil),
+					mStore.EXPECT().RestoreResource(gomock.Any(), int64(1)).Return(nil),
+					mStore.EXPECT().InsertEvent(gomock.Any(), &models.ResourceEvent{CloudAccountID: 123, ResourceID: 1,
+						ResourceUID: "zopdev/sql-instance-1", ResourceName: "sql-instance-1", ResourceType: string(SQL),
+						EventType: models.EventRestored, Source: models.EventSourceSync}).Return(nil),
 					mStore.EXPECT().UpdateStatus(gomock.Any(), RUNNING, int64(1)).Return(nil),
+					mStore.EXPECT().InsertEvent(gomock.Any(), &models.ResourceEvent{CloudAccountID: 123, ResourceID: 1,
+						ResourceUID: "zopdev/sql-instance-1", ResourceName: "sql-instance-1", ResourceType: string(SQL),
+						EventType: models.EventStatusChanged, Source: models.EventSourceSync,
+						OldValue: json.RawMessage(`""`), NewValue: json.RawMessage(`"RUNNING"`)}).Return(nil),
+					mStore.EXPECT().UpdateLabels(gomock.Any(), models.Labels{"env": "prod"}, int64(1)).Return(nil),
+					mStore.EXPECT().InsertEvent(gomock.Any(), gomock.Any()).Return(nil),
+					mStore.EXPECT().UpdateProject(gomock.Any(), "test-project", int64(1)).Return(nil),
 					mStore.EXPECT().InsertResource(gomock.Any(), gomock.Any()).Return(nil),
-					mStore.EXPECT().RemoveResource(gomock.Any(), gomock.Any()).Return(nil),
+					mStore.EXPECT().InsertEvent(gomock.Any(), gomock.Any()).Return(nil),
+					mStore.EXPECT().RemoveResource(gomock.Any(), int64(2)).Return(nil),
+					mStore.EXPECT().InsertEvent(gomock.Any(), &models.ResourceEvent{CloudAccountID: 123, ResourceID: 2,
+						ResourceUID: "zopdev/sql-instance-3", ResourceName: "sql-instance-3", ResourceType: string(SQL),
+						EventType: models.EventDeleted, Source: models.EventSourceSync,
+						OldValue: json.RawMessage(`""`)}).Return(nil),
 					mStore.EXPECT().GetResources(gomock.Any(), int64(123), nil).Return([]models.Resource{
 						{ID: 1, CloudAccount: models.CloudAccount{ID: 123, Type: string(GCP)},
 							Name: "sql-instance-1", Type: string(SQL), UID: "zopdev/sql-instance-1", Status: "RUNNING"},
@@ -105,7 +160,7 @@ func TestService_SyncResources(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCalls()
 
-			res, err := s.SyncResources(ctx, tc.id)
+			res, err := s.SyncResources(ctx, tc.id, nil)
 
 			assert.Equal(t, tc.expErr, err)
 			assert.Equal(t, tc.expResp, res)
@@ -113,6 +168,219 @@ func TestService_SyncResources(t *testing.T) {
 	}
 }
 
+func TestService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	mRes := []models.Resource{
+		{ID: 1, Name: "sql-instance-1", Type: "SQL", Labels: models.Labels{"env": "prod", "team": "core"}},
+		{ID: 2, Name: "sql-instance-2", Type: "SQL", Labels: models.Labels{"env": "dev"}},
+		{ID: 3, Name: "sql-instance-3", Type: "SQL"},
+	}
+
+	testCases := []struct {
+		name      string
+		filter    *models.ResourceFilter
+		selector  string
+		expResp   []models.Resource
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name:    "nil filter returns all resources",
+			expResp: mRes,
+			mockCalls: func() {
+				mStore.EXPECT().GetResources(ctx, int64(1), nil).Return(mRes, nil)
+			},
+		},
+		{
+			name:     "filter by label equality",
+			filter:   &models.ResourceFilter{ResourceTypes: []string{"SQL"}},
+			selector: "env=prod",
+			expResp:  []models.Resource{mRes[0]},
+			mockCalls: func() {
+				mStore.EXPECT().GetResources(ctx, int64(1), []string{"SQL"}).Return(mRes, nil)
+			},
+		},
+		{
+			name:     "filter by missing label",
+			filter:   &models.ResourceFilter{},
+			selector: "!env",
+			expResp:  []models.Resource{mRes[2]},
+			mockCalls: func() {
+				mStore.EXPECT().GetResources(ctx, int64(1), nil).Return(mRes, nil)
+			},
+		},
+		{
+			name:     "filter by name substring and label",
+			filter:   &models.ResourceFilter{Name: "INSTANCE-"},
+			selector: "env",
+			expResp:  []models.Resource{mRes[0], mRes[1]},
+			mockCalls: func() {
+				mStore.EXPECT().GetResources(ctx, int64(1), nil).Return(mRes, nil)
+			},
+		},
+		{
+			name:    "no resource matches",
+			filter:  &models.ResourceFilter{Name: "redis"},
+			expResp: []models.Resource{},
+			mockCalls: func() {
+				mStore.EXPECT().GetResources(ctx, int64(1), nil).Return(mRes, nil)
+			},
+		},
+		{
+			name:   "store error",
+			filter: &models.ResourceFilter{},
+			expErr: errMock,
+			mockCalls: func() {
+				mStore.EXPECT().GetResources(ctx, int64(1), nil).Return(nil, errMock)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			if tc.selector != "" {
+				sel, err := models.ParseLabelSelector(tc.selector)
+				assert.NoError(t, err)
+
+				tc.filter.Labels = sel
+			}
+
+			res, err := s.GetAll(ctx, 1, tc.filter)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expResp, res)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	mRes := []models.Resource{
+		{ID: 1, Name: "web", Type: "EC2", Status: RUNNING, Region: "us-east-1"},
+		{ID: 2, Name: "api", Type: "EC2", Status: STOPPED, Region: "us-east-1"},
+		{ID: 3, Name: "users", Type: "RDS", Status: RUNNING, Region: "eu-west-1"},
+	}
+
+	filter := &models.ResourceFilter{Statuses: []string{"running"}}
+	page := &models.ResourcePage{Limit: 1}
+	expList := &models.ResourceList{Resources: mRes[2:], Total: 2, NextCursor: "next",
+		Facets: models.ResourceFacets{Types: map[string]int{"EC2": 1, "RDS": 1}}}
+
+	mStore.EXPECT().ListResources(ctx, []int64{1}, filter, page).Return(expList, nil)
+
+	list, err := s.List(ctx, 1, filter, page)
+
+	require.NoError(t, err)
+	assert.Equal(t, expList, list)
+
+	mStore.EXPECT().ListResources(ctx, []int64{1}, &models.ResourceFilter{}, &models.ResourcePage{Cursor: "not-a-cursor"}).
+		Return(nil, models.ErrInvalidCursor)
+
+	_, err = s.List(ctx, 1, nil, &models.ResourcePage{Cursor: "not-a-cursor"})
+
+	assert.Equal(t, gofrHttp.ErrorInvalidParam{Params: []string{"cursor"}}, err)
+
+	mStore.EXPECT().ListResources(ctx, []int64{1}, &models.ResourceFilter{}, &models.ResourcePage{}).Return(nil, errMock)
+
+	_, err = s.List(ctx, 1, nil, nil)
+
+	assert.Equal(t, errMock, err)
+}
+
+func TestService_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	mHTTP := NewMockHTTPClient(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, mHTTP, mStore)
+
+	accounts := []client.CloudAccount{{ID: 1, Name: "prod", Provider: "aws"}, {ID: 2, Name: "staging", Provider: "gcp"}}
+	mRes := []models.Resource{
+		{ID: 2, Name: "users", UID: "users", Type: "SQL", CloudAccount: models.CloudAccount{ID: 2, Type: "gcp"},
+			Labels: models.Labels{"app": "web"}},
+		{ID: 1, Name: "web", UID: "i-1", Type: "EC2", CloudAccount: models.CloudAccount{ID: 1, Type: "aws"}},
+	}
+
+	t.Run("full-text match across accounts", func(t *testing.T) {
+		filter := &models.ResourceFilter{Query: "web"}
+
+		mHTTP.EXPECT().GetAllCloudAccounts(ctx).Return(accounts, nil)
+		mStore.EXPECT().ListResources(ctx, []int64{1, 2}, filter, &models.ResourcePage{}).
+			Return(&models.ResourceList{Resources: slices.Clone(mRes), Total: 2}, nil)
+
+		list, err := s.Search(ctx, filter, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Resource{
+			{ID: 2, Name: "users", UID: "users", Type: "SQL", CloudAccount: models.CloudAccount{ID: 2, Type: "gcp", Name: "staging"},
+				Labels: models.Labels{"app": "web"}},
+			{ID: 1, Name: "web", UID: "i-1", Type: "EC2", CloudAccount: models.CloudAccount{ID: 1, Type: "aws", Name: "prod"}},
+		}, list.Resources)
+		assert.Equal(t, 2, list.Total)
+	})
+
+	t.Run("other organization", func(t *testing.T) {
+		orgCtx := &gofr.Context{Context: auth.NewContext(context.Background(), &auth.Principal{Organization: 2,
+			Bindings: []auth.Binding{{Role: auth.RoleViewer, Scope: auth.Global()}}})}
+		orgAccounts := []client.CloudAccount{{ID: 1, OrganizationID: 1, Name: "prod", Provider: "aws"},
+			{ID: 2, OrganizationID: 2, Name: "staging", Provider: "gcp"}}
+
+		mHTTP.EXPECT().GetAllCloudAccounts(orgCtx).Return(orgAccounts, nil)
+		mStore.EXPECT().ListResources(orgCtx, []int64{2}, &models.ResourceFilter{}, &models.ResourcePage{}).
+			Return(&models.ResourceList{Resources: slices.Clone(mRes[:1]), Total: 1}, nil)
+
+		list, err := s.Search(orgCtx, nil, nil)
+
+		require.NoError(t, err)
+		require.Len(t, list.Resources, 1)
+		assert.Equal(t, "staging", list.Resources[0].CloudAccount.Name)
+	})
+
+	t.Run("cloud accounts error", func(t *testing.T) {
+		mHTTP.EXPECT().GetAllCloudAccounts(ctx).Return(nil, errMock)
+
+		_, err := s.Search(ctx, nil, nil)
+
+		assert.Equal(t, errMock, err)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		page := &models.ResourcePage{Cursor: "not-a-cursor"}
+
+		mHTTP.EXPECT().GetAllCloudAccounts(ctx).Return(accounts, nil)
+		mStore.EXPECT().ListResources(ctx, []int64{1, 2}, &models.ResourceFilter{}, page).Return(nil, models.ErrInvalidCursor)
+
+		_, err := s.Search(ctx, nil, page)
+
+		assert.Equal(t, gofrHttp.ErrorInvalidParam{Params: []string{"cursor"}}, err)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		mHTTP.EXPECT().GetAllCloudAccounts(ctx).Return(accounts, nil)
+		mStore.EXPECT().ListResources(ctx, []int64{1, 2}, &models.ResourceFilter{}, &models.ResourcePage{}).Return(nil, errMock)
+
+		_, err := s.Search(ctx, nil, nil)
+
+		assert.Equal(t, errMock, err)
+	})
+}
+
 func TestService_SyncResources_Errors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -123,14 +391,8 @@ func TestService_SyncResources_Errors(t *testing.T) {
 	ctx := &gofr.Context{Context: context.Background(), Container: ct}
 	ca := &client.CloudAccount{ID: 123, Name: "MyCloud", Provider: string(GCP),
 		Credentials: map[string]any{"project_id": "test-project", "region": "us-central1"}}
-	s := New(mGCP, nil, mClient, nil)
-	req := CloudDetails{
-		CloudType: GCP,
-		Creds: map[string]any{
-			"project_id": "test-project",
-			"region":     "us-central1",
-		},
-	}
+	mStore := NewMockStore(ctrl)
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
 
 	testCases := []struct {
 		name      string
@@ -147,8 +409,8 @@ func TestService_SyncResources_Errors(t *testing.T) {
 			expErr:    errMock,
 			mockCalls: func() {
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
-				mGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(nil, errMock)
+				mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), ca.Credentials, "https://www.googleapis.com/auth/cloud-platform").
+					Return(nil, errMock).Times(4)
 			},
 		},
 		{
@@ -166,8 +428,8 @@ func TestService_SyncResources_Errors(t *testing.T) {
 			expErr:    errMock,
 			mockCalls: func() {
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
-				mGCP.EXPECT().NewGoogleCredentials(ctx, req.Creds, "https://www.googleapis.com/auth/cloud-platform").
-					Return(nil, errMock)
+				mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), ca.Credentials, "https://www.googleapis.com/auth/cloud-platform").
+					Return(nil, errMock).Times(4)
 			},
 		},
 	}
@@ -176,7 +438,20 @@ func TestService_SyncResources_Errors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCalls()
 
-			res, err := s.SyncResources(ctx, tc.id)
+			mStore.EXPECT().InsertSyncRun(ctx, gomock.Any()).
+				DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+					run.ID = 1
+					return nil
+				})
+			mStore.EXPECT().UpdateSyncRun(ctx, gomock.Any()).
+				DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+					assert.Equal(t, models.SyncFailed, run.Status)
+					assert.Equal(t, models.StringList{tc.expErr.Error()}, run.Errors)
+
+					return nil
+				})
+
+			res, err := s.SyncResources(ctx, tc.id, nil)
 
 			assert.Equal(t, tc.expErr, err)
 			assert.Equal(t, tc.expResp, res)
@@ -184,6 +459,209 @@ func TestService_SyncResources_Errors(t *testing.T) {
 	}
 }
 
+// TestService_SyncResources_SharedProject syncs two cloud accounts of the same project, as the UIDs of the resources are
+// unique per cloud account each of them holds its own copy of the resources of the project.
+func TestService_SyncResources_SharedProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mGCP := NewMockGCPClient(ctrl)
+	ct, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: ct}
+	creds := map[string]any{"project_id": "shared-project"}
+	mockCreds := &google.Credentials{ProjectID: "shared-project"}
+	lister := &mockSQLClient{instances: []models.Resource{
+		{Name: "sql-instance-1", UID: "shared-project/sql-instance-1", Type: string(SQL), Status: RUNNING},
+	}}
+	scope := &models.SyncScope{ResourceTypes: models.StringList{string(SQL)}}
+	inserted := make(map[int64]models.Resource)
+
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
+
+	for _, id := range []int64{1, 2} {
+		mClient.EXPECT().GetCloudCredentials(ctx, id).
+			Return(&client.CloudAccount{ID: id, Provider: string(GCP), Credentials: creds}, nil)
+		mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), creds, cloudPlatformScope).Return(mockCreds, nil)
+		mGCP.EXPECT().GetProjects(gomock.Any(), creds).Return([]string{"shared-project"}, nil)
+		mGCP.EXPECT().NewSQLClient(gomock.Any(), option.WithCredentials(mockCreds)).Return(lister, nil)
+		mStore.EXPECT().InsertSyncRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+				run.ID = id
+				return nil
+			})
+		mStore.EXPECT().UpdateSyncRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+				assert.Equal(t, models.SyncSucceeded, run.Status)
+				assert.Equal(t, 1, run.Created)
+
+				return nil
+			})
+		// The resources of the other cloud account are not compared, the resource is created for this one as well.
+		mStore.EXPECT().GetResourcesIncludingDeleted(gomock.Any(), id).Return(nil, nil)
+		mStore.EXPECT().InsertResource(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, res *models.Resource) error {
+				inserted[res.CloudAccount.ID] = *res
+
+				return nil
+			})
+		mStore.EXPECT().InsertEvent(gomock.Any(), gomock.Any()).Return(nil)
+		mStore.EXPECT().GetResources(gomock.Any(), id, []string{string(SQL)}).
+			DoAndReturn(func(_ *gofr.Context, id int64, _ []string) ([]models.Resource, error) {
+				return []models.Resource{inserted[id]}, nil
+			})
+
+		res, err := s.SyncResources(ctx, id, scope)
+
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, id, res[0].CloudAccount.ID)
+	}
+
+	assert.Equal(t, inserted[1].UID, inserted[2].UID)
+}
+
+// projectSQLClient lists a Cloud SQL instance per project.
+type projectSQLClient struct {
+	mockSQLClient
+}
+
+func (*projectSQLClient) GetAllInstances(_ *gofr.Context, projectID string) ([]models.Resource, error) {
+	return []models.Resource{{Name: "sql-1", UID: projectID + "/sql-1", Type: string(SQL), Status: RUNNING}}, nil
+}
+
+// TestService_SyncResources_OverlappingAccounts syncs a folder-wide cloud account and a single-project one covering
+// one of the projects of the folder, each of them holds its own copy of the resources of the shared project.
+func TestService_SyncResources_OverlappingAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mGCP := NewMockGCPClient(ctrl)
+	ct, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: ct}
+	mockCreds := &google.Credentials{ProjectID: "prod"}
+	scope := &models.SyncScope{ResourceTypes: models.StringList{string(SQL)}}
+	accounts := []struct {
+		id       int64
+		creds    map[string]any
+		projects []string
+		expUIDs  []string
+	}{
+		{id: 1, creds: map[string]any{"project_id": "prod", "folder_id": "1"}, projects: []string{"prod", "staging"},
+			expUIDs: []string{"prod/sql-1", "staging/sql-1"}},
+		{id: 2, creds: map[string]any{"project_id": "prod"}, projects: []string{"prod"},
+			expUIDs: []string{"prod/sql-1"}},
+	}
+	inserted := make(map[int64][]string)
+
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
+
+	for _, acc := range accounts {
+		mClient.EXPECT().GetCloudCredentials(ctx, acc.id).
+			Return(&client.CloudAccount{ID: acc.id, Provider: string(GCP), Credentials: acc.creds}, nil)
+		mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), acc.creds, cloudPlatformScope).Return(mockCreds, nil)
+		mGCP.EXPECT().GetProjects(gomock.Any(), acc.creds).Return(acc.projects, nil)
+		mGCP.EXPECT().NewSQLClient(gomock.Any(), option.WithCredentials(mockCreds)).Return(&projectSQLClient{}, nil)
+		mStore.EXPECT().InsertSyncRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+				run.ID = acc.id
+				return nil
+			})
+		mStore.EXPECT().UpdateSyncRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+				assert.Equal(t, models.SyncSucceeded, run.Status)
+				assert.Equal(t, len(acc.expUIDs), run.Created)
+
+				return nil
+			})
+		mStore.EXPECT().GetResourcesIncludingDeleted(gomock.Any(), acc.id).Return(nil, nil)
+		mStore.EXPECT().InsertResource(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, res *models.Resource) error {
+				inserted[res.CloudAccount.ID] = append(inserted[res.CloudAccount.ID], res.UID)
+
+				return nil
+			}).Times(len(acc.expUIDs))
+		mStore.EXPECT().InsertEvent(gomock.Any(), gomock.Any()).Return(nil).Times(len(acc.expUIDs))
+		mStore.EXPECT().GetResources(gomock.Any(), acc.id, []string{string(SQL)}).Return(nil, nil)
+
+		_, err := s.SyncResources(ctx, acc.id, scope)
+
+		require.NoError(t, err)
+		assert.Equal(t, acc.expUIDs, inserted[acc.id])
+	}
+}
+
+// TestService_SyncResources_ReaddedAccount syncs a cloud account added again after its deletion, the resources of the
+// deleted account are kept with their UIDs while the ones of the new account are created along them.
+func TestService_SyncResources_ReaddedAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mGCP := NewMockGCPClient(ctrl)
+	ct, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: ct}
+	creds := map[string]any{"project_id": "prod"}
+	mockCreds := &google.Credentials{ProjectID: "prod"}
+	scope := &models.SyncScope{ResourceTypes: models.StringList{string(SQL)}}
+	deletedAt := time.Now()
+
+	type key struct {
+		cloudAccountID int64
+		uid            string
+	}
+
+	// The deleted cloud account 1 keeps its resources, the UIDs are unique per cloud account.
+	stored := map[key]models.Resource{{1, "prod/sql-1"}: {ID: 1, UID: "prod/sql-1", DeletedAt: &deletedAt}}
+
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
+
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(2)).
+		Return(&client.CloudAccount{ID: 2, Provider: string(GCP), Credentials: creds}, nil)
+	mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), creds, cloudPlatformScope).Return(mockCreds, nil)
+	mGCP.EXPECT().GetProjects(gomock.Any(), creds).Return([]string{"prod"}, nil)
+	mGCP.EXPECT().NewSQLClient(gomock.Any(), option.WithCredentials(mockCreds)).Return(&projectSQLClient{}, nil)
+	mStore.EXPECT().InsertSyncRun(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+			run.ID = 1
+			return nil
+		})
+	mStore.EXPECT().UpdateSyncRun(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *gofr.Context, run *models.SyncRun) error {
+			assert.Equal(t, models.SyncSucceeded, run.Status)
+			assert.Equal(t, 1, run.Created)
+			assert.Zero(t, run.Restored)
+
+			return nil
+		})
+	mStore.EXPECT().GetResourcesIncludingDeleted(gomock.Any(), int64(2)).Return(nil, nil)
+	mStore.EXPECT().InsertResource(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *gofr.Context, res *models.Resource) error {
+			k := key{res.CloudAccount.ID, res.UID}
+			if _, ok := stored[k]; ok {
+				return errMock
+			}
+
+			res.ID = int64(len(stored) + 1)
+			stored[k] = *res
+
+			return nil
+		})
+	mStore.EXPECT().InsertEvent(gomock.Any(), gomock.Any()).Return(nil)
+	mStore.EXPECT().GetResources(gomock.Any(), int64(2), []string{string(SQL)}).Return(nil, nil)
+
+	_, err := s.SyncResources(ctx, 2, scope)
+
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+	assert.Equal(t, &deletedAt, stored[key{1, "prod/sql-1"}].DeletedAt)
+	assert.Nil(t, stored[key{2, "prod/sql-1"}].DeletedAt)
+}
+
 func TestService_ChangeState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -192,12 +670,12 @@ func TestService_ChangeState(t *testing.T) {
 	mStore := NewMockStore(ctrl)
 	mGCP := NewMockGCPClient(ctrl)
 	mAWS := NewMockAWSClient(ctrl)
-	ctx := &gofr.Context{Context: context.Background()}
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
 	ca := &client.CloudAccount{ID: 123, Name: "MyCloud", Provider: string(GCP),
 		Credentials: map[string]any{"project_id": "test-project", "region": "us-central1"}}
 	mockCreds := &google.Credentials{ProjectID: "test-project"}
-	mockStopper := &mockSQLClient{}
-	s := New(mGCP, mAWS, mClient, mStore)
+	s := New(mGCP, mAWS, nil, nil, mClient, mStore)
 
 	testCases := []struct {
 		name      string
@@ -210,15 +688,19 @@ func TestService_ChangeState(t *testing.T) {
 			input: ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL, State: START},
 			mockCalls: func() {
 				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
-					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 1}, Status: STOPPED}, nil)
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Type: string(SQL), Status: STOPPED}, nil)
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).
 					Return(ca, nil)
 				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
-					Return(mockCreds, nil)
+					Return(mockCreds, nil).Times(2)
 				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
-					Return(mockStopper, nil)
+					Return(&mockSQLClient{status: RUNNING}, nil).Times(2)
 				mStore.EXPECT().UpdateStatus(ctx, RUNNING, int64(1)).
 					Return(nil)
+				mStore.EXPECT().InsertEvent(ctx, &models.ResourceEvent{CloudAccountID: 123, ResourceID: 1, ResourceType: string(SQL),
+					EventType: models.EventStatusChanged, Source: models.EventSourceAPI,
+					OldValue: json.RawMessage(`"STOPPED"`), NewValue: json.RawMessage(`"RUNNING"`)}).
+					Return(nil)
 			},
 		},
 		{
@@ -226,14 +708,16 @@ func TestService_ChangeState(t *testing.T) {
 			input: ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL, State: SUSPEND},
 			mockCalls: func() {
 				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
-					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 1}, Status: RUNNING}, nil)
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Type: string(SQL), Status: RUNNING}, nil)
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
 				mGCP.EXPECT().NewGoogleCredentials(ctx, gomock.Any(), "https://www.googleapis.com/auth/cloud-platform").
-					Return(mockCreds, nil)
+					Return(mockCreds, nil).Times(2)
 				mGCP.EXPECT().NewSQLClient(ctx, option.WithCredentials(mockCreds)).
-					Return(mockStopper, nil)
+					Return(&mockSQLClient{status: STOPPED}, nil).Times(2)
 				mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(1)).
 					Return(nil)
+				mStore.EXPECT().InsertEvent(ctx, gomock.Any()).
+					Return(assert.AnError)
 			},
 		},
 		{
@@ -241,7 +725,18 @@ func TestService_ChangeState(t *testing.T) {
 			input: ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL, State: START},
 			mockCalls: func() {
 				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
-					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 1}, Status: RUNNING}, nil)
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Type: string(SQL), Status: RUNNING}, nil)
+			},
+		},
+		{
+			name:   "Error - Resource deleted",
+			input:  ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL, State: START},
+			expErr: gofrHttp.ErrorEntityNotFound{Name: "resource", Value: "1"},
+			mockCalls: func() {
+				deletedAt := time.Now()
+
+				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
+					Return(&models.Resource{ID: 1, Status: STOPPED, DeletedAt: &deletedAt}, nil)
 			},
 		},
 		{
@@ -259,21 +754,49 @@ func TestService_ChangeState(t *testing.T) {
 			expErr: errMock,
 			mockCalls: func() {
 				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
-					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 1}, Status: STOPPED}, nil)
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Type: string(SQL), Status: STOPPED}, nil)
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).
 					Return(nil, errMock)
 			},
 		},
 		{
 			name:   "Error - Invalid Type",
-			input:  ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: "invalid"},
+			input:  ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL},
 			expErr: gofrHttp.ErrorInvalidParam{Params: []string{"req.Type"}},
 			mockCalls: func() {
 				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
-					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 1}, Status: RUNNING}, nil)
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Type: "invalid", Status: RUNNING}, nil)
 				mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
 			},
 		},
+		{
+			// The type of the request is ignored, the driver is picked by the stored type of the resource.
+			name:   "Error - Read-only Type",
+			input:  ResourceDetails{ID: 1, CloudAccID: 123, Name: "assets", Type: SQL, State: SUSPEND},
+			expErr: &errReadOnlyResource{resourceType: GCSBUCKET},
+			mockCalls: func() {
+				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Type: string(GCSBUCKET),
+						Status: RUNNING}, nil)
+			},
+		},
+		{
+			name:   "Error - Resource of another cloud account",
+			input:  ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL, State: SUSPEND},
+			expErr: gofrHttp.ErrorEntityNotFound{Name: "resource", Value: "1"},
+			mockCalls: func() {
+				mStore.EXPECT().GetResourceByID(ctx, int64(1)).
+					Return(&models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 7}, Type: string(SQL), Status: RUNNING}, nil)
+			},
+		},
+		{
+			name:   "Error - Resource not found",
+			input:  ResourceDetails{ID: 1, CloudAccID: 123, Name: "test-instance", Type: SQL, State: SUSPEND},
+			expErr: gofrHttp.ErrorEntityNotFound{Name: "resource", Value: "1"},
+			mockCalls: func() {
+				mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(nil, sql.ErrNoRows)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -286,3 +809,366 @@ func TestService_ChangeState(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ChangeState_GKENodePool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mGCP := NewMockGCPClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(GCP), Credentials: map[string]any{"project_id": "test-project"}}
+	mockCreds := &google.Credentials{ProjectID: "test-project"}
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
+
+	previous := map[string]any{"node_count": float64(3)}
+	running := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: "test-project/us-central1/dev/default", Type: string(GKENODEPOOL),
+		Status: RUNNING, Settings: models.Settings{"cluster": "dev", "node_count": float64(3)}}
+	stopped := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: "test-project/us-central1/dev/default", Type: string(GKENODEPOOL),
+		Status: STOPPED, Settings: models.Settings{"cluster": "dev", "node_count": float64(0), previousStateKey: previous}}
+
+	testCases := []struct {
+		name        string
+		res         *models.Resource
+		state       ResourceState
+		gke         *mockGKEClient
+		expErr      error
+		expSettings models.Settings
+		expPrevious models.Settings
+	}{
+		{
+			name: "suspend records the previous state", res: running, state: SUSPEND,
+			gke: &mockGKEClient{previous: models.Settings{"node_count": int64(3)}, status: STOPPED},
+			expSettings: models.Settings{"cluster": "dev", "node_count": float64(3),
+				previousStateKey: models.Settings{"node_count": int64(3)}},
+		},
+		{
+			name: "start restores the previous state", res: stopped, state: START, gke: &mockGKEClient{status: RUNNING},
+			expSettings: models.Settings{"cluster": "dev", "node_count": float64(0)},
+			expPrevious: previous,
+		},
+		{
+			name: "error suspending node pool", res: running, state: SUSPEND, gke: &mockGKEClient{isError: true},
+			expErr: errMock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The clients are created again to refresh the status of a node pool whose state was changed.
+			clients := 2
+			if tc.expErr != nil {
+				clients = 1
+			}
+
+			mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(tc.res, nil)
+			mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+			mGCP.EXPECT().NewGoogleCredentials(ctx, ca.Credentials, "https://www.googleapis.com/auth/cloud-platform").
+				Return(mockCreds, nil).Times(clients)
+			mGCP.EXPECT().NewGKEClient(ctx, option.WithCredentials(mockCreds)).Return(tc.gke, nil).Times(clients)
+
+			if tc.expErr == nil {
+				mStore.EXPECT().UpdateSettings(ctx, tc.expSettings, int64(1)).Return(nil)
+				mStore.EXPECT().UpdateStatus(ctx, getStatus(tc.state), int64(1)).Return(nil)
+				mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+			}
+
+			err := s.ChangeState(ctx, ResourceDetails{ID: 1, CloudAccID: 123, Type: GKENODEPOOL, State: tc.state})
+
+			assert.Equal(t, tc.expErr, err)
+
+			if tc.state == START {
+				assert.Equal(t, tc.expPrevious, tc.gke.previous)
+			}
+		})
+	}
+}
+
+// stubAutoScaling implements the AutoScalingAPI interface with a single Auto Scaling group.
+type stubAutoScaling struct {
+	group   *autoscaling.Group
+	updates []*autoscaling.UpdateAutoScalingGroupInput
+}
+
+func (m *stubAutoScaling) DescribeAutoScalingGroupsPagesWithContext(_ aws.Context, _ *autoscaling.DescribeAutoScalingGroupsInput,
+	fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool, _ ...request.Option) error {
+	fn(&autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: []*autoscaling.Group{m.group}}, true)
+
+	return nil
+}
+
+func (m *stubAutoScaling) UpdateAutoScalingGroupWithContext(_ aws.Context, input *autoscaling.UpdateAutoScalingGroupInput,
+	_ ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+	m.updates = append(m.updates, input)
+	m.group.DesiredCapacity = input.DesiredCapacity
+
+	return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+}
+
+func TestService_ChangeState_AutoScalingGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mAWS := NewMockAWSClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(AWS), Credentials: map[string]any{}}
+	s := New(nil, mAWS, nil, nil, mClient, mStore)
+
+	asg := &stubAutoScaling{group: &autoscaling.Group{AutoScalingGroupName: aws.String("workers"),
+		DesiredCapacity: aws.Int64(2), MinSize: aws.Int64(1), MaxSize: aws.Int64(4)}}
+	cl := &scaling.Client{Regions: map[string]*scaling.RegionalClient{"us-east-1": {AutoScaling: asg}}}
+	res := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, Name: "workers", Type: string(ASG), Region: "us-east-1", Status: RUNNING,
+		Settings: models.Settings{"desired_capacity": float64(2)}}
+
+	mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(res, nil)
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+	mAWS.EXPECT().NewScalingClient(ctx, ca.Credentials).Return(cl, nil).Times(2)
+	mStore.EXPECT().UpdateSettings(ctx, models.Settings{"desired_capacity": float64(2), previousStateKey: models.Settings{
+		"desired_capacity": int64(2), "min_size": int64(1), "max_size": int64(4)}}, int64(1)).Return(nil)
+	mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(1)).Return(nil)
+	mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+	err := s.ChangeState(ctx, ResourceDetails{ID: 1, CloudAccID: 123, Type: ASG, State: SUSPEND})
+
+	require.NoError(t, err)
+	assert.Equal(t, &autoscaling.UpdateAutoScalingGroupInput{AutoScalingGroupName: aws.String("workers"),
+		DesiredCapacity: aws.Int64(0), MinSize: aws.Int64(0), MaxSize: aws.Int64(0)}, asg.updates[0])
+}
+
+func TestService_ChangeState_CloudRunService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mGCP := NewMockGCPClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(GCP), Credentials: map[string]any{"project_id": "test-project"}}
+	mockCreds := &google.Credentials{ProjectID: "test-project"}
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
+
+	previous := models.Settings{"ingress": "INGRESS_TRAFFIC_ALL", "min_instance_count": int64(2)}
+	cl := &mockServerlessClient{previous: previous, status: STOPPED}
+	res := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: "projects/test-project/locations/us-central1/services/api",
+		Type: string(CLOUDRUNSERVICE), Status: RUNNING, Settings: models.Settings{"min_instance_count": float64(2)}}
+
+	mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(res, nil)
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+	mGCP.EXPECT().NewGoogleCredentials(ctx, ca.Credentials, "https://www.googleapis.com/auth/cloud-platform").
+		Return(mockCreds, nil).Times(2)
+	mGCP.EXPECT().NewServerlessClient(ctx, option.WithCredentials(mockCreds)).Return(cl, nil).Times(2)
+	mStore.EXPECT().UpdateSettings(ctx, models.Settings{"min_instance_count": float64(2), previousStateKey: previous},
+		int64(1)).Return(nil)
+	mStore.EXPECT().UpdateStatus(ctx, STOPPED, int64(1)).Return(nil)
+	mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+	err := s.ChangeState(ctx, ResourceDetails{ID: 1, CloudAccID: 123, Type: CLOUDRUNSERVICE, State: SUSPEND})
+
+	require.NoError(t, err)
+}
+
+// newAzureARM returns a Resource Manager client served by the given handler.
+func newAzureARM(t *testing.T, handler http.HandlerFunc) *arm.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &arm.Client{HTTP: srv.Client(), Endpoint: srv.URL, SubscriptionID: "sub-1"}
+}
+
+func TestService_ChangeState_Azure(t *testing.T) {
+	const (
+		vmID = "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Compute/virtualMachines/web"
+		dbID = "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.DBforPostgreSQL/flexibleServers/users"
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mAzure := NewMockAzureClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(AZURE), Credentials: map[string]any{"subscription_id": "sub-1"}}
+	s := New(nil, nil, mAzure, nil, mClient, mStore)
+
+	var calls []string
+
+	// The resources are still changing when their status is read after the action.
+	armClient := newAzureARM(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/instanceView"):
+			_, _ = w.Write([]byte(`{"statuses": [{"code": "PowerState/deallocating"}]}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"properties": {"state": "Starting"}}`))
+		default:
+			calls = append(calls, r.URL.Path)
+
+			w.WriteHeader(http.StatusAccepted)
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		res       *models.Resource
+		details   ResourceDetails
+		mockCalls func()
+		expStatus string
+		expCall   string
+	}{
+		{
+			name:    "suspend virtual machine",
+			res:     &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: vmID, Type: string(AZUREVM), Status: RUNNING},
+			details: ResourceDetails{ID: 1, CloudAccID: 123, Type: AZUREVM, State: SUSPEND},
+			mockCalls: func() {
+				mAzure.EXPECT().NewVMClient(ctx, ca.Credentials).Return(&azureVM.Client{ARM: armClient}, nil).Times(2)
+			},
+			expStatus: "DEALLOCATING",
+			expCall:   vmID + "/deallocate",
+		},
+		{
+			name:    "start flexible server",
+			res:     &models.Resource{ID: 2, CloudAccount: models.CloudAccount{ID: 123}, UID: dbID, Type: string(AZUREFLEXIBLESERVER), Status: STOPPED},
+			details: ResourceDetails{ID: 2, CloudAccID: 123, Type: AZUREFLEXIBLESERVER, State: START},
+			mockCalls: func() {
+				mAzure.EXPECT().NewDatabaseClient(ctx, ca.Credentials).
+					Return(&azureDatabase.Client{ARM: armClient}, nil).Times(2)
+			},
+			expStatus: "STARTING",
+			expCall:   dbID + "/start",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+
+			mStore.EXPECT().GetResourceByID(ctx, tc.res.ID).Return(tc.res, nil)
+			mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+			tc.mockCalls()
+			mStore.EXPECT().UpdateStatus(ctx, tc.expStatus, tc.res.ID).Return(nil)
+			mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+			err := s.ChangeState(ctx, tc.details)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{tc.expCall}, calls)
+		})
+	}
+}
+
+func TestService_ChangeState_AzureError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mAzure := NewMockAzureClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(AZURE), Credentials: map[string]any{}}
+	s := New(nil, nil, mAzure, nil, mClient, mStore)
+
+	armClient := newAzureARM(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":{"code":"OperationNotAllowed","message":"busy"}}`))
+	})
+	res := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: "/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Compute/virtualMachines/web",
+		Type: string(AZUREVM), Status: RUNNING}
+
+	mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(res, nil)
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+	mAzure.EXPECT().NewVMClient(ctx, ca.Credentials).Return(&azureVM.Client{ARM: armClient}, nil)
+
+	err := s.ChangeState(ctx, ResourceDetails{ID: 1, CloudAccID: 123, Type: AZUREVM, State: SUSPEND})
+
+	var armErr *arm.Error
+
+	require.ErrorAs(t, err, &armErr)
+	assert.Equal(t, http.StatusConflict, armErr.StatusCode())
+}
+
+// stubOCICompute records the actions on the OCI compute instances.
+type stubOCICompute struct {
+	actions []core.InstanceActionRequest
+}
+
+func (*stubOCICompute) ListInstances(context.Context, core.ListInstancesRequest) (core.ListInstancesResponse, error) {
+	return core.ListInstancesResponse{}, nil
+}
+
+func (s *stubOCICompute) InstanceAction(_ context.Context, req core.InstanceActionRequest) (core.InstanceActionResponse, error) {
+	s.actions = append(s.actions, req)
+
+	return core.InstanceActionResponse{}, nil
+}
+
+// GetInstance returns an instance stopping once it was asked to.
+func (s *stubOCICompute) GetInstance(context.Context, core.GetInstanceRequest) (core.GetInstanceResponse, error) {
+	state := core.InstanceLifecycleStateRunning
+	if len(s.actions) > 0 {
+		state = core.InstanceLifecycleStateStopping
+	}
+
+	return core.GetInstanceResponse{Instance: core.Instance{LifecycleState: state}}, nil
+}
+
+func TestService_ChangeState_OCIInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mOCI := NewMockOCIClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(OCI), Credentials: map[string]any{}}
+	s := New(nil, nil, nil, mOCI, mClient, mStore)
+
+	api := &stubOCICompute{}
+	res := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: "ocid1.instance.oc1..web", Type: string(OCIINSTANCE), Status: RUNNING}
+
+	mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(res, nil)
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+	mOCI.EXPECT().NewComputeClient(ctx, ca.Credentials).Return(&ociCompute.Client{Compute: api}, nil).Times(2)
+	mStore.EXPECT().UpdateStatus(ctx, "STOPPING", int64(1)).Return(nil)
+	mStore.EXPECT().InsertEvent(ctx, gomock.Any()).Return(nil)
+
+	err := s.ChangeState(ctx, ResourceDetails{ID: 1, CloudAccID: 123, Type: OCIINSTANCE, State: SUSPEND})
+
+	require.NoError(t, err)
+	assert.Equal(t, []core.InstanceActionRequest{{InstanceId: &res.UID, Action: core.InstanceActionActionSoftstop}},
+		api.actions)
+}
+
+func TestService_ChangeState_OCIClientError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mOCI := NewMockOCIClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	ca := &client.CloudAccount{ID: 123, Provider: string(OCI), Credentials: map[string]any{}}
+	s := New(nil, nil, nil, mOCI, mClient, mStore)
+
+	res := &models.Resource{ID: 1, CloudAccount: models.CloudAccount{ID: 123}, UID: "ocid1.autonomousdatabase.oc1..reports", Type: string(OCIAUTONOMOUSDB),
+		Status: STOPPED}
+
+	mStore.EXPECT().GetResourceByID(ctx, int64(1)).Return(res, nil)
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(123)).Return(ca, nil)
+	mOCI.EXPECT().NewDatabaseClient(ctx, ca.Credentials).Return(nil, errMock)
+
+	err := s.ChangeState(ctx, ResourceDetails{ID: 1, CloudAccID: 123, Type: OCIAUTONOMOUSDB, State: START})
+
+	require.ErrorIs(t, err, errMock)
+}
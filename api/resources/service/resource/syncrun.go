@@ -0,0 +1,106 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+const (
+	// maxSyncErrors caps the number of errors recorded for a single sync run.
+	maxSyncErrors = 50
+	// syncStatusRuns is the number of latest sync runs returned in the sync status.
+	syncStatusRuns = 10
+)
+
+// GetSyncStatus returns whether the resources of a cloud account are being synced along with its latest sync runs.
+func (s *Service) GetSyncStatus(ctx *gofr.Context, id int64) (*models.SyncStatus, error) {
+	runs, err := s.store.GetSyncRuns(ctx, id, syncStatusRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	if runs == nil {
+		runs = []models.SyncRun{}
+	}
+
+	// Only one run of a cloud account runs at a time, it is the latest one.
+	inProgress := len(runs) > 0 && runs[0].IsRunning(time.Now())
+
+	return &models.SyncStatus{InProgress: inProgress, Runs: runs}, nil
+}
+
+// startSyncRun records the start of a sync run, the running run keeps the other syncs of the cloud account from
+// starting until it is finished. errSyncInProgress is returned when another run of the cloud account is running.
+func (s *Service) startSyncRun(ctx *gofr.Context, id int64, scope *models.SyncScope) (*models.SyncRun, error) {
+	run := &models.SyncRun{
+		CloudAccountID: id,
+		SyncScope:      *scope,
+		Status:         models.SyncRunning,
+		StartedAt:      time.Now(),
+	}
+
+	err := s.store.InsertSyncRun(ctx, run)
+	if errors.Is(err, models.ErrSyncRunning) {
+		return nil, &errSyncInProgress{cloudAccID: id}
+	}
+
+	if err != nil {
+		ctx.Errorf("failed to record sync run of cloud account %d: %v", id, err)
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// finishSyncRun records the outcome of a sync run, err is the error that stopped the sync, if any.
+func (s *Service) finishSyncRun(ctx *gofr.Context, run *models.SyncRun, err error) {
+	endedAt := time.Now()
+	run.EndedAt = &endedAt
+
+	switch {
+	case err != nil:
+		run.Status = models.SyncFailed
+		run.Errors = append(run.Errors, err.Error())
+	case len(run.Errors) > 0:
+		run.Status = models.SyncPartial
+	default:
+		run.Status = models.SyncSucceeded
+	}
+
+	// The run releases the syncs of the cloud account once it is updated, they start again after the timeout of the run
+	// if the update fails.
+	er := s.store.UpdateSyncRun(ctx, run)
+	if er != nil {
+		ctx.Errorf("failed to update sync run %d: %v", run.ID, er)
+	}
+}
+
+func addSyncError(run *models.SyncRun, format string, args ...any) {
+	if len(run.Errors) >= maxSyncErrors {
+		return
+	}
+
+	run.Errors = append(run.Errors, fmt.Sprintf(format, args...))
+}
+
+// filterByScope returns the resources within the scope, keeping their order.
+func filterByScope(res []models.Resource, scope *models.SyncScope) []models.Resource {
+	if len(scope.ResourceTypes) == 0 && len(scope.Regions) == 0 {
+		return res
+	}
+
+	filtered := make([]models.Resource, 0, len(res))
+
+	for i := range res {
+		if scope.Matches(&res[i]) {
+			filtered = append(filtered, res[i])
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,131 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"golang.org/x/oauth2/google"
+
+	"github.com/zopdev/zopdev/api/resources/client"
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestService_SyncResources_InProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	s := New(nil, nil, nil, nil, nil, mStore)
+
+	// Another run of the cloud account is running, in this instance of the API or another one.
+	mStore.EXPECT().InsertSyncRun(ctx, gomock.Any()).Return(models.ErrSyncRunning)
+
+	res, err := s.SyncResources(ctx, 1, nil)
+
+	assert.Nil(t, res)
+	assert.Equal(t, &errSyncInProgress{cloudAccID: 1}, err)
+	assert.Equal(t, http.StatusConflict, (&errSyncInProgress{}).StatusCode())
+
+	// The sync does not start when its run can not be recorded, as the run is the lock of the syncs.
+	mStore.EXPECT().InsertSyncRun(ctx, gomock.Any()).Return(errMock)
+
+	res, err = s.SyncResources(ctx, 1, nil)
+
+	assert.Nil(t, res)
+	assert.Equal(t, errMock, err)
+}
+
+func TestService_SyncResources_Scoped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mClient := NewMockHTTPClient(ctrl)
+	mStore := NewMockStore(ctrl)
+	mGCP := NewMockGCPClient(ctrl)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+	s := New(mGCP, nil, nil, nil, mClient, mStore)
+
+	ca := &client.CloudAccount{ID: 1, Provider: string(GCP), Credentials: map[string]any{}}
+	lister := &mockSQLClient{instances: []models.Resource{
+		{UID: "p/sql-1", Type: string(SQL), Region: "us-central1", Status: RUNNING},
+		{UID: "p/sql-2", Type: string(SQL), Region: "europe-west1", Status: RUNNING},
+	}}
+	stored := []models.Resource{
+		{ID: 1, UID: "p/sql-1", Type: string(SQL), Region: "us-central1", Status: RUNNING},
+		// Out of the scope, must not be removed even though it was not listed.
+		{ID: 2, UID: "p/sql-3", Type: string(SQL), Region: "europe-west1", Status: RUNNING},
+	}
+	scope := &models.SyncScope{ResourceTypes: models.StringList{"sql"}, Regions: models.StringList{"us-central1"}}
+
+	mClient.EXPECT().GetCloudCredentials(ctx, int64(1)).Return(ca, nil)
+	mGCP.EXPECT().NewGoogleCredentials(gomock.Any(), gomock.Any(), gomock.Any()).Return(&google.Credentials{}, nil)
+	mGCP.EXPECT().NewSQLClient(gomock.Any(), gomock.Any()).Return(lister, nil)
+	mGCP.EXPECT().GetProjects(gomock.Any(), gomock.Any()).Return([]string{"p"}, nil)
+	mStore.EXPECT().InsertSyncRun(ctx, gomock.Any()).Return(nil)
+	mStore.EXPECT().UpdateSyncRun(ctx, gomock.Any()).Return(nil)
+	mStore.EXPECT().GetResourcesIncludingDeleted(ctx, int64(1)).Return(stored, nil)
+	mStore.EXPECT().UpdateProject(ctx, "p", int64(1)).Return(nil)
+	mStore.EXPECT().GetResources(ctx, int64(1), []string{"sql"}).Return(stored, nil)
+
+	res, err := s.SyncResources(ctx, 1, scope)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored[:1], res)
+}
+
+func TestService_GetSyncStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := NewMockStore(ctrl)
+	ctx := &gofr.Context{Context: context.Background()}
+	s := New(nil, nil, nil, nil, nil, mStore)
+	runs := []models.SyncRun{{ID: 2, Status: models.SyncRunning, StartedAt: time.Now()}, {ID: 1, Status: models.SyncFailed}}
+	finished := []models.SyncRun{{ID: 4, Status: models.SyncSucceeded}, {ID: 3, Status: models.SyncRunning}}
+
+	mStore.EXPECT().GetSyncRuns(ctx, int64(1), syncStatusRuns).Return(runs, nil)
+	mStore.EXPECT().GetSyncRuns(ctx, int64(2), syncStatusRuns).Return(nil, nil)
+	mStore.EXPECT().GetSyncRuns(ctx, int64(3), syncStatusRuns).Return(nil, errMock)
+	mStore.EXPECT().GetSyncRuns(ctx, int64(4), syncStatusRuns).Return(finished, nil)
+
+	status, err := s.GetSyncStatus(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SyncStatus{InProgress: true, Runs: runs}, status)
+
+	status, err = s.GetSyncStatus(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SyncStatus{Runs: []models.SyncRun{}}, status)
+
+	status, err = s.GetSyncStatus(ctx, 3)
+
+	assert.Equal(t, errMock, err)
+	assert.Nil(t, status)
+
+	// Only the latest run can be running, an older one was abandoned.
+	status, err = s.GetSyncStatus(ctx, 4)
+
+	assert.NoError(t, err)
+	assert.False(t, status.InProgress)
+}
+
+func Test_addSyncError(t *testing.T) {
+	run := &models.SyncRun{}
+
+	for i := 0; i < maxSyncErrors+5; i++ {
+		addSyncError(run, "failed to insert resource %d", i)
+	}
+
+	assert.Len(t, run.Errors, maxSyncErrors)
+	assert.Equal(t, "failed to insert resource 0", run.Errors[0])
+}
@@ -0,0 +1,36 @@
+package resourcegroup
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// GetCost returns the uptime and the estimated spend and savings of the current members of a resource group over a
+// period.
+func (s *Service) GetCost(ctx *gofr.Context, cloudAccID, id int64, period models.CostPeriod) (*models.CostReport, error) {
+	rg, err := s.grpStore.GetResourceGroupByID(ctx, cloudAccID, id)
+	if err != nil {
+		return nil, &errInternalServer{}
+	}
+
+	if rg == nil {
+		return nil, gofrHttp.ErrorEntityNotFound{Name: "resource group", Value: strconv.FormatInt(id, 10)}
+	}
+
+	members, err := s.getMembers(ctx, rg)
+	if err != nil {
+		return nil, &errInternalServer{}
+	}
+
+	ids := make([]int64, 0, len(members))
+
+	for i := range members {
+		ids = append(ids, members[i].ID)
+	}
+
+	return s.resSvc.GetResourcesCost(ctx, cloudAccID, ids, period)
+}
@@ -0,0 +1,80 @@
+package resourcegroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestService_GetCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := NewMockRGStore(ctrl)
+	mockResSvc := NewMockResourceService(ctrl)
+	svc := New(mockStore, mockResSvc)
+	ctx := &gofr.Context{}
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	period := models.CostPeriod{From: from, To: from.Add(24 * time.Hour)}
+	rg := &models.ResourceGroup{ID: 1, CloudAccountID: 2}
+	report := &models.CostReport{CostPeriod: period, Currency: "USD", EstimatedSpend: 4.2}
+
+	tests := []struct {
+		name        string
+		setup       func()
+		expected    *models.CostReport
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(2), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{10, 11}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(&models.Resource{ID: 10}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(11)).Return(&models.Resource{ID: 11}, nil)
+				mockResSvc.EXPECT().GetResourcesCost(ctx, int64(2), []int64{10, 11}, period).Return(report, nil)
+			},
+			expected: report,
+		},
+		{
+			name: "group not found",
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(2), int64(1)).Return(nil, nil)
+			},
+			expectedErr: gofrHttp.ErrorEntityNotFound{Name: "resource group", Value: "1"},
+		},
+		{
+			name: "store error",
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(2), int64(1)).Return(nil, assert.AnError)
+			},
+			expectedErr: &errInternalServer{},
+		},
+		{
+			name: "members error",
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(2), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return(nil, assert.AnError)
+			},
+			expectedErr: &errInternalServer{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			res, err := svc.GetCost(ctx, 2, 1, period)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"gofr.dev/pkg/gofr"
 
 	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/service/resource"
 )
 
 type RGStore interface {
@@ -19,5 +20,8 @@ type RGStore interface {
 }
 
 type ResourceService interface {
+	GetAll(ctx *gofr.Context, id int64, filter *models.ResourceFilter) ([]models.Resource, error)
 	GetByID(ctx *gofr.Context, id int64) (*models.Resource, error)
+	ChangeState(ctx *gofr.Context, resDetails resource.ResourceDetails) error
+	GetResourcesCost(ctx *gofr.Context, cloudAccID int64, ids []int64, period models.CostPeriod) (*models.CostReport, error)
 }
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/zopdev/zopdev/api/resources/models"
+	resource "github.com/zopdev/zopdev/api/resources/service/resource"
 	gomock "go.uber.org/mock/gomock"
 	gofr "gofr.dev/pkg/gofr"
 )
@@ -181,6 +182,35 @@ func (m *MockResourceService) EXPECT() *MockResourceServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangeState mocks base method.
+func (m *MockResourceService) ChangeState(ctx *gofr.Context, resDetails resource.ResourceDetails) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeState", ctx, resDetails)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeState indicates an expected call of ChangeState.
+func (mr *MockResourceServiceMockRecorder) ChangeState(ctx, resDetails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeState", reflect.TypeOf((*MockResourceService)(nil).ChangeState), ctx, resDetails)
+}
+
+// GetAll mocks base method.
+func (m *MockResourceService) GetAll(ctx *gofr.Context, id int64, filter *models.ResourceFilter) ([]models.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, id, filter)
+	ret0, _ := ret[0].([]models.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockResourceServiceMockRecorder) GetAll(ctx, id, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockResourceService)(nil).GetAll), ctx, id, filter)
+}
+
 // GetByID mocks base method.
 func (m *MockResourceService) GetByID(ctx *gofr.Context, id int64) (*models.Resource, error) {
 	m.ctrl.T.Helper()
@@ -195,3 +225,18 @@ func (mr *MockResourceServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockResourceService)(nil).GetByID), ctx, id)
 }
+
+// GetResourcesCost mocks base method.
+func (m *MockResourceService) GetResourcesCost(ctx *gofr.Context, cloudAccID int64, ids []int64, period models.CostPeriod) (*models.CostReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcesCost", ctx, cloudAccID, ids, period)
+	ret0, _ := ret[0].(*models.CostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcesCost indicates an expected call of GetResourcesCost.
+func (mr *MockResourceServiceMockRecorder) GetResourcesCost(ctx, cloudAccID, ids, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesCost", reflect.TypeOf((*MockResourceService)(nil).GetResourcesCost), ctx, cloudAccID, ids, period)
+}
@@ -1,6 +1,8 @@
 package resourcegroup
 
 import (
+	"database/sql"
+	"errors"
 	"strconv"
 	"sync"
 
@@ -14,6 +16,8 @@ import (
 const (
 	STOPPED = "STOPPED"
 	RUNNING = "RUNNING"
+	// MISSING is the status of a member that is no longer present on the cloud.
+	MISSING = "MISSING"
 )
 
 type Service struct {
@@ -39,28 +43,17 @@ func (s *Service) GetAllResourceGroups(ctx *gofr.Context, cloudAccID int64) ([]m
 
 	for _, rg := range rsg {
 		errGrp.Go(func() error {
-			// Get resource IDs for the current resource group
-			resIDs, er := s.grpStore.GetResourceIDs(ctx, rg.ID)
+			resources, er := s.getMembers(ctx, &rg)
 			if er != nil {
 				return er
 			}
 
-			var (
-				resources []models.Resource
-				status    = RUNNING
-			)
+			status := RUNNING
 
-			for i := range resIDs {
-				resource, er := s.resSvc.GetByID(ctx, resIDs[i])
-				if er != nil {
-					return er
-				}
-
-				if resource.Status == STOPPED {
+			for i := range resources {
+				if resources[i].Status == STOPPED {
 					status = STOPPED
 				}
-
-				resources = append(resources, *resource)
 			}
 
 			mu.Lock()
@@ -89,26 +82,17 @@ func (s *Service) GetResourceGroupByID(ctx *gofr.Context, cloudAccID, id int64)
 		return nil, &errInternalServer{}
 	}
 
-	resIDs, err := s.grpStore.GetResourceIDs(ctx, id)
+	resources, err := s.getMembers(ctx, rg)
 	if err != nil {
 		return nil, &errInternalServer{}
 	}
 
 	rg.Status = RUNNING
 
-	resources := make([]models.Resource, 0, len(resIDs))
-
-	for i := range resIDs {
-		resource, er := s.resSvc.GetByID(ctx, resIDs[i])
-		if er != nil {
-			return nil, &errInternalServer{}
-		}
-
-		if resource.Status == STOPPED {
+	for i := range resources {
+		if resources[i].Status == STOPPED {
 			rg.Status = STOPPED
 		}
-
-		resources = append(resources, *resource)
 	}
 
 	return &models.ResourceGroupData{
@@ -117,7 +101,79 @@ func (s *Service) GetResourceGroupByID(ctx *gofr.Context, cloudAccID, id int64)
 	}, nil
 }
 
+// getMembers returns the resources of a resource group. The members of a dynamic group are the resources
+// of the cloud account matching its label selector, for the other groups they are the resources added to it.
+func (s *Service) getMembers(ctx *gofr.Context, rg *models.ResourceGroup) ([]models.Resource, error) {
+	if rg.LabelSelector != "" {
+		selector, err := models.ParseLabelSelector(rg.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.resSvc.GetAll(ctx, rg.CloudAccountID, &models.ResourceFilter{Labels: selector})
+	}
+
+	resIDs, err := s.grpStore.GetResourceIDs(ctx, rg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]models.Resource, 0, len(resIDs))
+
+	for i := range resIDs {
+		resource, err := s.getStaticMember(ctx, resIDs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, *resource)
+	}
+
+	return resources, nil
+}
+
+// getStaticMember returns a member of a static resource group, a member deleted from the cloud is MISSING.
+func (s *Service) getStaticMember(ctx *gofr.Context, id int64) (*models.Resource, error) {
+	resource, err := s.resSvc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if resource.DeletedAt != nil {
+		resource.Status = MISSING
+	}
+
+	return resource, nil
+}
+
+// validateMembers checks that a resource group either has a valid label selector or a static list of resources, not both.
+func validateMembers(labelSelector string, resourceIDs []int64) error {
+	if labelSelector == "" {
+		return nil
+	}
+
+	if len(resourceIDs) > 0 {
+		return gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}}
+	}
+
+	if _, err := models.ParseLabelSelector(labelSelector); err != nil {
+		return gofrHttp.ErrorInvalidParam{Params: []string{"label_selector"}}
+	}
+
+	return nil
+}
+
 func (s *Service) CreateResourceGroup(ctx *gofr.Context, rg *models.RGCreate) (*models.ResourceGroupData, error) {
+	err := validateMembers(rg.LabelSelector, rg.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateResources(ctx, rg.CloudAccountID, rg.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	id, err := s.grpStore.CreateResourceGroup(ctx, rg)
 	if err != nil {
 		return nil, &errInternalServer{}
@@ -135,6 +191,11 @@ func (s *Service) CreateResourceGroup(ctx *gofr.Context, rg *models.RGCreate) (*
 }
 
 func (s *Service) UpdateResourceGroup(ctx *gofr.Context, rg *models.RGUpdate) (*models.ResourceGroupData, error) {
+	err := validateMembers(rg.LabelSelector, rg.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if the resource group exists
 	existingRG, err := s.grpStore.GetResourceGroupByID(ctx, rg.CloudAccountID, rg.ID)
 	if err != nil {
@@ -145,6 +206,11 @@ func (s *Service) UpdateResourceGroup(ctx *gofr.Context, rg *models.RGUpdate) (*
 		return nil, gofrHttp.ErrorEntityNotFound{Name: "resource group", Value: strconv.FormatInt(rg.ID, 10)}
 	}
 
+	err = s.validateResources(ctx, rg.CloudAccountID, rg.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get existing resource IDs
 	existingResourceIDs, err := s.grpStore.GetResourceIDs(ctx, rg.ID)
 	if err != nil {
@@ -156,6 +222,7 @@ func (s *Service) UpdateResourceGroup(ctx *gofr.Context, rg *models.RGUpdate) (*
 		return nil, &errInternalServer{}
 	}
 
+	// A group turned dynamic has no resource IDs in the update, so all its static members get removed here.
 	err = s.modifyResources(ctx, rg.ID, existingResourceIDs, rg.ResourceIDs)
 	if err != nil {
 		return nil, &errInternalServer{}
@@ -164,6 +231,27 @@ func (s *Service) UpdateResourceGroup(ctx *gofr.Context, rg *models.RGUpdate) (*
 	return s.GetResourceGroupByID(ctx, rg.CloudAccountID, rg.ID)
 }
 
+// validateResources checks that the static members of a resource group are resources of its cloud account, the
+// resources of the other accounts, and so of the other organizations, cannot be added to it.
+func (s *Service) validateResources(ctx *gofr.Context, cloudAccID int64, resourceIDs []int64) error {
+	for _, id := range resourceIDs {
+		res, err := s.resSvc.GetByID(ctx, id)
+
+		var notFound gofrHttp.ErrorEntityNotFound
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows) || errors.As(err, &notFound):
+			return gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}}
+		case err != nil:
+			return &errInternalServer{}
+		case res.CloudAccount.ID != cloudAccID || res.DeletedAt != nil:
+			return gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}}
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) modifyResources(ctx *gofr.Context, rgID int64, existingResources, resourceIDs []int64) error {
 	set := make(map[int64]struct{})
 
@@ -1,8 +1,10 @@
 package resourcegroup
 
 import (
+	"database/sql"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -112,6 +114,7 @@ func TestService_GetResourceGroupByID(t *testing.T) {
 	r1 := &models.Resource{ID: 10, Status: RUNNING}
 	r2 := &models.Resource{ID: 11, Status: STOPPED}
 	resourceIDs := []int64{10, 11}
+	deletedAt := time.Now()
 
 	tests := []struct {
 		name        string
@@ -133,6 +136,19 @@ func TestService_GetResourceGroupByID(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "member missing on the cloud",
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{12}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(12)).
+					Return(&models.Resource{ID: 12, Status: RUNNING, DeletedAt: &deletedAt}, nil)
+			},
+			expected: &models.ResourceGroupData{
+				ResourceGroup: models.ResourceGroup{ID: 1, Status: RUNNING},
+				Resources:     []models.Resource{{ID: 12, Status: MISSING, DeletedAt: &deletedAt}},
+			},
+		},
 		{
 			name: "store error - group not found",
 			setup: func() {
@@ -183,7 +199,7 @@ func TestService_CreateResourceGroup(t *testing.T) {
 
 	rgCreate := &models.RGCreate{CloudAccountID: 1, ResourceIDs: []int64{10}}
 	rg := &models.ResourceGroup{ID: 1}
-	resource := &models.Resource{ID: 10}
+	resource := &models.Resource{ID: 10, CloudAccount: models.CloudAccount{ID: 1}}
 	resourceIDs := []int64{10}
 
 	tests := []struct {
@@ -194,6 +210,7 @@ func TestService_CreateResourceGroup(t *testing.T) {
 		{
 			name: "success",
 			setup: func() {
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(resource, nil)
 				mockStore.EXPECT().CreateResourceGroup(ctx, rgCreate).Return(int64(1), nil)
 				mockStore.EXPECT().AddResourcesToGroup(ctx, int64(1), rgCreate.ResourceIDs).Return(nil)
 				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
@@ -205,6 +222,7 @@ func TestService_CreateResourceGroup(t *testing.T) {
 		{
 			name: "store error",
 			setup: func() {
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(resource, nil)
 				mockStore.EXPECT().CreateResourceGroup(ctx, rgCreate).
 					Return(int64(0), assert.AnError)
 			},
@@ -213,11 +231,34 @@ func TestService_CreateResourceGroup(t *testing.T) {
 		{
 			name: "store error - add resources to group",
 			setup: func() {
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(resource, nil)
 				mockStore.EXPECT().CreateResourceGroup(ctx, rgCreate).Return(int64(1), nil)
 				mockStore.EXPECT().AddResourcesToGroup(ctx, int64(1), rgCreate.ResourceIDs).Return(assert.AnError)
 			},
 			expectedErr: &errInternalServer{},
 		},
+		{
+			name: "resource of another cloud account",
+			setup: func() {
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).
+					Return(&models.Resource{ID: 10, CloudAccount: models.CloudAccount{ID: 2}}, nil)
+			},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}},
+		},
+		{
+			name: "resource not found",
+			setup: func() {
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}},
+		},
+		{
+			name: "error fetching resource",
+			setup: func() {
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(nil, assert.AnError)
+			},
+			expectedErr: &errInternalServer{},
+		},
 	}
 
 	for _, tc := range tests {
@@ -231,6 +272,75 @@ func TestService_CreateResourceGroup(t *testing.T) {
 	}
 }
 
+func TestService_CreateResourceGroup_LabelSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := NewMockRGStore(ctrl)
+	mockResSvc := NewMockResourceService(ctrl)
+	svc := New(mockStore, mockResSvc)
+	ctx := &gofr.Context{}
+
+	selector, _ := models.ParseLabelSelector("env=prod")
+	rg := &models.ResourceGroup{ID: 1, CloudAccountID: 1, LabelSelector: "env=prod"}
+	resources := []models.Resource{{ID: 10, Status: RUNNING}, {ID: 11, Status: STOPPED}}
+
+	tests := []struct {
+		name        string
+		rgCreate    *models.RGCreate
+		setup       func()
+		expectedRes *models.ResourceGroupData
+		expectedErr error
+	}{
+		{
+			name:     "dynamic group",
+			rgCreate: &models.RGCreate{CloudAccountID: 1, LabelSelector: "env=prod"},
+			setup: func() {
+				mockStore.EXPECT().CreateResourceGroup(ctx, gomock.Any()).Return(int64(1), nil)
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockResSvc.EXPECT().GetAll(ctx, int64(1), &models.ResourceFilter{Labels: selector}).Return(resources, nil)
+			},
+			expectedRes: &models.ResourceGroupData{
+				ResourceGroup: models.ResourceGroup{ID: 1, CloudAccountID: 1, LabelSelector: "env=prod", Status: STOPPED},
+				Resources:     resources,
+			},
+		},
+		{
+			name:        "both label selector and resource ids",
+			rgCreate:    &models.RGCreate{CloudAccountID: 1, LabelSelector: "env=prod", ResourceIDs: []int64{10}},
+			setup:       func() {},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}},
+		},
+		{
+			name:        "invalid label selector",
+			rgCreate:    &models.RGCreate{CloudAccountID: 1, LabelSelector: "=prod"},
+			setup:       func() {},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"label_selector"}},
+		},
+		{
+			name:     "error fetching matching resources",
+			rgCreate: &models.RGCreate{CloudAccountID: 1, LabelSelector: "env=prod"},
+			setup: func() {
+				mockStore.EXPECT().CreateResourceGroup(ctx, gomock.Any()).Return(int64(1), nil)
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockResSvc.EXPECT().GetAll(ctx, int64(1), &models.ResourceFilter{Labels: selector}).Return(nil, assert.AnError)
+			},
+			expectedErr: &errInternalServer{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			res, err := svc.CreateResourceGroup(ctx, tc.rgCreate)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedRes, res)
+		})
+	}
+}
+
 func TestService_UpdateResourceGroup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -242,8 +352,12 @@ func TestService_UpdateResourceGroup(t *testing.T) {
 
 	rgUpdate := &models.RGUpdate{ID: 2, CloudAccountID: 1, ResourceIDs: []int64{10, 11}}
 	rg := &models.ResourceGroup{ID: 2, CloudAccountID: 1, Name: "myGroup", Status: RUNNING}
-	r1 := &models.Resource{ID: 10}
-	r2 := &models.Resource{ID: 11}
+	r1 := &models.Resource{ID: 10, CloudAccount: models.CloudAccount{ID: 1}}
+	r2 := &models.Resource{ID: 11, CloudAccount: models.CloudAccount{ID: 1}}
+	validated := func() {
+		mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(r1, nil)
+		mockResSvc.EXPECT().GetByID(ctx, int64(11)).Return(r2, nil)
+	}
 	resourceIDs := []int64{10}
 
 	tests := []struct {
@@ -256,6 +370,7 @@ func TestService_UpdateResourceGroup(t *testing.T) {
 			name: "success",
 			setup: func() {
 				mockStore.EXPECT().GetResourceGroupByID(ctx, rg.CloudAccountID, rgUpdate.ID).Return(rg, nil)
+				validated()
 				mockStore.EXPECT().GetResourceIDs(ctx, rgUpdate.ID).Return(resourceIDs, nil) // existing resource IDs - 10
 				mockStore.EXPECT().UpdateResourceGroup(ctx, rgUpdate).Return(nil)
 				mockStore.EXPECT().AddResourcesToGroup(ctx, rgUpdate.ID, []int64{11}).Return(nil) // adding new resource ID - 11
@@ -274,12 +389,23 @@ func TestService_UpdateResourceGroup(t *testing.T) {
 			name: "error - adding new resource",
 			setup: func() {
 				mockStore.EXPECT().GetResourceGroupByID(ctx, rg.CloudAccountID, rgUpdate.ID).Return(rg, nil)
+				validated()
 				mockStore.EXPECT().GetResourceIDs(ctx, rgUpdate.ID).Return(resourceIDs, nil) // existing resource IDs - 10
 				mockStore.EXPECT().UpdateResourceGroup(ctx, rgUpdate).Return(nil)
 				mockStore.EXPECT().AddResourcesToGroup(ctx, rgUpdate.ID, []int64{11}).Return(assert.AnError) // adding new resource ID - 11
 			},
 			expectedErr: &errInternalServer{},
 		},
+		{
+			name: "resource of another cloud account",
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, rg.CloudAccountID, rgUpdate.ID).Return(rg, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(r1, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(11)).
+					Return(&models.Resource{ID: 11, CloudAccount: models.CloudAccount{ID: 2}}, nil)
+			},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"resource_ids"}},
+		},
 		{
 			name: "not found",
 			setup: func() {
@@ -299,6 +425,7 @@ func TestService_UpdateResourceGroup(t *testing.T) {
 			name: "store error - get resource IDs",
 			setup: func() {
 				mockStore.EXPECT().GetResourceGroupByID(ctx, rg.CloudAccountID, rgUpdate.ID).Return(rg, nil)
+				validated()
 				mockStore.EXPECT().GetResourceIDs(ctx, rgUpdate.ID).Return(nil, assert.AnError)
 			},
 			expectedErr: &errInternalServer{},
@@ -307,6 +434,7 @@ func TestService_UpdateResourceGroup(t *testing.T) {
 			name: "store error - update resource group",
 			setup: func() {
 				mockStore.EXPECT().GetResourceGroupByID(ctx, rg.CloudAccountID, rgUpdate.ID).Return(rg, nil)
+				validated()
 				mockStore.EXPECT().GetResourceIDs(ctx, rgUpdate.ID).Return(resourceIDs, nil)
 				mockStore.EXPECT().UpdateResourceGroup(ctx, rgUpdate).Return(assert.AnError)
 			},
@@ -0,0 +1,279 @@
+package resourcegroup
+
+import (
+	"sort"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/service/resource"
+)
+
+const (
+	// defaultConcurrency is the number of members whose state is changed at the same time when not specified.
+	defaultConcurrency = 5
+	// maxConcurrency caps the concurrency requested by the user to avoid hitting the cloud provider rate limits.
+	maxConcurrency = 20
+
+	// Result of the state change of a single member.
+
+	memberSucceeded = "SUCCEEDED"
+	memberFailed    = "FAILED"
+	memberSkipped   = "SKIPPED"
+
+	// Result of the state change of the whole group.
+
+	groupSucceeded = "SUCCEEDED"
+	groupPartial   = "PARTIALLY_SUCCEEDED"
+	groupFailed    = "FAILED"
+)
+
+// ChangeState starts or suspends every member of the resource group.
+// The members are changed with bounded concurrency and, if requested, in stages based on their type.
+// A failing member does not stop the others, the outcome of every member is reported in the result.
+func (s *Service) ChangeState(ctx *gofr.Context, cloudAccID, id int64, req *models.RGStateChange) (*models.RGStateResult, error) {
+	state := resource.ResourceState(req.State)
+	if state != resource.START && state != resource.SUSPEND {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"state"}}
+	}
+
+	if req.Concurrency < 0 {
+		return nil, gofrHttp.ErrorInvalidParam{Params: []string{"concurrency"}}
+	}
+
+	rg, err := s.grpStore.GetResourceGroupByID(ctx, cloudAccID, id)
+	if err != nil {
+		return nil, &errInternalServer{}
+	}
+
+	if rg == nil {
+		return nil, gofrHttp.ErrorEntityNotFound{Name: "resource group", Value: strconv.FormatInt(id, 10)}
+	}
+
+	members, failed, err := s.getStateMembers(ctx, rg)
+	if err != nil {
+		return nil, &errInternalServer{}
+	}
+
+	result := &models.RGStateResult{
+		ResourceGroupID: id,
+		State:           req.State,
+		Members:         make([]models.RGMemberResult, 0, len(members)+len(failed)),
+	}
+
+	for _, stage := range getStages(members, state, req.Ordered) {
+		result.Members = append(result.Members, s.changeStageState(ctx, cloudAccID, stage, state,
+			getConcurrency(req.Concurrency))...)
+	}
+
+	result.Members = append(result.Members, failed...)
+
+	result.Status = getGroupStatus(result.Members)
+
+	return result, nil
+}
+
+// getStateMembers returns the members of a resource group whose state is to be changed. The members of a static group
+// that can not be loaded are returned as failed, so that they do not prevent the change of the other members.
+func (s *Service) getStateMembers(ctx *gofr.Context, rg *models.ResourceGroup) (members []models.Resource,
+	failed []models.RGMemberResult, err error) {
+	if rg.LabelSelector != "" {
+		members, err = s.getMembers(ctx, rg)
+
+		return members, nil, err
+	}
+
+	resIDs, err := s.grpStore.GetResourceIDs(ctx, rg.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members = make([]models.Resource, 0, len(resIDs))
+
+	for _, resID := range resIDs {
+		member, er := s.getStaticMember(ctx, resID)
+		if er != nil {
+			ctx.Errorf("failed to get resource %d of resource group %d: %v", resID, rg.ID, er)
+
+			failed = append(failed, models.RGMemberResult{ResourceID: resID, Status: memberFailed, Error: er.Error()})
+
+			continue
+		}
+
+		members = append(members, *member)
+	}
+
+	return members, failed, nil
+}
+
+// changeStageState changes the state of all the given members concurrently and waits for all of them to finish.
+func (s *Service) changeStageState(ctx *gofr.Context, cloudAccID int64, members []models.Resource,
+	state resource.ResourceState, concurrency int) []models.RGMemberResult {
+	var (
+		results = make([]models.RGMemberResult, len(members))
+		errGrp  = new(errgroup.Group)
+	)
+
+	errGrp.SetLimit(concurrency)
+
+	for i := range members {
+		errGrp.Go(func() error {
+			// Results are written at the index of the member, so no lock is needed here.
+			results[i] = s.changeMemberState(ctx, cloudAccID, &members[i], state)
+
+			return nil
+		})
+	}
+
+	_ = errGrp.Wait()
+
+	return results
+}
+
+func (s *Service) changeMemberState(ctx *gofr.Context, cloudAccID int64, member *models.Resource,
+	state resource.ResourceState) models.RGMemberResult {
+	res := models.RGMemberResult{
+		ResourceID: member.ID,
+		Name:       member.Name,
+		Type:       member.Type,
+	}
+
+	if member.Status == getTargetStatus(state) {
+		res.Status = memberSkipped
+
+		return res
+	}
+
+	if member.Status == MISSING {
+		res.Status = memberSkipped
+		res.Error = "resource is missing on the cloud"
+
+		return res
+	}
+
+	if resource.ResourceType(member.Type).IsReadOnly() {
+		res.Status = memberSkipped
+		res.Error = "state of the resource type can not be changed"
+
+		return res
+	}
+
+	err := s.resSvc.ChangeState(ctx, resource.ResourceDetails{
+		ID:         member.ID,
+		CloudAccID: cloudAccID,
+		Name:       member.Name,
+		Type:       resource.ResourceType(member.Type),
+		State:      state,
+	})
+	if err != nil {
+		ctx.Errorf("failed to change state of resource %d: %v", member.ID, err)
+
+		res.Status = memberFailed
+		res.Error = err.Error()
+
+		return res
+	}
+
+	res.Status = memberSucceeded
+
+	return res
+}
+
+// getStages splits the members into stages that are to be executed one after the other.
+// When the change is not ordered all the members are part of a single stage.
+func getStages(members []models.Resource, state resource.ResourceState, ordered bool) [][]models.Resource {
+	if len(members) == 0 {
+		return nil
+	}
+
+	if !ordered {
+		return [][]models.Resource{members}
+	}
+
+	byPriority := make(map[int][]models.Resource)
+
+	for i := range members {
+		p := getStartPriority(members[i].Type)
+		byPriority[p] = append(byPriority[p], members[i])
+	}
+
+	priorities := make([]int, 0, len(byPriority))
+
+	for p := range byPriority {
+		priorities = append(priorities, p)
+	}
+
+	// Resources are started in the increasing order of their priority and suspended in the reverse order.
+	if state == resource.START {
+		sort.Ints(priorities)
+	} else {
+		sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+	}
+
+	stages := make([][]models.Resource, 0, len(priorities))
+
+	for _, p := range priorities {
+		stages = append(stages, byPriority[p])
+	}
+
+	return stages
+}
+
+// getStartPriority returns the stage in which a resource type is started, e.g. databases are started before
+// the VMs that depend on them. Resource types that are not known are started last.
+func getStartPriority(resType string) int {
+	switch resource.ResourceType(resType) {
+	case resource.SQL, resource.RDS, resource.AZUREFLEXIBLESERVER, resource.OCIDBSYSTEM, resource.OCIAUTONOMOUSDB:
+		return 0
+	case resource.AWSCOMPUTE, resource.GKENODEPOOL, resource.ASG, resource.EKSNODEGROUP, resource.CLOUDRUNSERVICE,
+		resource.AZUREVM, resource.OCIINSTANCE:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func getConcurrency(concurrency int) int {
+	switch {
+	case concurrency == 0:
+		return defaultConcurrency
+	case concurrency > maxConcurrency:
+		return maxConcurrency
+	default:
+		return concurrency
+	}
+}
+
+func getTargetStatus(state resource.ResourceState) string {
+	if state == resource.START {
+		return RUNNING
+	}
+
+	return STOPPED
+}
+
+func getGroupStatus(members []models.RGMemberResult) string {
+	var failed, attempted int
+
+	for i := range members {
+		switch members[i].Status {
+		case memberFailed:
+			failed++
+			attempted++
+		case memberSucceeded:
+			attempted++
+		}
+	}
+
+	switch {
+	case failed == 0:
+		return groupSucceeded
+	case failed == attempted:
+		return groupFailed
+	default:
+		return groupPartial
+	}
+}
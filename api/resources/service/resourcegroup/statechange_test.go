@@ -0,0 +1,225 @@
+package resourcegroup
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrHttp "gofr.dev/pkg/gofr/http"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+	"github.com/zopdev/zopdev/api/resources/service/resource"
+)
+
+func TestService_ChangeState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := NewMockRGStore(ctrl)
+	mockResSvc := NewMockResourceService(ctrl)
+	svc := New(mockStore, mockResSvc)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: context.Background(), Container: mockContainer}
+
+	rg := &models.ResourceGroup{ID: 1, CloudAccountID: 1}
+	sqlRes := &models.Resource{ID: 10, Name: "sql", Type: string(resource.SQL), Status: STOPPED}
+	vmRes := &models.Resource{ID: 11, Name: "vm", Type: string(resource.AWSCOMPUTE), Status: STOPPED}
+	runningRes := &models.Resource{ID: 12, Name: "rds", Type: string(resource.RDS), Status: RUNNING}
+
+	details := func(r *models.Resource, state resource.ResourceState) resource.ResourceDetails {
+		return resource.ResourceDetails{ID: r.ID, CloudAccID: 1, Name: r.Name, Type: resource.ResourceType(r.Type), State: state}
+	}
+
+	tests := []struct {
+		name        string
+		req         *models.RGStateChange
+		setup       func()
+		expected    *models.RGStateResult
+		expectedErr error
+	}{
+		{
+			name: "ordered start - databases before VMs",
+			req:  &models.RGStateChange{State: string(resource.START), Ordered: true},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{11, 10, 12}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(11)).Return(vmRes, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(sqlRes, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(12)).Return(runningRes, nil)
+				gomock.InOrder(
+					mockResSvc.EXPECT().ChangeState(ctx, details(sqlRes, resource.START)).Return(nil),
+					mockResSvc.EXPECT().ChangeState(ctx, details(vmRes, resource.START)).Return(nil),
+				)
+			},
+			expected: &models.RGStateResult{
+				ResourceGroupID: 1, State: string(resource.START), Status: groupSucceeded,
+				Members: []models.RGMemberResult{
+					{ResourceID: 10, Name: "sql", Type: "SQL", Status: memberSucceeded},
+					{ResourceID: 12, Name: "rds", Type: "RDS", Status: memberSkipped},
+					{ResourceID: 11, Name: "vm", Type: "EC2", Status: memberSucceeded},
+				},
+			},
+		},
+		{
+			name: "missing member is skipped",
+			req:  &models.RGStateChange{State: string(resource.START)},
+			setup: func() {
+				deletedAt := time.Now()
+
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{13}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(13)).
+					Return(&models.Resource{ID: 13, Name: "gone", Type: "SQL", Status: STOPPED, DeletedAt: &deletedAt}, nil)
+			},
+			expected: &models.RGStateResult{
+				ResourceGroupID: 1, State: string(resource.START), Status: groupSucceeded,
+				Members: []models.RGMemberResult{
+					{ResourceID: 13, Name: "gone", Type: "SQL", Status: memberSkipped, Error: "resource is missing on the cloud"},
+				},
+			},
+		},
+		{
+			name: "read-only member is skipped",
+			req:  &models.RGStateChange{State: string(resource.SUSPEND)},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{14}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(14)).
+					Return(&models.Resource{ID: 14, Name: "assets", Type: string(resource.S3BUCKET), Status: "AVAILABLE"}, nil)
+			},
+			expected: &models.RGStateResult{
+				ResourceGroupID: 1, State: string(resource.SUSPEND), Status: groupSucceeded,
+				Members: []models.RGMemberResult{
+					{ResourceID: 14, Name: "assets", Type: "S3_BUCKET", Status: memberSkipped,
+						Error: "state of the resource type can not be changed"},
+				},
+			},
+		},
+		{
+			name: "partial failure",
+			req:  &models.RGStateChange{State: string(resource.START), Concurrency: 1},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{10, 11}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(sqlRes, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(11)).Return(vmRes, nil)
+				mockResSvc.EXPECT().ChangeState(ctx, details(sqlRes, resource.START)).Return(assert.AnError)
+				mockResSvc.EXPECT().ChangeState(ctx, details(vmRes, resource.START)).Return(nil)
+			},
+			expected: &models.RGStateResult{
+				ResourceGroupID: 1, State: string(resource.START), Status: groupPartial,
+				Members: []models.RGMemberResult{
+					{ResourceID: 10, Name: "sql", Type: "SQL", Status: memberFailed, Error: assert.AnError.Error()},
+					{ResourceID: 11, Name: "vm", Type: "EC2", Status: memberSucceeded},
+				},
+			},
+		},
+		{
+			name: "all members fail",
+			req:  &models.RGStateChange{State: string(resource.SUSPEND)},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{12}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(12)).Return(runningRes, nil)
+				mockResSvc.EXPECT().ChangeState(ctx, details(runningRes, resource.SUSPEND)).Return(assert.AnError)
+			},
+			expected: &models.RGStateResult{
+				ResourceGroupID: 1, State: string(resource.SUSPEND), Status: groupFailed,
+				Members: []models.RGMemberResult{
+					{ResourceID: 12, Name: "rds", Type: "RDS", Status: memberFailed, Error: assert.AnError.Error()},
+				},
+			},
+		},
+		{
+			name:        "invalid state",
+			req:         &models.RGStateChange{State: "PAUSE"},
+			setup:       func() {},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"state"}},
+		},
+		{
+			name:        "invalid concurrency",
+			req:         &models.RGStateChange{State: string(resource.START), Concurrency: -1},
+			setup:       func() {},
+			expectedErr: gofrHttp.ErrorInvalidParam{Params: []string{"concurrency"}},
+		},
+		{
+			name: "resource group not found",
+			req:  &models.RGStateChange{State: string(resource.START)},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(nil, nil)
+			},
+			expectedErr: gofrHttp.ErrorEntityNotFound{Name: "resource group", Value: strconv.FormatInt(1, 10)},
+		},
+		{
+			name: "store error - get resource IDs",
+			req:  &models.RGStateChange{State: string(resource.START)},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return(nil, assert.AnError)
+			},
+			expectedErr: &errInternalServer{},
+		},
+		{
+			name: "member that can not be loaded fails",
+			req:  &models.RGStateChange{State: string(resource.START)},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).Return(rg, nil)
+				mockStore.EXPECT().GetResourceIDs(ctx, int64(1)).Return([]int64{10, 11}, nil)
+				mockResSvc.EXPECT().GetByID(ctx, int64(10)).Return(nil, assert.AnError)
+				mockResSvc.EXPECT().GetByID(ctx, int64(11)).Return(vmRes, nil)
+				mockResSvc.EXPECT().ChangeState(ctx, details(vmRes, resource.START)).Return(nil)
+			},
+			expected: &models.RGStateResult{
+				ResourceGroupID: 1, State: string(resource.START), Status: groupPartial,
+				Members: []models.RGMemberResult{
+					{ResourceID: 11, Name: "vm", Type: "EC2", Status: memberSucceeded},
+					{ResourceID: 10, Status: memberFailed, Error: assert.AnError.Error()},
+				},
+			},
+		},
+		{
+			name: "resource service error - get dynamic members",
+			req:  &models.RGStateChange{State: string(resource.START)},
+			setup: func() {
+				mockStore.EXPECT().GetResourceGroupByID(ctx, int64(1), int64(1)).
+					Return(&models.ResourceGroup{ID: 1, CloudAccountID: 1, LabelSelector: "env=dev"}, nil)
+				mockResSvc.EXPECT().GetAll(ctx, int64(1), gomock.Any()).Return(nil, assert.AnError)
+			},
+			expectedErr: &errInternalServer{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			got, err := svc.ChangeState(ctx, 1, 1, tc.req)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_getStages(t *testing.T) {
+	sql := models.Resource{ID: 1, Type: string(resource.SQL)}
+	vm := models.Resource{ID: 2, Type: string(resource.AWSCOMPUTE)}
+	other := models.Resource{ID: 3, Type: "UNKNOWN"}
+	members := []models.Resource{other, vm, sql}
+
+	assert.Nil(t, getStages(nil, resource.START, true))
+	assert.Equal(t, [][]models.Resource{members}, getStages(members, resource.START, false))
+	assert.Equal(t, [][]models.Resource{{sql}, {vm}, {other}}, getStages(members, resource.START, true))
+	assert.Equal(t, [][]models.Resource{{other}, {vm}, {sql}}, getStages(members, resource.SUSPEND, true))
+}
+
+func Test_getConcurrency(t *testing.T) {
+	assert.Equal(t, defaultConcurrency, getConcurrency(0))
+	assert.Equal(t, 3, getConcurrency(3))
+	assert.Equal(t, maxConcurrency, getConcurrency(maxConcurrency+1))
+}
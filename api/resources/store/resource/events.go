@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"database/sql"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// InsertEvent records a change in the inventory history.
+func (*Store) InsertEvent(ctx *gofr.Context, event *models.ResourceEvent) error {
+	_, err := ctx.SQL.ExecContext(ctx,
+		`INSERT INTO resource_events (cloud_account_id, resource_id, resource_uid, resource_name, resource_type, 
+event_type, source, actor, old_value, new_value) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.CloudAccountID, event.ResourceID, event.ResourceUID, event.ResourceName, event.ResourceType,
+		event.EventType, event.Source, event.Actor, toNullString(event.OldValue), toNullString(event.NewValue))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetEvents fetches the inventory history of a cloud account matching the given filter, the latest events come first.
+func (*Store) GetEvents(ctx *gofr.Context, cloudAccountID int64, filter *models.EventFilter) ([]models.ResourceEvent, error) {
+	var (
+		where = ` WHERE cloud_account_id = ?`
+		args  = []any{cloudAccountID}
+	)
+
+	if filter.ResourceID != 0 {
+		where += ` AND resource_id = ?`
+
+		args = append(args, filter.ResourceID)
+	}
+
+	if len(filter.EventTypes) > 0 {
+		where += ` AND event_type IN (`
+
+		for _, t := range filter.EventTypes {
+			where += `?, `
+
+			args = append(args, t)
+		}
+
+		where = where[:len(where)-2] + `)`
+	}
+
+	if !filter.Since.IsZero() {
+		// created_at is stored in UTC using the SQL timestamp format, hence the time is formatted the same way to compare them.
+		where += ` AND created_at >= ?`
+
+		args = append(args, filter.Since.UTC().Format(time.DateTime))
+	}
+
+	args = append(args, filter.Limit)
+
+	rows, err := ctx.SQL.QueryContext(ctx, `SELECT id, cloud_account_id, resource_id, resource_uid, resource_name, 
+       resource_type, event_type, source, actor, old_value, new_value, created_at
+		FROM resource_events`+where+` ORDER BY created_at DESC, id DESC LIMIT ?`, args...)
+	if err != nil || rows.Err() != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// GetStatusHistory fetches the events of a cloud account that change the status of its resources or whether they
+// exist, up to the given time. The oldest events come first so that the status of the resources can be replayed.
+func (*Store) GetStatusHistory(ctx *gofr.Context, cloudAccountID int64, until time.Time) ([]models.ResourceEvent, error) {
+	rows, err := ctx.SQL.QueryContext(ctx, `SELECT id, cloud_account_id, resource_id, resource_uid, resource_name, 
+       resource_type, event_type, source, actor, old_value, new_value, created_at
+		FROM resource_events WHERE cloud_account_id = ? AND event_type IN (?, ?, ?, ?) AND created_at < ?
+		ORDER BY created_at, id`, cloudAccountID, models.EventCreated, models.EventStatusChanged, models.EventDeleted,
+		models.EventRestored, until.UTC().Format(time.DateTime))
+	if err != nil || rows.Err() != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func scanEvents(rows *sql.Rows) ([]models.ResourceEvent, error) {
+	var events []models.ResourceEvent
+
+	for rows.Next() {
+		var (
+			event          models.ResourceEvent
+			oldVal, newVal sql.NullString
+		)
+
+		if er := rows.Scan(&event.ID, &event.CloudAccountID, &event.ResourceID, &event.ResourceUID, &event.ResourceName,
+			&event.ResourceType, &event.EventType, &event.Source, &event.Actor, &oldVal, &newVal, &event.CreatedAt); er != nil {
+			return nil, er
+		}
+
+		if oldVal.Valid {
+			event.OldValue = []byte(oldVal.String)
+		}
+
+		if newVal.Valid {
+			event.NewValue = []byte(newVal.String)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func toNullString(b []byte) sql.NullString {
+	return sql.NullString{String: string(b), Valid: len(b) > 0}
+}
@@ -0,0 +1,178 @@
+package resource
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestStore_InsertEvent(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	event := &models.ResourceEvent{CloudAccountID: 1, ResourceID: 2, ResourceUID: "zopdev/sql-instance-1",
+		ResourceName: "sql-instance-1", ResourceType: "SQL", EventType: models.EventStatusChanged,
+		Source: models.EventSourceAPI, Actor: "alice@example.com", OldValue: json.RawMessage(`"RUNNING"`),
+		NewValue: json.RawMessage(`"STOPPED"`)}
+	query := `INSERT INTO resource_events (cloud_account_id, resource_id, resource_uid, resource_name, resource_type, 
+event_type, source, actor, old_value, new_value) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	testCases := []struct {
+		name      string
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name: "Successful Insert",
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(query).
+					WithArgs(int64(1), int64(2), "zopdev/sql-instance-1", "sql-instance-1", "SQL", models.EventStatusChanged,
+						models.EventSourceAPI, "alice@example.com", sql.NullString{String: `"RUNNING"`, Valid: true},
+						sql.NullString{String: `"STOPPED"`, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:   "Insert Error",
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(query).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			err := store.InsertEvent(ctx, event)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestStore_GetEvents(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	mockTime := time.Date(2025, 6, 12, 10, 30, 0, 0, time.UTC)
+	columns := []string{"id", "cloud_account_id", "resource_id", "resource_uid", "resource_name", "resource_type",
+		"event_type", "source", "actor", "old_value", "new_value", "created_at"}
+	selectQuery := `SELECT id, cloud_account_id, resource_id, resource_uid, resource_name, 
+       resource_type, event_type, source, actor, old_value, new_value, created_at
+		FROM resource_events`
+
+	testCases := []struct {
+		name      string
+		filter    *models.EventFilter
+		expErr    error
+		expResp   []models.ResourceEvent
+		mockCalls func()
+	}{
+		{
+			name:   "Fetch Account Events",
+			filter: &models.EventFilter{Limit: 10},
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectQuery(selectQuery+` WHERE cloud_account_id = ? ORDER BY created_at DESC, id DESC LIMIT ?`).
+					WithArgs(1, 10).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, 1, 3, "zopdev/sql-instance-1", "sql-instance-1", "SQL", models.EventDeleted,
+							models.EventSourceSync, "", `"RUNNING"`, nil, mockTime).
+						AddRow(1, 1, 3, "zopdev/sql-instance-1", "sql-instance-1", "SQL", models.EventCreated,
+							models.EventSourceSync, "", nil, `"RUNNING"`, mockTime))
+			},
+			expResp: []models.ResourceEvent{
+				{ID: 2, CloudAccountID: 1, ResourceID: 3, ResourceUID: "zopdev/sql-instance-1", ResourceName: "sql-instance-1",
+					ResourceType: "SQL", EventType: models.EventDeleted, Source: models.EventSourceSync,
+					OldValue: json.RawMessage(`"RUNNING"`), CreatedAt: mockTime},
+				{ID: 1, CloudAccountID: 1, ResourceID: 3, ResourceUID: "zopdev/sql-instance-1", ResourceName: "sql-instance-1",
+					ResourceType: "SQL", EventType: models.EventCreated, Source: models.EventSourceSync,
+					NewValue: json.RawMessage(`"RUNNING"`), CreatedAt: mockTime},
+			},
+		},
+		{
+			name: "Fetch Resource Events With Filters",
+			filter: &models.EventFilter{ResourceID: 3, EventTypes: []string{models.EventCreated, models.EventDeleted},
+				Since: mockTime, Limit: 10},
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectQuery(selectQuery+` WHERE cloud_account_id = ? AND resource_id = ? AND event_type IN (?, ?) 
+AND created_at >= ? ORDER BY created_at DESC, id DESC LIMIT ?`).
+					WithArgs(1, 3, models.EventCreated, models.EventDeleted, "2025-06-12 10:30:00", 10).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name:   "Query Error",
+			filter: &models.EventFilter{Limit: 10},
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectQuery(selectQuery+` WHERE cloud_account_id = ? ORDER BY created_at DESC, id DESC LIMIT ?`).
+					WithArgs(1, 10).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			events, err := store.GetEvents(ctx, 1, tc.filter)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expResp, events)
+		})
+	}
+}
+
+func TestStore_GetStatusHistory(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	mockTime := time.Date(2025, 6, 12, 10, 30, 0, 0, time.UTC)
+	until := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "cloud_account_id", "resource_id", "resource_uid", "resource_name", "resource_type",
+		"event_type", "source", "actor", "old_value", "new_value", "created_at"}
+	query := `SELECT id, cloud_account_id, resource_id, resource_uid, resource_name, 
+       resource_type, event_type, source, actor, old_value, new_value, created_at
+		FROM resource_events WHERE cloud_account_id = ? AND event_type IN (?, ?, ?, ?) AND created_at < ?
+		ORDER BY created_at, id`
+
+	mocks.SQL.Sqlmock.ExpectQuery(query).
+		WithArgs(int64(1), models.EventCreated, models.EventStatusChanged, models.EventDeleted, models.EventRestored,
+			"2025-07-01 00:00:00").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 1, 2, "i-1", "web", "EC2", models.EventCreated, models.EventSourceSync, "", nil, `"RUNNING"`, mockTime).
+			AddRow(2, 1, 2, "i-1", "web", "EC2", models.EventStatusChanged, models.EventSourceAPI, "alice@example.com",
+				`"RUNNING"`, `"STOPPED"`, mockTime.Add(time.Hour)))
+
+	events, err := store.GetStatusHistory(ctx, 1, until)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ResourceEvent{
+		{ID: 1, CloudAccountID: 1, ResourceID: 2, ResourceUID: "i-1", ResourceName: "web", ResourceType: "EC2",
+			EventType: models.EventCreated, Source: models.EventSourceSync, NewValue: json.RawMessage(`"RUNNING"`),
+			CreatedAt: mockTime},
+		{ID: 2, CloudAccountID: 1, ResourceID: 2, ResourceUID: "i-1", ResourceName: "web", ResourceType: "EC2",
+			EventType: models.EventStatusChanged, Source: models.EventSourceAPI, Actor: "alice@example.com",
+			OldValue: json.RawMessage(`"RUNNING"`), NewValue: json.RawMessage(`"STOPPED"`), CreatedAt: mockTime.Add(time.Hour)},
+	}, events)
+
+	mocks.SQL.Sqlmock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+	_, err = store.GetStatusHistory(ctx, 1, until)
+
+	assert.Equal(t, assert.AnError, err)
+}
@@ -0,0 +1,190 @@
+package resource
+
+import (
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// ListResources fetches a page of the sorted resources of the given cloud accounts that match the filter, along with
+// the number of matching resources per type, status and region. The deleted resources are not returned.
+// models.ErrInvalidCursor is returned when the cursor of the page was not issued for its sort order.
+func (*Store) ListResources(ctx *gofr.Context, cloudAccountIDs []int64, filter *models.ResourceFilter,
+	page *models.ResourcePage) (*models.ResourceList, error) {
+	p := page.WithDefaults()
+
+	key, id, after, err := p.After()
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.ResourceList{
+		Resources: []models.Resource{},
+		Facets: models.ResourceFacets{Types: map[string]int{}, Statuses: map[string]int{},
+			Regions: map[string]int{}},
+	}
+
+	if len(cloudAccountIDs) == 0 {
+		return list, nil
+	}
+
+	where, args := filterClause(cloudAccountIDs, filter)
+
+	if err = getFacets(ctx, list, where, args); err != nil {
+		return nil, err
+	}
+
+	column, direction, compare := sortColumn(p.SortBy), `ASC`, `>`
+	if p.Order == models.SortDesc {
+		direction, compare = `DESC`, `<`
+	}
+
+	if after {
+		// The page starts at the first resource after the cursor, the resource of the cursor may have been removed.
+		where += ` AND (` + column + ` ` + compare + ` ? OR (` + column + ` = ? AND id ` + compare + ` ?))`
+
+		args = append(args, key, key, id)
+	}
+
+	// One more resource than the limit is fetched to know whether there is a next page.
+	args = append(args, p.Limit+1)
+
+	res, err := queryResources(ctx, where+` ORDER BY `+column+` `+direction+`, id `+direction+` LIMIT ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) > p.Limit {
+		res = res[:p.Limit]
+		last := &res[p.Limit-1]
+		list.NextCursor = p.NextCursor(sortKey(last, p.SortBy), last.ID)
+	}
+
+	list.Resources = append(list.Resources, res...)
+
+	return list, nil
+}
+
+func getFacets(ctx *gofr.Context, list *models.ResourceList, where string, args []any) error {
+	rows, err := ctx.SQL.QueryContext(ctx, `SELECT resource_type, state, region, COUNT(*) FROM resources WHERE `+where+
+		` GROUP BY resource_type, state, region`, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			resType, status, region string
+			count                   int
+		)
+
+		if er := rows.Scan(&resType, &status, &region, &count); er != nil {
+			return er
+		}
+
+		list.Total += count
+		list.Facets.Types[resType] += count
+		list.Facets.Statuses[status] += count
+		list.Facets.Regions[region] += count
+	}
+
+	return rows.Err()
+}
+
+// filterClause returns the conditions selecting the resources of the cloud accounts that match the filter, along with
+// their arguments. The statuses, regions and providers match case-insensitively, as do the name and the query.
+func filterClause(cloudAccountIDs []int64, filter *models.ResourceFilter) (string, []any) {
+	where := `deleted_at IS NULL AND cloud_account_id IN (` + strings.TrimSuffix(strings.Repeat(`?, `, len(cloudAccountIDs)), `, `) + `)`
+	args := make([]any, 0, len(cloudAccountIDs))
+
+	for _, id := range cloudAccountIDs {
+		args = append(args, id)
+	}
+
+	for _, in := range []struct {
+		column string
+		values []string
+	}{
+		{`resource_type`, filter.ResourceTypes},
+		{`state COLLATE NOCASE`, filter.Statuses},
+		{`region COLLATE NOCASE`, filter.Regions},
+		{`cloud_provider COLLATE NOCASE`, filter.Providers},
+	} {
+		clause, inArgs := inClause(in.column, in.values)
+		where += clause
+		args = append(args, inArgs...)
+	}
+
+	if filter.Name != "" {
+		where += ` AND INSTR(LOWER(name), ?) > 0`
+
+		args = append(args, strings.ToLower(filter.Name))
+	}
+
+	if filter.Query != "" {
+		query := strings.ToLower(filter.Query)
+		where += ` AND (INSTR(LOWER(name), ?) > 0 OR INSTR(LOWER(resource_uid), ?) > 0 OR EXISTS (SELECT 1 FROM ` +
+			`json_each(resources.labels) WHERE INSTR(LOWER(key), ?) > 0 OR INSTR(LOWER(value), ?) > 0))`
+
+		args = append(args, query, query, query, query)
+	}
+
+	for _, req := range filter.Labels {
+		clause, reqArgs := labelClause(req)
+		where += clause
+		args = append(args, reqArgs...)
+	}
+
+	return where, args
+}
+
+// labelClause returns the condition of a requirement of a label selector, the labels are stored as a JSON object.
+func labelClause(req models.LabelRequirement) (string, []any) {
+	const label = `EXISTS (SELECT 1 FROM json_each(resources.labels) WHERE key = ?`
+
+	switch req.Operator {
+	case models.SelectorEquals:
+		return ` AND ` + label + ` AND value = ?)`, []any{req.Key, req.Value}
+	case models.SelectorNotEquals:
+		return ` AND NOT ` + label + ` AND value = ?)`, []any{req.Key, req.Value}
+	case models.SelectorNotExists:
+		return ` AND NOT ` + label + `)`, []any{req.Key}
+	default:
+		return ` AND ` + label + `)`, []any{req.Key}
+	}
+}
+
+// sortColumn returns the column the resources are sorted by, the names are sorted case-insensitively. updated_at is
+// set by every update of a resource.
+func sortColumn(sortBy string) string {
+	switch sortBy {
+	case models.SortByRegion:
+		return `region`
+	case models.SortByStatus:
+		return `state`
+	case models.SortByUpdatedAt:
+		return `updated_at`
+	default:
+		return `name COLLATE NOCASE`
+	}
+}
+
+// sortKey returns the value of the sort column of a resource, the cursors hold it to start the next page after it.
+func sortKey(res *models.Resource, sortBy string) string {
+	switch sortBy {
+	case models.SortByRegion:
+		return res.Region
+	case models.SortByStatus:
+		return res.Status
+	case models.SortByUpdatedAt:
+		// updated_at is stored in UTC using the SQL timestamp format, hence the time is formatted the same way to compare them.
+		return res.UpdatedAt.UTC().Format(time.DateTime)
+	default:
+		return res.Name
+	}
+}
@@ -0,0 +1,131 @@
+package resource
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestStore_ListResources(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+	updatedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "resource_uid", "name", "state", "cloud_account_id", "cloud_provider", "resource_type",
+		"created_at", "updated_at", "settings", "region", "labels", "project", "deleted_at"}
+	selectQuery := `SELECT id, resource_uid, name, state, cloud_account_id,
+       cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
+		FROM resources WHERE `
+	where := `deleted_at IS NULL AND cloud_account_id IN (?, ?) AND resource_type IN (?) AND state COLLATE NOCASE IN (?) ` +
+		`AND INSTR(LOWER(name), ?) > 0 AND EXISTS (SELECT 1 FROM json_each(resources.labels) WHERE key = ? AND value = ?) ` +
+		`AND NOT EXISTS (SELECT 1 FROM json_each(resources.labels) WHERE key = ?)`
+	whereArgs := []driver.Value{1, 2, "EC2", "running", "web", "env", "prod", "temp"}
+	filter := &models.ResourceFilter{ResourceTypes: []string{"EC2"}, Statuses: []string{"running"}, Name: "Web",
+		Labels: models.LabelSelector{
+			{Key: "env", Operator: models.SelectorEquals, Value: "prod"},
+			{Key: "temp", Operator: models.SelectorNotExists},
+		}}
+	page := &models.ResourcePage{SortBy: models.SortByUpdatedAt, Order: models.SortDesc, Limit: 1}
+
+	mocks.SQL.Sqlmock.ExpectQuery(`SELECT resource_type, state, region, COUNT(*) FROM resources WHERE ` + where +
+		` GROUP BY resource_type, state, region`).WithArgs(whereArgs...).
+		WillReturnRows(sqlmock.NewRows([]string{"resource_type", "state", "region", "count"}).
+			AddRow("EC2", "RUNNING", "us-east-1", 2).
+			AddRow("EC2", "RUNNING", "eu-west-1", 1))
+	mocks.SQL.Sqlmock.ExpectQuery(selectQuery + where + ` ORDER BY updated_at DESC, id DESC LIMIT ?`).
+		WithArgs(append(whereArgs, 2)...).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(3, "i-3", "web-3", "RUNNING", 2, "AWS", "EC2", updatedAt, updatedAt, nil, "us-east-1", nil, "", nil).
+			AddRow(1, "i-1", "web-1", "RUNNING", 1, "AWS", "EC2", updatedAt, updatedAt, nil, "eu-west-1", nil, "", nil))
+
+	list, err := store.ListResources(ctx, []int64{1, 2}, filter, page)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.Resource{{ID: 3, UID: "i-3", Name: "web-3", Status: "RUNNING",
+		CloudAccount: models.CloudAccount{ID: 2, Type: "AWS"}, Type: "EC2", CreatedAt: updatedAt, UpdatedAt: updatedAt,
+		Region: "us-east-1"}}, list.Resources)
+	assert.Equal(t, 3, list.Total)
+	assert.Equal(t, models.ResourceFacets{
+		Types:    map[string]int{"EC2": 3},
+		Statuses: map[string]int{"RUNNING": 3},
+		Regions:  map[string]int{"us-east-1": 2, "eu-west-1": 1},
+	}, list.Facets)
+	require.NotEmpty(t, list.NextCursor)
+
+	// The next page starts after the last resource of the previous one.
+	page.Cursor = list.NextCursor
+
+	mocks.SQL.Sqlmock.ExpectQuery(`SELECT resource_type, state, region, COUNT(*) FROM resources WHERE ` + where +
+		` GROUP BY resource_type, state, region`).WithArgs(whereArgs...).
+		WillReturnRows(sqlmock.NewRows([]string{"resource_type", "state", "region", "count"}).
+			AddRow("EC2", "RUNNING", "us-east-1", 2))
+	mocks.SQL.Sqlmock.ExpectQuery(selectQuery + where + ` AND (updated_at < ? OR (updated_at = ? AND id < ?))` +
+		` ORDER BY updated_at DESC, id DESC LIMIT ?`).
+		WithArgs(append(whereArgs, "2025-01-01 10:00:00", "2025-01-01 10:00:00", 3, 2)...).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "i-1", "web-1", "RUNNING", 1, "AWS", "EC2", updatedAt, updatedAt, nil, "eu-west-1", nil, "", nil))
+
+	list, err = store.ListResources(ctx, []int64{1, 2}, filter, page)
+
+	require.NoError(t, err)
+	assert.Len(t, list.Resources, 1)
+	assert.Empty(t, list.NextCursor)
+
+	// The cursor of a page sorted by updated_at can not be used with another sort.
+	_, err = store.ListResources(ctx, []int64{1, 2}, filter, &models.ResourcePage{Cursor: page.Cursor})
+
+	assert.Equal(t, models.ErrInvalidCursor, err)
+
+	require.NoError(t, mocks.SQL.Sqlmock.ExpectationsWereMet())
+}
+
+func TestStore_ListResources_Query(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+	where := `deleted_at IS NULL AND cloud_account_id IN (?) AND region COLLATE NOCASE IN (?, ?) ` +
+		`AND cloud_provider COLLATE NOCASE IN (?) AND (INSTR(LOWER(name), ?) > 0 OR INSTR(LOWER(resource_uid), ?) > 0 ` +
+		`OR EXISTS (SELECT 1 FROM json_each(resources.labels) WHERE INSTR(LOWER(key), ?) > 0 OR INSTR(LOWER(value), ?) > 0))`
+	filter := &models.ResourceFilter{Regions: []string{"us-east-1", "eu-west-1"}, Providers: []string{"aws"}, Query: "API"}
+
+	mocks.SQL.Sqlmock.ExpectQuery(`SELECT resource_type, state, region, COUNT(*) FROM resources WHERE `+where+
+		` GROUP BY resource_type, state, region`).
+		WithArgs(1, "us-east-1", "eu-west-1", "aws", "api", "api", "api", "api").
+		WillReturnRows(sqlmock.NewRows([]string{"resource_type", "state", "region", "count"}))
+	mocks.SQL.Sqlmock.ExpectQuery(`SELECT id, resource_uid, name, state, cloud_account_id,
+       cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
+		FROM resources WHERE `+where+` ORDER BY name COLLATE NOCASE ASC, id ASC LIMIT ?`).
+		WithArgs(1, "us-east-1", "eu-west-1", "aws", "api", "api", "api", "api", models.DefaultPageSize+1).
+		WillReturnError(assert.AnError)
+
+	_, err := store.ListResources(ctx, []int64{1}, filter, &models.ResourcePage{})
+
+	assert.Equal(t, assert.AnError, err)
+
+	mocks.SQL.Sqlmock.ExpectQuery(`SELECT resource_type, state, region, COUNT(*) FROM resources WHERE `+where+
+		` GROUP BY resource_type, state, region`).
+		WithArgs(1, "us-east-1", "eu-west-1", "aws", "api", "api", "api", "api").
+		WillReturnError(assert.AnError)
+
+	_, err = store.ListResources(ctx, []int64{1}, filter, &models.ResourcePage{})
+
+	assert.Equal(t, assert.AnError, err)
+
+	// No resources are listed without cloud accounts.
+	list, err := store.ListResources(ctx, nil, filter, &models.ResourcePage{})
+
+	require.NoError(t, err)
+	assert.Empty(t, list.Resources)
+	assert.Zero(t, list.Total)
+
+	require.NoError(t, mocks.SQL.Sqlmock.ExpectationsWereMet())
+}
@@ -1,6 +1,9 @@
 package resource
 
 import (
+	"database/sql"
+	"time"
+
 	"gofr.dev/pkg/gofr"
 
 	"github.com/zopdev/zopdev/api/resources/models"
@@ -15,10 +18,16 @@ func New() *Store { return &Store{} }
 
 // InsertResource inserts a new resource into the database.
 func (*Store) InsertResource(ctx *gofr.Context, res *models.Resource) error {
-	_, err := ctx.SQL.ExecContext(ctx,
+	result, err := ctx.SQL.ExecContext(ctx,
 		`INSERT INTO resources (resource_uid, name, state, cloud_account_id, cloud_provider, resource_type, 
-settings, region) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		res.UID, res.Name, res.Status, res.CloudAccount.ID, res.CloudAccount.Type, res.Type, res.Settings, res.Region)
+settings, region, labels, project) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		res.UID, res.Name, res.Status, res.CloudAccount.ID, res.CloudAccount.Type, res.Type, res.Settings, res.Region, res.Labels,
+		res.Project)
+	if err != nil {
+		return err
+	}
+
+	res.ID, err = result.LastInsertId()
 	if err != nil {
 		return err
 	}
@@ -27,11 +36,15 @@ settings, region) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 }
 
 // GetResourceByID fetches a resource by its unique identifier from the database.
+// Deleted resources are returned as well, so that they can be shown as missing wherever they are referenced.
 func (*Store) GetResourceByID(ctx *gofr.Context, id int64) (*models.Resource, error) {
-	var res models.Resource
+	var (
+		res       models.Resource
+		deletedAt sql.NullTime
+	)
 
 	row := ctx.SQL.QueryRowContext(ctx, `SELECT id, resource_uid, name, state, cloud_account_id, 
-	   cloud_provider, resource_type, created_at, updated_at, settings, region
+	   cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
 		FROM resources WHERE id = ?`, id)
 
 	if row.Err() != nil {
@@ -40,44 +53,78 @@ func (*Store) GetResourceByID(ctx *gofr.Context, id int64) (*models.Resource, er
 
 	if err := row.Scan(&res.ID, &res.UID, &res.Name, &res.Status,
 		&res.CloudAccount.ID, &res.CloudAccount.Type, &res.Type,
-		&res.CreatedAt, &res.UpdatedAt, &res.Settings, &res.Region); err != nil {
+		&res.CreatedAt, &res.UpdatedAt, &res.Settings, &res.Region, &res.Labels, &res.Project, &deletedAt); err != nil {
 		return nil, err
 	}
 
+	if deletedAt.Valid {
+		res.DeletedAt = &deletedAt.Time
+	}
+
 	return &res, nil
 }
 
-// GetResources fetches resources for a given cloud account ID.
+// GetResources fetches resources for a given cloud account ID, the deleted resources are not returned.
 // IMP: The returned result is sorted by resource UID. This is to ensure that the resources are returned in a consistent order.
-// The service layer can use this to compare the resources fetched from the cloud provider with the resources stored in the database.
 func (*Store) GetResources(ctx *gofr.Context, cloudAccountID int64, resourceType []string) ([]models.Resource, error) {
-	var (
-		resources []models.Resource
-		args      = make([]any, 0, maxResTypes)
-	)
+	return getResources(ctx, cloudAccountID, resourceType, false)
+}
 
+// GetResourcesIncludingDeleted fetches all the resources for a given cloud account ID along with the deleted ones.
+// IMP: The returned result is sorted by resource UID. The service layer can use this to compare the resources fetched
+// from the cloud provider with the resources stored in the database, and restore the deleted resources that reappear.
+func (*Store) GetResourcesIncludingDeleted(ctx *gofr.Context, cloudAccountID int64) ([]models.Resource, error) {
+	return getResources(ctx, cloudAccountID, nil, true)
+}
+
+func getResources(ctx *gofr.Context, cloudAccountID int64, resourceType []string, includeDeleted bool) ([]models.Resource, error) {
 	// Form the IN clause, otherwise we fetch all resources for the given cloud account ID.
-	inClause := ``
+	inClause, typeArgs := typesInClause(resourceType)
 
+	args := make([]any, 0, maxResTypes)
 	args = append(args, cloudAccountID)
+	args = append(args, typeArgs...)
 
-	if len(resourceType) > 0 {
-		inClause = ` AND resource_type IN (`
+	if !includeDeleted {
+		inClause += ` AND deleted_at IS NULL`
+	}
 
-		for _, res := range resourceType {
-			inClause += `?, `
+	return queryResources(ctx, `cloud_account_id = ?`+inClause+` ORDER BY resource_uid`, args...)
+}
 
-			args = append(args, res)
-		}
+// typesInClause returns the clause limiting the resources to the given types, if any, along with its arguments.
+func typesInClause(resourceType []string) (string, []any) {
+	return inClause(`resource_type`, resourceType)
+}
 
-		inClause = inClause[:len(inClause)-2] // Remove the last comma
+// inClause returns the clause limiting the column to the given values, if any, along with its arguments.
+func inClause(column string, values []string) (string, []any) {
+	if len(values) == 0 {
+		return ``, nil
+	}
 
-		inClause += `)`
+	args := make([]any, 0, len(values))
+	inClause := ` AND ` + column + ` IN (`
+
+	for _, v := range values {
+		inClause += `?, `
+
+		args = append(args, v)
 	}
 
+	inClause = inClause[:len(inClause)-2] // Remove the last comma
+
+	inClause += `)`
+
+	return inClause, args
+}
+
+func queryResources(ctx *gofr.Context, where string, args ...any) ([]models.Resource, error) {
+	var resources []models.Resource
+
 	rows, err := ctx.SQL.QueryContext(ctx, `SELECT id, resource_uid, name, state, cloud_account_id, 
-       cloud_provider, resource_type, created_at, updated_at, settings, region
-		FROM resources WHERE cloud_account_id = ?`+inClause+` ORDER BY resource_uid`, args...)
+       cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
+		FROM resources WHERE `+where, args...)
 	if err != nil || rows.Err() != nil {
 		return nil, err
 	}
@@ -85,13 +132,21 @@ func (*Store) GetResources(ctx *gofr.Context, cloudAccountID int64, resourceType
 	defer rows.Close()
 
 	for rows.Next() {
-		var res models.Resource
+		var (
+			res       models.Resource
+			deletedAt sql.NullTime
+		)
+
 		if er := rows.Scan(&res.ID, &res.UID, &res.Name, &res.Status,
 			&res.CloudAccount.ID, &res.CloudAccount.Type, &res.Type,
-			&res.CreatedAt, &res.UpdatedAt, &res.Settings, &res.Region); er != nil {
+			&res.CreatedAt, &res.UpdatedAt, &res.Settings, &res.Region, &res.Labels, &res.Project, &deletedAt); er != nil {
 			return nil, er
 		}
 
+		if deletedAt.Valid {
+			res.DeletedAt = &deletedAt.Time
+		}
+
 		resources = append(resources, res)
 	}
 
@@ -99,9 +154,10 @@ func (*Store) GetResources(ctx *gofr.Context, cloudAccountID int64, resourceType
 }
 
 // UpdateStatus updates the state of a resource in the database by its ID with the provided status.
-// It returns an error if the update operation fails.
+// It returns an error if the update operation fails. As every update of a resource, it sets its updated_at to the
+// current time, in UTC.
 func (*Store) UpdateStatus(ctx *gofr.Context, status string, id int64) error {
-	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET state = ? WHERE id = ?`,
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET state = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
 		status, id)
 	if err != nil {
 		return err
@@ -110,9 +166,54 @@ func (*Store) UpdateStatus(ctx *gofr.Context, status string, id int64) error {
 	return nil
 }
 
-// RemoveResource deletes a resource by its ID from the database and returns an error if the operation fails.
+// UpdateLabels replaces the labels of a resource in the database by its ID.
+func (*Store) UpdateLabels(ctx *gofr.Context, labels models.Labels, id int64) error {
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET labels = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		labels, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSettings replaces the settings of a resource in the database by its ID.
+func (*Store) UpdateSettings(ctx *gofr.Context, settings models.Settings, id int64) error {
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET settings = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		settings, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateProject sets the project of a resource in the database by its ID.
+func (*Store) UpdateProject(ctx *gofr.Context, project string, id int64) error {
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET project = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		project, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveResource marks a resource as deleted by its ID. The row is kept so that the group memberships
+// and the history of the resource are not lost. It returns an error if the operation fails.
 func (*Store) RemoveResource(ctx *gofr.Context, id int64) error {
-	_, err := ctx.SQL.ExecContext(ctx, `DELETE FROM resources WHERE id = ?`, id)
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET deleted_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RestoreResource clears the deleted mark of a resource by its ID.
+func (*Store) RestoreResource(ctx *gofr.Context, id int64) error {
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resources SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, id)
 	if err != nil {
 		return err
 	}
@@ -32,6 +32,7 @@ func TestStore_InsertResource(t *testing.T) {
 		},
 		Type:   "SQL",
 		Region: "us-central1",
+		Labels: models.Labels{"env": "staging"},
 	}
 	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
 	store := New()
@@ -48,9 +49,10 @@ func TestStore_InsertResource(t *testing.T) {
 			mockCalls: func() {
 				mocks.SQL.Sqlmock.ExpectExec(
 					`INSERT INTO resources (resource_uid, name, state, cloud_account_id, cloud_provider, resource_type, 
-settings, region) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
+settings, region, labels, project) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 					WithArgs(mockInput.UID, mockInput.Name, mockInput.Status, mockInput.CloudAccount.ID,
-						mockInput.CloudAccount.Type, mockInput.Type, mockInput.Settings, mockInput.Region).
+						mockInput.CloudAccount.Type, mockInput.Type, mockInput.Settings, mockInput.Region, mockInput.Labels,
+						mockInput.Project).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -61,9 +63,10 @@ settings, region) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
 			mockCalls: func() {
 				mocks.SQL.Sqlmock.ExpectExec(
 					`INSERT INTO resources (resource_uid, name, state, cloud_account_id, cloud_provider, resource_type,  
-settings, region) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
+settings, region, labels, project) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 					WithArgs(mockInput.UID, mockInput.Name, mockInput.Status, mockInput.CloudAccount.ID,
-						mockInput.CloudAccount.Type, mockInput.Type, mockInput.Settings, mockInput.Region).
+						mockInput.CloudAccount.Type, mockInput.Type, mockInput.Settings, mockInput.Region, mockInput.Labels,
+						mockInput.Project).
 					WillReturnError(assert.AnError)
 			},
 		},
@@ -87,9 +90,10 @@ func TestStore_GetResources(t *testing.T) {
 	mockContainer, mocks := container.NewMockContainer(t)
 	mockTime := time.Now()
 	settings := models.Settings{"region": "us-central1", "zone": "us-central1-a"}
+	labels := models.Labels{"env": "staging"}
 	query := `SELECT id, resource_uid, name, state, cloud_account_id, 
-       cloud_provider, resource_type, created_at, updated_at, settings, region
-		FROM resources WHERE cloud_account_id = ? AND resource_type IN (?, ?) ORDER BY resource_uid`
+       cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
+		FROM resources WHERE cloud_account_id = ? AND resource_type IN (?, ?) AND deleted_at IS NULL ORDER BY resource_uid`
 	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
 	store := New()
 
@@ -108,19 +112,20 @@ func TestStore_GetResources(t *testing.T) {
 			mockCalls: func() {
 				mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(123, "SQL", "VM").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "resource_uid", "name", "state",
-						"cloud_account_id", "cloud_provider", "resource_type", "created_at", "updated_at", "settings", "region"}).
+						"cloud_account_id", "cloud_provider", "resource_type", "created_at", "updated_at", "settings", "region",
+						"labels", "project", "deleted_at"}).
 						AddRow(1, "zopdev/sql-instance-1", "sql-instance-1", "RUNNING", 123, "GCP",
-							"SQL", mockTime, mockTime, &settings, "us-central1").
+							"SQL", mockTime, mockTime, &settings, "us-central1", &labels, "", nil).
 						AddRow(2, "zopdev/vm-instance-1", "vm-instance-1", "STOPPED", 123, "GCP",
-							"VM", mockTime, mockTime, &settings, "us-central1"))
+							"VM", mockTime, mockTime, &settings, "us-central1", &labels, "", nil))
 			},
 			expResp: []models.Resource{
 				{ID: 1, UID: "zopdev/sql-instance-1", CloudAccount: models.CloudAccount{ID: 123, Type: "GCP"},
 					Type: "SQL", Name: "sql-instance-1", Status: "RUNNING", CreatedAt: mockTime, UpdatedAt: mockTime,
-					Settings: settings, Region: "us-central1"},
+					Settings: settings, Region: "us-central1", Labels: labels},
 				{ID: 2, UID: "zopdev/vm-instance-1", CloudAccount: models.CloudAccount{ID: 123, Type: "GCP"},
 					Type: "VM", Name: "vm-instance-1", Status: "STOPPED", CreatedAt: mockTime, UpdatedAt: mockTime,
-					Settings: settings, Region: "us-central1"},
+					Settings: settings, Region: "us-central1", Labels: labels},
 			},
 		},
 		{
@@ -130,16 +135,16 @@ func TestStore_GetResources(t *testing.T) {
 			expErr:       nil,
 			expResp: []models.Resource{
 				{ID: 1, Name: "sql-instance-1", Type: "SQL", Status: "RUNNING", CreatedAt: mockTime, UpdatedAt: mockTime, Region: "us-central1",
-					Settings: settings, UID: "zopdev/sql-instance-1", CloudAccount: models.CloudAccount{ID: 123, Type: "GCP"}},
+					Settings: settings, Labels: labels, UID: "zopdev/sql-instance-1", CloudAccount: models.CloudAccount{ID: 123, Type: "GCP"}},
 			},
 			mockCalls: func() {
 				mocks.SQL.Sqlmock.ExpectQuery(`SELECT id, resource_uid, name, state, cloud_account_id, 
-       cloud_provider, resource_type, created_at, updated_at, settings, region
-		FROM resources WHERE cloud_account_id = ? AND resource_type IN (?) ORDER BY resource_uid`).WithArgs(123, "SQL").
+       cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
+		FROM resources WHERE cloud_account_id = ? AND resource_type IN (?) AND deleted_at IS NULL ORDER BY resource_uid`).WithArgs(123, "SQL").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "resource_uid", "name", "state", "cloud_account_id",
-						"cloud_provider", "resource_type", "created_at", "updated_at", "settings", "region"}).
+						"cloud_provider", "resource_type", "created_at", "updated_at", "settings", "region", "labels", "project", "deleted_at"}).
 						AddRow(1, "zopdev/sql-instance-1", "sql-instance-1", "RUNNING", 123, "GCP",
-							"SQL", mockTime, mockTime, &settings, "us-central1"))
+							"SQL", mockTime, mockTime, &settings, "us-central1", &labels, "", nil))
 			},
 		},
 		{
@@ -186,7 +191,7 @@ func TestStore_UpdateResource(t *testing.T) {
 			id:     1,
 			status: "RUNNING",
 			mockCalls: func() {
-				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET state = ? WHERE id = ?`).
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET state = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
 					WithArgs("RUNNING", 1).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -196,7 +201,7 @@ func TestStore_UpdateResource(t *testing.T) {
 			status: "STOPPED",
 			expErr: assert.AnError,
 			mockCalls: func() {
-				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET state = ? WHERE id = ?`).
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET state = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
 					WithArgs("STOPPED", 2).WillReturnError(assert.AnError)
 			},
 		},
@@ -213,6 +218,140 @@ func TestStore_UpdateResource(t *testing.T) {
 	}
 }
 
+func TestStore_UpdateLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	labels := models.Labels{"env": "staging", "team": "data"}
+	store := New()
+
+	testCases := []struct {
+		name      string
+		id        int64
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name: "Successful Update",
+			id:   1,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET labels = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(labels, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:   "Update Error",
+			id:     2,
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET labels = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(labels, 2).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			err := store.UpdateLabels(ctx, labels, tc.id)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestStore_UpdateSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	settings := models.Settings{"tier": "db-f1-micro"}
+	store := New()
+
+	testCases := []struct {
+		name      string
+		id        int64
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name: "Successful Update",
+			id:   1,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET settings = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(settings, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:   "Update Error",
+			id:     2,
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET settings = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(settings, 2).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			err := store.UpdateSettings(ctx, settings, tc.id)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestStore_UpdateProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	testCases := []struct {
+		name      string
+		id        int64
+		expErr    error
+		mockCalls func()
+	}{
+		{
+			name: "Successful Update",
+			id:   1,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET project = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs("zopdev-prod", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:   "Update Error",
+			id:     2,
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET project = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs("zopdev-prod", 2).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			err := store.UpdateProject(ctx, "zopdev-prod", tc.id)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
 func TestStore_RemoveResource(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -231,8 +370,8 @@ func TestStore_RemoveResource(t *testing.T) {
 			name:       "Successful Removal",
 			resourceID: 1,
 			mockCalls: func() {
-				mocks.SQL.Sqlmock.ExpectExec(`DELETE FROM resources WHERE id = ?`).
-					WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET deleted_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 		{
@@ -240,8 +379,8 @@ func TestStore_RemoveResource(t *testing.T) {
 			resourceID: 2,
 			expErr:     assert.AnError,
 			mockCalls: func() {
-				mocks.SQL.Sqlmock.ExpectExec(`DELETE FROM resources WHERE id = ?`).
-					WithArgs(2).WillReturnError(assert.AnError)
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET deleted_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(sqlmock.AnyArg(), 2).WillReturnError(assert.AnError)
 			},
 		},
 	}
@@ -257,6 +396,50 @@ func TestStore_RemoveResource(t *testing.T) {
 	}
 }
 
+func TestStore_RestoreResource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	testCases := []struct {
+		name       string
+		resourceID int64
+		expErr     error
+		mockCalls  func()
+	}{
+		{
+			name:       "Successful Restore",
+			resourceID: 1,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:       "Restore Error",
+			resourceID: 2,
+			expErr:     assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(`UPDATE resources SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ?`).
+					WithArgs(2).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			err := store.RestoreResource(ctx, tc.resourceID)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
 func TestStore_GetResourceByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -264,9 +447,10 @@ func TestStore_GetResourceByID(t *testing.T) {
 	mockTime := time.Now()
 	mockContainer, mocks := container.NewMockContainer(t)
 	settings := models.Settings{"region": "us-central1", "zone": "us-central1-a"}
+	labels := models.Labels{"env": "staging"}
 	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
 	query := `SELECT id, resource_uid, name, state, cloud_account_id,
-		cloud_provider, resource_type, created_at, updated_at, settings, region
+		cloud_provider, resource_type, created_at, updated_at, settings, region, labels, project, deleted_at
 	FROM resources WHERE id = ?`
 	mockResp := &models.Resource{
 		ID:     1,
@@ -282,6 +466,8 @@ func TestStore_GetResourceByID(t *testing.T) {
 		UpdatedAt: mockTime,
 		Settings:  settings,
 		Region:    "us-central1",
+		Labels:    labels,
+		Project:   "zopdev-prod",
 	}
 	store := New()
 
@@ -299,11 +485,11 @@ func TestStore_GetResourceByID(t *testing.T) {
 			mockCalls: func() {
 				mocks.SQL.ExpectQuery(query).WithArgs(int64(1)).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "resource_uid", "name", "state", "cloud_account_id",
-						"cloud_provider", "resource_type", "created_at", "updated_at", "settings", "region"}).
+						"cloud_provider", "resource_type", "created_at", "updated_at", "settings", "region", "labels", "project", "deleted_at"}).
 						AddRow(1, "zopdev/sql-instance-1", "sql-instance-1", "RUNNING", 123, "GCP",
-							"SQL", mockTime, mockTime, &settings, "us-central1").
+							"SQL", mockTime, mockTime, &settings, "us-central1", &labels, "zopdev-prod", nil).
 						AddRow(2, "zopdev/vm-instance-1", "vm-instance-1", "STOPPED", 123, "GCP",
-							"VM", mockTime, mockTime, &settings, "us-central1"))
+							"VM", mockTime, mockTime, &settings, "us-central1", &labels, "", nil))
 			},
 		},
 		{
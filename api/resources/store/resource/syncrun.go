@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"database/sql"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+// InsertSyncRun records the start of a sync run and sets the ID of the run. The run is only recorded when no other run
+// of the cloud account is running, in a single statement so that the concurrent syncs, of this instance of the API or
+// of another one, can not both start. models.ErrSyncRunning is returned otherwise.
+func (*Store) InsertSyncRun(ctx *gofr.Context, run *models.SyncRun) error {
+	result, err := ctx.SQL.ExecContext(ctx,
+		`INSERT INTO sync_runs (cloud_account_id, resource_types, regions, status, started_at) SELECT ?, ?, ?, ?, ?
+WHERE NOT EXISTS (SELECT 1 FROM sync_runs WHERE cloud_account_id = ? AND status = ? AND started_at > ?)`,
+		run.CloudAccountID, run.ResourceTypes, run.Regions, run.Status, run.StartedAt,
+		run.CloudAccountID, models.SyncRunning, run.StartedAt.Add(-models.SyncRunTimeout))
+	if err != nil {
+		return err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if inserted == 0 {
+		return models.ErrSyncRunning
+	}
+
+	run.ID, err = result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSyncRun stores the status, the counts and the errors of a sync run.
+func (*Store) UpdateSyncRun(ctx *gofr.Context, run *models.SyncRun) error {
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE sync_runs SET status = ?, discovered = ?, created = ?, updated = ?, 
+deleted = ?, restored = ?, errors = ?, ended_at = ? WHERE id = ?`,
+		run.Status, run.Discovered, run.Created, run.Updated, run.Deleted, run.Restored, run.Errors, run.EndedAt, run.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetSyncRuns fetches the latest sync runs of a cloud account, the most recent run comes first.
+func (*Store) GetSyncRuns(ctx *gofr.Context, cloudAccountID int64, limit int) ([]models.SyncRun, error) {
+	var runs []models.SyncRun
+
+	rows, err := ctx.SQL.QueryContext(ctx, `SELECT id, cloud_account_id, resource_types, regions, status, discovered, 
+       created, updated, deleted, restored, errors, started_at, ended_at
+		FROM sync_runs WHERE cloud_account_id = ? ORDER BY started_at DESC, id DESC LIMIT ?`, cloudAccountID, limit)
+	if err != nil || rows.Err() != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			run     models.SyncRun
+			endedAt sql.NullTime
+		)
+
+		if er := rows.Scan(&run.ID, &run.CloudAccountID, &run.ResourceTypes, &run.Regions, &run.Status, &run.Discovered,
+			&run.Created, &run.Updated, &run.Deleted, &run.Restored, &run.Errors, &run.StartedAt, &endedAt); er != nil {
+			return nil, er
+		}
+
+		if endedAt.Valid {
+			run.EndedAt = &endedAt.Time
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,145 @@
+package resource
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"github.com/zopdev/zopdev/api/resources/models"
+)
+
+func TestStore_InsertSyncRun(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	startedAt := time.Now()
+	query := `INSERT INTO sync_runs (cloud_account_id, resource_types, regions, status, started_at) SELECT ?, ?, ?, ?, ?
+WHERE NOT EXISTS (SELECT 1 FROM sync_runs WHERE cloud_account_id = ? AND status = ? AND started_at > ?)`
+	args := []driver.Value{int64(1), models.StringList{"SQL"}, nil, models.SyncRunning, startedAt, int64(1), models.SyncRunning,
+		startedAt.Add(-models.SyncRunTimeout)}
+
+	testCases := []struct {
+		name      string
+		expErr    error
+		expID     int64
+		mockCalls func()
+	}{
+		{
+			name:  "Successful Insert",
+			expID: 7,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(query).WithArgs(args...).WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+		},
+		{
+			// The run is not recorded while another run of the cloud account is running.
+			name:   "Sync Running",
+			expErr: models.ErrSyncRunning,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(query).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name:   "Insert Error",
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectExec(query).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			run := &models.SyncRun{CloudAccountID: 1, SyncScope: models.SyncScope{ResourceTypes: []string{"SQL"}},
+				Status: models.SyncRunning, StartedAt: startedAt}
+
+			err := store.InsertSyncRun(ctx, run)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expID, run.ID)
+		})
+	}
+}
+
+func TestStore_UpdateSyncRun(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	endedAt := time.Now()
+	run := &models.SyncRun{ID: 7, Status: models.SyncPartial, Discovered: 5, Created: 1, Updated: 2, Deleted: 1,
+		Restored: 1, Errors: models.StringList{"failed to insert resource"}, EndedAt: &endedAt}
+
+	mocks.SQL.Sqlmock.ExpectExec(`UPDATE sync_runs SET status = ?, discovered = ?, created = ?, updated = ?, 
+deleted = ?, restored = ?, errors = ?, ended_at = ? WHERE id = ?`).
+		WithArgs(models.SyncPartial, 5, 1, 2, 1, 1, models.StringList{"failed to insert resource"}, &endedAt, int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := store.UpdateSyncRun(ctx, run)
+
+	assert.NoError(t, err)
+}
+
+func TestStore_GetSyncRuns(t *testing.T) {
+	mockContainer, mocks := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockContainer, Context: context.Background()}
+	store := New()
+
+	mockTime := time.Now()
+	query := `SELECT id, cloud_account_id, resource_types, regions, status, discovered, 
+       created, updated, deleted, restored, errors, started_at, ended_at
+		FROM sync_runs WHERE cloud_account_id = ? ORDER BY started_at DESC, id DESC LIMIT ?`
+	columns := []string{"id", "cloud_account_id", "resource_types", "regions", "status", "discovered", "created",
+		"updated", "deleted", "restored", "errors", "started_at", "ended_at"}
+
+	testCases := []struct {
+		name      string
+		expErr    error
+		expResp   []models.SyncRun
+		mockCalls func()
+	}{
+		{
+			name: "Fetch Sync Runs",
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(1, 10).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, 1, nil, nil, models.SyncRunning, 0, 0, 0, 0, 0, nil, mockTime, nil).
+						AddRow(1, 1, `["SQL"]`, `["us-east-1"]`, models.SyncFailed, 0, 0, 0, 0, 0, `["access denied"]`,
+							mockTime, mockTime))
+			},
+			expResp: []models.SyncRun{
+				{ID: 2, CloudAccountID: 1, Status: models.SyncRunning, StartedAt: mockTime},
+				{ID: 1, CloudAccountID: 1, SyncScope: models.SyncScope{ResourceTypes: models.StringList{"SQL"},
+					Regions: models.StringList{"us-east-1"}}, Status: models.SyncFailed,
+					Errors: models.StringList{"access denied"}, StartedAt: mockTime, EndedAt: &mockTime},
+			},
+		},
+		{
+			name:   "Query Error",
+			expErr: assert.AnError,
+			mockCalls: func() {
+				mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(1, 10).WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCalls()
+
+			runs, err := store.GetSyncRuns(ctx, 1, 10)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expResp, runs)
+		})
+	}
+}
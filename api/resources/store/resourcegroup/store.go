@@ -20,7 +20,7 @@ func New() *Store {
 // GetAllResourceGroups retrieves all resource groups from the database.
 func (*Store) GetAllResourceGroups(ctx *gofr.Context, cloudAccID int64) ([]models.ResourceGroup, error) {
 	rows, err := ctx.SQL.QueryContext(ctx,
-		`SELECT id, name, description, cloud_account_id FROM resource_groups 
+		`SELECT id, name, description, cloud_account_id, label_selector FROM resource_groups 
                                                WHERE cloud_account_id = ? AND deleted_at IS NULL`, cloudAccID)
 	if err != nil || rows.Err() != nil {
 		return nil, err
@@ -33,11 +33,15 @@ func (*Store) GetAllResourceGroups(ctx *gofr.Context, cloudAccID int64) ([]model
 	for rows.Next() {
 		var resourceGroup models.ResourceGroup
 
+		var selector sql.NullString
+
 		if er := rows.Scan(&resourceGroup.ID, &resourceGroup.Name,
-			&resourceGroup.Description, &resourceGroup.CloudAccountID); er != nil {
+			&resourceGroup.Description, &resourceGroup.CloudAccountID, &selector); er != nil {
 			return nil, er
 		}
 
+		resourceGroup.LabelSelector = selector.String
+
 		resourceGroups = append(resourceGroups, resourceGroup)
 	}
 
@@ -47,13 +51,16 @@ func (*Store) GetAllResourceGroups(ctx *gofr.Context, cloudAccID int64) ([]model
 // GetResourceGroupByID retrieves a resource group by its ID from the database.
 func (*Store) GetResourceGroupByID(ctx *gofr.Context, cloudAccID, id int64) (*models.ResourceGroup, error) {
 	row := ctx.SQL.QueryRowContext(ctx,
-		`SELECT id, name, description, cloud_account_id FROM resource_groups 
+		`SELECT id, name, description, cloud_account_id, label_selector FROM resource_groups 
                                                WHERE id = ? AND cloud_account_id = ? AND deleted_at IS NULL`, id, cloudAccID)
 
-	var resourceGroup models.ResourceGroup
+	var (
+		resourceGroup models.ResourceGroup
+		selector      sql.NullString
+	)
 
 	err := row.Scan(&resourceGroup.ID, &resourceGroup.Name,
-		&resourceGroup.Description, &resourceGroup.CloudAccountID)
+		&resourceGroup.Description, &resourceGroup.CloudAccountID, &selector)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil // No resource group found
@@ -62,14 +69,16 @@ func (*Store) GetResourceGroupByID(ctx *gofr.Context, cloudAccID, id int64) (*mo
 		return nil, err // Other error
 	}
 
+	resourceGroup.LabelSelector = selector.String
+
 	return &resourceGroup, nil
 }
 
 // CreateResourceGroup inserts a new resource group into the database and returns its ID.
 func (*Store) CreateResourceGroup(ctx *gofr.Context, resourceGroup *models.RGCreate) (int64, error) {
 	result, err := ctx.SQL.ExecContext(ctx,
-		`INSERT INTO resource_groups (name, description, cloud_account_id) VALUES (?, ?, ?)`,
-		resourceGroup.Name, resourceGroup.Description, resourceGroup.CloudAccountID)
+		`INSERT INTO resource_groups (name, description, cloud_account_id, label_selector) VALUES (?, ?, ?, ?)`,
+		resourceGroup.Name, resourceGroup.Description, resourceGroup.CloudAccountID, resourceGroup.LabelSelector)
 	if err != nil {
 		return 0, err
 	}
@@ -84,8 +93,8 @@ func (*Store) CreateResourceGroup(ctx *gofr.Context, resourceGroup *models.RGCre
 
 // UpdateResourceGroup updates an existing resource group in the database.
 func (*Store) UpdateResourceGroup(ctx *gofr.Context, resourceGroup *models.RGUpdate) error {
-	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resource_groups SET name = ?, description = ? WHERE id = ?`,
-		resourceGroup.Name, resourceGroup.Description, resourceGroup.ID)
+	_, err := ctx.SQL.ExecContext(ctx, `UPDATE resource_groups SET name = ?, description = ?, label_selector = ? WHERE id = ?`,
+		resourceGroup.Name, resourceGroup.Description, resourceGroup.LabelSelector, resourceGroup.ID)
 	if err != nil {
 		return err
 	}
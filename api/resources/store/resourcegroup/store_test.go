@@ -25,11 +25,11 @@ func setup(t *testing.T) (*gofr.Context, *container.Mocks, *Store) {
 
 func TestStore_GetAllResourceGroups(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	query := `SELECT id, name, description, cloud_account_id FROM resource_groups
+	query := `SELECT id, name, description, cloud_account_id, label_selector FROM resource_groups
                                                WHERE cloud_account_id = ? AND deleted_at IS NULL`
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "cloud_account_id"}).
-		AddRow(1, "group1", "desc1", 123).
-		AddRow(2, "group2", "desc2", 123)
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "cloud_account_id", "label_selector"}).
+		AddRow(1, "group1", "desc1", 123, nil).
+		AddRow(2, "group2", "desc2", 123, "env=staging")
 
 	mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(123).WillReturnRows(rows)
 
@@ -39,11 +39,13 @@ func TestStore_GetAllResourceGroups(t *testing.T) {
 	assert.Len(t, result, 2)
 	assert.Equal(t, int64(1), result[0].ID)
 	assert.Equal(t, "group1", result[0].Name)
+	assert.Empty(t, result[0].LabelSelector)
+	assert.Equal(t, "env=staging", result[1].LabelSelector)
 }
 
 func TestStore_GetAllResourceGroups_Error(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	query := `SELECT id, name, description, cloud_account_id FROM resource_groups
+	query := `SELECT id, name, description, cloud_account_id, label_selector FROM resource_groups
                                                WHERE cloud_account_id = ? AND deleted_at IS NULL`
 	mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(123).WillReturnError(assert.AnError)
 
@@ -54,10 +56,10 @@ func TestStore_GetAllResourceGroups_Error(t *testing.T) {
 
 func TestStore_GetResourceGroupByID(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	query := `SELECT id, name, description, cloud_account_id FROM resource_groups
+	query := `SELECT id, name, description, cloud_account_id, label_selector FROM resource_groups
                                                WHERE id = ? AND cloud_account_id = ? AND deleted_at IS NULL`
-	row := sqlmock.NewRows([]string{"id", "name", "description", "cloud_account_id"}).
-		AddRow(1, "group1", "desc1", 123)
+	row := sqlmock.NewRows([]string{"id", "name", "description", "cloud_account_id", "label_selector"}).
+		AddRow(1, "group1", "desc1", 123, "env=staging")
 	mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(1, 123).WillReturnRows(row)
 
 	result, err := store.GetResourceGroupByID(ctx, 123, 1)
@@ -69,7 +71,7 @@ func TestStore_GetResourceGroupByID(t *testing.T) {
 
 func TestStore_GetResourceGroupByID_NotFound(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	query := `SELECT id, name, description, cloud_account_id FROM resource_groups
+	query := `SELECT id, name, description, cloud_account_id, label_selector FROM resource_groups
                                                WHERE id = ? AND cloud_account_id = ? AND deleted_at IS NULL`
 	mocks.SQL.Sqlmock.ExpectQuery(query).WithArgs(2, 123).WillReturnError(sql.ErrNoRows)
 
@@ -81,8 +83,8 @@ func TestStore_GetResourceGroupByID_NotFound(t *testing.T) {
 
 func TestStore_CreateResourceGroup(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	mocks.SQL.Sqlmock.ExpectExec(`INSERT INTO resource_groups (name, description, cloud_account_id) VALUES (?, ?, ?)`).
-		WithArgs("group1", "desc1", int64(123)).
+	mocks.SQL.Sqlmock.ExpectExec(`INSERT INTO resource_groups (name, description, cloud_account_id, label_selector) VALUES (?, ?, ?, ?)`).
+		WithArgs("group1", "desc1", int64(123), "").
 		WillReturnResult(sqlmock.NewResult(10, 1))
 
 	id, err := store.CreateResourceGroup(ctx, &models.RGCreate{Name: "group1", Description: "desc1", CloudAccountID: 123})
@@ -93,8 +95,8 @@ func TestStore_CreateResourceGroup(t *testing.T) {
 
 func TestStore_CreateResourceGroup_Error(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	mocks.SQL.Sqlmock.ExpectExec(`INSERT INTO resource_groups (name, description, cloud_account_id) VALUES (?, ?, ?)`).
-		WithArgs("group1", "desc1", int64(123)).
+	mocks.SQL.Sqlmock.ExpectExec(`INSERT INTO resource_groups (name, description, cloud_account_id, label_selector) VALUES (?, ?, ?, ?)`).
+		WithArgs("group1", "desc1", int64(123), "").
 		WillReturnError(assert.AnError)
 
 	id, err := store.CreateResourceGroup(ctx, &models.RGCreate{Name: "group1", Description: "desc1", CloudAccountID: 123})
@@ -105,22 +107,22 @@ func TestStore_CreateResourceGroup_Error(t *testing.T) {
 
 func TestStore_UpdateResourceGroup(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	mocks.SQL.Sqlmock.ExpectExec(`UPDATE resource_groups SET name = ?, description = ? WHERE id = ?`).
-		WithArgs("group1", "desc1", int64(1)).
+	mocks.SQL.Sqlmock.ExpectExec(`UPDATE resource_groups SET name = ?, description = ?, label_selector = ? WHERE id = ?`).
+		WithArgs("group1", "desc1", "env=staging", int64(1)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err := store.UpdateResourceGroup(ctx, &models.RGUpdate{ID: 1, Name: "group1", Description: "desc1"})
+	err := store.UpdateResourceGroup(ctx, &models.RGUpdate{ID: 1, Name: "group1", Description: "desc1", LabelSelector: "env=staging"})
 
 	require.NoError(t, err)
 }
 
 func TestStore_UpdateResourceGroup_Error(t *testing.T) {
 	ctx, mocks, store := setup(t)
-	mocks.SQL.Sqlmock.ExpectExec(`UPDATE resource_groups SET name = ?, description = ? WHERE id = ?`).
-		WithArgs("group1", "desc1", int64(1)).
+	mocks.SQL.Sqlmock.ExpectExec(`UPDATE resource_groups SET name = ?, description = ?, label_selector = ? WHERE id = ?`).
+		WithArgs("group1", "desc1", "env=staging", int64(1)).
 		WillReturnError(assert.AnError)
 
-	err := store.UpdateResourceGroup(ctx, &models.RGUpdate{ID: 1, Name: "group1", Description: "desc1"})
+	err := store.UpdateResourceGroup(ctx, &models.RGUpdate{ID: 1, Name: "group1", Description: "desc1", LabelSelector: "env=staging"})
 
 	require.Error(t, err)
 }
@@ -0,0 +1,199 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+const (
+	masterKeySize = 32
+	gcmNonceSize  = 12
+
+	// The current master key and the master keys it replaced, as comma-separated id:base64 pairs.
+	masterKeyConfig    = "CREDENTIALS_MASTER_KEY"
+	previousKeysConfig = "CREDENTIALS_PREVIOUS_MASTER_KEYS"
+	// The keyfile is used when no master key is configured, it is created on first use.
+	keyfileConfig  = "CREDENTIALS_KEYFILE"
+	defaultKeyfile = "credentials.key"
+	defaultKeyID   = "default"
+
+	keyfileMode = 0o600
+)
+
+var (
+	errInvalidKeySize    = errors.New("master key must be 32 bytes")
+	errMissingPrimaryKey = errors.New("primary master key is missing")
+	errUnknownKey        = errors.New("unknown master key")
+	errDuplicateKey      = errors.New("duplicate master key")
+)
+
+// LocalKMS wraps the data keys with master keys held in memory. Only the primary key wraps new data keys, the other
+// keys are kept so that the data keys they wrapped can still be unwrapped until they are re-wrapped.
+type LocalKMS struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// keyfile is the JSON format of a local keyfile, the keys are base64 encoded.
+type keyfile struct {
+	Primary string            `json:"primary"`
+	Keys    map[string][]byte `json:"keys"`
+}
+
+// NewLocalKMS creates a LocalKMS from 32 bytes master keys indexed by their ID.
+func NewLocalKMS(primary string, keys map[string][]byte) (*LocalKMS, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, errMissingPrimaryKey
+	}
+
+	for id, key := range keys {
+		if len(key) != masterKeySize {
+			return nil, fmt.Errorf("%w: %s", errInvalidKeySize, id)
+		}
+	}
+
+	return &LocalKMS{primary: primary, keys: keys}, nil
+}
+
+// LoadLocalKMS loads the master keys from CREDENTIALS_MASTER_KEY and CREDENTIALS_PREVIOUS_MASTER_KEYS, or from the
+// keyfile at CREDENTIALS_KEYFILE when no master key is configured. The master key is rotated by making it a previous
+// key and configuring a new one, the data keys it wrapped are re-wrapped on the next start.
+func LoadLocalKMS(cfg config.Config) (*LocalKMS, error) {
+	primary := cfg.Get(masterKeyConfig)
+	if primary == "" {
+		return LoadKeyfile(cfg.GetOrDefault(keyfileConfig, defaultKeyfile))
+	}
+
+	keys := make(map[string][]byte)
+
+	primaryID, err := parseKeys(primary, keys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", masterKeyConfig, err)
+	}
+
+	if previous := cfg.Get(previousKeysConfig); previous != "" {
+		if _, err = parseKeys(previous, keys); err != nil {
+			return nil, fmt.Errorf("%s: %w", previousKeysConfig, err)
+		}
+	}
+
+	return NewLocalKMS(primaryID, keys)
+}
+
+// LoadKeyfile loads the master keys from a keyfile, the keyfile is created with a new master key if it does not exist.
+func LoadKeyfile(path string) (*LocalKMS, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createKeyfile(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var kf keyfile
+
+	if err = json.Unmarshal(b, &kf); err != nil {
+		return nil, fmt.Errorf("keyfile %s: %w", path, err)
+	}
+
+	return NewLocalKMS(kf.Primary, kf.Keys)
+}
+
+func createKeyfile(path string) (*LocalKMS, error) {
+	key := make([]byte, masterKeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	kf := keyfile{Primary: "k" + time.Now().UTC().Format("20060102150405"), Keys: make(map[string][]byte)}
+	kf.Keys[kf.Primary] = key
+
+	b, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// O_EXCL avoids overwriting a keyfile created concurrently by another instance.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, keyfileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	if _, err = f.Write(b); err != nil {
+		return nil, err
+	}
+
+	return NewLocalKMS(kf.Primary, kf.Keys)
+}
+
+// parseKeys adds the comma-separated id:base64 keys to the given map and returns the ID of the first one. A key
+// without an ID is given the default ID.
+func parseKeys(s string, keys map[string][]byte) (string, error) {
+	var first string
+
+	for _, pair := range strings.Split(s, ",") {
+		id, encoded, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found {
+			id, encoded = defaultKeyID, id
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", err
+		}
+
+		if _, ok := keys[id]; ok {
+			return "", fmt.Errorf("%w: %s", errDuplicateKey, id)
+		}
+
+		keys[id] = key
+
+		if first == "" {
+			first = id
+		}
+	}
+
+	return first, nil
+}
+
+// KeyID returns the ID of the primary master key.
+func (k *LocalKMS) KeyID() string {
+	return k.primary
+}
+
+// Wrap encrypts a data key with the primary master key, the ID of the key is authenticated along with the data key.
+func (k *LocalKMS) Wrap(_ context.Context, dataKey []byte) (keyID string, wrapped []byte, err error) {
+	nonce, ciphertext, err := encrypt(k.keys[k.primary], dataKey, []byte(k.primary))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return k.primary, append(nonce, ciphertext...), nil
+}
+
+// Unwrap decrypts a data key wrapped by one of the master keys.
+func (k *LocalKMS) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownKey, keyID)
+	}
+
+	if len(wrapped) < gcmNonceSize {
+		return nil, errMalformedSecret
+	}
+
+	return decrypt(key, wrapped[:gcmNonceSize], wrapped[gcmNonceSize:], []byte(keyID))
+}
@@ -0,0 +1,205 @@
+// Package secrets encrypts the secrets stored by zopdev, e.g. the credentials of the cloud accounts, with envelope
+// encryption. Every secret is encrypted with its own data key, and the data key is stored wrapped by a master key
+// held by a KMS. Rotating the master key only re-wraps the data keys, the secrets themselves are not re-encrypted.
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// sealedPrefix marks the values sealed by this package and the version of their format. The secrets of the version 2
+// are bound to the additional data they are sealed with, e.g. the ID of their cloud account, so that a secret copied to
+// another row can not be opened. The secrets of the version 1 are bound to nothing, they are upgraded when rewrapped.
+const (
+	sealedPrefix  = "enc:v2:"
+	unboundPrefix = "enc:v1:"
+)
+
+const dataKeySize = 32
+
+var errMalformedSecret = errors.New("malformed sealed secret")
+
+// KMS wraps and unwraps the data keys with its master keys. The master keys never leave the KMS.
+type KMS interface {
+	// KeyID returns the ID of the master key that wraps the new data keys.
+	KeyID() string
+	Wrap(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// envelope is a secret encrypted with its data key, along with the data key wrapped by a master key.
+type envelope struct {
+	KeyID   string `json:"kid"`
+	DataKey []byte `json:"dek"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// Sealer seals and opens secrets with envelope encryption.
+type Sealer struct {
+	kms KMS
+}
+
+// New creates a Sealer wrapping the data keys with the master keys of the given KMS.
+func New(kms KMS) *Sealer {
+	return &Sealer{kms: kms}
+}
+
+// IsSealed returns whether a stored value was sealed by a Sealer, as opposed to being stored in plaintext.
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix) || strings.HasPrefix(value, unboundPrefix)
+}
+
+// KeyID returns the ID of the master key that wraps the data keys of the new secrets.
+func (s *Sealer) KeyID() string {
+	return s.kms.KeyID()
+}
+
+// Seal encrypts a secret with a new data key, the secret can only be opened with the same additional data.
+func (s *Sealer) Seal(ctx context.Context, plaintext, additionalData []byte) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	nonce, data, err := encrypt(dataKey, plaintext, additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	keyID, wrapped, err := s.kms.Wrap(ctx, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(&envelope{KeyID: keyID, DataKey: wrapped, Nonce: nonce, Data: data})
+}
+
+// Open decrypts a sealed secret, it fails when the additional data differs from the one the secret was sealed with.
+func (s *Sealer) Open(ctx context.Context, sealed string, additionalData []byte) ([]byte, error) {
+	env, bound, err := unmarshal(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := s.kms.Unwrap(ctx, env.KeyID, env.DataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bound {
+		additionalData = nil
+	}
+
+	return decrypt(dataKey, env.Nonce, env.Data, additionalData)
+}
+
+// Rewrap wraps the data key of a sealed secret with the current master key of the KMS. A secret not bound to any
+// additional data is re-encrypted to be bound to the given one.
+func (s *Sealer) Rewrap(ctx context.Context, sealed string, additionalData []byte) (string, error) {
+	env, bound, err := unmarshal(sealed)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := s.kms.Unwrap(ctx, env.KeyID, env.DataKey)
+	if err != nil {
+		return "", err
+	}
+
+	if !bound {
+		plaintext, er := decrypt(dataKey, env.Nonce, env.Data, nil)
+		if er != nil {
+			return "", er
+		}
+
+		env.Nonce, env.Data, err = encrypt(dataKey, plaintext, additionalData)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	env.KeyID, env.DataKey, err = s.kms.Wrap(ctx, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(env)
+}
+
+func marshal(env *envelope) (string, error) {
+	b, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+
+	return sealedPrefix + string(b), nil
+}
+
+// unmarshal decodes a sealed secret, bound is false for the secrets of the version 1.
+func unmarshal(sealed string) (env *envelope, bound bool, err error) {
+	var data string
+
+	switch {
+	case strings.HasPrefix(sealed, sealedPrefix):
+		data, bound = strings.TrimPrefix(sealed, sealedPrefix), true
+	case strings.HasPrefix(sealed, unboundPrefix):
+		data = strings.TrimPrefix(sealed, unboundPrefix)
+	default:
+		return nil, false, errMalformedSecret
+	}
+
+	env = &envelope{}
+
+	err = json.Unmarshal([]byte(data), env)
+	if err != nil || env.KeyID == "" || len(env.DataKey) == 0 {
+		return nil, false, errMalformedSecret
+	}
+
+	return env, bound, nil
+}
+
+// encrypt encrypts with AES-256-GCM, the additional data is authenticated but not encrypted.
+func encrypt(key, plaintext, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce = make([]byte, aead.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+
+	return nonce, aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+func decrypt(key, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return nil, errMalformedSecret
+	}
+
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
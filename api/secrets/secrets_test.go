@@ -0,0 +1,227 @@
+package secrets
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr/config"
+)
+
+type mapConfig map[string]string
+
+func (c mapConfig) Get(key string) string {
+	return c[key]
+}
+
+func (c mapConfig) GetOrDefault(key, def string) string {
+	if v, ok := c[key]; ok {
+		return v
+	}
+
+	return def
+}
+
+var _ config.Config = mapConfig{}
+
+func newKMS(t *testing.T, primary string, ids ...string) *LocalKMS {
+	t.Helper()
+
+	keys := make(map[string][]byte)
+
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, masterKeySize)
+	}
+
+	kms, err := NewLocalKMS(primary, keys)
+	require.NoError(t, err)
+
+	return kms
+}
+
+func TestSealer_SealOpen(t *testing.T) {
+	ctx := context.Background()
+	s := New(newKMS(t, "k1", "k1"))
+	secret := []byte(`{"aws_secret_access_key":"secret"}`)
+
+	sealed, err := s.Seal(ctx, secret, []byte("1"))
+	require.NoError(t, err)
+
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, sealed, "secret")
+
+	opened, err := s.Open(ctx, sealed, []byte("1"))
+	require.NoError(t, err)
+	assert.Equal(t, secret, opened)
+
+	// Every secret has its own data key.
+	other, err := s.Seal(ctx, secret, []byte("1"))
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, other)
+}
+
+func TestSealer_Open_Errors(t *testing.T) {
+	ctx := context.Background()
+	s := New(newKMS(t, "k1", "k1"))
+
+	sealed, err := s.Seal(ctx, []byte("secret"), []byte("1"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		sealed         string
+		additionalData string
+	}{
+		{name: "plaintext", sealed: `{"key":"value"}`},
+		{name: "other additional data", sealed: sealed, additionalData: "2"},
+		{name: "malformed envelope", sealed: sealedPrefix + "{"},
+		{name: "tampered data", sealed: strings.Replace(sealed, `"data":"`, `"data":"AAAA`, 1)},
+		{name: "unknown master key", sealed: strings.Replace(sealed, `"kid":"k1"`, `"kid":"k9"`, 1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			additionalData := cmp.Or(tc.additionalData, "1")
+
+			_, err := s.Open(ctx, tc.sealed, []byte(additionalData))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSealer_Rewrap(t *testing.T) {
+	ctx := context.Background()
+	old := New(newKMS(t, "k1", "k1"))
+
+	sealed, err := old.Seal(ctx, []byte("secret"), []byte("1"))
+	require.NoError(t, err)
+
+	// The new master key is primary, the old one is kept to unwrap the data keys it wrapped.
+	rotated := New(newKMS(t, "k2", "k1", "k2"))
+
+	opened, err := rotated.Open(ctx, sealed, []byte("1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), opened)
+
+	rewrapped, err := rotated.Rewrap(ctx, sealed, []byte("1"))
+	require.NoError(t, err)
+	assert.Contains(t, rewrapped, `"kid":"k2"`)
+
+	// Once re-wrapped, the old master key is no longer needed.
+	opened, err = New(newKMS(t, "k2", "k0", "k2")).Open(ctx, rewrapped, []byte("1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), opened)
+}
+
+func TestSealer_Rewrap_Unbound(t *testing.T) {
+	ctx := context.Background()
+	s := New(newKMS(t, "k1", "k1"))
+
+	// A secret of the version 1 is not bound to any additional data.
+	dataKey := bytes.Repeat([]byte{7}, dataKeySize)
+	nonce, data, err := encrypt(dataKey, []byte("secret"), nil)
+	require.NoError(t, err)
+
+	keyID, wrapped, err := s.kms.Wrap(ctx, dataKey)
+	require.NoError(t, err)
+
+	env, err := marshal(&envelope{KeyID: keyID, DataKey: wrapped, Nonce: nonce, Data: data})
+	require.NoError(t, err)
+
+	unbound := unboundPrefix + strings.TrimPrefix(env, sealedPrefix)
+
+	assert.True(t, IsSealed(unbound))
+
+	opened, err := s.Open(ctx, unbound, []byte("1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), opened)
+
+	// Once re-wrapped, the secret is bound to the additional data.
+	rewrapped, err := s.Rewrap(ctx, unbound, []byte("1"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rewrapped, sealedPrefix))
+
+	opened, err = s.Open(ctx, rewrapped, []byte("1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), opened)
+
+	_, err = s.Open(ctx, rewrapped, []byte("2"))
+	require.Error(t, err)
+}
+
+func TestNewLocalKMS_Errors(t *testing.T) {
+	_, err := NewLocalKMS("k1", map[string][]byte{"k2": make([]byte, masterKeySize)})
+	require.ErrorIs(t, err, errMissingPrimaryKey)
+
+	_, err = NewLocalKMS("k1", map[string][]byte{"k1": make([]byte, 16)})
+	require.ErrorIs(t, err, errInvalidKeySize)
+}
+
+func TestLoadLocalKMS(t *testing.T) {
+	key1 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, masterKeySize))
+	key2 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, masterKeySize))
+
+	testCases := []struct {
+		name     string
+		cfg      mapConfig
+		expKeyID string
+		expKeys  []string
+		expErr   bool
+	}{
+		{name: "key without id", cfg: mapConfig{masterKeyConfig: key1}, expKeyID: defaultKeyID, expKeys: []string{defaultKeyID}},
+		{
+			name:     "rotated key",
+			cfg:      mapConfig{masterKeyConfig: "k2:" + key2, previousKeysConfig: "k1:" + key1},
+			expKeyID: "k2", expKeys: []string{"k1", "k2"},
+		},
+		{name: "invalid base64", cfg: mapConfig{masterKeyConfig: "k1:not-base64"}, expErr: true},
+		{name: "invalid size", cfg: mapConfig{masterKeyConfig: "k1:" + base64.StdEncoding.EncodeToString([]byte("short"))}, expErr: true},
+		{name: "duplicate id", cfg: mapConfig{masterKeyConfig: "k1:" + key1, previousKeysConfig: "k1:" + key2}, expErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kms, err := LoadLocalKMS(tc.cfg)
+
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expKeyID, kms.KeyID())
+
+			for _, id := range tc.expKeys {
+				assert.Contains(t, kms.keys, id)
+			}
+		})
+	}
+}
+
+func TestLoadKeyfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.key")
+
+	created, err := LoadLocalKMS(mapConfig{keyfileConfig: path})
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(keyfileMode), info.Mode().Perm())
+
+	loaded, err := LoadKeyfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, created, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), keyfileMode))
+
+	_, err = LoadKeyfile(path)
+	require.Error(t, err)
+}
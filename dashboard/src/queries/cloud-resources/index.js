@@ -5,9 +5,20 @@ export function useGetCloudResources(id, options = {}) {
   return useQuery({
     queryKey: ['cloudResourcesGetData', id],
     queryFn: async () => {
-      const url = `/cloud-account/${id}/resources`;
-      const response = await fetchData(url);
-      return response?.data;
+      // The resources are paginated, the table shows all of them so every page is fetched.
+      const resources = [];
+      let cursor = '';
+
+      do {
+        const params = new URLSearchParams({ limit: '1000' });
+        if (cursor) params.set('cursor', cursor);
+
+        const response = await fetchData(`/cloud-account/${id}/resources?${params}`);
+        resources.push(...(response?.data?.resources ?? []));
+        cursor = response?.data?.next_cursor;
+      } while (cursor);
+
+      return resources;
     },
     ...options,
   });